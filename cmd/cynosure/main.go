@@ -7,6 +7,7 @@ import (
 	"github.com/quenbyako/core/contrib/runtime"
 
 	"github.com/quenbyako/cynosure/cmd/cynosure/root"
+	"github.com/quenbyako/cynosure/internal/adapters/mcp"
 )
 
 //nolint:gochecknoglobals // ldflags doesn't work with constants
@@ -17,6 +18,10 @@ var (
 )
 
 func main() {
+	// stdio servers are launched through the service binary, which applies
+	// resource limits before executing them.
+	mcp.RunStdioLimitsHelper()
+
 	ctx, cancel := core.BuildContext(
 		core.NewAppName("cynosure", "Cynosure"),
 		core.NewVersion(version, commit, date),
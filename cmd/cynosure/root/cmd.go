@@ -28,15 +28,36 @@ func Cmd(ctx context.Context, appCtx core.AppContext[Config]) core.ExitCode {
 		cynosure.WithTelegramServer(cfg.TelegramPort.Register),
 		cynosure.WithTelegramPublicAddr(cfg.TelegramPublicAddr),
 		cynosure.WithTelegramClient(cfg.TelegramClient),
+		cynosure.WithSupportContact(cfg.SupportContact),
 		cynosure.WithOry(cfg.OryEndpoint, cfg.OryAdminKey),
 		cynosure.WithOryClientCredentials(cfg.OryClientID, cfg.OryClientSecret),
 		cynosure.WithOryClient(cfg.OryClient),
 		cynosure.WithOAuthCallbackURL(cfg.OAuthRedirectURL),
 		cynosure.WithMCP(cfg.MCPPort.Register),
 		cynosure.WithMCPTransports(cfg.InternalMCPClient, cfg.ExternalMCPClient),
+		cynosure.WithMCPToolTimeout(cfg.MCPToolTimeout),
+		cynosure.WithMCPToolTimeoutOverrides(cfg.MCPToolTimeouts),
+		cynosure.WithMCPRediscoveryInterval(cfg.MCPRediscovery),
+		cynosure.WithMCPStrictOutput(cfg.MCPStrictOutput),
+		cynosure.WithMCPOutboundLimits(cfg.MCPServerRateLimit, cfg.MCPAccountLimit),
+		cynosure.WithMCPOutboundWait(cfg.MCPRateLimitWait),
+		cynosure.WithMCPSampling(cfg.MCPSampling, cfg.MCPSamplingLimit),
+		cynosure.WithMCPStdioServers(cfg.MCPStdioServers),
 		cynosure.WithAdminMCPID(cfg.AdminMCPServerID),
 		cynosure.WithRateLimit(cfg.RateLimit),
+		cynosure.WithTokenRateLimit(cfg.TokenRateLimit),
+		cynosure.WithPlans(cfg.Plans),
 		cynosure.WithChatLimits(cfg.ChatSoftLimit, cfg.ChatHardCap),
+		cynosure.WithToolCacheTTL(cfg.ToolCacheTTL),
+		cynosure.WithLinkTTL(cfg.LinkTTL),
+		cynosure.WithModelPrices(cfg.ModelPrices),
+		cynosure.WithUserBudget(cfg.UserBudget),
+		cynosure.WithBlobStorageDir(cfg.BlobStorageDir),
+		cynosure.WithLinksRateLimit(cfg.LinksRateLimit),
+	}
+
+	if cfg.HTTPPublicAddr != nil && cfg.HTTPPublicAddr.Scheme != "" {
+		opts = append(opts, cynosure.WithLinksPublicAddr(cfg.HTTPPublicAddr))
 	}
 
 	if cfg.DatabaseURL != nil && cfg.DatabaseURL.Scheme != "" {
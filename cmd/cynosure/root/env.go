@@ -3,13 +3,17 @@ package root
 import (
 	"log/slog"
 	"net/url"
+	"time"
 
 	"github.com/quenbyako/core"
 	"github.com/quenbyako/core/contrib/params/grpc"
 	"github.com/quenbyako/core/contrib/params/http"
 	"github.com/quenbyako/core/contrib/params/secrets"
+	"github.com/quenbyako/cynosure/contrib/core-params/budget"
 	"github.com/quenbyako/cynosure/contrib/core-params/httpclient"
 	"github.com/quenbyako/cynosure/contrib/core-params/ratelimit"
+	"github.com/quenbyako/cynosure/contrib/core-params/stdio"
+	"github.com/quenbyako/cynosure/contrib/core-params/timeouts"
 )
 
 // Note: reason for ignoring most of linters in config is that tag order is that
@@ -27,14 +31,20 @@ type Config struct {
 	LogLevel           slog.Level        `env:"CYNOSURE_LOG_LEVEL"     default:"info"`
 	Port               grpc.Server       `env:"CYNOSURE_GRPC_ADDR"     default:"grpc://0.0.0.0:5001"`
 	HTTPPort           http.Server       `env:"CYNOSURE_HTTP_ADDR"     default:"http://0.0.0.0:5002"`
+	HTTPPublicAddr     *url.URL          `env:"CYNOSURE_HTTP_PUBLIC_ADDR" default:""`
+	LinksRateLimit     ratelimit.Policy  `env:"CYNOSURE_LINKS_RATELIMIT"  default:"60/1m"`
 	TelegramPort       http.Server       `env:"CYNOSURE_TELEGRAM_ADDR" default:"http://0.0.0.0:5003"`
 	MCPPort            http.Server       `env:"CYNOSURE_MCP_ADDR"      default:"http://0.0.0.0:5004"`
 	DatabaseURL        *url.URL          `env:"CYNOSURE_DATABASE_URL"`
+	BlobStorageDir     string            `env:"CYNOSURE_BLOB_DIR"            default:""`
 	GeminiKey          secrets.Secret    `env:"CYNOSURE_GEMINI_KEY"`
 	GeminiClient       httpclient.Client `env:"CYNOSURE_GEMINI_API"     default:"https://generativelanguage.googleapis.com#timeout=30s"`
 	TelegramKey        secrets.Secret    `env:"CYNOSURE_TELEGRAM_KEY"`
 	TelegramPublicAddr *url.URL          `env:"CYNOSURE_TELEGRAM_PUBLIC_ADDR"`
 	TelegramClient     httpclient.Client `env:"CYNOSURE_TELEGRAM_API"  default:"https://api.telegram.org#rate=30/1s"`
+	// whom users ask to upgrade their plan, e.g. "@admin". Empty value
+	// omits the advice.
+	SupportContact     string            `env:"CYNOSURE_SUPPORT_CONTACT" default:""`
 	FileSecret         *url.URL          `env:"CYNOSURE_FILE_SECRETS" default:""`
 	OryAdminKey        secrets.Secret    `env:"CYNOSURE_ORY_ADMIN_API_KEY"`
 	OryEndpoint        *url.URL          `env:"CYNOSURE_ORY_ISSUER_URL"`
@@ -43,12 +53,34 @@ type Config struct {
 	OryClient          httpclient.Client `env:"CYNOSURE_ORY_API"           default:"#timeout=30s"`
 	InternalMCPClient  httpclient.Client `env:"CYNOSURE_MCP_API_INTERNAL"  default:"#timeout=30s"`
 	ExternalMCPClient  httpclient.Client `env:"CYNOSURE_MCP_API_EXTERNAL"  default:"#timeout=30s&ssrf=true"`
+	MCPToolTimeout     time.Duration     `env:"CYNOSURE_MCP_TOOL_TIMEOUT"  default:"2m"`
+	MCPRediscovery     time.Duration     `env:"CYNOSURE_MCP_REDISCOVERY_INTERVAL" default:"6h"`
+	MCPStrictOutput    bool              `env:"CYNOSURE_MCP_STRICT_OUTPUT" default:"false"`
+	MCPServerRateLimit ratelimit.Policy  `env:"CYNOSURE_MCP_SERVER_RATELIMIT"  default:""`
+	MCPAccountLimit    ratelimit.Policy  `env:"CYNOSURE_MCP_ACCOUNT_RATELIMIT" default:""`
+	MCPRateLimitWait   time.Duration     `env:"CYNOSURE_MCP_RATELIMIT_WAIT"    default:"0s"`
+	MCPSampling        string            `env:"CYNOSURE_MCP_SAMPLING"           default:"deny"`
+	MCPSamplingLimit   ratelimit.Policy  `env:"CYNOSURE_MCP_SAMPLING_RATELIMIT" default:""`
+	MCPStdioServers    stdio.Servers     `env:"CYNOSURE_MCP_STDIO_SERVERS"      default:""`
 	AdminMCPServerID   string            `env:"CYNOSURE_ADMIN_MCP_SERVER_ID"`
 	OAuthRedirectURL   *url.URL          `env:"CYNOSURE_OAUTH_REDIRECT_URL" default:"http://localhost:5002/oauth/callback"`
 	RateLimit          ratelimit.Policy  `env:"CYNOSURE_RATELIMIT"          default:"20/1h"`
+	TokenRateLimit     ratelimit.Policy  `env:"CYNOSURE_TOKEN_RATELIMIT"    default:""`
+	Plans              ratelimit.Plans   `env:"CYNOSURE_PLANS"             default:""`
 
 	ChatSoftLimit uint `env:"CYNOSURE_CHAT_SOFT_LIMIT" default:"20"`
 	ChatHardCap   uint `env:"CYNOSURE_CHAT_HARD_CAP"   default:"50"`
+	// ttl of cached results of read-only tools, zero disables it.
+	ToolCacheTTL time.Duration `env:"CYNOSURE_TOOL_CACHE_TTL" default:"5m"`
+	// link references, unused for this time, are deleted, zero keeps them.
+	LinkTTL time.Duration `env:"CYNOSURE_LINK_TTL" default:"720h"`
+
+	// per-server and per-tool deadlines of MCP tool calls, e.g.
+	// "{server-id}=30s,{server-id}/slow_tool=10m".
+	MCPToolTimeouts timeouts.Overrides `env:"CYNOSURE_MCP_TOOL_TIMEOUT_OVERRIDES" default:""`
+
+	ModelPrices budget.Prices `env:"CYNOSURE_MODEL_PRICES" default:""`
+	UserBudget  budget.Limits `env:"CYNOSURE_USER_BUDGET"  default:""`
 
 	MetricsPort  *url.URL          `env:"CYNOSURE_METRICS_ADDR"          default:""`
 	OtlpHost     *url.URL          `env:"CYNOSURE_OTLP_HOST"             default:""`
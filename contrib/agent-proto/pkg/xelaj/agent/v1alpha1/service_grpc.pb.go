@@ -20,6 +20,11 @@ const _ = grpc.SupportPackageIsVersion7
 type AdminServiceClient interface {
 	AddServer(ctx context.Context, in *AddServerRequest, opts ...grpc.CallOption) (*AddServerResponse, error)
 	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	SetUserPlan(ctx context.Context, in *SetUserPlanRequest, opts ...grpc.CallOption) (*SetUserPlanResponse, error)
+	GetUserPlan(ctx context.Context, in *GetUserPlanRequest, opts ...grpc.CallOption) (*GetUserPlanResponse, error)
+	ImportServerCatalog(ctx context.Context, in *ImportServerCatalogRequest, opts ...grpc.CallOption) (*ImportServerCatalogResponse, error)
+	SearchServerCatalog(ctx context.Context, in *SearchServerCatalogRequest, opts ...grpc.CallOption) (*SearchServerCatalogResponse, error)
 }
 
 type adminServiceClient struct {
@@ -48,12 +53,62 @@ func (c *adminServiceClient) Authorize(ctx context.Context, in *AuthorizeRequest
 	return out, nil
 }
 
+func (c *adminServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, "/xelaj.agent.v1alpha1.AdminService/GetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetUserPlan(ctx context.Context, in *SetUserPlanRequest, opts ...grpc.CallOption) (*SetUserPlanResponse, error) {
+	out := new(SetUserPlanResponse)
+	err := c.cc.Invoke(ctx, "/xelaj.agent.v1alpha1.AdminService/SetUserPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetUserPlan(ctx context.Context, in *GetUserPlanRequest, opts ...grpc.CallOption) (*GetUserPlanResponse, error) {
+	out := new(GetUserPlanResponse)
+	err := c.cc.Invoke(ctx, "/xelaj.agent.v1alpha1.AdminService/GetUserPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ImportServerCatalog(ctx context.Context, in *ImportServerCatalogRequest, opts ...grpc.CallOption) (*ImportServerCatalogResponse, error) {
+	out := new(ImportServerCatalogResponse)
+	err := c.cc.Invoke(ctx, "/xelaj.agent.v1alpha1.AdminService/ImportServerCatalog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SearchServerCatalog(ctx context.Context, in *SearchServerCatalogRequest, opts ...grpc.CallOption) (*SearchServerCatalogResponse, error) {
+	out := new(SearchServerCatalogResponse)
+	err := c.cc.Invoke(ctx, "/xelaj.agent.v1alpha1.AdminService/SearchServerCatalog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility
 type AdminServiceServer interface {
 	AddServer(context.Context, *AddServerRequest) (*AddServerResponse, error)
 	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	SetUserPlan(context.Context, *SetUserPlanRequest) (*SetUserPlanResponse, error)
+	GetUserPlan(context.Context, *GetUserPlanRequest) (*GetUserPlanResponse, error)
+	ImportServerCatalog(context.Context, *ImportServerCatalogRequest) (*ImportServerCatalogResponse, error)
+	SearchServerCatalog(context.Context, *SearchServerCatalogRequest) (*SearchServerCatalogResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -67,6 +122,21 @@ func (UnimplementedAdminServiceServer) AddServer(context.Context, *AddServerRequ
 func (UnimplementedAdminServiceServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
 }
+func (UnimplementedAdminServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedAdminServiceServer) SetUserPlan(context.Context, *SetUserPlanRequest) (*SetUserPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserPlan not implemented")
+}
+func (UnimplementedAdminServiceServer) GetUserPlan(context.Context, *GetUserPlanRequest) (*GetUserPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserPlan not implemented")
+}
+func (UnimplementedAdminServiceServer) ImportServerCatalog(context.Context, *ImportServerCatalogRequest) (*ImportServerCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportServerCatalog not implemented")
+}
+func (UnimplementedAdminServiceServer) SearchServerCatalog(context.Context, *SearchServerCatalogRequest) (*SearchServerCatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchServerCatalog not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +186,96 @@ func _AdminService_Authorize_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xelaj.agent.v1alpha1.AdminService/GetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetUserPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetUserPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xelaj.agent.v1alpha1.AdminService/SetUserPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetUserPlan(ctx, req.(*SetUserPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetUserPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetUserPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xelaj.agent.v1alpha1.AdminService/GetUserPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetUserPlan(ctx, req.(*GetUserPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ImportServerCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportServerCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ImportServerCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xelaj.agent.v1alpha1.AdminService/ImportServerCatalog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ImportServerCatalog(ctx, req.(*ImportServerCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SearchServerCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchServerCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SearchServerCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xelaj.agent.v1alpha1.AdminService/SearchServerCatalog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SearchServerCatalog(ctx, req.(*SearchServerCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +291,26 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Authorize",
 			Handler:    _AdminService_Authorize_Handler,
 		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _AdminService_GetUsage_Handler,
+		},
+		{
+			MethodName: "SetUserPlan",
+			Handler:    _AdminService_SetUserPlan_Handler,
+		},
+		{
+			MethodName: "GetUserPlan",
+			Handler:    _AdminService_GetUserPlan_Handler,
+		},
+		{
+			MethodName: "ImportServerCatalog",
+			Handler:    _AdminService_ImportServerCatalog_Handler,
+		},
+		{
+			MethodName: "SearchServerCatalog",
+			Handler:    _AdminService_SearchServerCatalog_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "xelaj/agent/v1alpha1/service.proto",
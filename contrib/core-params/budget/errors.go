@@ -0,0 +1,16 @@
+package budget
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidPrice is returned when the model price format is invalid.
+	ErrInvalidPrice = errors.New("invalid model price format, expected model=input/output (e.g. gemini-2.5-flash=0.3/2.5)")
+
+	// ErrInvalidLimit is returned when the budget limit format is invalid.
+	ErrInvalidLimit = errors.New("invalid budget format, expected period.metric=value (e.g. day.cost=1.5)")
+
+	// ErrNegativeValue is returned when the price or limit is negative.
+	ErrNegativeValue = errors.New("value must not be negative")
+)
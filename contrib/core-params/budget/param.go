@@ -0,0 +1,190 @@
+// Package budget provides token and cost budget parameter types.
+package budget
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+const (
+	expectedParts = 2
+
+	PeriodRun   = "run"
+	PeriodDay   = "day"
+	PeriodMonth = "month"
+
+	MetricInput  = "input"
+	MetricOutput = "output"
+	MetricCost   = "cost"
+)
+
+// Price is a price of the model in USD per 1M tokens.
+type Price struct {
+	Input  float64
+	Output float64
+}
+
+// Prices defines price table of models.
+// It implements encoding.TextUnmarshaler to allow parsing from strings like
+// "gemini-2.5-flash=0.3/2.5,gemini-2.5-pro=1.25/10".
+//
+//nolint:recvcheck // it's necessary to use value receiver to prevent modifying envs
+type Prices struct {
+	models map[string]Price
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Format: {model}={input}/{output}[,...], prices are in USD per 1M tokens.
+func (p *Prices) UnmarshalText(text []byte) error {
+	models := make(map[string]Price)
+
+	for item := range splitItems(string(text)) {
+		model, value, ok := strings.Cut(item, "=")
+		if !ok || model == "" {
+			return ErrInvalidPrice
+		}
+
+		parts := strings.Split(value, "/")
+		if len(parts) != expectedParts {
+			return ErrInvalidPrice
+		}
+
+		input, err := parseValue(parts[0])
+		if err != nil {
+			return fmt.Errorf("invalid input price of %q: %w", model, err)
+		}
+
+		output, err := parseValue(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid output price of %q: %w", model, err)
+		}
+
+		models[model] = Price{Input: input, Output: output}
+	}
+
+	p.models = models
+
+	return nil
+}
+
+// Models returns prices indexed by model name.
+func (p Prices) Models() map[string]Price { return p.models }
+
+// Limit is a budget for single period. Zero values are not limited.
+type Limit struct {
+	InputTokens  uint64
+	OutputTokens uint64
+	// cost in USD.
+	Cost float64
+}
+
+// Limits defines token and cost budgets per run, day and month.
+// It implements encoding.TextUnmarshaler to allow parsing from strings like
+// "run.cost=0.5,day.input=1000000,month.cost=20".
+//
+//nolint:recvcheck // it's necessary to use value receiver to prevent modifying envs
+type Limits struct {
+	periods map[string]Limit
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Format: {period}.{metric}={value}[,...], where period is one of run, day,
+// month and metric is one of input, output (tokens) or cost (USD).
+func (l *Limits) UnmarshalText(text []byte) error {
+	periods := make(map[string]Limit)
+
+	for item := range splitItems(string(text)) {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return ErrInvalidLimit
+		}
+
+		period, metric, ok := strings.Cut(key, ".")
+		if !ok {
+			return ErrInvalidLimit
+		}
+
+		switch period {
+		case PeriodRun, PeriodDay, PeriodMonth:
+		default:
+			return fmt.Errorf("unknown period %q: %w", period, ErrInvalidLimit)
+		}
+
+		limit, err := setMetric(periods[period], metric, value)
+		if err != nil {
+			return err
+		}
+
+		periods[period] = limit
+	}
+
+	l.periods = periods
+
+	return nil
+}
+
+// Run returns budget of single response.
+func (l Limits) Run() Limit { return l.periods[PeriodRun] }
+
+// Day returns daily budget.
+func (l Limits) Day() Limit { return l.periods[PeriodDay] }
+
+// Month returns monthly budget.
+func (l Limits) Month() Limit { return l.periods[PeriodMonth] }
+
+func setMetric(limit Limit, metric, value string) (Limit, error) {
+	switch metric {
+	case MetricInput, MetricOutput:
+		tokens, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return Limit{}, fmt.Errorf("invalid %s tokens %q: %w", metric, value, err)
+		}
+
+		if metric == MetricInput {
+			limit.InputTokens = tokens
+		} else {
+			limit.OutputTokens = tokens
+		}
+	case MetricCost:
+		cost, err := parseValue(value)
+		if err != nil {
+			return Limit{}, fmt.Errorf("invalid cost %q: %w", value, err)
+		}
+
+		limit.Cost = cost
+	default:
+		return Limit{}, fmt.Errorf("unknown metric %q: %w", metric, ErrInvalidLimit)
+	}
+
+	return limit, nil
+}
+
+func parseValue(value string) (float64, error) {
+	res, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing number: %w", err)
+	}
+
+	if res < 0 {
+		return 0, ErrNegativeValue
+	}
+
+	return res, nil
+}
+
+func splitItems(text string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for item := range strings.SplitSeq(text, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package budget_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/contrib/core-params/budget"
+)
+
+func TestPrices_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var prices budget.Prices
+	require.NoError(t, prices.UnmarshalText([]byte("flash=0.3/2.5, pro=1.25/10")))
+
+	assert.Equal(t, map[string]budget.Price{
+		"flash": {Input: 0.3, Output: 2.5},
+		"pro":   {Input: 1.25, Output: 10},
+	}, prices.Models())
+
+	for _, raw := range []string{"flash", "flash=0.3", "=1/2", "flash=-1/2", "flash=a/2"} {
+		require.Error(t, prices.UnmarshalText([]byte(raw)), raw)
+	}
+}
+
+func TestLimits_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var limits budget.Limits
+	require.NoError(t, limits.UnmarshalText(
+		[]byte("run.cost=0.5,day.input=1000,day.output=200,month.cost=20"),
+	))
+
+	assert.Equal(t, budget.Limit{Cost: 0.5}, limits.Run())
+	assert.Equal(t, budget.Limit{InputTokens: 1000, OutputTokens: 200}, limits.Day())
+	assert.Equal(t, budget.Limit{Cost: 20}, limits.Month())
+
+	require.NoError(t, limits.UnmarshalText(nil))
+	assert.Equal(t, budget.Limit{}, limits.Run())
+
+	for _, raw := range []string{"cost=1", "week.cost=1", "day.tokens=1", "day.input=-1", "run.cost=-1"} {
+		require.Error(t, limits.UnmarshalText([]byte(raw)), raw)
+	}
+}
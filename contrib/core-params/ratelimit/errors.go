@@ -10,4 +10,7 @@ var (
 
 	// ErrInvalidBurst is returned when the burst is not positive.
 	ErrInvalidBurst = errors.New("burst must be positive")
+
+	// ErrInvalidPlan is returned when the plan format is invalid.
+	ErrInvalidPlan = errors.New("invalid plan format, expected name=messages[:tokens] (e.g. pro=200/1h:2000000/24h)")
 )
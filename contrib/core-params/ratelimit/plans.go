@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plan is a named pair of message and token policies. Zero policy is
+// unlimited.
+type Plan struct {
+	Name     string
+	Messages Policy
+	Tokens   Policy
+}
+
+// Plans defines rate limit plans, available for users.
+// It implements encoding.TextUnmarshaler to allow parsing from strings like
+// "free=20/1h:100000/24h,pro=200/1h:2000000/24h,internal=".
+//
+//nolint:recvcheck // it's necessary to use value receiver to prevent modifying envs
+type Plans struct {
+	plans []Plan
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Format: {name}={messages}[:{tokens}][,...], where both policies have
+// {burst}/{period} format, and empty policy is unlimited.
+func (p *Plans) UnmarshalText(text []byte) error {
+	var plans []Plan
+
+	for item := range strings.SplitSeq(string(text), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		if !ok || name == "" {
+			return ErrInvalidPlan
+		}
+
+		messages, tokens, _ := strings.Cut(value, ":")
+
+		plan := Plan{Name: name, Messages: Policy{}, Tokens: Policy{}}
+
+		if err := plan.Messages.UnmarshalText([]byte(messages)); err != nil {
+			return fmt.Errorf("invalid messages policy of %q: %w", name, err)
+		}
+
+		if err := plan.Tokens.UnmarshalText([]byte(tokens)); err != nil {
+			return fmt.Errorf("invalid tokens policy of %q: %w", name, err)
+		}
+
+		plans = append(plans, plan)
+	}
+
+	p.plans = plans
+
+	return nil
+}
+
+func (p Plans) String() string {
+	items := make([]string, 0, len(p.plans))
+	for _, plan := range p.plans {
+		item := plan.Name + "=" + plan.Messages.String()
+		if tokens := plan.Tokens.String(); tokens != "" {
+			item += ":" + tokens
+		}
+
+		items = append(items, item)
+	}
+
+	return strings.Join(items, ",")
+}
+
+// Plans returns plans in order of definition.
+func (p Plans) Plans() []Plan { return p.plans }
@@ -0,0 +1,42 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/contrib/core-params/ratelimit"
+)
+
+func TestPlans_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var plans ratelimit.Plans
+	require.NoError(t, plans.UnmarshalText([]byte("free=20/1h, pro=200/1h:2000000/24h,internal=")))
+
+	res := plans.Plans()
+	require.Len(t, res, 3)
+
+	assert.Equal(t, "free", res[0].Name)
+	assert.Equal(t, 20, res[0].Messages.Burst())
+	assert.Equal(t, time.Hour, res[0].Messages.Period())
+	assert.Equal(t, time.Duration(0), res[0].Tokens.Period())
+
+	assert.Equal(t, "pro", res[1].Name)
+	assert.Equal(t, 2000000, res[1].Tokens.Burst())
+	assert.Equal(t, 24*time.Hour, res[1].Tokens.Period())
+
+	assert.Equal(t, "internal", res[2].Name)
+	assert.Equal(t, time.Duration(0), res[2].Messages.Period())
+
+	assert.Equal(t, "free=20/1h0m0s,pro=200/1h0m0s:2000000/24h0m0s,internal=", plans.String())
+
+	require.NoError(t, plans.UnmarshalText(nil))
+	assert.Empty(t, plans.Plans())
+
+	for _, raw := range []string{"free", "=20/1h", "free=20", "free=0/1h", "pro=20/1h:a/1h"} {
+		require.Error(t, plans.UnmarshalText([]byte(raw)), raw)
+	}
+}
@@ -0,0 +1,23 @@
+package stdio
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidServers is returned when servers are not a JSON object.
+	ErrInvalidServers = errors.New(`invalid stdio servers format, expected JSON object (e.g. {"git":{"command":"mcp-server-git"}})`)
+
+	// ErrInvalidName is returned when server name can't be used as a host of
+	// "stdio://<name>" link.
+	ErrInvalidName = errors.New("server name must contain only lowercase letters, digits and dashes")
+
+	// ErrEmptyCommand is returned when server has no command.
+	ErrEmptyCommand = errors.New("server command is required")
+
+	// ErrInvalidEnv is returned when environment variable has invalid name.
+	ErrInvalidEnv = errors.New("environment variable name must be non-empty and must not contain '='")
+
+	// ErrNegativeValue is returned when duration is negative.
+	ErrNegativeValue = errors.New("value must not be negative")
+)
@@ -0,0 +1,190 @@
+// Package stdio provides configuration of local MCP servers, which are
+// launched as subprocesses.
+package stdio
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRestarts = 3
+	DefaultBackoff     = time.Second
+	DefaultWindow      = 10 * time.Minute
+
+	bytesInMB = 1 << 20
+)
+
+// Server is a local MCP server. Zero limits are not limited.
+type Server struct {
+	Name    string
+	Command string
+	Args    []string
+	// variables in "KEY=value" form, sorted by name.
+	Env []string
+	Dir string
+
+	// address space of the process, in bytes.
+	Memory    uint64
+	CPUTime   time.Duration
+	OpenFiles uint64
+
+	MaxRestarts uint
+	Backoff     time.Duration
+	Window      time.Duration
+}
+
+// Servers defines local MCP servers, available by "stdio://<name>" links.
+// It implements encoding.TextUnmarshaler to allow parsing from JSON like
+// {"git":{"command":"mcp-server-git","args":["--repository","/srv/repo"]}}.
+//
+//nolint:recvcheck // it's necessary to use value receiver to prevent modifying envs
+type Servers struct {
+	servers []Server
+}
+
+type rawServer struct {
+	Env     map[string]string `json:"env"`
+	Restart *rawRestart       `json:"restart"`
+	Command string            `json:"command"`
+	Dir     string            `json:"dir"`
+	Args    []string          `json:"args"`
+	Limits  rawLimits         `json:"limits"`
+}
+
+type rawLimits struct {
+	CPU      string `json:"cpu"`
+	MemoryMB uint64 `json:"memory_mb"`
+	Files    uint64 `json:"files"`
+}
+
+type rawRestart struct {
+	Backoff string `json:"backoff"`
+	Window  string `json:"window"`
+	Max     uint   `json:"max"`
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Format: {"{name}":{"command":"...","args":[...],"env":{...},"dir":"...",
+// "limits":{"memory_mb":512,"cpu":"1m","files":256},
+// "restart":{"max":3,"backoff":"1s","window":"10m"}},...}. Empty text means
+// no servers, missing restart policy is replaced with default one.
+func (s *Servers) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		s.servers = nil
+		return nil
+	}
+
+	var raw map[string]rawServer
+	if err := json.Unmarshal(text, &raw); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidServers, err)
+	}
+
+	servers := make([]Server, 0, len(raw))
+
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		server, err := parseServer(name, raw[name])
+		if err != nil {
+			return fmt.Errorf("invalid server %q: %w", name, err)
+		}
+
+		servers = append(servers, server)
+	}
+
+	s.servers = servers
+
+	return nil
+}
+
+// Servers returns servers sorted by name.
+func (s Servers) Servers() []Server { return s.servers }
+
+func parseServer(name string, raw rawServer) (Server, error) {
+	if !validName(name) {
+		return Server{}, ErrInvalidName
+	}
+
+	if raw.Command == "" {
+		return Server{}, ErrEmptyCommand
+	}
+
+	env := make([]string, 0, len(raw.Env))
+	for _, key := range slices.Sorted(maps.Keys(raw.Env)) {
+		if key == "" || strings.Contains(key, "=") {
+			return Server{}, fmt.Errorf("%w: %q", ErrInvalidEnv, key)
+		}
+
+		env = append(env, key+"="+raw.Env[key])
+	}
+
+	cpu, err := parseDuration(raw.Limits.CPU)
+	if err != nil {
+		return Server{}, fmt.Errorf("invalid cpu limit: %w", err)
+	}
+
+	server := Server{
+		Name:        name,
+		Command:     raw.Command,
+		Args:        raw.Args,
+		Env:         env,
+		Dir:         raw.Dir,
+		Memory:      raw.Limits.MemoryMB * bytesInMB,
+		CPUTime:     cpu,
+		OpenFiles:   raw.Limits.Files,
+		MaxRestarts: DefaultMaxRestarts,
+		Backoff:     DefaultBackoff,
+		Window:      DefaultWindow,
+	}
+
+	if raw.Restart == nil {
+		return server, nil
+	}
+
+	server.MaxRestarts = raw.Restart.Max
+
+	if server.Backoff, err = parseDuration(raw.Restart.Backoff); err != nil {
+		return Server{}, fmt.Errorf("invalid restart backoff: %w", err)
+	}
+
+	if server.Window, err = parseDuration(raw.Restart.Window); err != nil {
+		return Server{}, fmt.Errorf("invalid restart window: %w", err)
+	}
+
+	return server, nil
+}
+
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseDuration parses optional duration: empty string is zero.
+func parseDuration(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parsing duration: %w", err)
+	}
+
+	if d < 0 {
+		return 0, ErrNegativeValue
+	}
+
+	return d, nil
+}
@@ -0,0 +1,62 @@
+package stdio_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/contrib/core-params/stdio"
+)
+
+func TestServers_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var servers stdio.Servers
+	require.NoError(t, servers.UnmarshalText([]byte(`{
+		"git": {
+			"command": "mcp-server-git",
+			"args": ["--repository", "/srv/repo"],
+			"env": {"HOME": "/srv", "LANG": "C"},
+			"dir": "/srv/repo",
+			"limits": {"memory_mb": 512, "cpu": "1m", "files": 256},
+			"restart": {"max": 1, "backoff": "2s", "window": "1h"}
+		},
+		"fs": {"command": "mcp-server-fs"}
+	}`)))
+
+	assert.Equal(t, []stdio.Server{{
+		Name:        "fs",
+		Command:     "mcp-server-fs",
+		Env:         []string{},
+		MaxRestarts: stdio.DefaultMaxRestarts,
+		Backoff:     stdio.DefaultBackoff,
+		Window:      stdio.DefaultWindow,
+	}, {
+		Name:        "git",
+		Command:     "mcp-server-git",
+		Args:        []string{"--repository", "/srv/repo"},
+		Env:         []string{"HOME=/srv", "LANG=C"},
+		Dir:         "/srv/repo",
+		Memory:      512 << 20,
+		CPUTime:     time.Minute,
+		OpenFiles:   256,
+		MaxRestarts: 1,
+		Backoff:     2 * time.Second,
+		Window:      time.Hour,
+	}}, servers.Servers())
+
+	require.NoError(t, servers.UnmarshalText(nil))
+	assert.Empty(t, servers.Servers())
+
+	for _, raw := range []string{
+		`[]`,
+		`{"Git":{"command":"git"}}`,
+		`{"git":{}}`,
+		`{"git":{"command":"git","env":{"A=B":"c"}}}`,
+		`{"git":{"command":"git","limits":{"cpu":"-1s"}}}`,
+	} {
+		require.Error(t, servers.UnmarshalText([]byte(raw)), raw)
+	}
+}
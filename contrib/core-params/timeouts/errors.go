@@ -0,0 +1,13 @@
+package timeouts
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidOverride is returned when the override format is invalid.
+	ErrInvalidOverride = errors.New("invalid timeout override format, expected server[/tool]=duration (e.g. {server-id}/search=30s)")
+
+	// ErrNegativeValue is returned when timeout is negative.
+	ErrNegativeValue = errors.New("value must not be negative")
+)
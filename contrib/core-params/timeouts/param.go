@@ -0,0 +1,78 @@
+// Package timeouts provides deadlines of MCP tool calls, overridden for
+// specific servers and tools.
+package timeouts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Override is a deadline of every tool of the server, or of the single tool,
+// if Tool is not empty. Zero timeout means that calls are not limited by
+// time.
+type Override struct {
+	Server  string
+	Tool    string
+	Timeout time.Duration
+}
+
+// Overrides defines per-server and per-tool deadlines of MCP tool calls.
+// It implements encoding.TextUnmarshaler to allow parsing from strings like
+// "{server-id}=30s,{server-id}/slow_tool=10m".
+//
+//nolint:recvcheck // it's necessary to use value receiver to prevent modifying envs
+type Overrides struct {
+	overrides []Override
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Format: {server}[/{tool}]={duration}[,...]. Server is validated by
+// consumer, since its format is specific to the service.
+func (o *Overrides) UnmarshalText(text []byte) error {
+	var overrides []Override
+
+	for item := range strings.SplitSeq(string(text), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		override, err := parseOverride(item)
+		if err != nil {
+			return err
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	o.overrides = overrides
+
+	return nil
+}
+
+// Overrides returns overrides in the order they were defined.
+func (o Overrides) Overrides() []Override { return o.overrides }
+
+func parseOverride(item string) (Override, error) {
+	key, value, ok := strings.Cut(item, "=")
+	if !ok {
+		return Override{}, ErrInvalidOverride
+	}
+
+	server, tool, hasTool := strings.Cut(key, "/")
+	if server == "" || (hasTool && tool == "") {
+		return Override{}, ErrInvalidOverride
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return Override{}, fmt.Errorf("invalid timeout of %q: %w", key, err)
+	}
+
+	if timeout < 0 {
+		return Override{}, fmt.Errorf("invalid timeout of %q: %w", key, ErrNegativeValue)
+	}
+
+	return Override{Server: server, Tool: tool, Timeout: timeout}, nil
+}
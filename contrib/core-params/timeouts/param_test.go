@@ -0,0 +1,31 @@
+package timeouts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/contrib/core-params/timeouts"
+)
+
+func TestOverrides_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	var overrides timeouts.Overrides
+	require.NoError(t, overrides.UnmarshalText([]byte("srv=30s, srv/slow=10m,other/fast=0s")))
+
+	assert.Equal(t, []timeouts.Override{
+		{Server: "srv", Timeout: 30 * time.Second},
+		{Server: "srv", Tool: "slow", Timeout: 10 * time.Minute},
+		{Server: "other", Tool: "fast", Timeout: 0},
+	}, overrides.Overrides())
+
+	require.NoError(t, overrides.UnmarshalText(nil))
+	assert.Empty(t, overrides.Overrides())
+
+	for _, raw := range []string{"srv", "=1s", "/tool=1s", "srv/=1s", "srv=1", "srv=-1s"} {
+		require.Error(t, overrides.UnmarshalText([]byte(raw)), raw)
+	}
+}
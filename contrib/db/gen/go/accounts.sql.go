@@ -76,7 +76,8 @@ func (q *Queries) DeleteTool(ctx context.Context, toolID uuid.UUID) error {
 
 const getAccount = `-- name: GetAccount :one
 SELECT
-	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding,
+	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding, a.status,
+	a.sampling_allowed,
 	ot.type, ot.access_token, ot.refresh_token, ot.expiry
 FROM agents.mcp_accounts a
 LEFT JOIN agents.oauth_tokens ot ON a.id = ot.account_id
@@ -84,17 +85,19 @@ WHERE a.id = $1 AND a.deleted_at IS NULL
 `
 
 type GetAccountRow struct {
-	ID           uuid.UUID
-	UserID       uuid.UUID
-	ServerID     uuid.UUID
-	Name         string
-	Description  string
-	DeletedAt    pgtype.Timestamptz
-	Embedding    *pgvector.Vector
-	Type         *string
-	AccessToken  *string
-	RefreshToken *string
-	Expiry       pgtype.Timestamptz
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	ServerID        uuid.UUID
+	Name            string
+	Description     string
+	DeletedAt       pgtype.Timestamptz
+	Embedding       *pgvector.Vector
+	Status          string
+	SamplingAllowed bool
+	Type            *string
+	AccessToken     *string
+	RefreshToken    *string
+	Expiry          pgtype.Timestamptz
 }
 
 // GetAccount retrieves a single MCP account by ID, including its OAuth token if present.
@@ -112,6 +115,8 @@ func (q *Queries) GetAccount(ctx context.Context, accountID uuid.UUID) (GetAccou
 		&i.Description,
 		&i.DeletedAt,
 		&i.Embedding,
+		&i.Status,
+		&i.SamplingAllowed,
 		&i.Type,
 		&i.AccessToken,
 		&i.RefreshToken,
@@ -122,7 +127,8 @@ func (q *Queries) GetAccount(ctx context.Context, accountID uuid.UUID) (GetAccou
 
 const getAccountsBatch = `-- name: GetAccountsBatch :many
 SELECT
-	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding,
+	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding, a.status,
+	a.sampling_allowed,
 	ot.type, ot.access_token, ot.refresh_token, ot.expiry
 FROM agents.mcp_accounts a
 LEFT JOIN agents.oauth_tokens ot ON a.id = ot.account_id
@@ -130,17 +136,19 @@ WHERE a.id = ANY($1::uuid[]) AND a.deleted_at IS NULL
 `
 
 type GetAccountsBatchRow struct {
-	ID           uuid.UUID
-	UserID       uuid.UUID
-	ServerID     uuid.UUID
-	Name         string
-	Description  string
-	DeletedAt    pgtype.Timestamptz
-	Embedding    *pgvector.Vector
-	Type         *string
-	AccessToken  *string
-	RefreshToken *string
-	Expiry       pgtype.Timestamptz
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	ServerID        uuid.UUID
+	Name            string
+	Description     string
+	DeletedAt       pgtype.Timestamptz
+	Embedding       *pgvector.Vector
+	Status          string
+	SamplingAllowed bool
+	Type            *string
+	AccessToken     *string
+	RefreshToken    *string
+	Expiry          pgtype.Timestamptz
 }
 
 // GetAccountsBatch retrieves multiple accounts by ID in a single query.
@@ -164,6 +172,8 @@ func (q *Queries) GetAccountsBatch(ctx context.Context, accountIds []uuid.UUID)
 			&i.Description,
 			&i.DeletedAt,
 			&i.Embedding,
+			&i.Status,
+			&i.SamplingAllowed,
 			&i.Type,
 			&i.AccessToken,
 			&i.RefreshToken,
@@ -180,7 +190,8 @@ func (q *Queries) GetAccountsBatch(ctx context.Context, accountIds []uuid.UUID)
 }
 
 const getTool = `-- name: GetTool :one
-SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at, a.name AS account_name
+SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at,
+       t.read_only_hint, t.idempotent_hint, t.cache_ttl_seconds, a.name AS account_name
 FROM agents.mcp_tools AS t
 JOIN agents.mcp_accounts AS a ON t.account_id = a.id
 WHERE t.id = $1 AND t.account_id = $2 AND t.deleted_at IS NULL
@@ -192,15 +203,18 @@ type GetToolParams struct {
 }
 
 type GetToolRow struct {
-	ID          uuid.UUID
-	AccountID   uuid.UUID
-	Name        string
-	Description string
-	Input       []byte
-	Output      []byte
-	Embedding   *pgvector.Vector
-	DeletedAt   pgtype.Timestamptz
-	AccountName string
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	Name            string
+	Description     string
+	Input           []byte
+	Output          []byte
+	Embedding       *pgvector.Vector
+	DeletedAt       pgtype.Timestamptz
+	ReadOnlyHint    bool
+	IdempotentHint  bool
+	CacheTtlSeconds int32
+	AccountName     string
 }
 
 func (q *Queries) GetTool(ctx context.Context, arg GetToolParams) (GetToolRow, error) {
@@ -215,13 +229,19 @@ func (q *Queries) GetTool(ctx context.Context, arg GetToolParams) (GetToolRow, e
 		&i.Output,
 		&i.Embedding,
 		&i.DeletedAt,
+		&i.ReadOnlyHint,
+		&i.IdempotentHint,
+		&i.CacheTtlSeconds,
 		&i.AccountName,
 	)
 	return i, err
 }
 
 const insertAccountTool = `-- name: InsertAccountTool :exec
-INSERT INTO agents.mcp_tools (id, account_id, name, description, input, output, deleted_at, embedding)
+INSERT INTO agents.mcp_tools (
+    id, account_id, name, description, input, output, deleted_at, embedding,
+    read_only_hint, idempotent_hint, cache_ttl_seconds
+)
 VALUES (
     $1,
     $2,
@@ -230,7 +250,10 @@ VALUES (
     $5,
     $6,
     NULL,
-    $7
+    $7,
+    $8,
+    $9,
+    $10
 )
 ON CONFLICT (id) DO UPDATE
 SET account_id = EXCLUDED.account_id,
@@ -239,17 +262,23 @@ SET account_id = EXCLUDED.account_id,
     input = EXCLUDED.input,
     output = EXCLUDED.output,
     embedding = EXCLUDED.embedding,
+    read_only_hint = EXCLUDED.read_only_hint,
+    idempotent_hint = EXCLUDED.idempotent_hint,
+    cache_ttl_seconds = EXCLUDED.cache_ttl_seconds,
     deleted_at = NULL
 `
 
 type InsertAccountToolParams struct {
-	ID          uuid.UUID
-	AccountID   uuid.UUID
-	Name        string
-	Description string
-	Input       []byte
-	Output      []byte
-	Embedding   *pgvector.Vector
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	Name            string
+	Description     string
+	Input           []byte
+	Output          []byte
+	Embedding       *pgvector.Vector
+	ReadOnlyHint    bool
+	IdempotentHint  bool
+	CacheTtlSeconds int32
 }
 
 // InsertAccountTool adds a discovered tool to the account's catalog.
@@ -264,6 +293,9 @@ func (q *Queries) InsertAccountTool(ctx context.Context, arg InsertAccountToolPa
 		arg.Input,
 		arg.Output,
 		arg.Embedding,
+		arg.ReadOnlyHint,
+		arg.IdempotentHint,
+		arg.CacheTtlSeconds,
 	)
 	return err
 }
@@ -304,23 +336,62 @@ func (q *Queries) ListAccountIDs(ctx context.Context, userID uuid.UUID) ([]ListA
 	return items, nil
 }
 
+const listActiveAccountIDs = `-- name: ListActiveAccountIDs :many
+SELECT id, user_id, server_id
+FROM agents.mcp_accounts
+WHERE status = 'active' AND deleted_at IS NULL
+ORDER BY id
+`
+
+type ListActiveAccountIDsRow struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	ServerID uuid.UUID
+}
+
+// ListActiveAccountIDs returns active accounts of every user. Used by
+// background jobs, which maintain accounts regardless of their owners.
+func (q *Queries) ListActiveAccountIDs(ctx context.Context) ([]ListActiveAccountIDsRow, error) {
+	rows, err := q.db.Query(ctx, listActiveAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActiveAccountIDsRow
+	for rows.Next() {
+		var i ListActiveAccountIDsRow
+		if err := rows.Scan(&i.ID, &i.UserID, &i.ServerID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listToolsForAccounts = `-- name: ListToolsForAccounts :many
-SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at, a.name AS account_name
+SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at,
+       t.read_only_hint, t.idempotent_hint, t.cache_ttl_seconds, a.name AS account_name
 FROM agents.mcp_tools AS t
 JOIN agents.mcp_accounts AS a ON t.account_id = a.id
 WHERE t.account_id = ANY($1::uuid[]) AND t.deleted_at IS NULL
 `
 
 type ListToolsForAccountsRow struct {
-	ID          uuid.UUID
-	AccountID   uuid.UUID
-	Name        string
-	Description string
-	Input       []byte
-	Output      []byte
-	Embedding   *pgvector.Vector
-	DeletedAt   pgtype.Timestamptz
-	AccountName string
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	Name            string
+	Description     string
+	Input           []byte
+	Output          []byte
+	Embedding       *pgvector.Vector
+	DeletedAt       pgtype.Timestamptz
+	ReadOnlyHint    bool
+	IdempotentHint  bool
+	CacheTtlSeconds int32
+	AccountName     string
 }
 
 // ListToolsForAccounts retrieves all active tools for a given set of accounts.
@@ -345,6 +416,9 @@ func (q *Queries) ListToolsForAccounts(ctx context.Context, accountIds []uuid.UU
 			&i.Output,
 			&i.Embedding,
 			&i.DeletedAt,
+			&i.ReadOnlyHint,
+			&i.IdempotentHint,
+			&i.CacheTtlSeconds,
 			&i.AccountName,
 		); err != nil {
 			return nil, err
@@ -358,11 +432,13 @@ func (q *Queries) ListToolsForAccounts(ctx context.Context, accountIds []uuid.UU
 }
 
 const searchToolsByEmbedding = `-- name: SearchToolsByEmbedding :many
-SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at, a.name AS account_name,
-       1 - (t.embedding <=> $1::vector) AS similarity
+SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at,
+       t.read_only_hint, t.idempotent_hint, t.cache_ttl_seconds, a.name AS account_name,
+       COALESCE(1 - (t.embedding <=> $1::vector), 0)::FLOAT8 AS similarity
 FROM agents.mcp_tools AS t
 JOIN agents.mcp_accounts AS a ON t.account_id = a.id
 WHERE t.deleted_at IS NULL
+  AND a.status = 'active'
   AND t.account_id = ANY($2::uuid[])
 ORDER BY t.embedding <=> $1::vector
 LIMIT $3
@@ -375,21 +451,27 @@ type SearchToolsByEmbeddingParams struct {
 }
 
 type SearchToolsByEmbeddingRow struct {
-	ID          uuid.UUID
-	AccountID   uuid.UUID
-	Name        string
-	Description string
-	Input       []byte
-	Output      []byte
-	Embedding   *pgvector.Vector
-	DeletedAt   pgtype.Timestamptz
-	AccountName string
-	Similarity  float64
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	Name            string
+	Description     string
+	Input           []byte
+	Output          []byte
+	Embedding       *pgvector.Vector
+	DeletedAt       pgtype.Timestamptz
+	ReadOnlyHint    bool
+	IdempotentHint  bool
+	CacheTtlSeconds int32
+	AccountName     string
+	Similarity      float64
 }
 
 // SearchToolsByEmbedding finds relevant tools using semantic similarity.
 // Core component of RAG: helps the agent pick the right tool for the job.
 //
+// Tools of inactive accounts are excluded: agents can't call them anyway.
+// Tools without embedding have zero similarity.
+//
 // Returns: Tools ordered by similarity (closest first).
 func (q *Queries) SearchToolsByEmbedding(ctx context.Context, arg SearchToolsByEmbeddingParams) ([]SearchToolsByEmbeddingRow, error) {
 	rows, err := q.db.Query(ctx, searchToolsByEmbedding, arg.QueryEmbedding, arg.AccountIds, arg.LimitCount)
@@ -409,6 +491,9 @@ func (q *Queries) SearchToolsByEmbedding(ctx context.Context, arg SearchToolsByE
 			&i.Output,
 			&i.Embedding,
 			&i.DeletedAt,
+			&i.ReadOnlyHint,
+			&i.IdempotentHint,
+			&i.CacheTtlSeconds,
 			&i.AccountName,
 			&i.Similarity,
 		); err != nil {
@@ -425,7 +510,7 @@ func (q *Queries) SearchToolsByEmbedding(ctx context.Context, arg SearchToolsByE
 const softDeleteAccount = `-- name: SoftDeleteAccount :exec
 WITH deleted_account AS (
     UPDATE agents.mcp_accounts
-    SET deleted_at = NOW()
+    SET deleted_at = NOW(), status = 'deleted'
     WHERE id = $1::UUID
     RETURNING id
 )
@@ -461,7 +546,8 @@ func (q *Queries) UpdateToolEmbedding(ctx context.Context, arg UpdateToolEmbeddi
 }
 
 const upsertAccount = `-- name: UpsertAccount :exec
-INSERT INTO agents.mcp_accounts (id, user_id, server_id, name, description, deleted_at, embedding)
+INSERT INTO agents.mcp_accounts (id, user_id, server_id, name, description, deleted_at, embedding, status,
+    sampling_allowed)
 VALUES (
     $1,
     $2,
@@ -469,7 +555,9 @@ VALUES (
     $4,
     $5,
     NULL,
-    $6
+    $6,
+    $7,
+    $8
 )
 ON CONFLICT (id) DO UPDATE
 SET user_id = EXCLUDED.user_id,
@@ -477,16 +565,20 @@ SET user_id = EXCLUDED.user_id,
     name = EXCLUDED.name,
     description = EXCLUDED.description,
     embedding = EXCLUDED.embedding,
+    status = EXCLUDED.status,
+    sampling_allowed = EXCLUDED.sampling_allowed,
     deleted_at = NULL
 `
 
 type UpsertAccountParams struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	ServerID    uuid.UUID
-	Name        string
-	Description string
-	Embedding   *pgvector.Vector
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	ServerID        uuid.UUID
+	Name            string
+	Description     string
+	Embedding       *pgvector.Vector
+	Status          string
+	SamplingAllowed bool
 }
 
 // UpsertAccount creates or updates an MCP account.
@@ -500,6 +592,8 @@ func (q *Queries) UpsertAccount(ctx context.Context, arg UpsertAccountParams) er
 		arg.Name,
 		arg.Description,
 		arg.Embedding,
+		arg.Status,
+		arg.SamplingAllowed,
 	)
 	return err
 }
@@ -24,7 +24,7 @@ func (q *Queries) DeleteAgentSettings(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAgentSettings = `-- name: GetAgentSettings :one
-SELECT id, user_id, model, system_message, temperature, top_p, max_context, stop_words
+SELECT id, user_id, name, model, system_message, temperature, top_p, max_context, stop_words, budget, disabled
 FROM agents.agent_settings
 WHERE id = $1::UUID
 `
@@ -37,27 +37,30 @@ func (q *Queries) GetAgentSettings(ctx context.Context, id uuid.UUID) (AgentsAge
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
+		&i.Name,
 		&i.Model,
 		&i.SystemMessage,
 		&i.Temperature,
 		&i.TopP,
 		&i.MaxContext,
 		&i.StopWords,
+		&i.Budget,
+		&i.Disabled,
 	)
 	return i, err
 }
 
 const listAgentSettings = `-- name: ListAgentSettings :many
-SELECT id, user_id, model, system_message, temperature, top_p, max_context, stop_words
+SELECT id, user_id, name, model, system_message, temperature, top_p, max_context, stop_words, budget, disabled
 FROM agents.agent_settings
 WHERE user_id = $1::UUID
-ORDER BY model
+ORDER BY name, id
 `
 
 // ListAgentSettings retrieves all configured agent settings profiles.
 // Used for admin dashboards or selection menus.
 //
-// Returns: All settings ordered by model name.
+// Returns: All settings ordered by agent name.
 func (q *Queries) ListAgentSettings(ctx context.Context, userID uuid.UUID) ([]AgentsAgentSetting, error) {
 	rows, err := q.db.Query(ctx, listAgentSettings, userID)
 	if err != nil {
@@ -70,12 +73,15 @@ func (q *Queries) ListAgentSettings(ctx context.Context, userID uuid.UUID) ([]Ag
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
+			&i.Name,
 			&i.Model,
 			&i.SystemMessage,
 			&i.Temperature,
 			&i.TopP,
 			&i.MaxContext,
 			&i.StopWords,
+			&i.Budget,
+			&i.Disabled,
 		); err != nil {
 			return nil, err
 		}
@@ -88,7 +94,7 @@ func (q *Queries) ListAgentSettings(ctx context.Context, userID uuid.UUID) ([]Ag
 }
 
 const upsertAgentSettings = `-- name: UpsertAgentSettings :exec
-INSERT INTO agents.agent_settings (id, user_id, model, system_message, temperature, top_p, max_context, stop_words)
+INSERT INTO agents.agent_settings (id, user_id, name, model, system_message, temperature, top_p, max_context, stop_words, budget, disabled)
 VALUES (
     $1::UUID,
     $2::UUID,
@@ -97,26 +103,35 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10,
+    $11
 )
 ON CONFLICT (id) DO UPDATE SET
+	name = EXCLUDED.name,
 	model = EXCLUDED.model,
 	system_message = EXCLUDED.system_message,
 	temperature = EXCLUDED.temperature,
 	top_p = EXCLUDED.top_p,
 	max_context = EXCLUDED.max_context,
-	stop_words = EXCLUDED.stop_words
+	stop_words = EXCLUDED.stop_words,
+	budget = EXCLUDED.budget,
+	disabled = EXCLUDED.disabled
 `
 
 type UpsertAgentSettingsParams struct {
 	ID            uuid.UUID
 	UserID        uuid.UUID
+	Name          string
 	Model         string
 	SystemMessage string
 	Temperature   float32
 	TopP          float32
 	MaxContext    int32
 	StopWords     []string
+	Budget        []byte
+	Disabled      bool
 }
 
 // UpsertAgentSettings creates or updates a configuration profile.
@@ -125,12 +140,15 @@ func (q *Queries) UpsertAgentSettings(ctx context.Context, arg UpsertAgentSettin
 	_, err := q.db.Exec(ctx, upsertAgentSettings,
 		arg.ID,
 		arg.UserID,
+		arg.Name,
 		arg.Model,
 		arg.SystemMessage,
 		arg.Temperature,
 		arg.TopP,
 		arg.MaxContext,
 		arg.StopWords,
+		arg.Budget,
+		arg.Disabled,
 	)
 	return err
 }
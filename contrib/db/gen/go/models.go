@@ -13,22 +13,74 @@ import (
 type AgentsAgentSetting struct {
 	ID            uuid.UUID
 	UserID        uuid.UUID
+	Name          string
 	Model         string
 	SystemMessage string
 	Temperature   float32
 	TopP          float32
 	MaxContext    int32
 	StopWords     []string
+	Budget        []byte
+	Disabled      bool
+}
+
+type AgentsBlob struct {
+	ID        uuid.UUID
+	ThreadID  string
+	ObjectID  uint32
+	Size      int64
+	CreatedAt pgtype.Timestamptz
+}
+
+type AgentsLink struct {
+	Token     string
+	ThreadID  string
+	Url       string
+	CreatedAt pgtype.Timestamptz
+	UsedAt    pgtype.Timestamptz
 }
 
 type AgentsMcpAccount struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	ServerID    uuid.UUID
-	DeletedAt   pgtype.Timestamptz
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	ServerID        uuid.UUID
+	DeletedAt       pgtype.Timestamptz
+	Status          string
+	SamplingAllowed bool
+	Name            string
+	Description     string
+	Embedding       *pgvector.Vector
+}
+
+type AgentsMcpCatalog struct {
 	Name        string
+	Title       string
+	Url         string
 	Description string
+	AuthType    string
+	Categories  []string
 	Embedding   *pgvector.Vector
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type AgentsMcpPrompt struct {
+	AccountID   uuid.UUID
+	Name        string
+	Title       string
+	Description string
+	Arguments   []byte
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type AgentsMcpResource struct {
+	AccountID   uuid.UUID
+	Uri         string
+	Name        string
+	Title       string
+	Description string
+	MimeType    string
+	Size        int64
+	UpdatedAt   pgtype.Timestamptz
 }
 
 type AgentsMcpServer struct {
@@ -40,14 +92,17 @@ type AgentsMcpServer struct {
 }
 
 type AgentsMcpTool struct {
-	ID          uuid.UUID
-	AccountID   uuid.UUID
-	DeletedAt   pgtype.Timestamptz
-	Name        string
-	Description string
-	Input       []byte
-	Output      []byte
-	Embedding   *pgvector.Vector
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	DeletedAt       pgtype.Timestamptz
+	Name            string
+	Description     string
+	Input           []byte
+	Output          []byte
+	Embedding       *pgvector.Vector
+	ReadOnlyHint    bool
+	IdempotentHint  bool
+	CacheTtlSeconds int32
 }
 
 type AgentsMessage struct {
@@ -83,6 +138,7 @@ type AgentsMessagesToolResult struct {
 	ToolCallID      string
 	IsError         bool
 	Content         []byte
+	Attachments     []byte
 }
 
 type AgentsMessagesUser struct {
@@ -115,4 +171,34 @@ type AgentsThread struct {
 	UserID         uuid.UUID
 	CreatedAt      pgtype.Timestamptz
 	LastMessagePos int64
+	Paused         bool
+}
+
+type AgentsUsageDaily struct {
+	AgentID      uuid.UUID
+	UserID       uuid.UUID
+	Day          pgtype.Date
+	InputTokens  int64
+	OutputTokens int64
+	CostMicros   int64
+}
+
+type AgentsUsageLedger struct {
+	ID           int64
+	UserID       uuid.UUID
+	AgentID      uuid.UUID
+	ThreadID     string
+	Model        string
+	InputTokens  int64
+	OutputTokens int64
+	DurationMs   int64
+	ToolCalls    int64
+	Errors       int64
+	CreatedAt    pgtype.Timestamptz
+}
+
+type AgentsUserPlan struct {
+	UserID    uuid.UUID
+	Plan      string
+	UpdatedAt pgtype.Timestamptz
 }
@@ -38,6 +38,7 @@ SELECT
     t.user_id,
     t.last_message_pos,
     t.created_at AS thread_created_at,
+    t.paused,
     m.position,
     m.msg_type,
     m.merge_tag,
@@ -55,6 +56,7 @@ SELECT
     ts.tool_call_id AS result_tool_call_id,
     ts.is_error AS result_is_error,
     ts.content AS result_content,
+    ts.attachments AS result_attachments,
     tr_origin.tool_name AS result_tool_name
 FROM agents.threads t
 LEFT JOIN agents.messages m ON t.id = m.thread_id
@@ -77,6 +79,7 @@ type GetThreadWithMessagesRow struct {
 	UserID                uuid.UUID
 	LastMessagePos        int64
 	ThreadCreatedAt       pgtype.Timestamptz
+	Paused                bool
 	Position              *int64
 	MsgType               *string
 	MergeTag              *int64
@@ -94,6 +97,7 @@ type GetThreadWithMessagesRow struct {
 	ResultToolCallID      *string
 	ResultIsError         *bool
 	ResultContent         []byte
+	ResultAttachments     []byte
 	ResultToolName        *string
 }
 
@@ -102,7 +106,7 @@ type GetThreadWithMessagesRow struct {
 //
 // Returns:
 //
-//	First row contains thread metadata (id, last_message_pos, created_at)
+//	First row contains thread metadata (id, last_message_pos, created_at, paused)
 //	Subsequent rows contain messages with type-specific data via LEFT JOINs
 //
 // The last_message_pos field is critical for Optimistic Concurrency Control.
@@ -125,6 +129,7 @@ func (q *Queries) GetThreadWithMessages(ctx context.Context, id string) ([]GetTh
 			&i.UserID,
 			&i.LastMessagePos,
 			&i.ThreadCreatedAt,
+			&i.Paused,
 			&i.Position,
 			&i.MsgType,
 			&i.MergeTag,
@@ -142,6 +147,7 @@ func (q *Queries) GetThreadWithMessages(ctx context.Context, id string) ([]GetTh
 			&i.ResultToolCallID,
 			&i.ResultIsError,
 			&i.ResultContent,
+			&i.ResultAttachments,
 			&i.ResultToolName,
 		); err != nil {
 			return nil, err
@@ -296,18 +302,18 @@ func (q *Queries) InsertMessageToolRequest(ctx context.Context, arg InsertMessag
 const insertMessageToolResult = `-- name: InsertMessageToolResult :execrows
 WITH update_thread AS (
     UPDATE agents.threads
-    SET last_message_pos = $5::BIGINT
-    WHERE id = $6::TEXT AND last_message_pos = $7::BIGINT
+    SET last_message_pos = $6::BIGINT
+    WHERE id = $7::TEXT AND last_message_pos = $8::BIGINT
     RETURNING id
 ),
 msg AS (
     INSERT INTO agents.messages (thread_id, position, msg_type, merge_tag, created_at)
-    SELECT $6::TEXT, $5::BIGINT, 'tool_result', $8::BIGINT, NOW()
+    SELECT $7::TEXT, $6::BIGINT, 'tool_result', $9::BIGINT, NOW()
     FROM update_thread
     RETURNING thread_id, position
 )
-INSERT INTO agents.messages_tool_result (thread_id, position, request_position, tool_call_id, is_error, content)
-SELECT thread_id, position, $1::BIGINT, $2, $3, $4 FROM msg
+INSERT INTO agents.messages_tool_result (thread_id, position, request_position, tool_call_id, is_error, content, attachments)
+SELECT thread_id, position, $1::BIGINT, $2, $3, $4, $5 FROM msg
 `
 
 type InsertMessageToolResultParams struct {
@@ -315,6 +321,7 @@ type InsertMessageToolResultParams struct {
 	ToolCallID            string
 	IsError               bool
 	Content               []byte
+	Attachments           []byte
 	Position              int64
 	ThreadID              string
 	CurrentLastMessagePos int64
@@ -334,6 +341,7 @@ type InsertMessageToolResultParams struct {
 //	tool_call_id (must match the request's tool_call_id)
 //	is_error (false for success, true for errors)
 //	content (JSONB - either success result or error details)
+//	attachments (JSONB array - media content of the result)
 //
 // The request_position FK with ON DELETE CASCADE ensures referential integrity.
 func (q *Queries) InsertMessageToolResult(ctx context.Context, arg InsertMessageToolResultParams) (int64, error) {
@@ -342,6 +350,7 @@ func (q *Queries) InsertMessageToolResult(ctx context.Context, arg InsertMessage
 		arg.ToolCallID,
 		arg.IsError,
 		arg.Content,
+		arg.Attachments,
 		arg.Position,
 		arg.ThreadID,
 		arg.CurrentLastMessagePos,
@@ -412,3 +421,25 @@ func (q *Queries) InsertMessageUser(ctx context.Context, arg InsertMessageUserPa
 	}
 	return result.RowsAffected(), nil
 }
+
+const setThreadPaused = `-- name: SetThreadPaused :execrows
+UPDATE agents.threads SET paused = $1
+WHERE id = $2 AND paused <> $1
+`
+
+type SetThreadPausedParams struct {
+	Paused bool
+	ID     string
+}
+
+// SetThreadPaused marks thread as stopped at turn limit, or clears this mark,
+// when agent loop is resumed. Doesn't change last_message_pos: pause is not a
+// message. Mark is changed only if it differs, so concurrent resumes of the
+// same thread affect single row only once.
+func (q *Queries) SetThreadPaused(ctx context.Context, arg SetThreadPausedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setThreadPaused, arg.Paused, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -5,11 +5,11 @@ go 1.25.1
 require (
 	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.8.0
+	github.com/pgvector/pgvector-go v0.3.0
 )
 
 require (
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
-	github.com/pgvector/pgvector-go v0.3.0 // indirect
 	golang.org/x/text v0.33.0 // indirect
 )
@@ -6,13 +6,23 @@ SELECT id, server_id, name
 FROM agents.mcp_accounts
 WHERE user_id = sqlc.arg('user_id')::UUID AND deleted_at IS NULL;
 
+-- ListActiveAccountIDs returns active accounts of every user. Used by
+-- background jobs, which maintain accounts regardless of their owners.
+--
+-- name: ListActiveAccountIDs :many
+SELECT id, user_id, server_id
+FROM agents.mcp_accounts
+WHERE status = 'active' AND deleted_at IS NULL
+ORDER BY id;
+
 -- GetAccount retrieves a single MCP account by ID, including its OAuth token if present.
 -- The deleted_at filter ensures soft-deleted accounts are excluded.
 --
 -- Returns: Account details and associated OAuth token info (null if no token).
 -- name: GetAccount :one
 SELECT
-	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding,
+	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding, a.status,
+	a.sampling_allowed,
 	ot.type, ot.access_token, ot.refresh_token, ot.expiry
 FROM agents.mcp_accounts a
 LEFT JOIN agents.oauth_tokens ot ON a.id = ot.account_id
@@ -24,7 +34,8 @@ WHERE a.id = sqlc.arg('account_id') AND a.deleted_at IS NULL;
 -- Returns: List of accounts matched by IDs.
 -- name: GetAccountsBatch :many
 SELECT
-	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding,
+	a.id, a.user_id, a.server_id, a.name, a.description, a.deleted_at, a.embedding, a.status,
+	a.sampling_allowed,
 	ot.type, ot.access_token, ot.refresh_token, ot.expiry
 FROM agents.mcp_accounts a
 LEFT JOIN agents.oauth_tokens ot ON a.id = ot.account_id
@@ -35,7 +46,8 @@ WHERE a.id = ANY(sqlc.arg('account_ids')::uuid[]) AND a.deleted_at IS NULL;
 -- RESETs deleted_at to NULL if the account was previously soft-deleted.
 --
 -- name: UpsertAccount :exec
-INSERT INTO agents.mcp_accounts (id, user_id, server_id, name, description, deleted_at, embedding)
+INSERT INTO agents.mcp_accounts (id, user_id, server_id, name, description, deleted_at, embedding, status,
+    sampling_allowed)
 VALUES (
     sqlc.arg('id'),
     sqlc.arg('user_id'),
@@ -43,7 +55,9 @@ VALUES (
     sqlc.arg('name'),
     sqlc.arg('description'),
     NULL,
-    sqlc.arg('embedding')
+    sqlc.arg('embedding'),
+    sqlc.arg('status'),
+    sqlc.arg('sampling_allowed')
 )
 ON CONFLICT (id) DO UPDATE
 SET user_id = EXCLUDED.user_id,
@@ -51,6 +65,8 @@ SET user_id = EXCLUDED.user_id,
     name = EXCLUDED.name,
     description = EXCLUDED.description,
     embedding = EXCLUDED.embedding,
+    status = EXCLUDED.status,
+    sampling_allowed = EXCLUDED.sampling_allowed,
     deleted_at = NULL;
 
 -- AddOAuthToken saves or updates OAuth credentials for an account.
@@ -77,7 +93,7 @@ ON CONFLICT (account_id) DO UPDATE SET
 -- name: SoftDeleteAccount :exec
 WITH deleted_account AS (
     UPDATE agents.mcp_accounts
-    SET deleted_at = NOW()
+    SET deleted_at = NOW(), status = 'deleted'
     WHERE id = sqlc.arg('account_id')::UUID
     RETURNING id
 )
@@ -91,7 +107,10 @@ WHERE account_id IN (SELECT id FROM deleted_account)
 -- RESETs deleted_at to NULL if tool existed previously.
 --
 -- name: InsertAccountTool :exec
-INSERT INTO agents.mcp_tools (id, account_id, name, description, input, output, deleted_at, embedding)
+INSERT INTO agents.mcp_tools (
+    id, account_id, name, description, input, output, deleted_at, embedding,
+    read_only_hint, idempotent_hint, cache_ttl_seconds
+)
 VALUES (
     sqlc.arg('id'),
     sqlc.arg('account_id'),
@@ -100,7 +119,10 @@ VALUES (
     sqlc.arg('input'),
     sqlc.arg('output'),
     NULL,
-    sqlc.arg('embedding')
+    sqlc.arg('embedding'),
+    sqlc.arg('read_only_hint'),
+    sqlc.arg('idempotent_hint'),
+    sqlc.arg('cache_ttl_seconds')
 )
 ON CONFLICT (id) DO UPDATE
 SET account_id = EXCLUDED.account_id,
@@ -109,6 +131,9 @@ SET account_id = EXCLUDED.account_id,
     input = EXCLUDED.input,
     output = EXCLUDED.output,
     embedding = EXCLUDED.embedding,
+    read_only_hint = EXCLUDED.read_only_hint,
+    idempotent_hint = EXCLUDED.idempotent_hint,
+    cache_ttl_seconds = EXCLUDED.cache_ttl_seconds,
     deleted_at = NULL;
 
 -- ListToolsForAccounts retrieves all active tools for a given set of accounts.
@@ -116,7 +141,8 @@ SET account_id = EXCLUDED.account_id,
 --
 -- Returns: All non-deleted tools belonging to valid accounts.
 -- name: ListToolsForAccounts :many
-SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at, a.name AS account_name
+SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at,
+       t.read_only_hint, t.idempotent_hint, t.cache_ttl_seconds, a.name AS account_name
 FROM agents.mcp_tools AS t
 JOIN agents.mcp_accounts AS a ON t.account_id = a.id
 WHERE t.account_id = ANY(sqlc.arg('account_ids')::uuid[]) AND t.deleted_at IS NULL;
@@ -141,19 +167,25 @@ WHERE id = sqlc.arg('tool_id');
 -- SearchToolsByEmbedding finds relevant tools using semantic similarity.
 -- Core component of RAG: helps the agent pick the right tool for the job.
 --
+-- Tools of inactive accounts are excluded: agents can't call them anyway.
+-- Tools without embedding have zero similarity.
+--
 -- Returns: Tools ordered by similarity (closest first).
 -- name: SearchToolsByEmbedding :many
-SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at, a.name AS account_name,
-       1 - (t.embedding <=> sqlc.arg('query_embedding')::vector) AS similarity
+SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at,
+       t.read_only_hint, t.idempotent_hint, t.cache_ttl_seconds, a.name AS account_name,
+       COALESCE(1 - (t.embedding <=> sqlc.arg('query_embedding')::vector), 0)::FLOAT8 AS similarity
 FROM agents.mcp_tools AS t
 JOIN agents.mcp_accounts AS a ON t.account_id = a.id
 WHERE t.deleted_at IS NULL
+  AND a.status = 'active'
   AND t.account_id = ANY(sqlc.arg('account_ids')::uuid[])
 ORDER BY t.embedding <=> sqlc.arg('query_embedding')::vector
 LIMIT sqlc.arg('limit_count');
 
 -- name: GetTool :one
-SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at, a.name AS account_name
+SELECT t.id, t.account_id, t.name, t.description, t.input, t.output, t.embedding, t.deleted_at,
+       t.read_only_hint, t.idempotent_hint, t.cache_ttl_seconds, a.name AS account_name
 FROM agents.mcp_tools AS t
 JOIN agents.mcp_accounts AS a ON t.account_id = a.id
 WHERE t.id = sqlc.arg('tool_id') AND t.account_id = sqlc.arg('account_id') AND t.deleted_at IS NULL;
@@ -1,18 +1,18 @@
 -- ListAgentSettings retrieves all configured agent settings profiles.
 -- Used for admin dashboards or selection menus.
 --
--- Returns: All settings ordered by model name.
+-- Returns: All settings ordered by agent name.
 -- name: ListAgentSettings :many
-SELECT id, user_id, model, system_message, temperature, top_p, max_context, stop_words
+SELECT id, user_id, name, model, system_message, temperature, top_p, max_context, stop_words, budget, disabled
 FROM agents.agent_settings
 WHERE user_id = sqlc.arg('user_id')::UUID
-ORDER BY model;
+ORDER BY name, id;
 
 -- GetAgentSettings retrieves a specific configuration profile.
 -- Critical for the Agent Loop: loaded before processing messages to configure the LLM.
 --
 -- name: GetAgentSettings :one
-SELECT id, user_id, model, system_message, temperature, top_p, max_context, stop_words
+SELECT id, user_id, name, model, system_message, temperature, top_p, max_context, stop_words, budget, disabled
 FROM agents.agent_settings
 WHERE id = sqlc.arg('id')::UUID;
 
@@ -20,24 +20,30 @@ WHERE id = sqlc.arg('id')::UUID;
 -- Used when creating a new agent persona or tuning parameters.
 --
 -- name: UpsertAgentSettings :exec
-INSERT INTO agents.agent_settings (id, user_id, model, system_message, temperature, top_p, max_context, stop_words)
+INSERT INTO agents.agent_settings (id, user_id, name, model, system_message, temperature, top_p, max_context, stop_words, budget, disabled)
 VALUES (
     sqlc.arg('id')::UUID,
     sqlc.arg('user_id')::UUID,
+    sqlc.arg('name'),
     sqlc.arg('model'),
     sqlc.arg('system_message'),
     sqlc.arg('temperature'),
     sqlc.arg('top_p'),
     sqlc.arg('max_context'),
-    sqlc.narg('stop_words')
+    sqlc.narg('stop_words'),
+    sqlc.arg('budget'),
+    sqlc.arg('disabled')
 )
 ON CONFLICT (id) DO UPDATE SET
+	name = EXCLUDED.name,
 	model = EXCLUDED.model,
 	system_message = EXCLUDED.system_message,
 	temperature = EXCLUDED.temperature,
 	top_p = EXCLUDED.top_p,
 	max_context = EXCLUDED.max_context,
-	stop_words = EXCLUDED.stop_words;
+	stop_words = EXCLUDED.stop_words,
+	budget = EXCLUDED.budget,
+	disabled = EXCLUDED.disabled;
 
 -- DeleteAgentSettings removes a configuration profile.
 -- HARD delete allowed here as settings are lightweight configuration.
@@ -0,0 +1,19 @@
+-- InsertBlob registers large object with tool output in the thread.
+-- Content itself is written through large object API in the same transaction.
+--
+-- name: InsertBlob :exec
+INSERT INTO agents.blobs (id, thread_id, object_id, size)
+VALUES (
+	sqlc.arg('id')::UUID,
+	sqlc.arg('thread_id'),
+	sqlc.arg('object_id'),
+	sqlc.arg('size')
+);
+
+-- GetBlob finds large object of the blob. Thread id is checked to prevent
+-- reading blobs of other threads.
+--
+-- name: GetBlob :one
+SELECT object_id, size
+FROM agents.blobs
+WHERE id = sqlc.arg('id')::UUID AND thread_id = sqlc.arg('thread_id');
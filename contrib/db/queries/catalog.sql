@@ -0,0 +1,54 @@
+-- UpsertCatalogEntry creates or updates catalog entry by its name.
+-- Used on import: importing the same registry file twice updates entries.
+--
+-- name: UpsertCatalogEntry :exec
+INSERT INTO agents.mcp_catalog (name, title, url, description, auth_type, categories, embedding, updated_at)
+VALUES (
+    sqlc.arg('name'),
+    sqlc.arg('title'),
+    sqlc.arg('url'),
+    sqlc.arg('description'),
+    sqlc.arg('auth_type'),
+    sqlc.arg('categories'),
+    sqlc.narg('embedding'),
+    NOW()
+)
+ON CONFLICT (name) DO UPDATE SET
+	title = EXCLUDED.title,
+	url = EXCLUDED.url,
+	description = EXCLUDED.description,
+	auth_type = EXCLUDED.auth_type,
+	categories = EXCLUDED.categories,
+	embedding = EXCLUDED.embedding,
+	updated_at = EXCLUDED.updated_at;
+
+-- SearchCatalog performs full-text search over catalog entries. Catalog is
+-- small, so documents are built on the fly. Substring match of name and title
+-- helps with queries like "todo", which are not full words.
+--
+-- Returns: Entries ordered by rank (most relevant first).
+-- name: SearchCatalog :many
+SELECT name, title, url, description, auth_type, categories,
+       ts_rank(
+           to_tsvector('simple', name || ' ' || title || ' ' || description || ' ' || array_to_string(categories, ' ')),
+           websearch_to_tsquery('simple', sqlc.arg('query'))
+       )::FLOAT8 AS rank
+FROM agents.mcp_catalog
+WHERE to_tsvector('simple', name || ' ' || title || ' ' || description || ' ' || array_to_string(categories, ' '))
+        @@ websearch_to_tsquery('simple', sqlc.arg('query'))
+   OR name ILIKE '%' || sqlc.arg('query') || '%'
+   OR title ILIKE '%' || sqlc.arg('query') || '%'
+ORDER BY rank DESC, name
+LIMIT sqlc.arg('limit_count');
+
+-- SearchCatalogByEmbedding finds catalog entries using semantic similarity.
+-- Entries without embedding are skipped.
+--
+-- Returns: Entries ordered by similarity (closest first).
+-- name: SearchCatalogByEmbedding :many
+SELECT name, title, url, description, auth_type, categories,
+       (1 - (embedding <=> sqlc.arg('query_embedding')::vector))::FLOAT8 AS similarity
+FROM agents.mcp_catalog
+WHERE embedding IS NOT NULL
+ORDER BY embedding <=> sqlc.arg('query_embedding')::vector
+LIMIT sqlc.arg('limit_count');
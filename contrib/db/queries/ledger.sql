@@ -0,0 +1,40 @@
+-- AddLedgerEntry appends record of agent turn.
+--
+-- name: AddLedgerEntry :exec
+INSERT INTO agents.usage_ledger (
+	user_id, agent_id, thread_id, model,
+	input_tokens, output_tokens, duration_ms, tool_calls, errors, created_at
+)
+VALUES (
+	sqlc.arg('user_id'),
+	sqlc.arg('agent_id'),
+	sqlc.arg('thread_id'),
+	sqlc.arg('model'),
+	sqlc.arg('input_tokens'),
+	sqlc.arg('output_tokens'),
+	sqlc.arg('duration_ms'),
+	sqlc.arg('tool_calls'),
+	sqlc.arg('errors'),
+	sqlc.arg('created_at')
+);
+
+-- ListLedgerSummaries sums turns of user agents in [since, until) period by
+-- day (UTC), agent and model.
+--
+-- name: ListLedgerSummaries :many
+SELECT
+	(created_at AT TIME ZONE 'UTC')::DATE AS day,
+	agent_id,
+	model,
+	COUNT(*)::BIGINT AS turns,
+	SUM(input_tokens)::BIGINT AS input_tokens,
+	SUM(output_tokens)::BIGINT AS output_tokens,
+	SUM(duration_ms)::BIGINT AS duration_ms,
+	SUM(tool_calls)::BIGINT AS tool_calls,
+	SUM(errors)::BIGINT AS errors
+FROM agents.usage_ledger
+WHERE user_id = sqlc.arg('user_id')
+	AND created_at >= sqlc.arg('since')
+	AND created_at < sqlc.arg('until')
+GROUP BY day, agent_id, model
+ORDER BY day, agent_id, model;
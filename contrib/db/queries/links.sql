@@ -0,0 +1,26 @@
+-- UpsertLink binds token to the url. If url is already saved in the thread,
+-- its token is returned and marked as used instead. Token of another url
+-- fails with unique violation.
+--
+-- name: UpsertLink :one
+INSERT INTO agents.links (token, thread_id, url)
+VALUES (
+	sqlc.arg('token'),
+	sqlc.arg('thread_id'),
+	sqlc.arg('url')
+)
+ON CONFLICT (thread_id, url) DO UPDATE SET used_at = NOW()
+RETURNING token;
+
+-- GetLink resolves token to the original url.
+--
+-- name: GetLink :one
+SELECT url
+FROM agents.links
+WHERE token = sqlc.arg('token');
+
+-- DeleteLinksBefore removes links, which were not used since the given time.
+--
+-- name: DeleteLinksBefore :exec
+DELETE FROM agents.links
+WHERE used_at < sqlc.arg('before');
@@ -0,0 +1,15 @@
+-- GetUserPlan returns name of the plan, assigned to the user.
+--
+-- name: GetUserPlan :one
+SELECT plan
+FROM agents.user_plans
+WHERE user_id = sqlc.arg('user_id');
+
+-- SetUserPlan assigns plan to the user, replacing previous one.
+--
+-- name: SetUserPlan :exec
+INSERT INTO agents.user_plans (user_id, plan, updated_at)
+VALUES (sqlc.arg('user_id'), sqlc.arg('plan'), NOW())
+ON CONFLICT (user_id) DO UPDATE SET
+	plan = EXCLUDED.plan,
+	updated_at = EXCLUDED.updated_at;
@@ -0,0 +1,37 @@
+-- ListUserPrompts returns metadata of prompts, exposed by active accounts of
+-- the user. Prompts of disabled or deleted accounts are hidden, same as their
+-- tools and resources.
+--
+-- Returns: Prompts ordered by account and name.
+-- name: ListUserPrompts :many
+SELECT p.account_id, a.server_id, p.name, p.title, p.description, p.arguments
+FROM agents.mcp_prompts AS p
+JOIN agents.mcp_accounts AS a ON p.account_id = a.id
+WHERE a.user_id = sqlc.arg('user_id') AND a.status = 'active' AND a.deleted_at IS NULL
+ORDER BY p.account_id, p.name;
+
+-- UpsertPrompt creates or updates prompt metadata by account and name.
+--
+-- name: UpsertPrompt :exec
+INSERT INTO agents.mcp_prompts (account_id, name, title, description, arguments, updated_at)
+VALUES (
+    sqlc.arg('account_id'),
+    sqlc.arg('name'),
+    sqlc.arg('title'),
+    sqlc.arg('description'),
+    sqlc.arg('arguments'),
+    NOW()
+)
+ON CONFLICT (account_id, name) DO UPDATE SET
+	title = EXCLUDED.title,
+	description = EXCLUDED.description,
+	arguments = EXCLUDED.arguments,
+	updated_at = EXCLUDED.updated_at;
+
+-- DeletePromptsExcept removes prompts of the account, which server doesn't
+-- expose anymore. Empty list removes all prompts of the account.
+--
+-- name: DeletePromptsExcept :exec
+DELETE FROM agents.mcp_prompts
+WHERE account_id = sqlc.arg('account_id')
+  AND NOT (name = ANY(sqlc.arg('keep_names')::text[]));
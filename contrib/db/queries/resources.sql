@@ -0,0 +1,41 @@
+-- ListUserResources returns metadata of resources, exposed by active accounts
+-- of the user. Resources of disabled or deleted accounts are hidden, same as
+-- their tools.
+--
+-- Returns: Resources ordered by account and URI.
+-- name: ListUserResources :many
+SELECT r.account_id, a.server_id, r.uri, r.name, r.title, r.description, r.mime_type, r.size
+FROM agents.mcp_resources AS r
+JOIN agents.mcp_accounts AS a ON r.account_id = a.id
+WHERE a.user_id = sqlc.arg('user_id') AND a.status = 'active' AND a.deleted_at IS NULL
+ORDER BY r.account_id, r.uri;
+
+-- UpsertResource creates or updates resource metadata by account and URI.
+--
+-- name: UpsertResource :exec
+INSERT INTO agents.mcp_resources (account_id, uri, name, title, description, mime_type, size, updated_at)
+VALUES (
+    sqlc.arg('account_id'),
+    sqlc.arg('uri'),
+    sqlc.arg('name'),
+    sqlc.arg('title'),
+    sqlc.arg('description'),
+    sqlc.arg('mime_type'),
+    sqlc.arg('size'),
+    NOW()
+)
+ON CONFLICT (account_id, uri) DO UPDATE SET
+	name = EXCLUDED.name,
+	title = EXCLUDED.title,
+	description = EXCLUDED.description,
+	mime_type = EXCLUDED.mime_type,
+	size = EXCLUDED.size,
+	updated_at = EXCLUDED.updated_at;
+
+-- DeleteResourcesExcept removes resources of the account, which server
+-- doesn't expose anymore. Empty list removes all resources of the account.
+--
+-- name: DeleteResourcesExcept :exec
+DELETE FROM agents.mcp_resources
+WHERE account_id = sqlc.arg('account_id')
+  AND NOT (uri = ANY(sqlc.arg('keep_uris')::text[]));
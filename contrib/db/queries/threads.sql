@@ -11,7 +11,7 @@ VALUES (sqlc.arg(id), sqlc.arg(user_id), 0, NOW());
 -- This combines what were previously GetThread + GetThreadMessages for efficiency.
 --
 -- Returns:
---   First row contains thread metadata (id, last_message_pos, created_at)
+--   First row contains thread metadata (id, last_message_pos, created_at, paused)
 --   Subsequent rows contain messages with type-specific data via LEFT JOINs
 --
 -- The last_message_pos field is critical for Optimistic Concurrency Control.
@@ -26,6 +26,7 @@ SELECT
     t.user_id,
     t.last_message_pos,
     t.created_at AS thread_created_at,
+    t.paused,
     m.position,
     m.msg_type,
     m.merge_tag,
@@ -43,6 +44,7 @@ SELECT
     ts.tool_call_id AS result_tool_call_id,
     ts.is_error AS result_is_error,
     ts.content AS result_content,
+    ts.attachments AS result_attachments,
     tr_origin.tool_name AS result_tool_name
 FROM agents.threads t
 LEFT JOIN agents.messages m ON t.id = m.thread_id
@@ -173,6 +175,7 @@ WHERE thread_id = sqlc.arg(thread_id) AND tool_call_id = sqlc.arg(tool_call_id);
 --   tool_call_id (must match the request's tool_call_id)
 --   is_error (false for success, true for errors)
 --   content (JSONB - either success result or error details)
+--   attachments (JSONB array - media content of the result)
 --
 -- The request_position FK with ON DELETE CASCADE ensures referential integrity.
 -- name: InsertMessageToolResult :execrows
@@ -188,5 +191,13 @@ msg AS (
     FROM update_thread
     RETURNING thread_id, position
 )
-INSERT INTO agents.messages_tool_result (thread_id, position, request_position, tool_call_id, is_error, content)
-SELECT thread_id, position, sqlc.arg(request_position)::BIGINT, sqlc.arg(tool_call_id), sqlc.arg(is_error), sqlc.arg(content) FROM msg;
+INSERT INTO agents.messages_tool_result (thread_id, position, request_position, tool_call_id, is_error, content, attachments)
+SELECT thread_id, position, sqlc.arg(request_position)::BIGINT, sqlc.arg(tool_call_id), sqlc.arg(is_error), sqlc.arg(content), sqlc.arg(attachments) FROM msg;
+
+-- SetThreadPaused marks thread as stopped at turn limit, or clears this mark,
+-- when agent loop is resumed. Doesn't change last_message_pos: pause is not a
+-- message. Mark is changed only if it differs, so concurrent resumes of the
+-- same thread affect single row only once.
+-- name: SetThreadPaused :execrows
+UPDATE agents.threads SET paused = sqlc.arg(paused)
+WHERE id = sqlc.arg(id) AND paused <> sqlc.arg(paused);
@@ -0,0 +1,36 @@
+-- AddDailyUsage adds usage of the agent to the sums of the day.
+--
+-- name: AddDailyUsage :exec
+INSERT INTO agents.usage_daily (agent_id, user_id, day, input_tokens, output_tokens, cost_micros)
+VALUES (
+	sqlc.arg('agent_id'),
+	sqlc.arg('user_id'),
+	sqlc.arg('day'),
+	sqlc.arg('input_tokens'),
+	sqlc.arg('output_tokens'),
+	sqlc.arg('cost_micros')
+)
+ON CONFLICT (agent_id, day) DO UPDATE SET
+	input_tokens = agents.usage_daily.input_tokens + EXCLUDED.input_tokens,
+	output_tokens = agents.usage_daily.output_tokens + EXCLUDED.output_tokens,
+	cost_micros = agents.usage_daily.cost_micros + EXCLUDED.cost_micros;
+
+-- GetAgentUsageSince sums usage of the agent since the day (inclusive).
+--
+-- name: GetAgentUsageSince :one
+SELECT
+	COALESCE(SUM(input_tokens), 0)::BIGINT AS input_tokens,
+	COALESCE(SUM(output_tokens), 0)::BIGINT AS output_tokens,
+	COALESCE(SUM(cost_micros), 0)::BIGINT AS cost_micros
+FROM agents.usage_daily
+WHERE agent_id = sqlc.arg('agent_id') AND day >= sqlc.arg('since');
+
+-- GetUserUsageSince sums usage of all user agents since the day (inclusive).
+--
+-- name: GetUserUsageSince :one
+SELECT
+	COALESCE(SUM(input_tokens), 0)::BIGINT AS input_tokens,
+	COALESCE(SUM(output_tokens), 0)::BIGINT AS output_tokens,
+	COALESCE(SUM(cost_micros), 0)::BIGINT AS cost_micros
+FROM agents.usage_daily
+WHERE user_id = sqlc.arg('user_id') AND day >= sqlc.arg('since');
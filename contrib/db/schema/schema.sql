@@ -12,6 +12,12 @@ CREATE TABLE agents.mcp_accounts (
 	user_id    UUID NOT NULL,
 	server_id  UUID NOT NULL,
 	deleted_at TIMESTAMPTZ,
+	-- disabled and needs_reauth accounts are kept, but their tools are hidden
+	-- from agents. deleted status always comes with deleted_at.
+	status     TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'disabled', 'needs_reauth', 'deleted')),
+	-- set by user: server of the account may request completions on behalf
+	-- of the user. Connecting an account doesn't allow it.
+	sampling_allowed BOOLEAN NOT NULL DEFAULT FALSE,
 
 	name        TEXT NOT NULL,
 	description TEXT NOT NULL,
@@ -53,18 +59,62 @@ CREATE TABLE agents.mcp_tools (
 	-- currently — using only gemini with fixed vector, however, when the model
 	-- or configs will change — we will add a new column with new vector config.
 	-- Not perfect — but for now it's fine.
-	embedding VECTOR(1536)
+	embedding VECTOR(1536),
+
+	-- behavior hints, declared by mcp server.
+	read_only_hint  BOOLEAN NOT NULL DEFAULT FALSE,
+	idempotent_hint BOOLEAN NOT NULL DEFAULT FALSE,
+	-- user setting: how long results of the tool could be reused. Zero means,
+	-- that ttl is not set by user.
+	cache_ttl_seconds INTEGER NOT NULL DEFAULT 0 CHECK (cache_ttl_seconds >= 0)
+);
+
+-- Resources, exposed by mcp servers. Only metadata is stored: content is
+-- always read from the server, since it may change at any moment. URI is
+-- unique only within the account.
+CREATE TABLE agents.mcp_resources (
+	account_id  UUID   NOT NULL,
+	uri         TEXT   NOT NULL,
+
+	name        TEXT   NOT NULL DEFAULT '',
+	title       TEXT   NOT NULL DEFAULT '',
+	description TEXT   NOT NULL DEFAULT '',
+	mime_type   TEXT   NOT NULL DEFAULT '',
+	-- zero means, that server didn't report the size.
+	size        BIGINT NOT NULL DEFAULT 0 CHECK (size >= 0),
+	updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+	PRIMARY KEY (account_id, uri)
+);
+
+-- Prompts, exposed by mcp servers: parameterized message templates, which
+-- users invoke as commands. Only metadata is stored, templates are always
+-- rendered by the server. Name is unique only within the account.
+CREATE TABLE agents.mcp_prompts (
+	account_id  UUID  NOT NULL,
+	name        TEXT  NOT NULL,
+
+	title       TEXT  NOT NULL DEFAULT '',
+	description TEXT  NOT NULL DEFAULT '',
+	-- ordered list of {"name", "description", "required"} objects.
+	arguments   JSONB NOT NULL DEFAULT '[]' CHECK (jsonb_typeof(arguments) = 'array'),
+	updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+	PRIMARY KEY (account_id, name)
 );
 
 CREATE TABLE agents.agent_settings (
 	id             UUID PRIMARY KEY,
 	user_id        UUID NOT NULL,
+	name           TEXT NOT NULL DEFAULT '',
 	model          TEXT NOT NULL,
 	system_message TEXT NOT NULL,
 	temperature    REAL NOT NULL CHECK (temperature >= 0), -- zero value counts as unset
 	top_p          REAL NOT NULL CHECK (top_p >= 0),       -- zero value counts as unset
 	max_context    INT  NOT NULL CHECK (max_context >= 0), -- zero value counts as unset
-	stop_words     TEXT[]
+	stop_words     TEXT[],
+	budget         JSONB NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(budget) = 'object'), -- limits of tokens and cost per run, day and month
+	disabled       BOOLEAN NOT NULL DEFAULT FALSE -- disabled agents are kept for thread history, but never respond
 );
 
 CREATE TABLE agents.oauth_configs (
@@ -94,7 +144,9 @@ CREATE TABLE agents.threads (
 	user_id          UUID        NOT NULL,
 	created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 	-- last_message_pos is used for Optimistic Concurrency Control
-	last_message_pos BIGINT NOT NULL DEFAULT 0
+	last_message_pos BIGINT NOT NULL DEFAULT 0,
+	-- agent loop stopped at turn limit, and user may continue it.
+	paused           BOOLEAN NOT NULL DEFAULT FALSE
 );
 
 -- Messages table (Value Objects inside Thread aggregate)
@@ -153,10 +205,105 @@ CREATE TABLE agents.messages_tool_result (
 
 	is_error         BOOLEAN NOT NULL DEFAULT FALSE,
 	content          JSONB NOT NULL,
+	attachments      JSONB NOT NULL DEFAULT '[]' CHECK (jsonb_typeof(attachments) = 'array'), -- media content (images, audio, files) of the result; embedded data is kept in agents.blobs
 
 	PRIMARY KEY (thread_id, position)
 );
 
+-- Large tool outputs, which don't fit into thread history, and embedded media
+-- of tool results. Content is stored as postgres large object, so it could be
+-- read by pages without loading the whole blob.
+CREATE TABLE agents.blobs (
+	id         UUID        PRIMARY KEY,
+	thread_id  TEXT        NOT NULL,
+	object_id  OID         NOT NULL,
+	size       BIGINT      NOT NULL CHECK (size >= 0),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- large objects are not deleted with rows, that reference them.
+CREATE FUNCTION agents.unlink_blob_object() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM lo_unlink(OLD.object_id);
+	RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_blobs_unlink_object
+	AFTER DELETE ON agents.blobs
+	FOR EACH ROW EXECUTE FUNCTION agents.unlink_blob_object();
+
+-- Urls, replaced with short tokens in content, passed to the model. Tokens are
+-- random and resolved without authentication, so they must not be guessable.
+-- Each url has a single token in the thread. Links, which weren't used for a
+-- long time, are deleted by used_at.
+CREATE TABLE agents.links (
+	token      TEXT        PRIMARY KEY,
+	thread_id  TEXT        NOT NULL,
+	url        TEXT        NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	used_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	UNIQUE (thread_id, url)
+);
+
+-- Tokens and cost, spent by agents, summed by calendar days (UTC). Budgets are
+-- checked against these sums, and only against them: this table is
+-- authoritative for spending. Rows are kept after agent deletion, cause they
+-- still count in user budget.
+CREATE TABLE agents.usage_daily (
+	agent_id      UUID   NOT NULL,
+	user_id       UUID   NOT NULL,
+	day           DATE   NOT NULL,
+	input_tokens  BIGINT NOT NULL DEFAULT 0 CHECK (input_tokens >= 0),
+	output_tokens BIGINT NOT NULL DEFAULT 0 CHECK (output_tokens >= 0),
+	cost_micros   BIGINT NOT NULL DEFAULT 0 CHECK (cost_micros >= 0), -- millionths of US dollar
+
+	PRIMARY KEY (agent_id, day)
+);
+
+-- Every agent turn: model call and tool calls, requested by model. Rows are
+-- never updated, they are used for usage reports only. Turn is written here
+-- and to usage_daily separately, so if one of writes fails, tables differ:
+-- budgets never read this table.
+CREATE TABLE agents.usage_ledger (
+	id            BIGINT      GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id       UUID        NOT NULL,
+	agent_id      UUID        NOT NULL,
+	thread_id     TEXT        NOT NULL,
+	model         TEXT        NOT NULL,
+	input_tokens  BIGINT      NOT NULL CHECK (input_tokens >= 0),
+	output_tokens BIGINT      NOT NULL CHECK (output_tokens >= 0),
+	duration_ms   BIGINT      NOT NULL CHECK (duration_ms >= 0),
+	tool_calls    BIGINT      NOT NULL CHECK (tool_calls >= 0),
+	errors        BIGINT      NOT NULL CHECK (errors >= 0),
+	created_at    TIMESTAMPTZ NOT NULL
+);
+
+-- Rate limit plan of the user. Users without row get the default plan. Plan
+-- names are validated by application, as plans are configured there.
+CREATE TABLE agents.user_plans (
+	user_id    UUID        PRIMARY KEY,
+	plan       TEXT        NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Public MCP servers, which users may connect to. Entries are imported by
+-- operator from registry files and identified by registry name. Server config
+-- in mcp_servers is created only when user connects to the server, since it
+-- requires client registration.
+CREATE TABLE agents.mcp_catalog (
+	name        TEXT        PRIMARY KEY,
+	title       TEXT        NOT NULL DEFAULT '',
+	url         TEXT        NOT NULL,
+	description TEXT        NOT NULL DEFAULT '',
+	-- empty value means, that publisher didn't declare auth type.
+	auth_type   TEXT        NOT NULL DEFAULT ''
+		CHECK (auth_type IN ('', 'none', 'oauth', 'api_key')),
+	categories  TEXT[]      NOT NULL DEFAULT '{}',
+	embedding   VECTOR(1536),
+	updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
 -- =============================================================================
 -- INDEXES
 -- =============================================================================
@@ -166,6 +313,10 @@ CREATE INDEX idx_messages_type ON agents.messages(thread_id, msg_type);
 CREATE INDEX idx_tools_account ON agents.mcp_tools(account_id) WHERE deleted_at IS NULL;
 CREATE INDEX idx_accounts_user ON agents.mcp_accounts(user_id) WHERE deleted_at IS NULL;
 CREATE INDEX idx_tool_result_request ON agents.messages_tool_result(thread_id, request_position);
+CREATE INDEX idx_blobs_thread ON agents.blobs(thread_id);
+CREATE INDEX idx_links_used ON agents.links(used_at);
+CREATE INDEX idx_usage_daily_user ON agents.usage_daily(user_id, day);
+CREATE INDEX idx_usage_ledger_user ON agents.usage_ledger(user_id, created_at);
 
 -- =============================================================================
 -- FOREIGN KEYS
@@ -179,6 +330,14 @@ ALTER TABLE agents.mcp_tools ADD CONSTRAINT fk_tool_account
 	FOREIGN KEY (account_id) REFERENCES agents.mcp_accounts(id)
 	ON DELETE RESTRICT ON UPDATE RESTRICT;
 
+ALTER TABLE agents.mcp_resources ADD CONSTRAINT fk_resource_account
+	FOREIGN KEY (account_id) REFERENCES agents.mcp_accounts(id)
+	ON DELETE CASCADE ON UPDATE RESTRICT;
+
+ALTER TABLE agents.mcp_prompts ADD CONSTRAINT fk_prompt_account
+	FOREIGN KEY (account_id) REFERENCES agents.mcp_accounts(id)
+	ON DELETE CASCADE ON UPDATE RESTRICT;
+
 -- OAuth
 ALTER TABLE agents.oauth_configs ADD CONSTRAINT fk_oauth_config_server
 	FOREIGN KEY (server_id) REFERENCES agents.mcp_servers(id)
@@ -221,3 +380,10 @@ ALTER TABLE agents.messages_tool_result ADD CONSTRAINT fk_message_tool_result_re
 	FOREIGN KEY (thread_id, request_position) REFERENCES agents.messages_tool_request(thread_id, position)
 	ON DELETE CASCADE ON UPDATE CASCADE;
 
+ALTER TABLE agents.blobs ADD CONSTRAINT fk_blob_thread
+	FOREIGN KEY (thread_id) REFERENCES agents.threads(id)
+	ON DELETE CASCADE ON UPDATE RESTRICT;
+
+ALTER TABLE agents.links ADD CONSTRAINT fk_link_thread
+	FOREIGN KEY (thread_id) REFERENCES agents.threads(id)
+	ON DELETE CASCADE ON UPDATE RESTRICT;
@@ -90,8 +90,8 @@ func (m *MockServer) Tools(
 		must(tools.NewRawTool(
 			"mock_tool",
 			"A mock tool",
-			json.RawMessage(`{"type":"object"}`),
-			json.RawMessage(`{"type":"string"}`),
+			must(tools.NewInputSchema(json.RawMessage(`{"type":"object"}`))),
+			must(tools.NewOutputSchema(json.RawMessage(`{"type":"string"}`))),
 			toolID("mock_tool"), accountName, accountDesc,
 		)),
 	}
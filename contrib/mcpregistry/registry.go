@@ -0,0 +1,168 @@
+// Package mcpregistry parses server lists in the format of the official MCP
+// registry: https://github.com/modelcontextprotocol/registry. Both JSON and
+// YAML documents are supported.
+package mcpregistry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidFormat is returned when document is neither a server, a list of
+// servers, nor a registry list response.
+var ErrInvalidFormat = errors.New("invalid registry document")
+
+// PublisherMetaKey is a key of server metadata, which is provided by server
+// publisher. Registry itself doesn't define its structure, cynosure reads
+// categories and auth type from it.
+const PublisherMetaKey = "io.modelcontextprotocol.registry/publisher-provided"
+
+// Remote transport types, defined by registry schema.
+const (
+	RemoteStreamableHTTP = "streamable-http"
+	RemoteSSE            = "sse"
+)
+
+type Server struct {
+	Name        string   `yaml:"name"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Version     string   `yaml:"version"`
+	Remotes     []Remote `yaml:"remotes"`
+	Meta        Meta     `yaml:"_meta"`
+}
+
+type Remote struct {
+	Type    string   `yaml:"type"`
+	URL     string   `yaml:"url"`
+	Headers []Header `yaml:"headers"`
+}
+
+type Header struct {
+	Name       string `yaml:"name"`
+	IsRequired bool   `yaml:"isRequired"`
+	IsSecret   bool   `yaml:"isSecret"`
+}
+
+type Meta struct {
+	Publisher PublisherMeta `yaml:"io.modelcontextprotocol.registry/publisher-provided"`
+}
+
+type PublisherMeta struct {
+	Categories []string `yaml:"categories"`
+	// AuthType is one of "none", "oauth" or "api_key". Empty value means,
+	// that publisher didn't declare it.
+	AuthType string `yaml:"auth_type"`
+}
+
+// Remote returns preferred remote of the server: streamable HTTP goes first,
+// then SSE. Servers, which are distributed only as packages, have no remote.
+func (s Server) Remote() (Remote, bool) {
+	for _, kind := range []string{RemoteStreamableHTTP, RemoteSSE} {
+		for _, remote := range s.Remotes {
+			if remote.Type == kind && remote.URL != "" {
+				return remote, true
+			}
+		}
+	}
+
+	return Remote{}, false
+}
+
+// RequiresSecret reports, whether remote needs secret header, e.g. API key in
+// Authorization header.
+func (r Remote) RequiresSecret() bool {
+	for _, header := range r.Headers {
+		if header.IsRequired && (header.IsSecret || strings.EqualFold(header.Name, "Authorization")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// entry is an item of registry list response: server may be wrapped into
+// "server" field, or be placed directly.
+type entry struct {
+	Server *Server `yaml:"server"`
+	Inline Server  `yaml:",inline"`
+}
+
+func (e entry) server() Server {
+	if e.Server != nil {
+		return *e.Server
+	}
+
+	return e.Inline
+}
+
+// Parse reads servers from document. Accepted documents are:
+//
+//   - registry list response: {"servers": [{"server": {...}}, ...]}
+//   - list of servers: [{...}, ...]
+//   - single server: {...}
+func Parse(data []byte) ([]Server, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	if len(root.Content) == 0 {
+		return []Server{}, nil
+	}
+
+	entries, err := decodeEntries(root.Content[0])
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]Server, 0, len(entries))
+	for i, e := range entries {
+		server := e.server()
+		if server.Name == "" {
+			return nil, fmt.Errorf("%w: server #%d has no name", ErrInvalidFormat, i)
+		}
+
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
+
+func decodeEntries(doc *yaml.Node) ([]entry, error) {
+	var entries []entry
+
+	switch doc.Kind {
+	case yaml.SequenceNode:
+		if err := doc.Decode(&entries); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+
+	case yaml.MappingNode:
+		var list struct {
+			Servers []entry `yaml:"servers"`
+		}
+		if err := doc.Decode(&list); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+
+		if list.Servers != nil {
+			return list.Servers, nil
+		}
+
+		var single entry
+		if err := doc.Decode(&single); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+
+		entries = []entry{single}
+
+	default:
+		return nil, fmt.Errorf("%w: unexpected document kind", ErrInvalidFormat)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+package mcpregistry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/quenbyako/cynosure/contrib/mcpregistry"
+)
+
+func TestParseListResponse(t *testing.T) {
+	servers, err := Parse([]byte(`{
+		"servers": [{
+			"server": {
+				"name": "com.todoist/mcp",
+				"title": "Todoist",
+				"description": "Manage tasks and projects.",
+				"version": "1.0.0",
+				"remotes": [
+					{"type": "sse", "url": "https://ai.todoist.net/sse"},
+					{"type": "streamable-http", "url": "https://ai.todoist.net/mcp"}
+				],
+				"_meta": {
+					"io.modelcontextprotocol.registry/publisher-provided": {
+						"categories": ["productivity"],
+						"auth_type": "oauth"
+					}
+				}
+			},
+			"_meta": {"io.modelcontextprotocol.registry/official": {"status": "active"}}
+		}],
+		"metadata": {"count": 1}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+
+	server := servers[0]
+	require.Equal(t, "com.todoist/mcp", server.Name)
+	require.Equal(t, "Todoist", server.Title)
+	require.Equal(t, []string{"productivity"}, server.Meta.Publisher.Categories)
+	require.Equal(t, "oauth", server.Meta.Publisher.AuthType)
+
+	remote, ok := server.Remote()
+	require.True(t, ok)
+	require.Equal(t, "https://ai.todoist.net/mcp", remote.URL)
+}
+
+func TestParseYAML(t *testing.T) {
+	servers, err := Parse([]byte(`
+- name: io.github.example/weather
+  description: Weather forecasts.
+  remotes:
+    - type: streamable-http
+      url: https://weather.example.com/mcp
+      headers:
+        - name: Authorization
+          isRequired: true
+- name: io.github.example/local
+  description: Local only server.
+  packages: []
+`))
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+
+	remote, ok := servers[0].Remote()
+	require.True(t, ok)
+	require.True(t, remote.RequiresSecret())
+
+	_, ok = servers[1].Remote()
+	require.False(t, ok)
+}
+
+func TestParseSingleServer(t *testing.T) {
+	servers, err := Parse([]byte(`{"name": "a/b", "remotes": []}`))
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+
+	_, err = Parse([]byte(`[{"description": "no name"}]`))
+	require.ErrorIs(t, err, ErrInvalidFormat)
+
+	_, err = Parse([]byte(`"just a string"`))
+	require.ErrorIs(t, err, ErrInvalidFormat)
+}
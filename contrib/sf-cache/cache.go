@@ -107,6 +107,34 @@ func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, error) {
 	return *new(V), fmt.Errorf("key %v repeatedly evicted: %w", key, ErrEvicted)
 }
 
+// Invalidate evicts value of the key, if stale reports true for it. Check
+// allows concurrent callers to invalidate the same broken value without
+// destroying its replacement. Returns true, if value was evicted.
+func (c *Cache[K, V]) Invalidate(key K, stale func(V) bool) bool {
+	c.mu.Lock()
+	entry, ok := c.lru.Peek(key)
+	c.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	// waiting for constructor outside of the lock: it may take a while.
+	val, err, ok := entry.group.Get()
+	if !ok || err != nil || !stale(val) {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current, ok := c.lru.Peek(key); !ok || current != entry {
+		return false
+	}
+
+	return c.lru.Remove(key)
+}
+
 func (c *Cache[K, V]) getOrCreateEntry(key K) *cacheEntry[K, V] {
 	c.mu.Lock()
 	defer c.mu.Unlock()
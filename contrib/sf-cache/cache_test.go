@@ -287,3 +287,38 @@ func TestCache_RetryAfterError(t *testing.T) {
 
 	require.Equal(t, int32(2), callCount.Load())
 }
+
+func TestCache_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		callCount       atomic.Int32
+		destructorCalls atomic.Int32
+	)
+
+	constructor := func(ctx context.Context, key int) (int32, error) {
+		return callCount.Add(1), nil
+	}
+
+	destructor := func(key int, val int32) { destructorCalls.Add(1) }
+
+	testCache := New(constructor, destructor, testMaxSize, time.Minute)
+
+	t.Cleanup(func() { require.NoError(t, testCache.Close()) })
+
+	ctx := context.Background()
+
+	require.False(t, testCache.Invalidate(1, func(int32) bool { return true }))
+
+	val, err := testCache.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), val)
+
+	require.False(t, testCache.Invalidate(1, func(v int32) bool { return v != val }))
+	require.True(t, testCache.Invalidate(1, func(v int32) bool { return v == val }))
+	require.Equal(t, int32(1), destructorCalls.Load())
+
+	val, err = testCache.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), val, "invalidated value must be constructed again")
+}
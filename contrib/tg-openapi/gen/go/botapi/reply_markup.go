@@ -0,0 +1,23 @@
+package botapi
+
+import "encoding/json"
+
+// Generator skips union helpers for request bodies, so without them reply
+// markup is always encoded as empty object.
+
+// FromInlineKeyboardMarkup overwrites any union data inside the
+// SendMessageJSONBody_ReplyMarkup as the provided InlineKeyboardMarkup.
+func (t *SendMessageJSONBody_ReplyMarkup) FromInlineKeyboardMarkup(v InlineKeyboardMarkup) error {
+	b, err := json.Marshal(v)
+	t.union = b
+
+	return err
+}
+
+func (t SendMessageJSONBody_ReplyMarkup) MarshalJSON() ([]byte, error) {
+	return t.union.MarshalJSON()
+}
+
+func (t *SendMessageJSONBody_ReplyMarkup) UnmarshalJSON(b []byte) error {
+	return t.union.UnmarshalJSON(b)
+}
@@ -0,0 +1,152 @@
+// Package filesystem provides blob storage on the local filesystem.
+package filesystem
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+const (
+	dirPerm  = 0o750
+	filePerm = 0o640
+)
+
+// BlobStorage keeps blobs as files, grouped by thread:
+//
+//	<root>/<user id>/<encoded thread id>/<blob id>
+//
+// Thread ids are arbitrary strings, so they are encoded to be safe path
+// segments.
+type BlobStorage struct {
+	root string
+}
+
+var (
+	_ ports.BlobStorageFactory = (*BlobStorage)(nil)
+	_ ports.BlobStorage        = (*BlobStorage)(nil)
+)
+
+// NewBlobStorage creates storage in the given directory. Directory is created,
+// if it doesn't exist.
+func NewBlobStorage(root string) (*BlobStorage, error) {
+	if root == "" {
+		return nil, ErrRootEmpty
+	}
+
+	if err := os.MkdirAll(root, dirPerm); err != nil {
+		return nil, fmt.Errorf("creating blob directory: %w", err)
+	}
+
+	return &BlobStorage{root: root}, nil
+}
+
+// BlobStorage implements [ports.BlobStorageFactory].
+func (s *BlobStorage) BlobStorage() ports.BlobStorage { return s }
+
+// SaveBlob implements [ports.BlobStorage]. File is written to temporary
+// location first, so readers never see partially written blobs.
+func (s *BlobStorage) SaveBlob(_ context.Context, blob ids.BlobID, data []byte) error {
+	path := s.path(blob)
+
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return fmt.Errorf("creating thread directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	//nolint:errcheck // file is already renamed in case of success
+	defer os.Remove(tmp.Name())
+
+	if err := writeFile(tmp, data); err != nil {
+		return err
+	}
+
+	// link fails if destination exists, unlike rename, so concurrent saves
+	// of the same blob are detected.
+	if err := os.Link(tmp.Name(), path); errors.Is(err, fs.ErrExist) {
+		return ports.ErrAlreadyExists
+	} else if err != nil {
+		return fmt.Errorf("publishing blob: %w", err)
+	}
+
+	return nil
+}
+
+func writeFile(file *os.File, data []byte) error {
+	if _, err := file.Write(data); err != nil {
+		//nolint:errcheck // write error is more important
+		file.Close()
+
+		return fmt.Errorf("writing blob: %w", err)
+	}
+
+	if err := file.Chmod(filePerm); err != nil {
+		//nolint:errcheck // chmod error is more important
+		file.Close()
+
+		return fmt.Errorf("setting blob permissions: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing blob: %w", err)
+	}
+
+	return nil
+}
+
+// ReadBlob implements [ports.BlobStorage].
+func (s *BlobStorage) ReadBlob(
+	_ context.Context, blob ids.BlobID, offset, length int64,
+) ([]byte, int64, error) {
+	file, err := os.Open(s.path(blob))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, ports.ErrNotFound
+	} else if err != nil {
+		return nil, 0, fmt.Errorf("opening blob: %w", err)
+	}
+
+	//nolint:errcheck // file is opened only for reading
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading blob info: %w", err)
+	}
+
+	size := info.Size()
+	if offset >= size || length <= 0 {
+		return []byte{}, size, nil
+	}
+
+	chunk := make([]byte, min(length, size-offset))
+
+	n, err := file.ReadAt(chunk, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, fmt.Errorf("reading blob: %w", err)
+	}
+
+	return chunk[:n], size, nil
+}
+
+func (s *BlobStorage) path(blob ids.BlobID) string {
+	thread := blob.Thread()
+
+	return filepath.Join(
+		s.root,
+		thread.User().ID().String(),
+		base64.RawURLEncoding.EncodeToString([]byte(thread.ID())),
+		blob.ID().String(),
+	)
+}
@@ -0,0 +1,17 @@
+package filesystem_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/filesystem"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/testsuite"
+)
+
+func TestBlobStorage(t *testing.T) {
+	storage, err := filesystem.NewBlobStorage(t.TempDir())
+	require.NoError(t, err)
+
+	testsuite.RunBlobStorageTests(storage)(t)
+}
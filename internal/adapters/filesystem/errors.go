@@ -0,0 +1,8 @@
+package filesystem
+
+import (
+	"errors"
+)
+
+// ErrRootEmpty is returned when storage directory is not set.
+var ErrRootEmpty = errors.New("root directory is empty")
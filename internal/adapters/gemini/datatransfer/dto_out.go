@@ -162,12 +162,45 @@ func convertToolRespMsg(msg messages.MessageToolResponse, res []*genai.Content)
 	part := genai.NewPartFromFunctionResponse(msg.ToolName(), map[string]any{
 		"output": msg.Content(),
 	})
+	part.FunctionResponse.Parts = attachmentsToGenAI(msg.Attachments())
 
 	last.Parts = append(last.Parts, part)
 
 	return last
 }
 
+// attachmentsToGenAI converts media content of tool result into multimodal
+// function response parts. Gemini accepts only inline data of specific media
+// types there, so external links and unsupported types are skipped: model
+// still sees json result of the tool.
+func attachmentsToGenAI(attachments []messages.ChatContent) []*genai.FunctionResponsePart {
+	var res []*genai.FunctionResponsePart
+
+	for _, attachment := range attachments {
+		mimeType, data, ok := messages.DecodeDataURL(attachment.URL())
+		if !ok {
+			continue
+		}
+
+		if mediaType, _ := attachment.Type(); !isFunctionResponseMediaType(mediaType) {
+			continue
+		}
+
+		res = append(res, genai.NewFunctionResponsePartFromBytes(data, mimeType))
+	}
+
+	return res
+}
+
+func isFunctionResponseMediaType(mediaType string) bool {
+	switch mediaType {
+	case "image/png", "image/jpeg", "image/webp", "application/pdf", "text/plain":
+		return true
+	default:
+		return false
+	}
+}
+
 func convertToolErrorMsg(msg messages.MessageToolError, res []*genai.Content) *genai.Content {
 	last := ensureUserContent(res)
 	part := genai.NewPartFromFunctionResponse(msg.ToolName(), map[string]any{
@@ -198,8 +231,8 @@ func ToolInfoToGenAI(rawTools []tools.RawTool) []*genai.Tool {
 		decls[i] = &genai.FunctionDeclaration{
 			Name:                 t.Name(),
 			Description:          t.Desc(),
-			ParametersJsonSchema: t.ConvertedSchema(),
-			ResponseJsonSchema:   t.Response(),
+			ParametersJsonSchema: t.ConvertedSchema().PlainSchema(),
+			ResponseJsonSchema:   t.Response().PlainSchema(),
 			Parameters:           nil,
 			Response:             nil,
 			Behavior:             "",
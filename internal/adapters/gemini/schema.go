@@ -0,0 +1,35 @@
+package gemini
+
+import (
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+// SchemaOptions returns normalization of tool schemas for Gemini function
+// declarations. Tool client and storage must use the same options, so
+// schemas are normalized equally on discovery and on read.
+func SchemaOptions() []tools.SchemaOption {
+	return []tools.SchemaOption{tools.WithKeywords(
+		"type",
+		"format",
+		"title",
+		"description",
+		"nullable",
+		"enum",
+		"default",
+		"example",
+		"items",
+		"properties",
+		"additionalProperties",
+		"required",
+		"minItems",
+		"maxItems",
+		"minProperties",
+		"maxProperties",
+		"minLength",
+		"maxLength",
+		"pattern",
+		"minimum",
+		"maximum",
+		"anyOf",
+	)}
+}
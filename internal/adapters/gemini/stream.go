@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"iter"
+	"math"
 
 	"google.golang.org/genai"
 
@@ -104,6 +105,10 @@ func (g *GeminiModel) buildGenConfig(
 		config.Tools = datatransfer.ToolInfoToGenAI(toolList)
 	}
 
+	if limit := params.MaxOutputTokens(); limit > 0 {
+		config.MaxOutputTokens = int32(min(limit, math.MaxInt32)) //nolint:gosec // capped above
+	}
+
 	return config, nil
 }
 
@@ -149,6 +154,7 @@ func toolConfig(mode genai.FunctionCallingConfigMode) *genai.ToolConfig {
 type streamParamsProxy interface {
 	Toolbox() tools.Toolbox
 	ToolChoice() tools.ToolChoice
+	MaxOutputTokens() int
 }
 
 func convertToolChoice(choice tools.ToolChoice) (genai.FunctionCallingConfigMode, error) {
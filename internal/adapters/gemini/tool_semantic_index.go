@@ -2,7 +2,6 @@ package gemini
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -52,12 +51,7 @@ func (g *GeminiModel) IndexTool(
 	ctx context.Context,
 	tool entities.ToolReadOnly,
 ) ([embeddingSize]float32, error) {
-	schema := tool.InputSchema()
-
-	schemaBytes, err := json.Marshal(schema)
-	if err != nil {
-		return [embeddingSize]float32{}, fmt.Errorf("failed to marshal tool schema: %w", err)
-	}
+	schemaBytes := tool.InputSchema().PlainSchema()
 
 	content := fmt.Sprintf("Tool Name: %s\nAccount: %s\nDescription: %s\nArguments: %s",
 		tool.Name(),
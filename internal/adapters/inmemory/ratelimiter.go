@@ -15,16 +15,21 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
 const (
 	pkgName = "github.com/quenbyako/cynosure/internal/adapters/inmemory"
 )
 
-// userEntry stores the rate limiter and the last seen timestamp for a user.
+// userEntry stores the rate limiters and the last seen timestamp for a user
+// or an account. Limiters are created on first use, and are reconfigured,
+// when limit is changed.
 type userEntry struct {
 	limiter  *rate.Limiter
+	tokens   *tokenBucket
 	lastSeen atomic.Int64
+	mu       sync.Mutex
 }
 
 // RateLimiter is an in-memory implementation of the ratelimiter.Port.
@@ -32,9 +37,8 @@ type RateLimiter struct {
 	tracer     ports.ObserveStack
 	now        func() time.Time
 	entries    map[ids.UserID]*userEntry
-	limit      rate.Limit
+	accounts   map[ids.AccountID]*userEntry
 	ttl        time.Duration
-	burst      int
 	entiresMux sync.RWMutex
 }
 
@@ -45,10 +49,9 @@ var (
 
 type clock = func() time.Time
 
-// NewRateLimiter creates a new in-memory rate limiter.
+// NewRateLimiter creates a new in-memory rate limiter. Limiters of users,
+// which were not seen for ttl, are removed by [RateLimiter.Cleanup].
 func NewRateLimiter(
-	limit rate.Limit,
-	burst int,
 	ttl time.Duration,
 	now clock,
 	tracer core.Metrics,
@@ -63,12 +66,11 @@ func NewRateLimiter(
 	}
 
 	return &RateLimiter{
-		limit:      limit,
-		burst:      burst,
 		ttl:        ttl,
 		now:        now,
 		entiresMux: sync.RWMutex{},
 		entries:    make(map[ids.UserID]*userEntry),
+		accounts:   make(map[ids.AccountID]*userEntry),
 		tracer:     observability,
 	}
 }
@@ -76,37 +78,176 @@ func NewRateLimiter(
 // RateLimiter returns ratelimiter.PortWrapped interface.
 func (r *RateLimiter) RateLimiter() ratelimiter.PortWrapped { return ratelimiter.Wrap(r, r.tracer) }
 
+// unlimited is reported as remaining amount of unlimited quota.
+const unlimited = -1
+
 // Consume consumes message quota for the given user.
-func (r *RateLimiter) Consume(ctx context.Context, user ids.UserID, count int) error {
+func (r *RateLimiter) Consume(
+	ctx context.Context, user ids.UserID, limit plans.Limit, count int,
+) (ratelimiter.Quota, error) {
+	if limit.Unlimited() {
+		return ratelimiter.Quota{Remaining: unlimited}, nil
+	}
+
+	now := r.now()
+
+	return allow(r.entry(user, now).messages(now, limit), now, limit, count)
+}
+
+// ConsumeAccount consumes quota of the given account.
+func (r *RateLimiter) ConsumeAccount(
+	ctx context.Context, account ids.AccountID, limit plans.Limit, count int,
+) (ratelimiter.Quota, error) {
+	if limit.Unlimited() {
+		return ratelimiter.Quota{Remaining: unlimited}, nil
+	}
+
+	now := r.now()
+
+	return allow(r.accountEntry(account, now).messages(now, limit), now, limit, count)
+}
+
+func allow(limiter *rate.Limiter, now time.Time, limit plans.Limit, count int) (ratelimiter.Quota, error) {
+	if !limiter.AllowN(now, count) {
+		return ratelimiter.Quota{}, exceeded(limiter.TokensAt(now), count, limit, limiter.Limit())
+	}
+
+	return ratelimiter.Quota{Remaining: int(limiter.TokensAt(now))}, nil
+}
+
+// Reserve holds tokens from user token quota.
+func (r *RateLimiter) Reserve(
+	ctx context.Context, user ids.UserID, limit plans.Limit, tokens int,
+) (ratelimiter.Reservation, error) {
+	if limit.Unlimited() {
+		return ratelimiter.NewReservation(user, limit, tokens, unlimited), nil
+	}
+
+	now := r.now()
+	bucket := r.entry(user, now).tokenBucket(now, limit)
+
+	left, ok := bucket.take(now, tokens)
+	if !ok {
+		return ratelimiter.Reservation{}, exceeded(left, tokens, limit, bucket.limit)
+	}
+
+	return ratelimiter.NewReservation(user, limit, tokens, int(left)), nil
+}
+
+// Commit returns unused tokens or takes overused ones.
+func (r *RateLimiter) Commit(ctx context.Context, reservation ratelimiter.Reservation, used int) error {
+	r.settle(reservation, used-reservation.Tokens())
+
+	return nil
+}
+
+// Refund returns all reserved tokens.
+func (r *RateLimiter) Refund(ctx context.Context, reservation ratelimiter.Reservation) error {
+	r.settle(reservation, -reservation.Tokens())
+
+	return nil
+}
+
+func (r *RateLimiter) settle(reservation ratelimiter.Reservation, delta int) {
+	if delta == 0 || reservation.Limit().Unlimited() {
+		return
+	}
+
 	now := r.now()
 
-	r.entiresMux.RLock()
-	entry, ok := r.entries[user]
-	r.entiresMux.RUnlock()
+	r.entry(reservation.User(), now).tokenBucket(now, reservation.Limit()).settle(now, delta)
+}
+
+// exceeded describes exhausted quota: requested amount will be available,
+// when missing part is refilled.
+func exceeded(available float64, requested int, limit plans.Limit, refill rate.Limit) error {
+	err := &ratelimiter.LimitExceededError{
+		Remaining:  max(int(available), 0),
+		RetryAfter: 0,
+	}
+
+	if requested <= limit.Burst() && refill > 0 {
+		missing := float64(requested) - available
+		err.RetryAfter = time.Duration(missing / float64(refill) * float64(time.Second))
+	}
+
+	return err
+}
+
+// refillRate converts limit to the amount of tokens, refilled each second.
+func refillRate(limit plans.Limit) rate.Limit {
+	return rate.Every(limit.Period() / time.Duration(limit.Burst()))
+}
+
+// messages returns message limiter, configured for the given limit.
+func (e *userEntry) messages(now time.Time, limit plans.Limit) *rate.Limiter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	refill := refillRate(limit)
+
+	switch {
+	case e.limiter == nil:
+		e.limiter = rate.NewLimiter(refill, limit.Burst())
+	case e.limiter.Limit() != refill || e.limiter.Burst() != limit.Burst():
+		e.limiter.SetLimitAt(now, refill)
+		e.limiter.SetBurstAt(now, limit.Burst())
+	}
+
+	return e.limiter
+}
+
+// tokenBucket returns token bucket, configured for the given limit.
+func (e *userEntry) tokenBucket(now time.Time, limit plans.Limit) *tokenBucket {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.tokens == nil {
+		e.tokens = newTokenBucket(refillRate(limit), limit.Burst(), now)
+	} else {
+		e.tokens.configure(now, refillRate(limit), limit.Burst())
+	}
+
+	return e.tokens
+}
+
+// entry returns limiters of the user, creating them on first access.
+func (r *RateLimiter) entry(user ids.UserID, now time.Time) *userEntry {
+	return loadEntry(&r.entiresMux, r.entries, user, now)
+}
+
+// accountEntry returns limiters of the account, creating them on first
+// access.
+func (r *RateLimiter) accountEntry(account ids.AccountID, now time.Time) *userEntry {
+	return loadEntry(&r.entiresMux, r.accounts, account, now)
+}
+
+func loadEntry[K comparable](mux *sync.RWMutex, entries map[K]*userEntry, key K, now time.Time) *userEntry {
+	mux.RLock()
+	entry, ok := entries[key]
+	mux.RUnlock()
 
 	if !ok {
-		r.entiresMux.Lock()
+		mux.Lock()
 		// Double-check after acquiring write lock
-		entry, ok = r.entries[user]
+		entry, ok = entries[key]
 		if !ok {
 			entry = &userEntry{
-				limiter:  rate.NewLimiter(r.limit, r.burst),
+				limiter:  nil,
+				tokens:   nil,
 				lastSeen: atomic.Int64{},
+				mu:       sync.Mutex{},
 			}
 			// must set while locking to prevent leacing entry in zero value.
 			entry.lastSeen.Store(now.UnixNano())
-			r.entries[user] = entry
+			entries[key] = entry
 		}
-		r.entiresMux.Unlock()
+		mux.Unlock()
 	}
 
 	entry.lastSeen.Store(now.UnixNano())
 
-	if !entry.limiter.AllowN(now, count) {
-		return fmt.Errorf("%w for user %q", ratelimiter.ErrRateLimitExceeded, user.ID().String())
-	}
-
-	return nil
+	return entry
 }
 
 const (
@@ -145,10 +286,15 @@ func (r *RateLimiter) evictStaleEntries() {
 	r.entiresMux.Lock()
 	defer r.entiresMux.Unlock()
 
-	for user, entry := range r.entries {
+	evictStale(r.entries, now, r.ttl)
+	evictStale(r.accounts, now, r.ttl)
+}
+
+func evictStale[K comparable](entries map[K]*userEntry, now int64, ttl time.Duration) {
+	for key, entry := range entries {
 		lastSeen := entry.lastSeen.Load()
-		if lastSeen != 0 && now-lastSeen > int64(r.ttl) {
-			delete(r.entries, user)
+		if lastSeen != 0 && now-lastSeen > int64(ttl) {
+			delete(entries, key)
 		}
 	}
 }
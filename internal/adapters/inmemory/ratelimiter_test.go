@@ -5,8 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"golang.org/x/time/rate"
-
 	"github.com/quenbyako/cynosure/internal/adapters/inmemory"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter/testsuite"
@@ -20,8 +18,6 @@ func TestRateLimiter(t *testing.T) {
 
 func setupLimiter(_ context.Context, params testsuite.SetupParams) (ratelimiter.Port, error) {
 	return inmemory.NewRateLimiter(
-		rate.Limit(params.Limit),
-		params.Burst,
 		time.Hour, // large TTL for tests
 		params.Now,
 		nil,
@@ -0,0 +1,76 @@
+package inmemory
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// tokenBucket is a token bucket, which, unlike [rate.Limiter], allows to
+// return part of taken tokens and to go in debt, when model used more tokens,
+// than it was reserved.
+type tokenBucket struct {
+	last   time.Time
+	limit  rate.Limit
+	burst  float64
+	tokens float64
+	mu     sync.Mutex
+}
+
+func newTokenBucket(limit rate.Limit, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		last:   now,
+		limit:  limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		mu:     sync.Mutex{},
+	}
+}
+
+// take takes n tokens, if bucket has enough of them. Returns tokens, left in
+// the bucket.
+func (b *tokenBucket) take(now time.Time, n int) (float64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+
+	if b.tokens < float64(n) {
+		return b.tokens, false
+	}
+
+	b.tokens -= float64(n)
+
+	return b.tokens, true
+}
+
+// configure changes limits of the bucket, e.g. when user plan is changed.
+func (b *tokenBucket) configure(now time.Time, limit rate.Limit, burst int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+
+	b.limit = limit
+	b.burst = float64(burst)
+	b.tokens = min(b.tokens, b.burst)
+}
+
+// settle takes delta tokens unconditionally, negative delta returns tokens
+// back. Bucket never holds more than burst.
+func (b *tokenBucket) settle(now time.Time, delta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(now)
+
+	b.tokens = min(b.tokens-float64(delta), b.burst)
+}
+
+func (b *tokenBucket) advance(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.tokens+elapsed.Seconds()*float64(b.limit), b.burst)
+		b.last = now
+	}
+}
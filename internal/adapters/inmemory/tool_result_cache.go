@@ -0,0 +1,125 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+type cachedResult struct {
+	result    messages.MessageToolResponse
+	cachedAt  time.Time
+	expiresAt time.Time
+}
+
+// ToolResultCache is an in-memory implementation of the
+// ports.ToolResultCache.
+type ToolResultCache struct {
+	now        clock
+	entries    map[string]cachedResult
+	period     time.Duration
+	entriesMux sync.RWMutex
+}
+
+var (
+	_ ports.ToolResultCacheFactory = (*ToolResultCache)(nil)
+	_ ports.ToolResultCache        = (*ToolResultCache)(nil)
+)
+
+// NewToolResultCache creates a new in-memory cache. Expired results are
+// evicted by [ToolResultCache.Cleanup] every period.
+func NewToolResultCache(period time.Duration, now clock) *ToolResultCache {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &ToolResultCache{
+		now:        now,
+		entries:    make(map[string]cachedResult),
+		period:     period,
+		entriesMux: sync.RWMutex{},
+	}
+}
+
+// ToolResultCache returns ports.ToolResultCache interface.
+func (c *ToolResultCache) ToolResultCache() ports.ToolResultCache { return c }
+
+// GetToolResult returns cached result, if it's not expired yet.
+func (c *ToolResultCache) GetToolResult(
+	_ context.Context, key tools.ResultKey,
+) (messages.MessageToolResponse, time.Time, error) {
+	c.entriesMux.RLock()
+	entry, ok := c.entries[key.String()]
+	c.entriesMux.RUnlock()
+
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return messages.MessageToolResponse{}, time.Time{}, fmt.Errorf(
+			"%w: tool result %v", ports.ErrNotFound, key.String(),
+		)
+	}
+
+	return entry.result, entry.cachedAt, nil
+}
+
+// SetToolResult stores result until ttl expires.
+func (c *ToolResultCache) SetToolResult(
+	_ context.Context, key tools.ResultKey, result messages.MessageToolResponse, ttl time.Duration,
+) error {
+	now := c.now()
+
+	c.entriesMux.Lock()
+	defer c.entriesMux.Unlock()
+
+	c.entries[key.String()] = cachedResult{
+		result:    result,
+		cachedAt:  now,
+		expiresAt: now.Add(ttl),
+	}
+
+	return nil
+}
+
+// Cleanup periodically removes expired results from memory.
+func (c *ToolResultCache) Cleanup(ctx context.Context) error {
+	if c.period <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			err := ctx.Err()
+			if errors.Is(err, context.Canceled) {
+				// same as rate limiter: cleanup only frees memory.
+				return nil
+			}
+
+			return fmt.Errorf("cleanup job: %w", err)
+
+		case <-ticker.C:
+			c.evictExpired()
+		}
+	}
+}
+
+func (c *ToolResultCache) evictExpired() {
+	now := c.now()
+
+	c.entriesMux.Lock()
+	defer c.entriesMux.Unlock()
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
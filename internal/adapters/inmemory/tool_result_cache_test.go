@@ -0,0 +1,15 @@
+package inmemory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/adapters/inmemory"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/testsuite"
+)
+
+func TestToolResultCache(t *testing.T) {
+	t.Parallel()
+
+	testsuite.RunToolResultCacheTests(inmemory.NewToolResultCache(time.Minute, nil))(t)
+}
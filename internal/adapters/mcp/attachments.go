@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+const (
+	defaultBlobMIMEType = "application/octet-stream"
+	defaultTextMIMEType = "text/plain"
+)
+
+// extractAttachments converts media blocks of tool result (images, audio,
+// embedded resources and links to them) into chat content. Text blocks are
+// not attachments: they are part of json result.
+func extractAttachments(content []mcp.Content) []messages.ChatContent {
+	var res []messages.ChatContent
+
+	for _, item := range content {
+		var (
+			attachment messages.ChatContent
+			ok         bool
+		)
+
+		switch item := item.(type) {
+		case *mcp.ImageContent:
+			attachment, ok = newAttachment(messages.NewDataURL(item.MIMEType, item.Data), "", item.MIMEType), true
+		case *mcp.AudioContent:
+			attachment, ok = newAttachment(messages.NewDataURL(item.MIMEType, item.Data), "", item.MIMEType), true
+		case *mcp.EmbeddedResource:
+			attachment, ok = resourceAttachment(item.Resource)
+		case *mcp.ResourceLink:
+			attachment, ok = resourceLinkAttachment(item)
+		}
+
+		if ok {
+			res = append(res, attachment)
+		}
+	}
+
+	return res
+}
+
+func resourceAttachment(resource *mcp.ResourceContents) (messages.ChatContent, bool) {
+	if resource == nil {
+		return nil, false
+	}
+
+	data, mimeType := resource.Blob, resource.MIMEType
+
+	switch {
+	case len(data) > 0 && mimeType == "":
+		mimeType = defaultBlobMIMEType
+	case len(data) == 0 && resource.Text != "":
+		data = []byte(resource.Text)
+		if mimeType == "" {
+			mimeType = defaultTextMIMEType
+		}
+	case len(data) == 0:
+		return nil, false
+	}
+
+	return newAttachment(messages.NewDataURL(mimeType, data), resourceName(resource.URI), mimeType), true
+}
+
+// resourceLinkAttachment keeps only links, which could be downloaded by chat
+// clients directly. Links with custom schemes are server-specific, and they
+// are meaningful only for the model.
+func resourceLinkAttachment(link *mcp.ResourceLink) (messages.ChatContent, bool) {
+	address, err := url.Parse(link.URI)
+	if err != nil || (address.Scheme != "http" && address.Scheme != "https") {
+		return nil, false
+	}
+
+	name := link.Name
+	if name == "" {
+		name = resourceName(link.URI)
+	}
+
+	mimeType := link.MIMEType
+	if mimeType == "" {
+		mimeType = defaultBlobMIMEType
+	}
+
+	return newAttachment(*address, name, mimeType), true
+}
+
+//nolint:ireturn // constructs one of the content types
+func newAttachment(address url.URL, name, mimeType string) messages.ChatContent {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return messages.NewChatContentImageURL(address, mimeType, nil)
+	case strings.HasPrefix(mimeType, "audio/"):
+		return messages.NewChatContentAudioURL(address, mimeType, nil)
+	default:
+		return messages.NewChatContentFileURL(address, name, mimeType, nil)
+	}
+}
+
+func resourceName(uri string) string {
+	address, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+
+	if name := path.Base(address.Path); name != "." && name != "/" {
+		return name
+	}
+
+	return ""
+}
@@ -28,6 +28,11 @@ type connFactory struct {
 	externalTransport      http.RoundTripper
 	tracer                 trace.Tracer
 	tokenSourceConstructor TokenSourceConstructor
+	handlers               *sessionHandlers
+	// observes responses of accounts, optional.
+	limits *outboundLimits
+	// local servers by name, optional.
+	stdio map[string]*stdioSupervisor
 }
 
 func NewConnectionFactory(
@@ -43,6 +48,9 @@ func NewConnectionFactory(
 		externalTransport:      externalTransport,
 		tracer:                 tracer,
 		tokenSourceConstructor: tokenSourcer,
+		handlers:               newSessionHandlers(),
+		limits:                 nil,
+		stdio:                  nil,
 	}
 
 	if err := factory.validate(ctx, unsafeExternalTransport); err != nil {
@@ -64,13 +72,22 @@ func (f *connFactory) validate(ctx context.Context, unsafeExternalTransport bool
 	return nil
 }
 
-func (f *connFactory) buildAnonymousTransport(isInternal bool) http.RoundTripper {
+func (f *connFactory) baseTransport(account ids.AccountID, isInternal bool) http.RoundTripper {
 	base := f.externalTransport
 	if isInternal {
 		base = f.internalTransport
 	}
 
-	return authorizeHeaderCollector(base)
+	// probes are not bound to accounts, so they are not limited.
+	if f.limits == nil || !account.Valid() {
+		return base
+	}
+
+	return outboundObserver(base, f.limits, account)
+}
+
+func (f *connFactory) buildAnonymousTransport(account ids.AccountID, isInternal bool) http.RoundTripper {
+	return authorizeHeaderCollector(f.baseTransport(account, isInternal))
 }
 
 func (f *connFactory) buildTempAuthTransport(token *oauth2.Token, internal bool) http.RoundTripper {
@@ -90,10 +107,7 @@ func (f *connFactory) buildTempAuthTransport(token *oauth2.Token, internal bool)
 func (f *connFactory) buildAuthorizedTransport(
 	id ids.AccountID, config *oauth2.Config, token *oauth2.Token, isInternal bool,
 ) (http.RoundTripper, error) {
-	base := f.externalTransport
-	if isInternal {
-		base = f.internalTransport
-	}
+	base := f.baseTransport(id, isInternal)
 
 	tokenSource, err := f.tokenSourceConstructor(id, config, token, isInternal)
 	if err != nil {
@@ -112,21 +126,32 @@ type asyncClient struct {
 	cancel       context.CancelFunc
 	session      *mcp.ClientSession
 	usedProtocol tools.Protocol // Which protocol was successfully used
+	// detaches session from notification handlers, optional.
+	unbind func()
+	// closed, when subprocess of local server exits. Nil for remote servers.
+	exited <-chan struct{}
 }
 
+// GetAnonymous connects to the server without credentials. Account is
+// optional: if it's valid, connection is observed by outbound limits.
 func (f *connFactory) GetAnonymous(
-	ctx context.Context, targetURL *url.URL, protocol tools.Protocol, isInternal bool,
+	ctx context.Context, account ids.AccountID, targetURL *url.URL,
+	protocol tools.Protocol, isInternal bool,
 ) (*asyncClient, error) {
 	ctx, span := f.tracer.Start(ctx, "GetAnonymous", trace.WithAttributes(
 		attribute.String("mcp.url", targetURL.String()),
 	))
 	defer span.End()
 
+	if protocol == tools.ProtocolStdio || tools.IsStdioLink(targetURL) {
+		return f.getStdio(ctx, targetURL, isInternal)
+	}
+
 	clientCtx, clientCancel := context.WithCancel(context.WithoutCancel(ctx))
-	client := newHTTPClient(f.buildAnonymousTransport(isInternal))
+	client := newHTTPClient(f.buildAnonymousTransport(account, isInternal))
 
 	session, discovered, err := autoConnectProtocol(
-		clientCtx, targetURL.String(), client, protocol,
+		clientCtx, f.handlers, targetURL.String(), client, protocol,
 	)
 
 	return f.finalizeConnect(clientCancel, session, discovered, err)
@@ -151,7 +176,7 @@ func (f *connFactory) GetPartiallyAuthorized(
 	client := newHTTPClient(f.buildTempAuthTransport(token, isInternal))
 
 	session, discovered, err := autoConnectProtocol(
-		clientCtx, targetURL.String(), client, protocol,
+		clientCtx, f.handlers, targetURL.String(), client, protocol,
 	)
 
 	return f.finalizeConnect(clientCancel, session, discovered, err)
@@ -166,6 +191,10 @@ func validatePartiallyParams(u *url.URL, token *oauth2.Token, proto tools.Protoc
 		return ErrTokenIsNil
 	}
 
+	if tools.IsStdioLink(u) {
+		return ErrStdioCredentials
+	}
+
 	if !proto.Valid() {
 		return ErrProtocolIsInvalid
 	}
@@ -195,7 +224,8 @@ func (f *connFactory) GetAuthorized(
 
 	clientCtx, clientCancel := context.WithCancel(context.WithoutCancel(ctx))
 	session, discovered, err := autoConnectProtocol(
-		clientCtx, server.SSELink().String(), newHTTPClient(transport), server.PreferredProtocol(),
+		clientCtx, f.handlers, server.SSELink().String(), newHTTPClient(transport),
+		server.PreferredProtocol(),
 	)
 
 	return f.finalizeConnect(clientCancel, session, discovered, err)
@@ -218,6 +248,10 @@ func validateAuthorizedParams(
 		return ErrTokenIsNil
 	}
 
+	if tools.IsStdioLink(serverConfig.SSELink()) {
+		return ErrStdioCredentials
+	}
+
 	return nil
 }
 
@@ -233,10 +267,31 @@ func (f *connFactory) finalizeConnect(
 		session:      session,
 		cancel:       cancel,
 		usedProtocol: proto,
+		unbind:       nil,
+		exited:       nil,
 	}, nil
 }
 
+// crashed reports, whether subprocess of local server has exited, so its
+// session is broken.
+func (client *asyncClient) crashed() bool {
+	if client.exited == nil {
+		return false
+	}
+
+	select {
+	case <-client.exited:
+		return true
+	default:
+		return false
+	}
+}
+
 func (client *asyncClient) Close() error {
+	if client.unbind != nil {
+		client.unbind()
+	}
+
 	client.cancel()
 
 	if err := client.session.Close(); err != nil {
@@ -299,11 +354,12 @@ func authorizeHeaderCollector(next http.RoundTripper) http.RoundTripper {
 }
 
 func autoConnectProtocol(
-	ctx context.Context, targetURL string, client *http.Client, protocol tools.Protocol,
+	ctx context.Context, handlers *sessionHandlers,
+	targetURL string, client *http.Client, protocol tools.Protocol,
 ) (*mcp.ClientSession, tools.Protocol, error) {
 	switch protocol {
 	case tools.ProtocolHTTP:
-		session, err := connectWithTransport(ctx, &mcp.StreamableClientTransport{
+		session, err := connectWithTransport(ctx, handlers, &mcp.StreamableClientTransport{
 			Endpoint:             targetURL,
 			HTTPClient:           client,
 			MaxRetries:           0,
@@ -314,7 +370,7 @@ func autoConnectProtocol(
 		return session, protocol, err
 
 	case tools.ProtocolSSE:
-		session, err := connectWithTransport(ctx, &mcp.SSEClientTransport{
+		session, err := connectWithTransport(ctx, handlers, &mcp.SSEClientTransport{
 			Endpoint:   targetURL,
 			HTTPClient: client,
 		})
@@ -322,17 +378,17 @@ func autoConnectProtocol(
 		return session, protocol, err
 
 	case tools.ProtocolUnknown: // discovery process
-		return discoverProtocol(ctx, targetURL, client)
+		return discoverProtocol(ctx, handlers, targetURL, client)
 	default:
 		return nil, protocol, fmt.Errorf("%w: %v", ErrUnknownProtocol, protocol)
 	}
 }
 
 func discoverProtocol(
-	ctx context.Context, targetURL string, client *http.Client,
+	ctx context.Context, handlers *sessionHandlers, targetURL string, client *http.Client,
 ) (*mcp.ClientSession, tools.Protocol, error) {
 	// Try HTTP first, then SSE
-	session, httpErr := connectWithTransport(ctx, &mcp.StreamableClientTransport{
+	session, httpErr := connectWithTransport(ctx, handlers, &mcp.StreamableClientTransport{
 		Endpoint:             targetURL,
 		HTTPClient:           client,
 		MaxRetries:           noRetries, // No retries - fail fast for protocol detection
@@ -343,7 +399,7 @@ func discoverProtocol(
 		return session, tools.ProtocolHTTP, nil
 	}
 
-	session, sseErr := connectWithTransport(ctx, &mcp.SSEClientTransport{
+	session, sseErr := connectWithTransport(ctx, handlers, &mcp.SSEClientTransport{
 		Endpoint:   targetURL,
 		HTTPClient: client,
 	})
@@ -359,23 +415,9 @@ func discoverProtocol(
 // connectWithTransport attempts to connect using the specified transport.
 // Returns the session on success, or an error that can be classified for fallback.
 func connectWithTransport(
-	ctx context.Context, transport mcp.Transport,
+	ctx context.Context, handlers *sessionHandlers, transport mcp.Transport,
 ) (*mcp.ClientSession, error) {
-	client := mcp.NewClient(clientImpl, &mcp.ClientOptions{
-		KeepAlive:                     keepAliveInterval,
-		Logger:                        nil, // TODO: add logger
-		CreateMessageHandler:          nil,
-		ElicitationHandler:            nil,
-		Capabilities:                  nil,
-		ElicitationCompleteHandler:    nil,
-		ToolListChangedHandler:        nil,
-		PromptListChangedHandler:      nil,
-		ResourceListChangedHandler:    nil,
-		ResourceUpdatedHandler:        nil,
-		LoggingMessageHandler:         nil,
-		ProgressNotificationHandler:   nil,
-		CreateMessageWithToolsHandler: nil,
-	})
+	client := mcp.NewClient(clientImpl, handlers.clientOptions())
 
 	session, err := client.Connect(ctx, transport, nil)
 	if err != nil {
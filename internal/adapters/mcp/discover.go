@@ -53,7 +53,7 @@ func (h *Handler) convertMCPTools(
 ) ([]tools.RawTool, error) {
 	discovered := make([]tools.RawTool, 0, len(mcpTools))
 	for _, mcpTool := range mcpTools {
-		tool, err := convertMCPTool(mcpTool, account, slug, desc, idBuilder)
+		tool, err := convertMCPTool(mcpTool, account, slug, desc, idBuilder, h.schemaOpts)
 		if err != nil {
 			return nil, err
 		}
@@ -68,7 +68,7 @@ func (h *Handler) getDiscoveryClient(
 	ctx context.Context, targetURL *url.URL, token *oauth2.Token, internal bool,
 ) (*asyncClient, error) {
 	if token == nil {
-		return h.factory.GetAnonymous(ctx, targetURL, tools.ProtocolUnknown, internal)
+		return h.factory.GetAnonymous(ctx, ids.AccountID{}, targetURL, tools.ProtocolUnknown, internal)
 	}
 
 	return h.factory.GetPartiallyAuthorized(ctx, targetURL, token, tools.ProtocolUnknown, internal)
@@ -79,8 +79,11 @@ func convertMCPTool(
 	account ids.AccountID,
 	accountName, desc string,
 	idBuilder toolclient.ToolIDBuilder,
+	schemaOpts []tools.SchemaOption,
 ) (tools.RawTool, error) {
-	input, err := marshalSchema(mcpTool.Name, "input", mcpTool.InputSchema)
+	input, err := parseSchema(
+		mcpTool.Name, "input", mcpTool.InputSchema, tools.NewInputSchema, schemaOpts,
+	)
 	if err != nil {
 		return tools.RawTool{}, err
 	}
@@ -90,7 +93,7 @@ func convertMCPTool(
 		outputSchema = map[string]string{"type": "string"}
 	}
 
-	output, err := marshalSchema(mcpTool.Name, "output", outputSchema)
+	output, err := parseSchema(mcpTool.Name, "output", outputSchema, tools.NewOutputSchema, schemaOpts)
 	if err != nil {
 		return tools.RawTool{}, err
 	}
@@ -102,7 +105,7 @@ func createRawTool(
 	mcpTool *mcp.Tool,
 	account ids.AccountID,
 	accountName, desc string,
-	input, output []byte,
+	input, output tools.Schema,
 	idBuilder toolclient.ToolIDBuilder,
 ) (tools.RawTool, error) {
 	toolID, err := idBuilder(account, mcpTool.Name)
@@ -110,9 +113,15 @@ func createRawTool(
 		return tools.RawTool{}, fmt.Errorf("create tool id for %q: %w", mcpTool.Name, err)
 	}
 
+	var hints tools.Hints
+	if a := mcpTool.Annotations; a != nil {
+		hints = tools.NewHints(a.ReadOnlyHint, a.IdempotentHint)
+	}
+
 	tool, err := tools.NewRawTool(
 		mcpTool.Name, mcpTool.Description, input, output,
 		toolID, accountName, desc,
+		tools.WithRawToolHints(hints),
 	)
 	if err != nil {
 		return tools.RawTool{}, fmt.Errorf("new raw tool: %w", err)
@@ -121,11 +130,23 @@ func createRawTool(
 	return tool, nil
 }
 
-func marshalSchema(toolName, schemaType string, schema any) ([]byte, error) {
+// parseSchema normalizes tool schema right on discovery, so incompatible
+// tools are rejected before they reach the model.
+func parseSchema(
+	toolName, schemaType string,
+	schema any,
+	parse func(json.RawMessage, ...tools.SchemaOption) (tools.Schema, error),
+	opts []tools.SchemaOption,
+) (tools.Schema, error) {
 	data, err := json.Marshal(schema)
 	if err != nil {
-		return nil, fmt.Errorf("marshal %s schema for %q: %w", schemaType, toolName, err)
+		return tools.Schema{}, fmt.Errorf("marshal %s schema for %q: %w", schemaType, toolName, err)
+	}
+
+	res, err := parse(data, opts...)
+	if err != nil {
+		return tools.Schema{}, fmt.Errorf("parse %s schema for %q: %w", schemaType, toolName, err)
 	}
 
-	return data, nil
+	return res, nil
 }
@@ -0,0 +1,272 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/elicitation"
+)
+
+var errUnsupportedProperty = errors.New("unsupported elicitation property")
+
+type elicitationSub struct {
+	id      uint64
+	handler toolclient.ElicitationHandler
+}
+
+// elicitationRouter matches "elicitation/create" with running tool calls by
+// session. MCP doesn't bind elicitation to the request, which caused it, so
+// if several calls with handlers share the session, the latest one answers.
+type elicitationRouter struct {
+	subs   map[*mcp.ClientSession][]elicitationSub
+	lastID uint64
+	mu     sync.RWMutex
+}
+
+func newElicitationRouter() *elicitationRouter {
+	return &elicitationRouter{
+		subs:   make(map[*mcp.ClientSession][]elicitationSub),
+		lastID: 0,
+		mu:     sync.RWMutex{},
+	}
+}
+
+// subscribe registers handler of the session. Returned function must be
+// called after request finished.
+func (r *elicitationRouter) subscribe(
+	session *mcp.ClientSession, f toolclient.ElicitationHandler,
+) (unsubscribe func()) {
+	r.mu.Lock()
+	r.lastID++
+	id := r.lastID
+	r.subs[session] = append(r.subs[session], elicitationSub{id: id, handler: f})
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.subs[session] = slices.DeleteFunc(r.subs[session], func(s elicitationSub) bool {
+			return s.id == id
+		})
+		if len(r.subs[session]) == 0 {
+			delete(r.subs, session)
+		}
+	}
+}
+
+// handler returns the latest handler of the session. Like sampling, it's
+// called without lock: user may answer in minutes.
+func (r *elicitationRouter) handler(
+	session *mcp.ClientSession,
+) (toolclient.ElicitationHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subs := r.subs[session]
+	if len(subs) == 0 {
+		return nil, false
+	}
+
+	return subs[len(subs)-1].handler, true
+}
+
+// handle answers "elicitation/create" of pooled sessions. Schema is already
+// validated by sdk, as well as the answer, before it's sent to server.
+func (r *elicitationRouter) handle(
+	ctx context.Context, req *mcp.ElicitRequest,
+) (*mcp.ElicitResult, error) {
+	if req == nil || req.Params == nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: "params are required", Data: nil}
+	}
+
+	if req.Params.Mode != "" && req.Params.Mode != "form" {
+		return nil, &jsonrpc.Error{
+			Code: jsonrpc.CodeInvalidParams, Message: "only form elicitation is supported", Data: nil,
+		}
+	}
+
+	handler, ok := r.handler(req.Session)
+	if !ok {
+		// nobody waits for this session: e.g. tool call is already finished.
+		return &mcp.ElicitResult{Meta: nil, Action: elicitation.ActionCancel.String(), Content: nil}, nil
+	}
+
+	elicitReq, err := convertElicitRequest(req.Params)
+	if err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: err.Error(), Data: nil}
+	}
+
+	res, err := handler(ctx, elicitReq)
+	if err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInternalError, Message: "elicitation failed", Data: nil}
+	}
+
+	return &mcp.ElicitResult{
+		Meta:    nil,
+		Action:  res.Action().String(),
+		Content: res.Content(),
+	}, nil
+}
+
+// elicitSchema is a subset of json schema, which MCP allows for elicitation:
+// flat object with primitive properties.
+type elicitSchema struct {
+	Properties map[string]elicitProperty `json:"properties"`
+	Required   []string                  `json:"required"`
+}
+
+type elicitProperty struct {
+	Type        string        `json:"type"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Enum        []string      `json:"enum"`
+	EnumNames   []string      `json:"enumNames"`
+	OneOf       []elicitTitle `json:"oneOf"`
+	MinLength   *int          `json:"minLength"`
+	MaxLength   *int          `json:"maxLength"`
+	Minimum     *float64      `json:"minimum"`
+	Maximum     *float64      `json:"maximum"`
+}
+
+type elicitTitle struct {
+	Const string `json:"const"`
+	Title string `json:"title"`
+}
+
+// convertElicitRequest converts requested schema into form fields. Schema
+// comes as a decoded map, so declaration order is lost: required fields are
+// asked first, in order of "required" list, then optional ones by name.
+func convertElicitRequest(params *mcp.ElicitParams) (elicitation.Request, error) {
+	var schema elicitSchema
+
+	if params.RequestedSchema != nil {
+		raw, err := json.Marshal(params.RequestedSchema)
+		if err != nil {
+			return elicitation.Request{}, fmt.Errorf("encoding requested schema: %w", err)
+		}
+
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			return elicitation.Request{}, fmt.Errorf("decoding requested schema: %w", err)
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	optional := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		if !slices.Contains(names, name) {
+			optional = append(optional, name)
+		}
+	}
+
+	slices.Sort(optional)
+	names = append(names, optional...)
+
+	fields := make([]elicitation.Field, 0, len(names))
+
+	for _, name := range names {
+		required := slices.Contains(schema.Required, name)
+
+		field, err := convertElicitProperty(name, schema.Properties[name], required)
+		if err != nil {
+			return elicitation.Request{}, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	//nolint:wrapcheck // errors of the primitive are passed to server as is
+	return elicitation.NewRequest(params.Message, fields...)
+}
+
+func convertElicitProperty(
+	name string, prop elicitProperty, required bool,
+) (elicitation.Field, error) {
+	opts := []elicitation.FieldOption{
+		elicitation.WithTitle(prop.Title),
+		elicitation.WithDescription(prop.Description),
+	}
+
+	if required {
+		opts = append(opts, elicitation.WithRequired())
+	}
+
+	var kind elicitation.Kind
+
+	switch {
+	case prop.Type != "" && prop.Type != "string" && (len(prop.Enum) > 0 || len(prop.OneOf) > 0):
+		return elicitation.Field{}, fmt.Errorf(
+			"%w: %q has enum of type %q", errUnsupportedProperty, name, prop.Type,
+		)
+	case len(prop.Enum) > 0 || len(prop.OneOf) > 0:
+		kind = elicitation.KindEnum
+		opts = append(opts, elicitation.WithOptions(enumOptions(prop)...))
+	case prop.Type == "string":
+		kind = elicitation.KindString
+		opts = append(opts, elicitation.WithBounds(intBound(prop.MinLength), intBound(prop.MaxLength)))
+	case prop.Type == "number":
+		kind = elicitation.KindNumber
+		opts = append(opts, elicitation.WithBounds(prop.Minimum, prop.Maximum))
+	case prop.Type == "integer":
+		kind = elicitation.KindInteger
+		opts = append(opts, elicitation.WithBounds(prop.Minimum, prop.Maximum))
+	case prop.Type == "boolean":
+		kind = elicitation.KindBoolean
+	default:
+		// multi-select arrays are not supported yet.
+		return elicitation.Field{}, fmt.Errorf(
+			"%w: %q has type %q", errUnsupportedProperty, name, prop.Type,
+		)
+	}
+
+	//nolint:wrapcheck // errors of the primitive are passed to server as is
+	return elicitation.NewField(name, kind, opts...)
+}
+
+func enumOptions(prop elicitProperty) []elicitation.Option {
+	if len(prop.OneOf) > 0 {
+		options := make([]elicitation.Option, 0, len(prop.OneOf))
+		for _, entry := range prop.OneOf {
+			options = append(options, elicitation.NewOption(entry.Const, entry.Title))
+		}
+
+		return options
+	}
+
+	options := make([]elicitation.Option, 0, len(prop.Enum))
+
+	for i, value := range prop.Enum {
+		var title string
+		if i < len(prop.EnumNames) {
+			title = prop.EnumNames[i]
+		}
+
+		options = append(options, elicitation.NewOption(value, title))
+	}
+
+	return options
+}
+
+func intBound(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+
+	bound := float64(*v)
+
+	return &bound
+}
@@ -0,0 +1,83 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/elicitation"
+)
+
+func TestElicitation(t *testing.T) {
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "book_table",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(ctx context.Context, req *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		res, err := req.Session.Elicit(ctx, &sdk.ElicitParams{
+			Message: "Where do you want to sit?",
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"guests": map[string]any{"type": "integer", "minimum": 1},
+					"zone": map[string]any{
+						"type":      "string",
+						"enum":      []string{"in", "out"},
+						"enumNames": []string{"Inside", "Outside"},
+					},
+					"note": map[string]any{"type": "string"},
+				},
+				"required": []string{"zone", "guests"},
+			},
+		})
+		if err != nil {
+			return &sdk.CallToolResult{IsError: true, Content: []sdk.Content{&sdk.TextContent{Text: err.Error()}}}, nil
+		}
+
+		text := fmt.Sprintf("%s %v", res.Action, res.Content)
+
+		return &sdk.CallToolResult{Content: []sdk.Content{&sdk.TextContent{Text: text}}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+	tool := mustTool(t, account, "book_table")
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-1")
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "cancel", "requests must be cancelled without handler")
+
+	var received elicitation.Request
+
+	res, err = handler.ExecuteTool(t.Context(), tool, nil, "call-2",
+		toolclient.WithElicitationHandler(func(
+			_ context.Context, req elicitation.Request,
+		) (elicitation.Result, error) {
+			received = req
+
+			return req.Accept(map[string]any{"zone": "out", "guests": int64(2)})
+		}),
+	)
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "accept map[guests:2 zone:out]")
+	require.Equal(t, "Where do you want to sit?", received.Message())
+
+	fields := received.Fields()
+	require.Len(t, fields, 3)
+	require.Equal(t, "zone", fields[0].Name(), "required fields must be asked first")
+	require.Equal(t, "Outside", fields[0].Options()[1].Label())
+	require.Equal(t, elicitation.KindInteger, fields[1].Kind())
+	require.False(t, fields[2].Required())
+
+	res, err = handler.ExecuteTool(t.Context(), tool, nil, "call-3",
+		toolclient.WithElicitationHandler(func(context.Context, elicitation.Request) (elicitation.Result, error) {
+			return elicitation.Decline(), nil
+		}),
+	)
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "decline")
+}
@@ -6,11 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 // ExecuteTool implements ports.ToolClient.
@@ -19,18 +24,67 @@ import (
 func (h *Handler) ExecuteTool(
 	ctx context.Context, tool entities.ToolReadOnly,
 	args map[string]json.RawMessage, toolCallID string,
+	opts ...toolclient.ExecuteToolOption,
 ) (messages.MessageTool, error) {
-	client, err := h.clients.Get(ctx, tool.ID().Account())
+	params := toolclient.ExecuteToolParams(opts...)
+	account := tool.ID().Account()
+
+	delay, err := h.limits.acquire(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for outbound limit: %w", err)
+	} else if delay > 0 {
+		return newRateLimitedError(tool, toolCallID, delay)
+	}
+
+	client, err := h.client(ctx, account)
 	if err != nil {
 		return nil, MapError(err)
 	}
 
-	resp, err := client.session.CallTool(ctx, &mcp.CallToolParams{
+	callParams := &mcp.CallToolParams{
 		Name:      tool.Name(),
 		Arguments: args,
 		Meta:      nil,
-	})
+	}
+
+	if handler := params.ProgressHandler(); handler != nil {
+		token, unsubscribe := h.factory.handlers.progress.subscribe(
+			progressCallback(tool.Name(), toolCallID, handler),
+		)
+		defer unsubscribe()
+
+		callParams.SetProgressToken(token)
+	}
+
+	// deadline of the call keeps running, while user answers: servers, which
+	// ask for input, need longer timeouts.
+	if handler := params.ElicitationHandler(); handler != nil {
+		unsubscribe := h.factory.handlers.elicitation.subscribe(client.session, handler)
+		defer unsubscribe()
+	}
+
+	// when call context is done, sdk notifies server with
+	// "notifications/cancelled", so there is no need to do it manually.
+	callCtx, timeout, cancel := h.timeouts.withDeadline(ctx, tool)
+	defer cancel()
+
+	resp, err := client.session.CallTool(callCtx, callParams)
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return newToolError(tool, toolCallID, toolErrorPayload{
+			Error:      "tool execution timed out",
+			Timeout:    timeout.String(),
+			RetryAfter: "",
+			Violations: nil,
+		})
+	}
+
 	if err != nil {
+		// sdk doesn't keep transport errors, so rejection is detected by
+		// the state of limits.
+		if delay := h.limits.blocked(account); delay > 0 {
+			return newRateLimitedError(tool, toolCallID, delay)
+		}
+
 		return nil, MapError(err)
 	}
 
@@ -39,29 +93,61 @@ func (h *Handler) ExecuteTool(
 		return nil, err
 	}
 
-	return h.createToolMessage(tool, content, toolCallID)
+	return h.createToolMessage(ctx, tool, content, toolCallID, params.OversizeHandler())
+}
+
+func progressCallback(
+	toolName, toolCallID string, handler toolclient.ProgressHandler,
+) progressFunc {
+	return func(p *mcp.ProgressNotificationParams) {
+		msg, err := messages.NewMessageToolProgress(p.Progress, toolName, toolCallID,
+			messages.WithMessageToolProgressTotal(p.Total),
+			messages.WithMessageToolProgressMessage(p.Message),
+		)
+		if err != nil {
+			// server sent malformed progress, it's safe to ignore it.
+			return
+		}
+
+		handler(msg)
+	}
 }
 
 //nolint:ireturn // Helper that returns an interface for polymorphism.
 func (h *Handler) createToolMessage(
+	ctx context.Context,
 	tool entities.ToolReadOnly,
-	content json.RawMessage,
+	content toolContent,
 	toolCallID string,
+	oversize toolclient.OversizeHandler,
 ) (messages.MessageTool, error) {
-	msg, err := messages.NewMessageToolResponse(content, tool.Name(), toolCallID)
-	if errors.Is(err, messages.ErrMessageTooLarge) {
-		toolID := tool.ID().ID().String()
+	var opts []messages.NewMessageToolResponseOpt
 
-		errMsg, errCreate := messages.NewMessageToolError(
-			json.RawMessage(`"tool response is too large"`),
-			tool.Name(),
-			toolID,
-		)
-		if errCreate != nil {
-			return nil, fmt.Errorf("creating tool error message: %w", errCreate)
+	if len(content.attachments) > 0 {
+		opts = append(opts, messages.WithMessageToolResponseAttachments(content.attachments...))
+	}
+
+	if content.structured {
+		violations := h.validateResult(ctx, tool, content.data)
+		switch {
+		case len(violations) == 0:
+			opts = append(opts, messages.WithMessageToolResponseStructured())
+		case h.outputValidation == OutputValidationStrict:
+			return newToolError(tool, toolCallID, toolErrorPayload{
+				Error:      "tool result does not match its output schema",
+				Timeout:    "",
+				RetryAfter: "",
+				Violations: violations,
+			})
+		default:
+			// result is still useful for the model, but downstream can't rely
+			// on its structure.
 		}
+	}
 
-		return errMsg, nil
+	msg, err := messages.NewMessageToolResponse(content.data, tool.Name(), toolCallID, opts...)
+	if errors.Is(err, messages.ErrMessageTooLarge) {
+		return offloadToolMessage(ctx, tool, content, toolCallID, oversize)
 	}
 
 	if err != nil {
@@ -71,18 +157,153 @@ func (h *Handler) createToolMessage(
 	return msg, nil
 }
 
-func extractContent(resp *mcp.CallToolResult) (json.RawMessage, error) {
+// offloadToolMessage replaces too large result with content, provided by
+// oversize handler. Replacement is not structured anymore, so it's not
+// marked as such.
+//
+//nolint:ireturn // Helper that returns an interface for polymorphism.
+func offloadToolMessage(
+	ctx context.Context,
+	tool entities.ToolReadOnly,
+	content toolContent,
+	toolCallID string,
+	oversize toolclient.OversizeHandler,
+) (messages.MessageTool, error) {
+	tooLarge := toolErrorPayload{
+		Error:      "tool response is too large",
+		Timeout:    "",
+		RetryAfter: "",
+		Violations: nil,
+	}
+
+	if oversize == nil {
+		return newToolError(tool, toolCallID, tooLarge)
+	}
+
+	replacement, err := oversize(ctx, content.data)
+	if err != nil {
+		trace.SpanFromContext(ctx).AddEvent("failed to offload tool result",
+			trace.WithAttributes(
+				attribute.String("cynosure.tool.name", tool.Name()),
+				attribute.String("error", err.Error()),
+			),
+		)
+
+		return newToolError(tool, toolCallID, tooLarge)
+	}
+
+	msg, err := messages.NewMessageToolResponse(replacement, tool.Name(), toolCallID,
+		messages.WithMessageToolResponseAttachments(content.attachments...),
+	)
+	if errors.Is(err, messages.ErrMessageTooLarge) {
+		return newToolError(tool, toolCallID, tooLarge)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("creating offloaded tool response message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// toolErrorPayload is a structured error, returned to the model instead of
+// tool result.
+type toolErrorPayload struct {
+	Error      string            `json:"error"`
+	Timeout    string            `json:"timeout,omitempty"`
+	RetryAfter string            `json:"retry_after,omitempty"`
+	Violations []tools.Violation `json:"violations,omitempty"`
+}
+
+// validateResult checks structured result against output schema of the tool.
+// MCP requires output schemas to describe objects, so tools with other
+// schemas didn't declare any, and their results are never validated.
+//
+// Every mismatch is reported as a span event, even if it's ignored.
+func (h *Handler) validateResult(
+	ctx context.Context, tool entities.ToolReadOnly, result json.RawMessage,
+) []tools.Violation {
+	schema := tool.OutputSchema()
+	if h.outputValidation == OutputValidationDisabled || !schema.IsObject() {
+		return nil
+	}
+
+	var resultErr *tools.ResultError
+	if err := schema.ValidateResult(result); !errors.As(err, &resultErr) {
+		return nil
+	}
+
+	violations := resultErr.Violations()
+
+	trace.SpanFromContext(ctx).AddEvent("tool result does not match output schema",
+		trace.WithAttributes(
+			attribute.String("cynosure.tool.name", tool.Name()),
+			attribute.String("cynosure.tool.violations", resultErr.Error()),
+		),
+	)
+
+	return violations
+}
+
+//nolint:ireturn // Helper that returns an interface for polymorphism.
+func newToolError(
+	tool entities.ToolReadOnly, toolCallID string, payload toolErrorPayload,
+) (messages.MessageTool, error) {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling tool error: %w", err)
+	}
+
+	msg, err := messages.NewMessageToolError(content, tool.Name(), toolCallID)
+	if err != nil {
+		return nil, fmt.Errorf("creating tool error message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// newRateLimitedError tells model, that server is busy, and when the call may
+// be retried.
+//
+//nolint:ireturn // Helper that returns an interface for polymorphism.
+func newRateLimitedError(
+	tool entities.ToolReadOnly, toolCallID string, delay time.Duration,
+) (messages.MessageTool, error) {
+	// model can't wait for less than a second anyway.
+	retryAfter := (delay + time.Second - 1).Truncate(time.Second)
+
+	return newToolError(tool, toolCallID, toolErrorPayload{
+		Error:      "tool server rate limit exceeded, retry later",
+		Timeout:    "",
+		RetryAfter: retryAfter.String(),
+		Violations: nil,
+	})
+}
+
+// toolContent is a tool result, converted to json.
+type toolContent struct {
+	data json.RawMessage
+	// media blocks of the result.
+	attachments []messages.ChatContent
+	// structured reports that data is taken from structured content of the
+	// result.
+	structured bool
+}
+
+func extractContent(resp *mcp.CallToolResult) (toolContent, error) {
+	attachments := extractAttachments(resp.Content)
+
 	if resp.StructuredContent != nil {
 		content, err := json.Marshal(resp.StructuredContent)
 		if err != nil {
-			return nil, fmt.Errorf("marshalling structured content back: %w", err)
+			return toolContent{}, fmt.Errorf("marshalling structured content back: %w", err)
 		}
 
-		return content, nil
+		return toolContent{data: content, attachments: attachments, structured: true}, nil
 	}
 
 	if jsonContent, ok := contentIsJSON(resp.Content); ok {
-		return jsonContent, nil
+		return toolContent{data: jsonContent, attachments: attachments, structured: false}, nil
 	}
 
 	var result strings.Builder
@@ -95,10 +316,10 @@ func extractContent(resp *mcp.CallToolResult) (json.RawMessage, error) {
 
 	content, err := json.Marshal(result.String())
 	if err != nil {
-		return nil, fmt.Errorf("marshalling text content: %w", err)
+		return toolContent{}, fmt.Errorf("marshalling text content: %w", err)
 	}
 
-	return content, nil
+	return toolContent{data: content, attachments: attachments, structured: false}, nil
 }
 
 func contentIsJSON(content []mcp.Content) (json.RawMessage, bool) {
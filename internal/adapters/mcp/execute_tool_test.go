@@ -0,0 +1,468 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mcp"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+func TestExecuteToolTimeout(t *testing.T) {
+	cancelled := make(chan struct{})
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "slow_tool",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(ctx context.Context, _ *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		<-ctx.Done()
+		close(cancelled)
+
+		return nil, ctx.Err()
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv,
+		mcp.WithToolTimeout(time.Minute),
+		mcp.WithServerToolTimeout(account.Server(), time.Minute),
+		mcp.WithSingleToolTimeout(account.Server(), "slow_tool", 100*time.Millisecond),
+	)
+	tool := mustTool(t, account, "slow_tool")
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-1")
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolError{}, res)
+	require.Equal(t, "call-1", res.ToolCallID())
+	require.JSONEq(t, `{"error":"tool execution timed out","timeout":"100ms"}`, string(res.Content()))
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not receive cancellation notification")
+	}
+}
+
+func TestExecuteToolProgress(t *testing.T) {
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "progress_tool",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(ctx context.Context, req *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		for i := range 2 {
+			err := req.Session.NotifyProgress(ctx, &sdk.ProgressNotificationParams{
+				ProgressToken: req.Params.GetProgressToken(),
+				Message:       "Searching",
+				Progress:      float64(i + 1),
+				Total:         4,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// client handles notifications asynchronously, so tool must be
+		// alive for a while to deliver them.
+		time.Sleep(100 * time.Millisecond)
+
+		return &sdk.CallToolResult{Content: []sdk.Content{&sdk.TextContent{Text: "done"}}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+	tool := mustTool(t, account, "progress_tool")
+
+	var reported []messages.MessageToolProgress
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-1",
+		toolclient.WithProgressHandler(func(p messages.MessageToolProgress) {
+			reported = append(reported, p)
+		}),
+	)
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolResponse{}, res)
+	require.Len(t, reported, 2)
+
+	for _, p := range reported {
+		require.Equal(t, "call-1", p.ToolCallID())
+		require.Equal(t, "Searching", p.Message())
+	}
+
+	percent, ok := reported[1].Percent()
+	require.True(t, ok)
+	require.InDelta(t, 50.0, percent, 0.001)
+}
+
+func TestSubscribeToolsChanged(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	changed := make(chan ids.AccountID, 1)
+	unsubscribe := handler.SubscribeToolsChanged(func(_ context.Context, id ids.AccountID) {
+		select {
+		case changed <- id:
+		default:
+		}
+	})
+	t.Cleanup(unsubscribe)
+
+	// notifications are received only by pooled sessions, so account must
+	// call something first.
+	_, err := handler.ExecuteTool(t.Context(), mustTool(t, account, "echo"), nil, "call-1")
+	require.NoError(t, err)
+
+	srv.AddTool(&sdk.Tool{
+		Name:        "new_tool",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		return &sdk.CallToolResult{}, nil
+	})
+
+	select {
+	case id := <-changed:
+		require.Equal(t, account, id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tools/list_changed notification was not delivered")
+	}
+}
+
+func TestExecuteToolOutputSchema(t *testing.T) {
+	const outputSchema = `{
+		"type": "object",
+		"required": ["temperature"],
+		"properties": {"temperature": {"type": "number"}}
+	}`
+
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "weather",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(_ context.Context, req *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		var args struct {
+			Broken bool `json:"broken"`
+		}
+		if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+			return nil, err
+		}
+
+		if args.Broken {
+			return &sdk.CallToolResult{StructuredContent: map[string]any{"temperature": "warm"}}, nil
+		}
+
+		return &sdk.CallToolResult{StructuredContent: map[string]any{"temperature": 21.5}}, nil
+	})
+
+	valid := map[string]json.RawMessage{"broken": json.RawMessage(`false`)}
+	broken := map[string]json.RawMessage{"broken": json.RawMessage(`true`)}
+
+	t.Run("valid result is structured", func(t *testing.T) {
+		account := mustAccountID(t)
+		handler := setupToolHandler(t, account, srv)
+		tool := mustToolWithOutput(t, account, "weather", outputSchema)
+
+		res, err := handler.ExecuteTool(t.Context(), tool, valid, "call-1")
+		require.NoError(t, err)
+		require.IsType(t, messages.MessageToolResponse{}, res)
+
+		fields, ok := res.(messages.MessageToolResponse).Structured()
+		require.True(t, ok)
+		require.JSONEq(t, `21.5`, string(fields["temperature"]))
+	})
+
+	t.Run("mismatch is a warning by default", func(t *testing.T) {
+		account := mustAccountID(t)
+		handler := setupToolHandler(t, account, srv)
+		tool := mustToolWithOutput(t, account, "weather", outputSchema)
+
+		res, err := handler.ExecuteTool(t.Context(), tool, broken, "call-1")
+		require.NoError(t, err)
+		require.IsType(t, messages.MessageToolResponse{}, res)
+
+		_, ok := res.(messages.MessageToolResponse).Structured()
+		require.False(t, ok)
+	})
+
+	t.Run("mismatch is an error in strict mode", func(t *testing.T) {
+		account := mustAccountID(t)
+		handler := setupToolHandler(t, account, srv, mcp.WithOutputValidation(mcp.OutputValidationStrict))
+		tool := mustToolWithOutput(t, account, "weather", outputSchema)
+
+		res, err := handler.ExecuteTool(t.Context(), tool, broken, "call-1")
+		require.NoError(t, err)
+		require.IsType(t, messages.MessageToolError{}, res)
+
+		var payload struct {
+			Violations []tools.Violation `json:"violations"`
+		}
+		require.NoError(t, json.Unmarshal(res.Content(), &payload))
+		require.Len(t, payload.Violations, 1)
+		require.Equal(t, "temperature", payload.Violations[0].Field)
+	})
+}
+
+func TestExecuteToolAttachments(t *testing.T) {
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "chart",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		return &sdk.CallToolResult{Content: []sdk.Content{
+			&sdk.TextContent{Text: "rendered"},
+			&sdk.ImageContent{MIMEType: "image/png", Data: []byte("png")},
+			&sdk.ResourceLink{URI: "https://example.com/report.pdf", Name: "report.pdf", MIMEType: "application/pdf"},
+			&sdk.ResourceLink{URI: "file:///tmp/local.txt"},
+		}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	res, err := handler.ExecuteTool(t.Context(), mustTool(t, account, "chart"), nil, "call-1")
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolResponse{}, res)
+
+	attachments := res.(messages.MessageToolResponse).Attachments()
+	require.Len(t, attachments, 2)
+
+	require.IsType(t, &messages.ChatContentImageURL{}, attachments[0])
+	mimeType, data, ok := messages.DecodeDataURL(attachments[0].URL())
+	require.True(t, ok)
+	require.Equal(t, "image/png", mimeType)
+	require.Equal(t, []byte("png"), data)
+
+	require.IsType(t, &messages.ChatContentFileURL{}, attachments[1])
+	require.Equal(t, "https://example.com/report.pdf", attachments[1].URL().String())
+}
+
+func TestExecuteToolOversize(t *testing.T) {
+	large := strings.Repeat("x", 10<<10)
+
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "dump",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		return &sdk.CallToolResult{Content: []sdk.Content{&sdk.TextContent{Text: large}}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+	tool := mustTool(t, account, "dump")
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-1")
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolError{}, res)
+	require.JSONEq(t, `{"error":"tool response is too large"}`, string(res.Content()))
+
+	var offloaded json.RawMessage
+
+	res, err = handler.ExecuteTool(t.Context(), tool, nil, "call-2",
+		toolclient.WithOversizeHandler(func(_ context.Context, content json.RawMessage) (json.RawMessage, error) {
+			offloaded = content
+			return json.RawMessage(`{"ref":"stored"}`), nil
+		}),
+	)
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolResponse{}, res)
+	require.JSONEq(t, `{"ref":"stored"}`, string(res.Content()))
+	require.Contains(t, string(offloaded), large)
+}
+
+func TestExecuteToolOutboundLimit(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv,
+		mcp.WithAccountOutboundLimit(rate.Every(time.Minute), 1),
+	)
+	tool := mustTool(t, account, "echo")
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-1")
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolResponse{}, res)
+
+	res, err = handler.ExecuteTool(t.Context(), tool, nil, "call-2")
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolError{}, res)
+	require.JSONEq(t,
+		`{"error":"tool server rate limit exceeded, retry later","retry_after":"1m0s"}`,
+		string(res.Content()),
+	)
+}
+
+func TestForgetAccount(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv,
+		mcp.WithAccountOutboundLimit(rate.Every(time.Minute), 1),
+	)
+	tool := mustTool(t, account, "echo")
+
+	for _, id := range []string{"call-1", "call-2"} {
+		_, err := handler.ExecuteTool(t.Context(), tool, nil, id)
+		require.NoError(t, err)
+	}
+
+	// deleted account doesn't keep its limiter: same id starts from scratch.
+	handler.ForgetAccount(account)
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-3")
+	require.NoError(t, err)
+	require.IsType(t, messages.MessageToolResponse{}, res)
+}
+
+func TestExecuteToolOutboundWait(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv,
+		mcp.WithOutboundLimit(rate.Every(200*time.Millisecond), 1),
+		mcp.WithOutboundPolicy(mcp.OutboundWait, time.Second),
+	)
+	tool := mustTool(t, account, "echo")
+
+	start := time.Now()
+
+	for _, id := range []string{"call-1", "call-2"} {
+		res, err := handler.ExecuteTool(t.Context(), tool, nil, id)
+		require.NoError(t, err)
+		require.IsType(t, messages.MessageToolResponse{}, res)
+	}
+
+	require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestExecuteToolRetryAfter(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	sdkHandler := sdk.NewStreamableHTTPHandler(
+		func(*http.Request) *sdk.Server { return srv }, nil,
+	)
+	limited := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if strings.Contains(string(body), `"tools/call"`) {
+			w.Header().Set("Retry-After", "30")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		sdkHandler.ServeHTTP(w, r)
+	})
+
+	account := mustAccountID(t)
+	handler := setupHTTPToolHandler(t, account, limited)
+	tool := mustTool(t, account, "echo")
+
+	for _, id := range []string{"call-1", "call-2"} {
+		res, err := handler.ExecuteTool(t.Context(), tool, nil, id)
+		require.NoError(t, err)
+		require.IsType(t, messages.MessageToolError{}, res)
+
+		var payload struct {
+			RetryAfter string `json:"retry_after"`
+		}
+
+		require.NoError(t, json.Unmarshal(res.Content(), &payload))
+
+		delay, err := time.ParseDuration(payload.RetryAfter)
+		require.NoError(t, err)
+		require.InDelta(t, 30*time.Second, delay, float64(2*time.Second))
+	}
+}
+
+func addEchoTool(srv *sdk.Server) {
+	srv.AddTool(&sdk.Tool{
+		Name:        "echo",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		return &sdk.CallToolResult{Content: []sdk.Content{&sdk.TextContent{Text: "ok"}}}, nil
+	})
+}
+
+func newTestServer() *sdk.Server {
+	return sdk.NewServer(&sdk.Implementation{Name: "test", Version: "1.0.0"}, nil)
+}
+
+func setupToolHandler(
+	t *testing.T, account ids.AccountID, srv *sdk.Server, opts ...mcp.HandlerOption,
+) *mcp.Handler {
+	t.Helper()
+
+	return setupHTTPToolHandler(t, account, sdk.NewStreamableHTTPHandler(
+		func(*http.Request) *sdk.Server { return srv }, nil,
+	), opts...)
+}
+
+func setupHTTPToolHandler(
+	t *testing.T, account ids.AccountID, httpHandler http.Handler, opts ...mcp.HandlerOption,
+) *mcp.Handler {
+	t.Helper()
+
+	testServer := httptest.NewServer(httpHandler)
+	t.Cleanup(testServer.Close)
+
+	server := must(entities.NewServerConfig(account.Server(), must(url.Parse(testServer.URL))))
+
+	accountToken := func(
+		context.Context, ids.AccountID,
+	) (entities.ServerConfigReadOnly, *oauth2.Token, error) {
+		return server, nil, nil
+	}
+
+	handler, err := mcp.New(t.Context(), accountToken, noopRefreshToken, append([]mcp.HandlerOption{
+		mcp.WithUnsafeExternalHTTPClient(http.DefaultTransport),
+		mcp.WithInternalHTTPClient(http.DefaultTransport),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, handler.Close()) })
+
+	return handler
+}
+
+func mustTool(t *testing.T, account ids.AccountID, name string) *entities.Tool {
+	t.Helper()
+
+	return mustToolWithOutput(t, account, name, `{"type":"object"}`)
+}
+
+func mustToolWithOutput(t *testing.T, account ids.AccountID, name, output string) *entities.Tool {
+	t.Helper()
+
+	return must(entities.NewTool(
+		must(ids.RandomToolID(account)), "test", name, "test tool",
+		must(tools.NewInputSchema(json.RawMessage(`{"type":"object"}`))),
+		must(tools.NewOutputSchema(json.RawMessage(output))),
+	))
+}
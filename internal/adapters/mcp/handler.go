@@ -14,6 +14,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 type (
@@ -31,6 +32,7 @@ type (
 
 const (
 	defaultMaxConnSize = 5
+	defaultToolTimeout = 2 * time.Minute
 	cacheTTL           = 10 * time.Minute
 )
 
@@ -42,10 +44,31 @@ var clientImpl = &mcp.Implementation{
 	Icons:      nil,
 }
 
+// OutputValidation defines how handler reacts on structured tool results,
+// which don't match output schema of the tool.
+type OutputValidation uint8
+
+const (
+	// OutputValidationWarn reports mismatch in traces and returns result to
+	// the model as unstructured content.
+	OutputValidationWarn OutputValidation = iota
+	// OutputValidationStrict replaces mismatched result with tool error,
+	// listing all violations.
+	OutputValidationStrict
+	// OutputValidationDisabled skips validation: structured results are
+	// trusted as is.
+	OutputValidationDisabled
+)
+
 type Handler struct {
 	clients *cache.Cache[ids.AccountID, *asyncClient]
 	tracer  ports.ObserveStack
 
+	timeouts         toolTimeouts
+	outputValidation OutputValidation
+	limits           *outboundLimits
+	schemaOpts       []tools.SchemaOption
+
 	// factory and accountToken for probing, bypass cache
 	factory *connFactory
 }
@@ -75,6 +98,10 @@ func New(
 		return nil, err
 	}
 
+	limits := newOutboundLimits(&params)
+	connFactory.limits = limits
+	connFactory.stdio = newStdioSupervisors(params.stdio.servers, params.stdio.logger)
+
 	return &Handler{
 		clients: cache.New(
 			cacheConstructor(connFactory, accountToken),
@@ -84,6 +111,11 @@ func New(
 		),
 		tracer: tracer,
 
+		timeouts:         params.timeouts,
+		outputValidation: params.outputValidation,
+		limits:           limits,
+		schemaOpts:       params.schemaOpts,
+
 		factory: connFactory,
 	}, nil
 }
@@ -121,6 +153,23 @@ func validateNewParams(accountToken AccountTokenFunc, refreshToken TokenSourceCo
 	return nil
 }
 
+// SubscribeToolsChanged implements toolclient.Port.
+func (h *Handler) SubscribeToolsChanged(handler toolclient.ToolsChangedHandler) func() {
+	return h.factory.handlers.accounts.subscribeTools(handler)
+}
+
+// ForgetAccount implements toolclient.Port.
+func (h *Handler) ForgetAccount(account ids.AccountID) {
+	h.clients.Invalidate(account, func(*asyncClient) bool { return true })
+	h.limits.forget(account)
+}
+
+// Cleanup periodically removes outbound limits of idle servers and accounts
+// from memory. Blocks until ctx is canceled.
+func (h *Handler) Cleanup(ctx context.Context) error {
+	return h.limits.cleanup(ctx)
+}
+
 // Close closes the handler and all active MCP sessions.
 func (h *Handler) Close() error {
 	if err := h.clients.Close(); err != nil {
@@ -130,6 +179,19 @@ func (h *Handler) Close() error {
 	return nil
 }
 
+// client returns pooled session of the account. Session of crashed local
+// server is replaced, so server is restarted according to its policy.
+func (h *Handler) client(ctx context.Context, account ids.AccountID) (*asyncClient, error) {
+	client, err := h.clients.Get(ctx, account)
+	if err != nil || !client.crashed() {
+		return client, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	h.clients.Invalidate(account, func(c *asyncClient) bool { return c == client })
+
+	return h.clients.Get(ctx, account) //nolint:wrapcheck // wrapped by caller
+}
+
 func cacheConstructor(
 	factory *connFactory,
 	accountToken AccountTokenFunc,
@@ -140,15 +202,26 @@ func cacheConstructor(
 			return nil, fmt.Errorf("retrieve session %v: %w", account.ID().String(), err)
 		}
 
-		if token != nil {
-			return getAuthorized(ctx, factory, account, server, token)
-		}
+		var client *asyncClient
 
-		if server.AuthConfig() != nil {
+		switch {
+		case token != nil:
+			client, err = getAuthorized(ctx, factory, account, server, token)
+		case server.AuthConfig() != nil:
 			return nil, ErrAuthRequired
+		default:
+			client, err = getAnonymous(ctx, factory, account, server)
+		}
+
+		if err != nil {
+			return nil, err
 		}
 
-		return getAnonymous(ctx, factory, server)
+		// only pooled sessions are bound to accounts: they live long enough
+		// to receive notifications.
+		client.unbind = factory.handlers.accounts.bind(client.session, account)
+
+		return client, nil
 	}
 }
 
@@ -165,10 +238,11 @@ func getAuthorized(
 }
 
 func getAnonymous(
-	ctx context.Context, factory *connFactory, server entities.ServerConfigReadOnly,
+	ctx context.Context, factory *connFactory,
+	account ids.AccountID, server entities.ServerConfigReadOnly,
 ) (*asyncClient, error) {
 	client, err := factory.GetAnonymous(
-		ctx, server.SSELink(), server.PreferredProtocol(), server.Internal(),
+		ctx, account, server.SSELink(), server.PreferredProtocol(), server.Internal(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("get anonymous: %w", err)
@@ -1,9 +1,16 @@
 package mcp
 
 import (
+	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/quenbyako/core"
+	"golang.org/x/time/rate"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 type handlerParams struct {
@@ -17,6 +24,25 @@ type handlerParams struct {
 	// This is useful for development and testing, but should not be used in
 	// production.
 	unsafeExternalClient bool
+	timeouts             toolTimeouts
+	outputValidation     OutputValidation
+	outbound             outboundParams
+	stdio                stdioParams
+	schemaOpts           []tools.SchemaOption
+}
+
+type stdioParams struct {
+	servers map[string]StdioServer
+	// receives stderr of servers.
+	logger slog.Handler
+}
+
+type outboundParams struct {
+	server          outboundRate
+	serverOverrides map[uuid.UUID]outboundRate
+	account         outboundRate
+	policy          OutboundPolicy
+	maxWait         time.Duration
 }
 
 type HandlerOption func(*handlerParams)
@@ -41,6 +67,80 @@ func WithInternalHTTPClient(client http.RoundTripper) HandlerOption {
 	return func(p *handlerParams) { p.internalTransport = client }
 }
 
+// WithToolTimeout sets default deadline for a single tool call. Zero disables
+// the deadline entirely.
+func WithToolTimeout(timeout time.Duration) HandlerOption {
+	return func(p *handlerParams) { p.timeouts.fallback = timeout }
+}
+
+// WithServerToolTimeout overrides tool call deadline for every tool of the
+// specific server.
+func WithServerToolTimeout(server ids.ServerID, timeout time.Duration) HandlerOption {
+	return func(p *handlerParams) { p.timeouts.servers[server.ID()] = timeout }
+}
+
+// WithSingleToolTimeout overrides tool call deadline for a single tool of the
+// specific server. It takes precedence over [WithServerToolTimeout].
+func WithSingleToolTimeout(server ids.ServerID, tool string, timeout time.Duration) HandlerOption {
+	return func(p *handlerParams) {
+		p.timeouts.tools[toolTimeoutKey{server: server.ID(), tool: tool}] = timeout
+	}
+}
+
+// WithOutputValidation sets, how handler reacts on structured tool results,
+// which don't match declared output schema. Default is
+// [OutputValidationWarn].
+func WithOutputValidation(mode OutputValidation) HandlerOption {
+	return func(p *handlerParams) { p.outputValidation = mode }
+}
+
+// WithOutboundLimit limits tool calls to every server. Zero burst disables
+// the limit.
+func WithOutboundLimit(limit rate.Limit, burst int) HandlerOption {
+	return func(p *handlerParams) { p.outbound.server = outboundRate{limit: limit, burst: burst} }
+}
+
+// WithServerOutboundLimit overrides [WithOutboundLimit] for the specific
+// server.
+func WithServerOutboundLimit(server ids.ServerID, limit rate.Limit, burst int) HandlerOption {
+	return func(p *handlerParams) {
+		p.outbound.serverOverrides[server.ID()] = outboundRate{limit: limit, burst: burst}
+	}
+}
+
+// WithAccountOutboundLimit limits tool calls of every account. Zero burst
+// disables the limit.
+func WithAccountOutboundLimit(limit rate.Limit, burst int) HandlerOption {
+	return func(p *handlerParams) { p.outbound.account = outboundRate{limit: limit, burst: burst} }
+}
+
+// WithOutboundPolicy sets, how limited calls are handled. maxWait is used
+// only by [OutboundWait] policy. Default is [OutboundFailFast].
+func WithOutboundPolicy(policy OutboundPolicy, maxWait time.Duration) HandlerOption {
+	return func(p *handlerParams) {
+		p.outbound.policy = policy
+		p.outbound.maxWait = maxWait
+	}
+}
+
+// WithStdioServer registers local server, which is available by
+// "stdio://<name>" link. Only internal servers may use such links.
+func WithStdioServer(name string, server StdioServer) HandlerOption {
+	return func(p *handlerParams) { p.stdio.servers[name] = server }
+}
+
+// WithStdioLogger sets logger for stderr and lifecycle of local servers.
+// Logs are discarded by default.
+func WithStdioLogger(logger slog.Handler) HandlerOption {
+	return func(p *handlerParams) { p.stdio.logger = logger }
+}
+
+// WithSchemaOptions sets normalization of discovered tool schemas, e.g. which
+// keywords model provider accepts.
+func WithSchemaOptions(opts ...tools.SchemaOption) HandlerOption {
+	return func(p *handlerParams) { p.schemaOpts = append(p.schemaOpts, opts...) }
+}
+
 func buildHandlerParams(opts ...HandlerOption) handlerParams {
 	params := handlerParams{
 		traceProvider: core.NoopMetrics(),
@@ -50,6 +150,24 @@ func buildHandlerParams(opts ...HandlerOption) handlerParams {
 		externalTransport:    nil,
 		internalTransport:    nil,
 		unsafeExternalClient: false,
+		timeouts: toolTimeouts{
+			fallback: defaultToolTimeout,
+			servers:  make(map[uuid.UUID]time.Duration),
+			tools:    make(map[toolTimeoutKey]time.Duration),
+		},
+		outputValidation: OutputValidationWarn,
+		outbound: outboundParams{
+			server:          outboundRate{limit: 0, burst: 0},
+			serverOverrides: make(map[uuid.UUID]outboundRate),
+			account:         outboundRate{limit: 0, burst: 0},
+			policy:          OutboundFailFast,
+			maxWait:         0,
+		},
+		stdio: stdioParams{
+			servers: make(map[string]StdioServer),
+			logger:  slog.DiscardHandler,
+		},
+		schemaOpts: nil,
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,357 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+const (
+	// defaultRetryDelay is used, when server rejected request with 429
+	// status, but didn't tell, when to retry.
+	defaultRetryDelay = 5 * time.Second
+	// state of servers and accounts, which were not called for this time, is
+	// removed from memory.
+	outboundIdleTTL = 30 * time.Minute
+)
+
+// OutboundPolicy defines, how handler behaves, when tool call exceeds
+// outbound limit, or server asked to slow down.
+type OutboundPolicy uint8
+
+const (
+	// OutboundFailFast rejects the call immediately: model receives tool
+	// error with retry delay and decides, whether to wait.
+	OutboundFailFast OutboundPolicy = iota
+	// OutboundWait holds the call until it's allowed, if delay is not longer
+	// than max wait. Longer delays are rejected as with [OutboundFailFast].
+	OutboundWait
+)
+
+// outboundRate limits tool calls, sent to MCP servers. Zero value is
+// unlimited.
+type outboundRate struct {
+	limit rate.Limit
+	burst int
+}
+
+func (r outboundRate) newLimiter() *rate.Limiter {
+	if r.burst <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(r.limit, r.burst)
+}
+
+// upstream is a state of a single server or account.
+type upstream struct {
+	limiter *rate.Limiter
+	// server asked not to send requests until this moment.
+	blockedUntil time.Time
+	usedAt       time.Time
+}
+
+// idle reports, whether state may be dropped: new state, created on next
+// call, must behave the same, so limiter must be refilled and block must be
+// expired.
+func (u *upstream) idle(now time.Time) bool {
+	return now.Sub(u.usedAt) >= outboundIdleTTL &&
+		!now.Before(u.blockedUntil) &&
+		u.limiter.TokensAt(now) >= float64(u.limiter.Burst())
+}
+
+// outboundLimits throttles tool calls per server and per account. Besides
+// local limits, it honors Retry-After and RateLimit headers of server
+// responses: 429 blocks the account, 503 blocks the whole server.
+type outboundLimits struct {
+	now      func() time.Time
+	servers  map[uuid.UUID]*upstream
+	accounts map[uuid.UUID]*upstream
+
+	server          outboundRate
+	serverOverrides map[uuid.UUID]outboundRate
+	account         outboundRate
+	policy          OutboundPolicy
+	maxWait         time.Duration
+
+	mu sync.Mutex
+}
+
+func newOutboundLimits(params *handlerParams) *outboundLimits {
+	return &outboundLimits{
+		now:             time.Now,
+		servers:         make(map[uuid.UUID]*upstream),
+		accounts:        make(map[uuid.UUID]*upstream),
+		server:          params.outbound.server,
+		serverOverrides: params.outbound.serverOverrides,
+		account:         params.outbound.account,
+		policy:          params.outbound.policy,
+		maxWait:         params.outbound.maxWait,
+		mu:              sync.Mutex{},
+	}
+}
+
+// acquire takes single call from server and account limits. If call is not
+// allowed, returns delay, after which it may be retried.
+func (l *outboundLimits) acquire(ctx context.Context, account ids.AccountID) (time.Duration, error) {
+	l.mu.Lock()
+
+	now := l.now()
+	server, acc := l.serverState(account.Server()), l.accountState(account)
+
+	delay := max(server.blockedUntil.Sub(now), acc.blockedUntil.Sub(now), 0)
+	if delay > 0 && (l.policy == OutboundFailFast || delay > l.maxWait) {
+		l.mu.Unlock()
+
+		return delay, nil
+	}
+
+	// reservations are taken after the block, to not spend limits in vain.
+	at := now.Add(delay)
+	reservations := []*rate.Reservation{server.limiter.ReserveN(at, 1), acc.limiter.ReserveN(at, 1)}
+
+	for _, r := range reservations {
+		delay = max(delay, r.DelayFrom(now))
+	}
+
+	if delay > 0 && (l.policy == OutboundFailFast || delay > l.maxWait) {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+
+		l.mu.Unlock()
+
+		return delay, nil
+	}
+
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return 0, nil
+	case <-ctx.Done():
+		return 0, ctx.Err() //nolint:wrapcheck // caller handles cancellation itself
+	}
+}
+
+// blocked returns remaining delay, which server asked to wait.
+func (l *outboundLimits) blocked(account ids.AccountID) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	server, acc := l.serverState(account.Server()), l.accountState(account)
+
+	return max(server.blockedUntil.Sub(now), acc.blockedUntil.Sub(now), 0)
+}
+
+// observe remembers delay, requested by server response.
+func (l *outboundLimits) observe(account ids.AccountID, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	delay, ok := retryDelay(resp, now)
+	if !ok {
+		return
+	}
+
+	state := l.accountState(account)
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		state = l.serverState(account.Server())
+	}
+
+	if until := now.Add(delay); until.After(state.blockedUntil) {
+		state.blockedUntil = until
+	}
+}
+
+func (l *outboundLimits) serverState(server ids.ServerID) *upstream {
+	state, ok := l.servers[server.ID()]
+	if !ok {
+		limit, overridden := l.serverOverrides[server.ID()]
+		if !overridden {
+			limit = l.server
+		}
+
+		state = &upstream{limiter: limit.newLimiter(), blockedUntil: time.Time{}, usedAt: time.Time{}}
+		l.servers[server.ID()] = state
+	}
+
+	state.usedAt = l.now()
+
+	return state
+}
+
+func (l *outboundLimits) accountState(account ids.AccountID) *upstream {
+	state, ok := l.accounts[account.ID()]
+	if !ok {
+		state = &upstream{limiter: l.account.newLimiter(), blockedUntil: time.Time{}, usedAt: time.Time{}}
+		l.accounts[account.ID()] = state
+	}
+
+	state.usedAt = l.now()
+
+	return state
+}
+
+// forget drops state of the deleted account.
+func (l *outboundLimits) forget(account ids.AccountID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.accounts, account.ID())
+}
+
+// cleanup periodically removes idle states from memory.
+func (l *outboundLimits) cleanup(ctx context.Context) error {
+	ticker := time.NewTicker(outboundIdleTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// cleanup only frees memory, it doesn't affect limits.
+			return nil
+
+		case <-ticker.C:
+			l.evictIdle()
+		}
+	}
+}
+
+func (l *outboundLimits) evictIdle() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	for _, states := range []map[uuid.UUID]*upstream{l.servers, l.accounts} {
+		for id, state := range states {
+			if state.idle(now) {
+				delete(states, id)
+			}
+		}
+	}
+}
+
+// retryDelay extracts delay from Retry-After header, or from RateLimit
+// headers (both "RateLimit-Remaining"/"RateLimit-Reset" pair and structured
+// "RateLimit" field are supported). Rejected requests without headers get
+// default delay.
+func retryDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	limited := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusServiceUnavailable
+
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok && limited {
+		return delay, true
+	}
+
+	if delay, ok := parseRateLimit(resp.Header); ok {
+		return delay, true
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return defaultRetryDelay, true
+	}
+
+	return 0, false
+}
+
+// parseRetryAfter parses delay in seconds or HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// parseRateLimit returns reset delay, only if quota is exhausted.
+func parseRateLimit(header http.Header) (time.Duration, bool) {
+	if remaining, reset, ok := parseRateLimitField(header.Get("RateLimit")); ok {
+		return resetDelay(remaining, reset)
+	}
+
+	return resetDelay(header.Get("RateLimit-Remaining"), header.Get("RateLimit-Reset"))
+}
+
+// parseRateLimitField parses structured field, like `"default";r=0;t=30`.
+// First policy with exhausted quota wins.
+func parseRateLimitField(value string) (remaining, reset string, ok bool) {
+	for item := range strings.SplitSeq(value, ",") {
+		var r, t string
+
+		for param := range strings.SplitSeq(item, ";") {
+			key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+			switch key {
+			case "r":
+				r = val
+			case "t":
+				t = val
+			}
+		}
+
+		if r == "0" {
+			return r, t, true
+		}
+	}
+
+	return "", "", false
+}
+
+func resetDelay(remaining, reset string) (time.Duration, bool) {
+	if strings.TrimSpace(remaining) != "0" {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseUint(strings.TrimSpace(reset), 10, 32)
+	if err != nil {
+		return defaultRetryDelay, true
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+// outboundObserver reports every server response to the limits. It must be
+// the closest wrapper to the base transport, since other wrappers convert
+// error statuses to errors.
+func outboundObserver(
+	next http.RoundTripper, limits *outboundLimits, account ids.AccountID,
+) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // transport errors are wrapped by callers
+		}
+
+		limits.observe(account, resp)
+
+		return resp, nil
+	})
+}
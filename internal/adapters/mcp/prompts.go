@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+// ListPrompts implements toolclient.Port.
+func (h *Handler) ListPrompts(ctx context.Context, account ids.AccountID) ([]prompts.Prompt, error) {
+	client, err := h.client(ctx, account)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	if caps := serverCapabilities(client.session); caps == nil || caps.Prompts == nil {
+		return []prompts.Prompt{}, nil
+	}
+
+	res := make([]prompts.Prompt, 0)
+	params := &mcp.ListPromptsParams{Meta: nil, Cursor: ""}
+
+	for {
+		page, err := client.session.ListPrompts(ctx, params)
+		if err != nil {
+			return nil, MapError(err)
+		}
+
+		for _, raw := range page.Prompts {
+			if prompt, ok := convertPrompt(account, raw); ok {
+				res = append(res, prompt)
+			}
+		}
+
+		if page.NextCursor == "" {
+			return res, nil
+		}
+
+		params.Cursor = page.NextCursor
+	}
+}
+
+// convertPrompt skips malformed prompts, same as resources: one broken entry
+// must not hide others from the user.
+func convertPrompt(account ids.AccountID, raw *mcp.Prompt) (prompts.Prompt, bool) {
+	if raw == nil {
+		return prompts.Prompt{}, false
+	}
+
+	args := make([]prompts.Argument, 0, len(raw.Arguments))
+
+	for _, rawArg := range raw.Arguments {
+		if rawArg == nil {
+			continue
+		}
+
+		description := rawArg.Description
+		if description == "" {
+			description = rawArg.Title
+		}
+
+		arg, err := prompts.NewArgument(rawArg.Name, description, rawArg.Required)
+		if err != nil {
+			return prompts.Prompt{}, false
+		}
+
+		args = append(args, arg)
+	}
+
+	prompt, err := prompts.New(account, raw.Name,
+		prompts.WithTitle(raw.Title),
+		prompts.WithDescription(raw.Description),
+		prompts.WithArguments(args...),
+	)
+	if err != nil {
+		return prompts.Prompt{}, false
+	}
+
+	return prompt, true
+}
+
+// GetPrompt implements toolclient.Port.
+func (h *Handler) GetPrompt(
+	ctx context.Context, account ids.AccountID, name string, args map[string]string,
+) ([]prompts.Message, error) {
+	client, err := h.client(ctx, account)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp, err := client.session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Meta:      nil,
+		Arguments: args,
+		Name:      name,
+	})
+	if err != nil {
+		return nil, mapPromptError(err)
+	}
+
+	res := make([]prompts.Message, 0, len(resp.Messages))
+
+	for _, raw := range resp.Messages {
+		if raw == nil {
+			continue
+		}
+
+		msg, ok, err := convertPromptMessage(raw)
+		if err != nil {
+			return nil, fmt.Errorf("converting message of %q: %w", name, err)
+		} else if ok {
+			res = append(res, msg)
+		}
+	}
+
+	return res, nil
+}
+
+// convertPromptMessage keeps only textual content: text and embedded text
+// resources. Images and audio are skipped, agent can't receive them from
+// user yet.
+func convertPromptMessage(raw *mcp.PromptMessage) (prompts.Message, bool, error) {
+	var text string
+
+	switch content := raw.Content.(type) {
+	case *mcp.TextContent:
+		text = content.Text
+	case *mcp.EmbeddedResource:
+		if content.Resource == nil || content.Resource.Blob != nil {
+			return prompts.Message{}, false, nil
+		}
+
+		text = content.Resource.Text
+	default:
+		return prompts.Message{}, false, nil
+	}
+
+	var role prompts.Role
+
+	switch raw.Role {
+	case "user":
+		role = prompts.RoleUser
+	case "assistant":
+		role = prompts.RoleAssistant
+	default:
+		return prompts.Message{}, false, fmt.Errorf("%w: %q", prompts.ErrInvalidRole, raw.Role)
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return prompts.Message{}, false, nil
+	}
+
+	msg, err := prompts.NewMessage(role, text)
+	if err != nil {
+		return prompts.Message{}, false, fmt.Errorf("building message: %w", err)
+	}
+
+	return msg, true, nil
+}
+
+// SubscribePromptsChanged implements toolclient.Port.
+func (h *Handler) SubscribePromptsChanged(handler toolclient.PromptsChangedHandler) func() {
+	return h.factory.handlers.accounts.subscribePrompts(handler)
+}
+
+func mapPromptError(err error) error {
+	if rpcErr := new(jsonrpc.Error); errors.As(err, &rpcErr) && rpcErr.Code == jsonrpc.CodeInvalidParams {
+		return fmt.Errorf("%w: %w", toolclient.ErrPromptRejected, err)
+	}
+
+	return MapError(err)
+}
@@ -0,0 +1,68 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+func TestPrompts(t *testing.T) {
+	srv := newTestServer()
+	srv.AddPrompt(&sdk.Prompt{
+		Name:        "code_review",
+		Title:       "Code review",
+		Description: "Reviews the code",
+		Arguments: []*sdk.PromptArgument{
+			{Name: "code", Description: "Code to review", Required: true},
+			{Name: "language", Title: "Language"},
+		},
+	}, func(_ context.Context, req *sdk.GetPromptRequest) (*sdk.GetPromptResult, error) {
+		return &sdk.GetPromptResult{Messages: []*sdk.PromptMessage{
+			{Role: "user", Content: &sdk.TextContent{Text: "Review this code: " + req.Params.Arguments["code"]}},
+			{Role: "assistant", Content: &sdk.ImageContent{MIMEType: "image/png", Data: []byte{0x89}}},
+			{Role: "assistant", Content: &sdk.TextContent{Text: "Sure, let me look."}},
+		}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	list, err := handler.ListPrompts(t.Context(), account)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, account, list[0].Account())
+	require.Equal(t, "code_review", list[0].Name())
+	require.Equal(t, "Code review", list[0].Title())
+
+	args := list[0].Arguments()
+	require.Len(t, args, 2)
+	require.True(t, args[0].Required())
+	require.Equal(t, "Language", args[1].Description(), "title must be used without description")
+
+	msgs, err := handler.GetPrompt(t.Context(), account, "code_review", map[string]string{"code": "x := 1"})
+	require.NoError(t, err)
+	require.Len(t, msgs, 2, "image must be skipped")
+	require.Equal(t, prompts.RoleUser, msgs[0].Role())
+	require.Equal(t, "Review this code: x := 1", msgs[0].Text())
+	require.Equal(t, prompts.RoleAssistant, msgs[1].Role())
+
+	_, err = handler.GetPrompt(t.Context(), account, "missing", nil)
+	require.ErrorIs(t, err, toolclient.ErrPromptRejected)
+}
+
+func TestPromptsWithoutCapability(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	list, err := handler.ListPrompts(t.Context(), account)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
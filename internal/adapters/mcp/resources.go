@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+)
+
+// ListResources implements toolclient.Port.
+func (h *Handler) ListResources(ctx context.Context, account ids.AccountID) ([]resources.Resource, error) {
+	client, err := h.client(ctx, account)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	if caps := serverCapabilities(client.session); caps == nil || caps.Resources == nil {
+		return []resources.Resource{}, nil
+	}
+
+	res := make([]resources.Resource, 0)
+	params := &mcp.ListResourcesParams{Meta: nil, Cursor: ""}
+
+	for {
+		page, err := client.session.ListResources(ctx, params)
+		if err != nil {
+			return nil, MapError(err)
+		}
+
+		for _, raw := range page.Resources {
+			if resource, ok := convertResource(account, raw); ok {
+				res = append(res, resource)
+			}
+		}
+
+		if page.NextCursor == "" {
+			return res, nil
+		}
+
+		params.Cursor = page.NextCursor
+	}
+}
+
+// convertResource skips malformed resources: one broken entry must not hide
+// others from the model.
+func convertResource(account ids.AccountID, raw *mcp.Resource) (resources.Resource, bool) {
+	if raw == nil {
+		return resources.Resource{}, false
+	}
+
+	res, err := resources.New(account, raw.URI, raw.Name,
+		resources.WithTitle(raw.Title),
+		resources.WithDescription(raw.Description),
+		resources.WithMIMEType(raw.MIMEType),
+		resources.WithSize(raw.Size),
+	)
+	if err != nil {
+		return resources.Resource{}, false
+	}
+
+	return res, true
+}
+
+// ReadResource implements toolclient.Port.
+func (h *Handler) ReadResource(
+	ctx context.Context, account ids.AccountID, uri string,
+) ([]resources.Content, error) {
+	client, err := h.client(ctx, account)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp, err := client.session.ReadResource(ctx, &mcp.ReadResourceParams{Meta: nil, URI: uri})
+	if err != nil {
+		return nil, mapResourceError(err)
+	}
+
+	contents := make([]resources.Content, 0, len(resp.Contents))
+
+	for _, raw := range resp.Contents {
+		if raw == nil {
+			continue
+		}
+
+		content, err := convertContent(uri, raw)
+		if err != nil {
+			return nil, fmt.Errorf("converting content of %q: %w", uri, err)
+		}
+
+		contents = append(contents, content)
+	}
+
+	return contents, nil
+}
+
+func convertContent(uri string, raw *mcp.ResourceContents) (resources.Content, error) {
+	// some servers omit uri for single-part contents.
+	if raw.URI != "" {
+		uri = raw.URI
+	}
+
+	if raw.Blob != nil {
+		//nolint:wrapcheck // primitive errors are descriptive enough
+		return resources.NewBlobContent(uri, raw.MIMEType, raw.Blob)
+	}
+
+	//nolint:wrapcheck // primitive errors are descriptive enough
+	return resources.NewTextContent(uri, raw.MIMEType, raw.Text)
+}
+
+// SubscribeResource implements toolclient.Port.
+func (h *Handler) SubscribeResource(ctx context.Context, account ids.AccountID, uri string) error {
+	session, err := h.subscribableSession(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{Meta: nil, URI: uri}); err != nil {
+		return mapResourceError(err)
+	}
+
+	return nil
+}
+
+// UnsubscribeResource implements toolclient.Port.
+func (h *Handler) UnsubscribeResource(ctx context.Context, account ids.AccountID, uri string) error {
+	session, err := h.subscribableSession(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if err := session.Unsubscribe(ctx, &mcp.UnsubscribeParams{Meta: nil, URI: uri}); err != nil {
+		return mapResourceError(err)
+	}
+
+	return nil
+}
+
+// SubscribeResourcesChanged implements toolclient.Port.
+func (h *Handler) SubscribeResourcesChanged(handler toolclient.ResourcesChangedHandler) func() {
+	return h.factory.handlers.accounts.subscribeResources(handler)
+}
+
+func (h *Handler) subscribableSession(ctx context.Context, account ids.AccountID) (*mcp.ClientSession, error) {
+	client, err := h.client(ctx, account)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	caps := serverCapabilities(client.session)
+	if caps == nil || caps.Resources == nil || !caps.Resources.Subscribe {
+		return nil, toolclient.ErrSubscriptionNotSupported
+	}
+
+	return client.session, nil
+}
+
+func serverCapabilities(session *mcp.ClientSession) *mcp.ServerCapabilities {
+	if init := session.InitializeResult(); init != nil {
+		return init.Capabilities
+	}
+
+	return nil
+}
+
+func mapResourceError(err error) error {
+	if rpcErr := new(jsonrpc.Error); errors.As(err, &rpcErr) && rpcErr.Code == mcp.CodeResourceNotFound {
+		return fmt.Errorf("%w: %w", toolclient.ErrResourceNotFound, err)
+	}
+
+	return MapError(err)
+}
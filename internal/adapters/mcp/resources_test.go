@@ -0,0 +1,139 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func TestResources(t *testing.T) {
+	srv := newTestServer()
+	srv.AddResource(&sdk.Resource{
+		Name:        "readme",
+		Title:       "Project README",
+		URI:         "file:///README.md",
+		MIMEType:    "text/markdown",
+		Description: "Project description",
+		Size:        7,
+	}, func(_ context.Context, req *sdk.ReadResourceRequest) (*sdk.ReadResourceResult, error) {
+		return &sdk.ReadResourceResult{Contents: []*sdk.ResourceContents{{
+			URI:      req.Params.URI,
+			MIMEType: "text/markdown",
+			Text:     "# Hello",
+		}}}, nil
+	})
+	srv.AddResource(&sdk.Resource{
+		Name: "logo",
+		URI:  "file:///logo.png",
+	}, func(_ context.Context, req *sdk.ReadResourceRequest) (*sdk.ReadResourceResult, error) {
+		return &sdk.ReadResourceResult{Contents: []*sdk.ResourceContents{{
+			URI:      req.Params.URI,
+			MIMEType: "image/png",
+			Blob:     []byte{0x89, 'P', 'N', 'G'},
+		}}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	list, err := handler.ListResources(t.Context(), account)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	byURI := make(map[string]int, len(list))
+	for i, res := range list {
+		require.Equal(t, account, res.Account())
+		byURI[res.URI()] = i
+	}
+
+	readme := list[byURI["file:///README.md"]]
+	require.Equal(t, "readme", readme.Name())
+	require.Equal(t, "Project README", readme.Title())
+	require.Equal(t, "text/markdown", readme.MIMEType())
+	require.Equal(t, int64(7), readme.Size())
+
+	contents, err := handler.ReadResource(t.Context(), account, "file:///README.md")
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	require.False(t, contents[0].IsBlob())
+	require.Equal(t, "# Hello", contents[0].Text())
+
+	contents, err = handler.ReadResource(t.Context(), account, "file:///logo.png")
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	require.True(t, contents[0].IsBlob())
+	require.Equal(t, []byte{0x89, 'P', 'N', 'G'}, contents[0].Blob())
+
+	_, err = handler.ReadResource(t.Context(), account, "file:///missing.txt")
+	require.ErrorIs(t, err, toolclient.ErrResourceNotFound)
+
+	// test server doesn't declare subscriptions support.
+	err = handler.SubscribeResource(t.Context(), account, "file:///README.md")
+	require.ErrorIs(t, err, toolclient.ErrSubscriptionNotSupported)
+}
+
+func TestResourcesWithoutCapability(t *testing.T) {
+	srv := newTestServer()
+	addEchoTool(srv)
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	list, err := handler.ListResources(t.Context(), account)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+func TestSubscribeResource(t *testing.T) {
+	srv := sdk.NewServer(&sdk.Implementation{Name: "test", Version: "1.0.0"}, &sdk.ServerOptions{
+		SubscribeHandler:   func(context.Context, *sdk.SubscribeRequest) error { return nil },
+		UnsubscribeHandler: func(context.Context, *sdk.UnsubscribeRequest) error { return nil },
+	})
+	srv.AddResource(&sdk.Resource{
+		Name: "status",
+		URI:  "status://build",
+	}, func(_ context.Context, req *sdk.ReadResourceRequest) (*sdk.ReadResourceResult, error) {
+		return &sdk.ReadResourceResult{Contents: []*sdk.ResourceContents{{
+			URI:  req.Params.URI,
+			Text: "green",
+		}}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+
+	type update struct {
+		account ids.AccountID
+		uri     string
+	}
+
+	updates := make(chan update, 1)
+	unsubscribe := handler.SubscribeResourcesChanged(func(_ context.Context, id ids.AccountID, uri string) {
+		select {
+		case updates <- update{account: id, uri: uri}:
+		default:
+		}
+	})
+	t.Cleanup(unsubscribe)
+
+	require.NoError(t, handler.SubscribeResource(t.Context(), account, "status://build"))
+	require.NoError(t, srv.ResourceUpdated(t.Context(), &sdk.ResourceUpdatedNotificationParams{
+		URI: "status://build",
+	}))
+
+	select {
+	case got := <-updates:
+		require.Equal(t, account, got.account)
+		require.Equal(t, "status://build", got.uri)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resources/updated notification was not delivered")
+	}
+
+	require.NoError(t, handler.UnsubscribeResource(t.Context(), account, "status://build"))
+}
@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
+)
+
+// codeUserRejected is returned to server, when sampling request is declined.
+// MCP specification uses it for requests, rejected by user.
+const codeUserRejected = -1
+
+// HandleSampling implements toolclient.Port.
+func (h *Handler) HandleSampling(handler toolclient.SamplingHandler) func() {
+	return h.factory.handlers.accounts.handleSampling(handler)
+}
+
+// handleCreateMessage answers "sampling/createMessage" of pooled sessions.
+// Details of internal errors are not passed to server: they may describe
+// user data, which server shouldn't know.
+func (r *accountRouter) handleCreateMessage(
+	ctx context.Context, req *mcp.CreateMessageRequest,
+) (*mcp.CreateMessageResult, error) {
+	if req == nil || req.Params == nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: "params are required", Data: nil}
+	}
+
+	handler, account, ok := r.samplingHandler(req.Session)
+	if !ok {
+		return nil, &jsonrpc.Error{Code: codeUserRejected, Message: "sampling is not available", Data: nil}
+	}
+
+	samplingReq, err := convertSamplingRequest(req.Params)
+	if err != nil {
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInvalidParams, Message: err.Error(), Data: nil}
+	}
+
+	res, err := handler(ctx, account, samplingReq)
+	switch {
+	case errors.Is(err, toolclient.ErrSamplingRejected):
+		return nil, &jsonrpc.Error{Code: codeUserRejected, Message: "sampling request rejected", Data: nil}
+	case err != nil:
+		return nil, &jsonrpc.Error{Code: jsonrpc.CodeInternalError, Message: "sampling failed", Data: nil}
+	}
+
+	return &mcp.CreateMessageResult{
+		Meta:       nil,
+		Content:    &mcp.TextContent{Text: res.Text(), Meta: nil, Annotations: nil},
+		Model:      res.Model(),
+		Role:       "assistant",
+		StopReason: "endTurn",
+	}, nil
+}
+
+// convertSamplingRequest keeps only textual content: agents receive sampling
+// as plain conversation.
+func convertSamplingRequest(params *mcp.CreateMessageParams) (sampling.Request, error) {
+	msgs := make([]messages.Message, 0, len(params.Messages))
+
+	for _, raw := range params.Messages {
+		msg, ok, err := convertSamplingMessage(raw)
+		if err != nil {
+			return sampling.Request{}, err
+		} else if ok {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	//nolint:wrapcheck // errors of the primitive are passed to server as is
+	return sampling.NewRequest(msgs, int(params.MaxTokens),
+		sampling.WithSystemPrompt(strings.TrimSpace(params.SystemPrompt)),
+	)
+}
+
+func convertSamplingMessage(raw *mcp.SamplingMessage) (messages.Message, bool, error) {
+	if raw == nil {
+		return nil, false, nil
+	}
+
+	content, ok := raw.Content.(*mcp.TextContent)
+	if !ok || strings.TrimSpace(content.Text) == "" {
+		return nil, false, nil
+	}
+
+	switch raw.Role {
+	case "user":
+		msg, err := messages.NewMessageUser(content.Text)
+		//nolint:wrapcheck // errors of the primitive are passed to server as is
+		return msg, err == nil, err
+	case "assistant":
+		msg, err := messages.NewMessageAssistant(content.Text)
+		//nolint:wrapcheck // errors of the primitive are passed to server as is
+		return msg, err == nil, err
+	default:
+		return nil, false, sampling.ErrUnsupportedMessage
+	}
+}
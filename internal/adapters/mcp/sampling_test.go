@@ -0,0 +1,71 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
+)
+
+func TestSampling(t *testing.T) {
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "summarize",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(ctx context.Context, req *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		res, err := req.Session.CreateMessage(ctx, &sdk.CreateMessageParams{
+			MaxTokens:    100,
+			SystemPrompt: "Be brief.",
+			Messages: []*sdk.SamplingMessage{
+				{Role: "user", Content: &sdk.ImageContent{MIMEType: "image/png", Data: []byte{0x89}}},
+				{Role: "user", Content: &sdk.TextContent{Text: "Summarize: lorem ipsum"}},
+			},
+		})
+		if err != nil {
+			return &sdk.CallToolResult{IsError: true, Content: []sdk.Content{&sdk.TextContent{Text: err.Error()}}}, nil
+		}
+
+		return &sdk.CallToolResult{Content: []sdk.Content{res.Content}}, nil
+	})
+
+	account := mustAccountID(t)
+	handler := setupToolHandler(t, account, srv)
+	tool := mustTool(t, account, "summarize")
+
+	res, err := handler.ExecuteTool(t.Context(), tool, nil, "call-1")
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "not available", "requests must be rejected without handler")
+
+	var received sampling.Request
+
+	unregister := handler.HandleSampling(func(
+		_ context.Context, from ids.AccountID, req sampling.Request,
+	) (sampling.Result, error) {
+		require.Equal(t, account, from)
+
+		received = req
+
+		return sampling.NewResult("Lorem.", "test-model"), nil
+	})
+
+	res, err = handler.ExecuteTool(t.Context(), tool, nil, "call-2")
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "Lorem.")
+	require.Equal(t, "Be brief.", received.SystemPrompt())
+	require.Equal(t, 100, received.MaxTokens())
+	require.Len(t, received.Messages(), 1, "image must be skipped")
+
+	unregister()
+	handler.HandleSampling(func(context.Context, ids.AccountID, sampling.Request) (sampling.Result, error) {
+		return sampling.Result{}, toolclient.ErrSamplingRejected
+	})
+
+	res, err = handler.ExecuteTool(t.Context(), tool, nil, "call-3")
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "rejected")
+}
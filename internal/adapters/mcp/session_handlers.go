@@ -0,0 +1,293 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// sessionHandlers receives server-initiated messages from every pooled
+// session. Since sessions are shared between calls, each handler routes
+// message to the exact caller by some request-specific key.
+type sessionHandlers struct {
+	progress    *progressRouter
+	accounts    *accountRouter
+	elicitation *elicitationRouter
+}
+
+func newSessionHandlers() *sessionHandlers {
+	return &sessionHandlers{
+		progress:    newProgressRouter(),
+		accounts:    newAccountRouter(),
+		elicitation: newElicitationRouter(),
+	}
+}
+
+func (h *sessionHandlers) clientOptions() *mcp.ClientOptions {
+	return &mcp.ClientOptions{
+		KeepAlive:                     keepAliveInterval,
+		Logger:                        nil, // TODO: add logger
+		CreateMessageHandler:          h.accounts.handleCreateMessage,
+		ElicitationHandler:            h.elicitation.handle,
+		Capabilities:                  nil,
+		ElicitationCompleteHandler:    nil,
+		ToolListChangedHandler:        h.accounts.handleToolsChanged,
+		PromptListChangedHandler:      h.accounts.handlePromptsChanged,
+		ResourceListChangedHandler:    h.accounts.handleResourcesChanged,
+		ResourceUpdatedHandler:        h.accounts.handleResourceUpdated,
+		LoggingMessageHandler:         nil,
+		ProgressNotificationHandler:   h.progress.handle,
+		CreateMessageWithToolsHandler: nil,
+	}
+}
+
+type progressFunc = func(*mcp.ProgressNotificationParams)
+
+// progressRouter matches "notifications/progress" with running tool calls by
+// progress token.
+type progressRouter struct {
+	subs map[string]progressFunc
+	mu   sync.RWMutex
+}
+
+func newProgressRouter() *progressRouter {
+	return &progressRouter{
+		subs: make(map[string]progressFunc),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// subscribe registers callback and returns unique progress token, which must
+// be sent with the request. Returned function must be called after request
+// finished, no callbacks are called after it.
+func (r *progressRouter) subscribe(f progressFunc) (token string, unsubscribe func()) {
+	token = uuid.NewString()
+
+	r.mu.Lock()
+	r.subs[token] = f
+	r.mu.Unlock()
+
+	return token, func() {
+		r.mu.Lock()
+		delete(r.subs, token)
+		r.mu.Unlock()
+	}
+}
+
+func (r *progressRouter) handle(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+	if req == nil || req.Params == nil {
+		return
+	}
+
+	token, ok := req.Params.ProgressToken.(string)
+	if !ok {
+		return
+	}
+
+	// holding read lock during the call guarantees that unsubscribe waits
+	// for running callback.
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if f, ok := r.subs[token]; ok {
+		f(req.Params)
+	}
+}
+
+// accountRouter matches "notifications/tools/list_changed",
+// "notifications/prompts/list_changed", "notifications/resources/list_changed",
+// "notifications/resources/updated" and "sampling/createMessage" with
+// accounts of pooled sessions. Short-living sessions (e.g. discovery) are not
+// bound to accounts, so their notifications are ignored, and their sampling
+// requests are rejected.
+type accountRouter struct {
+	sessions  map[*mcp.ClientSession]ids.AccountID
+	tools     map[uint64]toolclient.ToolsChangedHandler
+	prompts   map[uint64]toolclient.PromptsChangedHandler
+	resources map[uint64]toolclient.ResourcesChangedHandler
+	sampling  toolclient.SamplingHandler
+	// subscription id of sampling handler, so replaced handler can't be
+	// unregistered by its previous owner.
+	samplingID uint64
+	lastSub    uint64
+	mu         sync.RWMutex
+}
+
+func newAccountRouter() *accountRouter {
+	return &accountRouter{
+		sessions:   make(map[*mcp.ClientSession]ids.AccountID),
+		tools:      make(map[uint64]toolclient.ToolsChangedHandler),
+		prompts:    make(map[uint64]toolclient.PromptsChangedHandler),
+		resources:  make(map[uint64]toolclient.ResourcesChangedHandler),
+		sampling:   nil,
+		samplingID: 0,
+		lastSub:    0,
+		mu:         sync.RWMutex{},
+	}
+}
+
+// bind connects session with account. Returned function must be called
+// before session is closed.
+func (r *accountRouter) bind(session *mcp.ClientSession, account ids.AccountID) (unbind func()) {
+	r.mu.Lock()
+	r.sessions[session] = account
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		delete(r.sessions, session)
+		r.mu.Unlock()
+	}
+}
+
+func (r *accountRouter) subscribeTools(f toolclient.ToolsChangedHandler) (unsubscribe func()) {
+	r.mu.Lock()
+	r.lastSub++
+	id := r.lastSub
+	r.tools[id] = f
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		delete(r.tools, id)
+		r.mu.Unlock()
+	}
+}
+
+func (r *accountRouter) subscribePrompts(f toolclient.PromptsChangedHandler) (unsubscribe func()) {
+	r.mu.Lock()
+	r.lastSub++
+	id := r.lastSub
+	r.prompts[id] = f
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		delete(r.prompts, id)
+		r.mu.Unlock()
+	}
+}
+
+func (r *accountRouter) subscribeResources(f toolclient.ResourcesChangedHandler) (unsubscribe func()) {
+	r.mu.Lock()
+	r.lastSub++
+	id := r.lastSub
+	r.resources[id] = f
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		delete(r.resources, id)
+		r.mu.Unlock()
+	}
+}
+
+func (r *accountRouter) handleSampling(f toolclient.SamplingHandler) (unregister func()) {
+	r.mu.Lock()
+	r.lastSub++
+	id := r.lastSub
+	r.sampling, r.samplingID = f, id
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		if r.samplingID == id {
+			r.sampling, r.samplingID = nil, 0
+		}
+		r.mu.Unlock()
+	}
+}
+
+// samplingHandler returns handler and account of the session. Unlike
+// notifications, handler is called without lock: completion may take long
+// time, and it must not block binding of other sessions.
+func (r *accountRouter) samplingHandler(
+	session *mcp.ClientSession,
+) (toolclient.SamplingHandler, ids.AccountID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.sessions[session]
+	if !ok || r.sampling == nil {
+		return nil, ids.AccountID{}, false
+	}
+
+	return r.sampling, account, true
+}
+
+func (r *accountRouter) handleToolsChanged(ctx context.Context, req *mcp.ToolListChangedRequest) {
+	if req == nil || req.Session == nil {
+		return
+	}
+
+	// same as for progress: read lock guarantees, that unsubscribe waits for
+	// running callbacks.
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.sessions[req.Session]
+	if !ok {
+		return
+	}
+
+	for _, f := range r.tools {
+		f(ctx, account)
+	}
+}
+
+func (r *accountRouter) handlePromptsChanged(ctx context.Context, req *mcp.PromptListChangedRequest) {
+	if req == nil || req.Session == nil {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.sessions[req.Session]
+	if !ok {
+		return
+	}
+
+	for _, f := range r.prompts {
+		f(ctx, account)
+	}
+}
+
+func (r *accountRouter) handleResourcesChanged(ctx context.Context, req *mcp.ResourceListChangedRequest) {
+	if req == nil {
+		return
+	}
+
+	r.notifyResources(ctx, req.Session, "")
+}
+
+func (r *accountRouter) handleResourceUpdated(ctx context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+	if req == nil || req.Params == nil || req.Params.URI == "" {
+		return
+	}
+
+	r.notifyResources(ctx, req.Session, req.Params.URI)
+}
+
+func (r *accountRouter) notifyResources(ctx context.Context, session *mcp.ClientSession, uri string) {
+	if session == nil {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.sessions[session]
+	if !ok {
+		return
+	}
+
+	for _, f := range r.resources {
+		f(ctx, account, uri)
+	}
+}
@@ -0,0 +1,420 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+const (
+	defaultStdioRestartWindow = 10 * time.Minute
+	// how long stopped server may shut down gracefully, after its stdin is
+	// closed, and after SIGTERM.
+	stdioTerminateTimeout = 5 * time.Second
+	// backoff stops growing after this number of crashes.
+	maxStdioBackoffShift = 10
+)
+
+var (
+	ErrStdioNotInternal       = errors.New("stdio server must be internal")
+	ErrStdioUnknownServer     = errors.New("stdio server is not configured")
+	ErrStdioRestartsExhausted = errors.New("stdio server crashed too many times")
+	ErrStdioCredentials       = errors.New("stdio server doesn't accept credentials")
+	ErrStdioLimitsUnsupported = errors.New("resource limits are not supported on this platform")
+)
+
+// StdioServer describes local MCP server, which is launched as a subprocess
+// and talks over stdin/stdout. It's referred by "stdio://<name>" links.
+//
+// Subprocess doesn't inherit environment of the service, since it contains
+// secrets: only Env is passed. Otherwise server is not sandboxed: it runs with
+// permissions of the service, under resource limits, and is killed, when
+// service exits.
+type StdioServer struct {
+	Command string
+	Args    []string
+	// variables in "KEY=value" form.
+	Env []string
+	// working directory, empty value means current directory of the
+	// service.
+	Dir     string
+	Limits  StdioLimits
+	Restart StdioRestartPolicy
+}
+
+// StdioLimits restricts resources of the subprocess. Zero values are not
+// limited. Limits are applied before the server command is executed, and are
+// supported only on linux: service must call [RunStdioLimitsHelper] at the
+// start of main.
+type StdioLimits struct {
+	// address space of the process, in bytes.
+	Memory uint64
+	// process is killed after spending this cpu time.
+	CPUTime time.Duration
+	// maximum number of open file descriptors.
+	OpenFiles uint64
+}
+
+// StdioRestartPolicy defines, how crashed server is launched again. Crashed
+// sessions are replaced with new processes on next request.
+type StdioRestartPolicy struct {
+	// MaxRestarts is a number of restarts, allowed within the window. After
+	// that, server is unreachable until old crashes leave the window.
+	MaxRestarts uint
+	// Backoff is a delay before first restart, it's doubled for every next
+	// crash within the window.
+	Backoff time.Duration
+	// Window is a period, during which crashes are counted. Zero value
+	// defaults to 10 minutes.
+	Window time.Duration
+}
+
+// stdioSupervisor launches processes of the single server and counts their
+// crashes.
+type stdioSupervisor struct {
+	logger  *slog.Logger
+	name    string
+	server  StdioServer
+	crashes []time.Time
+	mu      sync.Mutex
+}
+
+func newStdioSupervisors(
+	servers map[string]StdioServer, logger slog.Handler,
+) map[string]*stdioSupervisor {
+	supervisors := make(map[string]*stdioSupervisor, len(servers))
+	for name, server := range servers {
+		if server.Restart.Window <= 0 {
+			server.Restart.Window = defaultStdioRestartWindow
+		}
+
+		supervisors[name] = &stdioSupervisor{
+			logger:  slog.New(logger).With("mcp.stdio.server", name),
+			name:    name,
+			server:  server,
+			crashes: nil,
+			mu:      sync.Mutex{},
+		}
+	}
+
+	return supervisors
+}
+
+// getStdio launches local server and connects to it. Only internal servers
+// may be local: both server and its command are configured by
+// administrator.
+func (f *connFactory) getStdio(
+	ctx context.Context, targetURL *url.URL, isInternal bool,
+) (*asyncClient, error) {
+	ctx, span := f.tracer.Start(ctx, "GetStdio", trace.WithAttributes(
+		attribute.String("mcp.stdio.server", targetURL.Host),
+	))
+	defer span.End()
+
+	if !isInternal {
+		err := fmt.Errorf("%w: %w: %q", toolclient.ErrProtocolNotSupported,
+			ErrStdioNotInternal, targetURL.Host)
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	supervisor, ok := f.stdio[targetURL.Host]
+	if !ok {
+		err := fmt.Errorf("%w: %w: %q", toolclient.ErrServerUnreachable,
+			ErrStdioUnknownServer, targetURL.Host)
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	proc, err := supervisor.launch(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	clientCtx, clientCancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	session, err := connectWithTransport(clientCtx, f.handlers, proc.transport())
+	if err != nil {
+		// failed handshake is counted as crash, so broken server is not
+		// relaunched in a loop.
+		supervisor.crashed(proc, err)
+		//nolint:errcheck,gosec // connection error is more important
+		proc.Close()
+	}
+
+	client, err := f.finalizeConnect(clientCancel, session, tools.ProtocolStdio, err)
+	if err != nil {
+		return nil, err
+	}
+
+	client.exited = proc.exited
+
+	return client, nil
+}
+
+// launch starts new process, waiting for backoff, if previous ones crashed.
+func (s *stdioSupervisor) launch(ctx context.Context) (*stdioProcess, error) {
+	delay, err := s.restartDelay(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: %q", toolclient.ErrServerUnreachable, err, s.name)
+	}
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for restart of %q: %w", s.name, ctx.Err())
+		}
+	}
+
+	return s.start()
+}
+
+func (s *stdioSupervisor) restartDelay(now time.Time) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policy := s.server.Restart
+	s.crashes = slices.DeleteFunc(s.crashes, func(crash time.Time) bool {
+		return now.Sub(crash) > policy.Window
+	})
+
+	crashes := len(s.crashes)
+	if crashes == 0 {
+		return 0, nil
+	}
+
+	if uint(crashes) > policy.MaxRestarts {
+		return 0, ErrStdioRestartsExhausted
+	}
+
+	delay := policy.Backoff << min(crashes-1, maxStdioBackoffShift)
+
+	return min(delay, policy.Window), nil
+}
+
+func (s *stdioSupervisor) crashed(proc *stdioProcess, err error) {
+	if !proc.crashed.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.mu.Lock()
+	s.crashes = append(s.crashes, time.Now())
+	s.mu.Unlock()
+
+	s.logger.Warn("stdio server crashed", "pid", proc.cmd.Process.Pid, "error", err)
+}
+
+func (s *stdioSupervisor) start() (*stdioProcess, error) {
+	cmd, err := limitedCommand(s.server.Command, s.server.Args, s.server.Limits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: limiting %q: %w", toolclient.ErrServerUnreachable, s.name, err)
+	}
+
+	var files stdioFiles
+	if err := files.open(); err != nil {
+		files.closeAll()
+		return nil, fmt.Errorf("creating pipes of %q: %w", s.name, err)
+	}
+
+	// non-nil env prevents inheriting environment of the service.
+	cmd.Env = append(make([]string, 0, len(s.server.Env)), s.server.Env...)
+	cmd.Dir = s.server.Dir
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = files.stdinR, files.stdoutW, files.stderrW
+	cmd.SysProcAttr = parentDeathAttrs()
+
+	if err := startProcess(cmd); err != nil {
+		files.closeAll()
+		return nil, fmt.Errorf("%w: starting %q: %w", toolclient.ErrServerUnreachable, s.name, err)
+	}
+
+	// ends of the subprocess are not needed anymore: otherwise reads won't
+	// see EOF after process exit.
+	files.closeChild()
+
+	proc := &stdioProcess{
+		cmd:      cmd,
+		stdin:    files.stdinW,
+		stdout:   files.stdoutR,
+		exited:   make(chan struct{}),
+		stopping: atomic.Bool{},
+		broken:   atomic.Bool{},
+		crashed:  atomic.Bool{},
+		close:    sync.Once{},
+	}
+
+	go s.logStderr(files.stderrR, cmd.Process.Pid)
+	go proc.wait(s.crashed)
+
+	s.logger.Info("stdio server started", "pid", cmd.Process.Pid)
+
+	return proc, nil
+}
+
+// logStderr writes every line of stderr to the log. Stream must be read to
+// the end, otherwise process blocks on writing.
+func (s *stdioSupervisor) logStderr(stderr *os.File, pid int) {
+	//nolint:errcheck // read-only end
+	defer stderr.Close()
+
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		s.logger.Info("stdio server stderr", "pid", pid, "line", scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		s.logger.Warn("reading stderr of stdio server", "pid", pid, "error", err)
+		//nolint:errcheck // only draining the stream
+		io.Copy(io.Discard, stderr)
+	}
+}
+
+type stdioFiles struct {
+	stdinR, stdinW   *os.File
+	stdoutR, stdoutW *os.File
+	stderrR, stderrW *os.File
+}
+
+func (f *stdioFiles) open() (err error) {
+	if f.stdinR, f.stdinW, err = os.Pipe(); err != nil {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	if f.stdoutR, f.stdoutW, err = os.Pipe(); err != nil {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	if f.stderrR, f.stderrW, err = os.Pipe(); err != nil {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return nil
+}
+
+func (f *stdioFiles) closeChild() { closeFiles(f.stdinR, f.stdoutW, f.stderrW) }
+
+func (f *stdioFiles) closeAll() {
+	closeFiles(f.stdinR, f.stdinW, f.stdoutR, f.stdoutW, f.stderrR, f.stderrW)
+}
+
+func closeFiles(files ...*os.File) {
+	for _, file := range files {
+		if file != nil {
+			//nolint:errcheck,gosec // nothing to do with error
+			file.Close()
+		}
+	}
+}
+
+// stdioProcess is a running server. It's both ends of the transport: closing
+// it stops the process.
+type stdioProcess struct {
+	cmd    *exec.Cmd
+	stdin  *os.File
+	stdout *os.File
+	// closed after process exited.
+	exited chan struct{}
+	// set, when process is stopped by client, so its exit is not a crash.
+	stopping atomic.Bool
+	// set, when server closed stdout before it was asked to stop. Session
+	// stops the process after that, but it's still a crash.
+	broken atomic.Bool
+	// set, when crash is counted, so it's counted once.
+	crashed atomic.Bool
+	close   sync.Once
+}
+
+var _ io.ReadWriteCloser = (*stdioProcess)(nil)
+
+func (p *stdioProcess) transport() mcp.Transport {
+	// stdout is closed by the process itself: closing it first makes server
+	// to fail on writing instead of graceful shutdown.
+	return &mcp.IOTransport{Reader: io.NopCloser(p), Writer: p}
+}
+
+func (p *stdioProcess) wait(crashed func(*stdioProcess, error)) {
+	err := p.cmd.Wait()
+
+	// crash is counted before exit is announced: client replaces exited
+	// process and stops it, so later check would take crash for a stop.
+	if !p.stopping.Load() || p.broken.Load() {
+		crashed(p, err)
+	}
+
+	close(p.exited)
+}
+
+// Read reads stdout of the server.
+func (p *stdioProcess) Read(b []byte) (int, error) {
+	n, err := p.stdout.Read(b)
+	if err != nil && !p.stopping.Load() {
+		p.broken.Store(true)
+	}
+
+	//nolint:wrapcheck // transport wraps errors itself
+	return n, err
+}
+
+func (p *stdioProcess) Write(b []byte) (int, error) {
+	//nolint:wrapcheck // transport wraps errors itself
+	return p.stdin.Write(b)
+}
+
+// Close stops the server like MCP spec recommends: closes stdin, then sends
+// SIGTERM, then SIGKILL, if process doesn't exit in time.
+func (p *stdioProcess) Close() error {
+	p.close.Do(func() {
+		p.stopping.Store(true)
+		closeFiles(p.stdin)
+
+		//nolint:errcheck,gosec // process may be already finished
+		if !p.waitExit(stdioTerminateTimeout) {
+			p.cmd.Process.Signal(syscall.SIGTERM)
+
+			if !p.waitExit(stdioTerminateTimeout) {
+				p.cmd.Process.Kill()
+				p.waitExit(stdioTerminateTimeout)
+			}
+		}
+
+		closeFiles(p.stdout)
+	})
+
+	return nil
+}
+
+func (p *stdioProcess) waitExit(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-p.exited:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
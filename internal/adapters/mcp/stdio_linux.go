@@ -0,0 +1,149 @@
+//go:build linux
+
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// stdioLimitsHelper is argv[0], under which the service binary applies
+	// resource limits and replaces itself with the server command. Go can't
+	// run code between fork and exec, so limits are applied by re-executed
+	// copy of the service: server is limited from its first instruction.
+	stdioLimitsHelper = "cynosure-stdio-limits"
+	// running image of the service, even if its file was replaced.
+	selfExecutable = "/proc/self/exe"
+	// exit code of the helper, if it couldn't start the server.
+	stdioHelperFailed = 127
+)
+
+var errStdioHelperArgs = errors.New("invalid arguments of stdio limits helper")
+
+// RunStdioLimitsHelper must be called at the start of main of the service,
+// which launches stdio servers with limits. If process is the limits helper,
+// it applies limits and executes the server, so function never returns.
+// Otherwise it does nothing.
+func RunStdioLimitsHelper() {
+	if len(os.Args) > 0 && os.Args[0] == stdioLimitsHelper {
+		runLimitsHelper(os.Args[1:])
+	}
+}
+
+// parentDeathAttrs kills the subprocess, when the service exits, even if
+// service crashed. Signal is bound to the thread, which started the process,
+// see startProcess.
+func parentDeathAttrs() *syscall.SysProcAttr {
+	//nolint:exhaustruct // only parent death signal is needed
+	return &syscall.SysProcAttr{
+		Pdeathsig: syscall.SIGKILL,
+	}
+}
+
+// startProcess starts the command on locked thread. Parent death signal is
+// sent, when the thread, which forked the process, exits: runtime exits
+// threads only if goroutine ends while locked to it, so process is never
+// started from such thread.
+func startProcess(cmd *exec.Cmd) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	//nolint:wrapcheck // wrapped by caller
+	return cmd.Start()
+}
+
+// limitedCommand makes command of the server. If any limit is set, server is
+// launched through the limits helper, which passes environment and working
+// directory as is.
+func limitedCommand(command string, args []string, limits StdioLimits) (*exec.Cmd, error) {
+	cmd := exec.Command(command, args...) //nolint:gosec // configured by admin
+	if cmd.Err != nil {
+		return nil, cmd.Err
+	}
+
+	if limits == (StdioLimits{}) {
+		return cmd, nil
+	}
+
+	helper := exec.Command(selfExecutable) //nolint:gosec // the service itself
+	helper.Args = append([]string{
+		stdioLimitsHelper,
+		strconv.FormatUint(limits.Memory, 10),
+		strconv.FormatUint(cpuSeconds(limits.CPUTime), 10),
+		strconv.FormatUint(limits.OpenFiles, 10),
+		cmd.Path,
+	}, cmd.Args...)
+
+	return helper, nil
+}
+
+// runLimitsHelper applies limits and executes the server. It never returns:
+// failure is written to stderr, which is logged by supervisor.
+func runLimitsHelper(args []string) {
+	err := execLimited(args)
+
+	//nolint:errcheck // nothing to do with error
+	fmt.Fprintf(os.Stderr, "%s: %v\n", stdioLimitsHelper, err)
+	os.Exit(stdioHelperFailed) //nolint:forbidigo // process is the helper
+}
+
+// execLimited expects memory, cpu seconds and open files limits, path of the
+// server and its argv.
+func execLimited(args []string) error {
+	resources := []int{syscall.RLIMIT_AS, syscall.RLIMIT_CPU, syscall.RLIMIT_NOFILE}
+	if len(args) <= len(resources)+1 {
+		return errStdioHelperArgs
+	}
+
+	for i, resource := range resources {
+		value, err := strconv.ParseUint(args[i], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errStdioHelperArgs, err)
+		}
+
+		if err := setLimit(resource, value); err != nil {
+			return err
+		}
+	}
+
+	path, argv := args[len(resources)], args[len(resources)+1:]
+
+	//nolint:gosec // configured by admin
+	if err := syscall.Exec(path, argv, os.Environ()); err != nil {
+		return fmt.Errorf("executing %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// setLimit uses syscall package, so exec doesn't restore open files limit,
+// raised by go runtime.
+func setLimit(resource int, value uint64) error {
+	if value == 0 {
+		return nil
+	}
+
+	limit := syscall.Rlimit{Cur: value, Max: value}
+	if err := syscall.Setrlimit(resource, &limit); err != nil {
+		return fmt.Errorf("setting limit %d: %w", resource, err)
+	}
+
+	return nil
+}
+
+// cpuSeconds rounds cpu time up, since limit is set in whole seconds.
+func cpuSeconds(d time.Duration) uint64 {
+	if d <= 0 {
+		return 0
+	}
+
+	return uint64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,27 @@
+//go:build !linux
+
+package mcp
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// RunStdioLimitsHelper does nothing: resource limits are supported only on
+// linux.
+func RunStdioLimitsHelper() {}
+
+func parentDeathAttrs() *syscall.SysProcAttr { return nil }
+
+func startProcess(cmd *exec.Cmd) error {
+	//nolint:wrapcheck // wrapped by caller
+	return cmd.Start()
+}
+
+func limitedCommand(command string, args []string, limits StdioLimits) (*exec.Cmd, error) {
+	if limits != (StdioLimits{}) {
+		return nil, ErrStdioLimitsUnsupported
+	}
+
+	return exec.Command(command, args...), nil //nolint:gosec // configured by admin
+}
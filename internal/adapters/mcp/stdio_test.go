@@ -0,0 +1,174 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mcp"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+const stdioHelperEnv = "CYNOSURE_TEST_STDIO_SERVER"
+
+// TestMain lets test binary act as limits helper, like the service does.
+func TestMain(m *testing.M) {
+	mcp.RunStdioLimitsHelper()
+
+	os.Exit(m.Run())
+}
+
+// TestStdioHelperServer is a tiny stdio server, which other tests launch from
+// the test binary. It's skipped, when runs as a regular test.
+func TestStdioHelperServer(t *testing.T) {
+	if os.Getenv(stdioHelperEnv) == "" {
+		t.Skip("runs only as a subprocess")
+	}
+
+	srv := newTestServer()
+	srv.AddTool(&sdk.Tool{
+		Name:        "greet",
+		Description: "Greets from the working directory",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		dir, _ := os.Getwd()
+		text := fmt.Sprintf("%s from %s, home=%q", os.Getenv("GREETING"), dir, os.Getenv("HOME"))
+
+		return &sdk.CallToolResult{Content: []sdk.Content{&sdk.TextContent{Text: text}}}, nil
+	})
+	srv.AddTool(&sdk.Tool{
+		Name:        "limits",
+		Description: "Returns resource limits of the server",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		limits, _ := os.ReadFile("/proc/self/limits")
+
+		content := []sdk.Content{&sdk.TextContent{Text: string(limits)}}
+
+		return &sdk.CallToolResult{Content: content}, nil
+	})
+	srv.AddTool(&sdk.Tool{
+		Name:        "crash",
+		Description: "Crashes the server",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *sdk.CallToolRequest) (*sdk.CallToolResult, error) {
+		os.Exit(3)
+		return nil, nil
+	})
+
+	fmt.Fprintln(os.Stderr, "helper started")
+
+	if err := srv.Run(context.Background(), &sdk.StdioTransport{}); err != nil {
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func TestStdioServer(t *testing.T) {
+	account := mustAccountID(t)
+	link := must(url.Parse("stdio://helper"))
+
+	_, err := entities.NewServerConfig(account.Server(), link)
+	require.Error(t, err, "stdio server must be internal")
+
+	server := must(entities.NewServerConfig(account.Server(), link, entities.WithInternal(true)))
+	dir := t.TempDir()
+
+	handler := setupStdioHandler(t, server, mcp.StdioServer{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=^TestStdioHelperServer$"},
+		Env:     []string{stdioHelperEnv + "=1", "GREETING=hello"},
+		Dir:     dir,
+		Limits:  stdioTestLimits(),
+		Restart: mcp.StdioRestartPolicy{
+			MaxRestarts: 1,
+			Backoff:     10 * time.Millisecond,
+			Window:      time.Minute,
+		},
+	})
+
+	_, err = handler.DiscoverTools(t.Context(), link, account, "helper", "desc")
+	require.ErrorIs(t, err, toolclient.ErrProtocolNotSupported)
+
+	discovered, err := handler.DiscoverTools(t.Context(), link, account, "helper", "desc",
+		toolclient.WithInternalTransport(),
+	)
+	require.NoError(t, err)
+	require.Len(t, discovered, 3)
+
+	greet, crash := mustTool(t, account, "greet"), mustTool(t, account, "crash")
+
+	if runtime.GOOS == "linux" {
+		res, err := handler.ExecuteTool(t.Context(), mustTool(t, account, "limits"), nil, "call-0")
+		require.NoError(t, err)
+		require.Regexp(t, `Max open files\s+256\s+256`, string(res.Content()),
+			"limits must be applied before server started")
+	}
+
+	res, err := handler.ExecuteTool(t.Context(), greet, nil, "call-1")
+	require.NoError(t, err)
+	require.Contains(t, string(res.Content()), "hello from "+dir)
+	require.Contains(t, string(res.Content()), `home=\"\"`, "environment must not be inherited")
+
+	//nolint:errcheck // server crashes during the call
+	handler.ExecuteTool(t.Context(), crash, nil, "call-2")
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, err := handler.ExecuteTool(t.Context(), greet, nil, "call-3")
+		if assert.NoError(c, err) {
+			assert.Contains(c, string(res.Content()), "hello")
+		}
+	}, 5*time.Second, 50*time.Millisecond, "crashed server must be restarted")
+
+	//nolint:errcheck // server crashes during the call
+	handler.ExecuteTool(t.Context(), crash, nil, "call-4")
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		_, err := handler.ExecuteTool(t.Context(), greet, nil, "call-5")
+		assert.ErrorIs(c, err, toolclient.ErrServerUnreachable)
+	}, 5*time.Second, 50*time.Millisecond, "restarts must be limited")
+}
+
+func setupStdioHandler(
+	t *testing.T, server entities.ServerConfigReadOnly, stdio mcp.StdioServer,
+) *mcp.Handler {
+	t.Helper()
+
+	accountToken := func(
+		context.Context, ids.AccountID,
+	) (entities.ServerConfigReadOnly, *oauth2.Token, error) {
+		return server, nil, nil
+	}
+
+	handler, err := mcp.New(t.Context(), accountToken, noopRefreshToken,
+		mcp.WithUnsafeExternalHTTPClient(http.DefaultTransport),
+		mcp.WithInternalHTTPClient(http.DefaultTransport),
+		mcp.WithStdioServer(server.SSELink().Host, stdio),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, handler.Close()) })
+
+	return handler
+}
+
+func stdioTestLimits() mcp.StdioLimits {
+	if runtime.GOOS != "linux" {
+		return mcp.StdioLimits{}
+	}
+
+	return mcp.StdioLimits{Memory: 0, CPUTime: time.Minute, OpenFiles: 256}
+}
@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+)
+
+type toolTimeoutKey struct {
+	server uuid.UUID
+	tool   string
+}
+
+// toolTimeouts resolves deadline of a single tool call. Most specific rule
+// wins: tool override, then server override, then fallback value.
+type toolTimeouts struct {
+	servers  map[uuid.UUID]time.Duration
+	tools    map[toolTimeoutKey]time.Duration
+	fallback time.Duration
+}
+
+func (t toolTimeouts) resolve(tool entities.ToolReadOnly) time.Duration {
+	server := tool.ID().Account().Server().ID()
+
+	if timeout, ok := t.tools[toolTimeoutKey{server: server, tool: tool.Name()}]; ok {
+		return timeout
+	}
+
+	if timeout, ok := t.servers[server]; ok {
+		return timeout
+	}
+
+	return t.fallback
+}
+
+// withDeadline applies tool deadline to the context. Non-positive timeout
+// means that tool is not limited by time, only by parent context.
+func (t toolTimeouts) withDeadline(
+	ctx context.Context, tool entities.ToolReadOnly,
+) (context.Context, time.Duration, context.CancelFunc) {
+	timeout := t.resolve(tool)
+	if timeout <= 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, 0, cancel
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, timeout, cancel
+}
@@ -300,6 +300,68 @@ func (_c *MockAccountStorage_ListAccounts_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// ListActiveAccounts provides a mock function for the type MockAccountStorage
+func (_mock *MockAccountStorage) ListActiveAccounts(ctx context.Context) ([]ids.AccountID, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveAccounts")
+	}
+
+	var r0 []ids.AccountID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]ids.AccountID, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []ids.AccountID); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ids.AccountID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountStorage_ListActiveAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveAccounts'
+type MockAccountStorage_ListActiveAccounts_Call struct {
+	*mock.Call
+}
+
+// ListActiveAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAccountStorage_Expecter) ListActiveAccounts(ctx interface{}) *MockAccountStorage_ListActiveAccounts_Call {
+	return &MockAccountStorage_ListActiveAccounts_Call{Call: _e.mock.On("ListActiveAccounts", ctx)}
+}
+
+func (_c *MockAccountStorage_ListActiveAccounts_Call) Run(run func(ctx context.Context)) *MockAccountStorage_ListActiveAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountStorage_ListActiveAccounts_Call) Return(accountIDs []ids.AccountID, err error) *MockAccountStorage_ListActiveAccounts_Call {
+	_c.Call.Return(accountIDs, err)
+	return _c
+}
+
+func (_c *MockAccountStorage_ListActiveAccounts_Call) RunAndReturn(run func(ctx context.Context) ([]ids.AccountID, error)) *MockAccountStorage_ListActiveAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveAccount provides a mock function for the type MockAccountStorage
 func (_mock *MockAccountStorage) SaveAccount(ctx context.Context, info entities.AccountReadOnly) error {
 	ret := _mock.Called(ctx, info)
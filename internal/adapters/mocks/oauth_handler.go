@@ -310,3 +310,81 @@ func (_c *OAuthHandler_RegisterClient_Call) RunAndReturn(run func(ctx context.Co
 	_c.Call.Return(run)
 	return _c
 }
+
+// RevokeToken provides a mock function for the type OAuthHandler
+func (_mock *OAuthHandler) RevokeToken(ctx context.Context, config *oauth2.Config, token *oauth2.Token, opts ...oauthhandler.RevokeTokenOption) error {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, config, token, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, config, token)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *oauth2.Config, *oauth2.Token, ...oauthhandler.RevokeTokenOption) error); ok {
+		r0 = returnFunc(ctx, config, token, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OAuthHandler_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type OAuthHandler_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - config *oauth2.Config
+//   - token *oauth2.Token
+//   - opts ...oauthhandler.RevokeTokenOption
+func (_e *OAuthHandler_Expecter) RevokeToken(ctx interface{}, config interface{}, token interface{}, opts ...interface{}) *OAuthHandler_RevokeToken_Call {
+	return &OAuthHandler_RevokeToken_Call{Call: _e.mock.On("RevokeToken",
+		append([]interface{}{ctx, config, token}, opts...)...)}
+}
+
+func (_c *OAuthHandler_RevokeToken_Call) Run(run func(ctx context.Context, config *oauth2.Config, token *oauth2.Token, opts ...oauthhandler.RevokeTokenOption)) *OAuthHandler_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *oauth2.Config
+		if args[1] != nil {
+			arg1 = args[1].(*oauth2.Config)
+		}
+		var arg2 *oauth2.Token
+		if args[2] != nil {
+			arg2 = args[2].(*oauth2.Token)
+		}
+		var arg3 []oauthhandler.RevokeTokenOption
+		var variadicArgs []oauthhandler.RevokeTokenOption
+		if len(args) > 3 {
+			variadicArgs = args[3].([]oauthhandler.RevokeTokenOption)
+		}
+		arg3 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *OAuthHandler_RevokeToken_Call) Return(err error) *OAuthHandler_RevokeToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OAuthHandler_RevokeToken_Call) RunAndReturn(run func(ctx context.Context, config *oauth2.Config, token *oauth2.Token, opts ...oauthhandler.RevokeTokenOption) error) *OAuthHandler_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
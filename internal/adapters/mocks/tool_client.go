@@ -13,6 +13,8 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -146,8 +148,14 @@ func (_c *ToolClient_DiscoverTools_Call) RunAndReturn(run func(ctx context.Conte
 }
 
 // ExecuteTool provides a mock function for the type ToolClient
-func (_mock *ToolClient) ExecuteTool(ctx context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage, toolCallID string) (messages.MessageTool, error) {
-	ret := _mock.Called(ctx, tool, args, toolCallID)
+func (_mock *ToolClient) ExecuteTool(ctx context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage, toolCallID string, opts ...toolclient.ExecuteToolOption) (messages.MessageTool, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, tool, args, toolCallID, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, tool, args, toolCallID)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteTool")
@@ -155,18 +163,18 @@ func (_mock *ToolClient) ExecuteTool(ctx context.Context, tool entities.ToolRead
 
 	var r0 messages.MessageTool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.ToolReadOnly, map[string]json.RawMessage, string) (messages.MessageTool, error)); ok {
-		return returnFunc(ctx, tool, args, toolCallID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.ToolReadOnly, map[string]json.RawMessage, string, ...toolclient.ExecuteToolOption) (messages.MessageTool, error)); ok {
+		return returnFunc(ctx, tool, args, toolCallID, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.ToolReadOnly, map[string]json.RawMessage, string) messages.MessageTool); ok {
-		r0 = returnFunc(ctx, tool, args, toolCallID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.ToolReadOnly, map[string]json.RawMessage, string, ...toolclient.ExecuteToolOption) messages.MessageTool); ok {
+		r0 = returnFunc(ctx, tool, args, toolCallID, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(messages.MessageTool)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.ToolReadOnly, map[string]json.RawMessage, string) error); ok {
-		r1 = returnFunc(ctx, tool, args, toolCallID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.ToolReadOnly, map[string]json.RawMessage, string, ...toolclient.ExecuteToolOption) error); ok {
+		r1 = returnFunc(ctx, tool, args, toolCallID, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -183,11 +191,13 @@ type ToolClient_ExecuteTool_Call struct {
 //   - tool entities.ToolReadOnly
 //   - args map[string]json.RawMessage
 //   - toolCallID string
-func (_e *ToolClient_Expecter) ExecuteTool(ctx interface{}, tool interface{}, args interface{}, toolCallID interface{}) *ToolClient_ExecuteTool_Call {
-	return &ToolClient_ExecuteTool_Call{Call: _e.mock.On("ExecuteTool", ctx, tool, args, toolCallID)}
+//   - opts ...toolclient.ExecuteToolOption
+func (_e *ToolClient_Expecter) ExecuteTool(ctx interface{}, tool interface{}, args interface{}, toolCallID interface{}, opts ...interface{}) *ToolClient_ExecuteTool_Call {
+	return &ToolClient_ExecuteTool_Call{Call: _e.mock.On("ExecuteTool",
+		append([]interface{}{ctx, tool, args, toolCallID}, opts...)...)}
 }
 
-func (_c *ToolClient_ExecuteTool_Call) Run(run func(ctx context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage, toolCallID string)) *ToolClient_ExecuteTool_Call {
+func (_c *ToolClient_ExecuteTool_Call) Run(run func(ctx context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage, toolCallID string, opts ...toolclient.ExecuteToolOption)) *ToolClient_ExecuteTool_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -205,11 +215,18 @@ func (_c *ToolClient_ExecuteTool_Call) Run(run func(ctx context.Context, tool en
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 []toolclient.ExecuteToolOption
+		var variadicArgs []toolclient.ExecuteToolOption
+		if len(args) > 4 {
+			variadicArgs = args[4].([]toolclient.ExecuteToolOption)
+		}
+		arg4 = variadicArgs
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4...,
 		)
 	})
 	return _c
@@ -220,7 +237,675 @@ func (_c *ToolClient_ExecuteTool_Call) Return(messageTool messages.MessageTool,
 	return _c
 }
 
-func (_c *ToolClient_ExecuteTool_Call) RunAndReturn(run func(ctx context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage, toolCallID string) (messages.MessageTool, error)) *ToolClient_ExecuteTool_Call {
+func (_c *ToolClient_ExecuteTool_Call) RunAndReturn(run func(ctx context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage, toolCallID string, opts ...toolclient.ExecuteToolOption) (messages.MessageTool, error)) *ToolClient_ExecuteTool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgetAccount provides a mock function for the type ToolClient
+func (_mock *ToolClient) ForgetAccount(account ids.AccountID) {
+	_mock.Called(account)
+	return
+}
+
+// ToolClient_ForgetAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgetAccount'
+type ToolClient_ForgetAccount_Call struct {
+	*mock.Call
+}
+
+// ForgetAccount is a helper method to define mock.On call
+//   - account ids.AccountID
+func (_e *ToolClient_Expecter) ForgetAccount(account interface{}) *ToolClient_ForgetAccount_Call {
+	return &ToolClient_ForgetAccount_Call{Call: _e.mock.On("ForgetAccount", account)}
+}
+
+func (_c *ToolClient_ForgetAccount_Call) Run(run func(account ids.AccountID)) *ToolClient_ForgetAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 ids.AccountID
+		if args[0] != nil {
+			arg0 = args[0].(ids.AccountID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_ForgetAccount_Call) Return() *ToolClient_ForgetAccount_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ToolClient_ForgetAccount_Call) RunAndReturn(run func(account ids.AccountID)) *ToolClient_ForgetAccount_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetPrompt provides a mock function for the type ToolClient
+func (_mock *ToolClient) GetPrompt(ctx context.Context, account ids.AccountID, name string, args map[string]string) ([]prompts.Message, error) {
+	ret := _mock.Called(ctx, account, name, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPrompt")
+	}
+
+	var r0 []prompts.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID, string, map[string]string) ([]prompts.Message, error)); ok {
+		return returnFunc(ctx, account, name, args)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID, string, map[string]string) []prompts.Message); ok {
+		r0 = returnFunc(ctx, account, name, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]prompts.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ids.AccountID, string, map[string]string) error); ok {
+		r1 = returnFunc(ctx, account, name, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ToolClient_GetPrompt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPrompt'
+type ToolClient_GetPrompt_Call struct {
+	*mock.Call
+}
+
+// GetPrompt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ids.AccountID
+//   - name string
+//   - args map[string]string
+func (_e *ToolClient_Expecter) GetPrompt(ctx interface{}, account interface{}, name interface{}, args interface{}) *ToolClient_GetPrompt_Call {
+	return &ToolClient_GetPrompt_Call{Call: _e.mock.On("GetPrompt", ctx, account, name, args)}
+}
+
+func (_c *ToolClient_GetPrompt_Call) Run(run func(ctx context.Context, account ids.AccountID, name string, args map[string]string)) *ToolClient_GetPrompt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.AccountID
+		if args[1] != nil {
+			arg1 = args[1].(ids.AccountID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 map[string]string
+		if args[3] != nil {
+			arg3 = args[3].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_GetPrompt_Call) Return(messages1 []prompts.Message, err error) *ToolClient_GetPrompt_Call {
+	_c.Call.Return(messages1, err)
+	return _c
+}
+
+func (_c *ToolClient_GetPrompt_Call) RunAndReturn(run func(ctx context.Context, account ids.AccountID, name string, args map[string]string) ([]prompts.Message, error)) *ToolClient_GetPrompt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleSampling provides a mock function for the type ToolClient
+func (_mock *ToolClient) HandleSampling(handler toolclient.SamplingHandler) func() {
+	ret := _mock.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleSampling")
+	}
+
+	var r0 func()
+	if returnFunc, ok := ret.Get(0).(func(toolclient.SamplingHandler) func()); ok {
+		r0 = returnFunc(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+	return r0
+}
+
+// ToolClient_HandleSampling_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleSampling'
+type ToolClient_HandleSampling_Call struct {
+	*mock.Call
+}
+
+// HandleSampling is a helper method to define mock.On call
+//   - handler toolclient.SamplingHandler
+func (_e *ToolClient_Expecter) HandleSampling(handler interface{}) *ToolClient_HandleSampling_Call {
+	return &ToolClient_HandleSampling_Call{Call: _e.mock.On("HandleSampling", handler)}
+}
+
+func (_c *ToolClient_HandleSampling_Call) Run(run func(handler toolclient.SamplingHandler)) *ToolClient_HandleSampling_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 toolclient.SamplingHandler
+		if args[0] != nil {
+			arg0 = args[0].(toolclient.SamplingHandler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_HandleSampling_Call) Return(unregister func()) *ToolClient_HandleSampling_Call {
+	_c.Call.Return(unregister)
+	return _c
+}
+
+func (_c *ToolClient_HandleSampling_Call) RunAndReturn(run func(handler toolclient.SamplingHandler) func()) *ToolClient_HandleSampling_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPrompts provides a mock function for the type ToolClient
+func (_mock *ToolClient) ListPrompts(ctx context.Context, account ids.AccountID) ([]prompts.Prompt, error) {
+	ret := _mock.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPrompts")
+	}
+
+	var r0 []prompts.Prompt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID) ([]prompts.Prompt, error)); ok {
+		return returnFunc(ctx, account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID) []prompts.Prompt); ok {
+		r0 = returnFunc(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]prompts.Prompt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ids.AccountID) error); ok {
+		r1 = returnFunc(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ToolClient_ListPrompts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPrompts'
+type ToolClient_ListPrompts_Call struct {
+	*mock.Call
+}
+
+// ListPrompts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ids.AccountID
+func (_e *ToolClient_Expecter) ListPrompts(ctx interface{}, account interface{}) *ToolClient_ListPrompts_Call {
+	return &ToolClient_ListPrompts_Call{Call: _e.mock.On("ListPrompts", ctx, account)}
+}
+
+func (_c *ToolClient_ListPrompts_Call) Run(run func(ctx context.Context, account ids.AccountID)) *ToolClient_ListPrompts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.AccountID
+		if args[1] != nil {
+			arg1 = args[1].(ids.AccountID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_ListPrompts_Call) Return(prompts1 []prompts.Prompt, err error) *ToolClient_ListPrompts_Call {
+	_c.Call.Return(prompts1, err)
+	return _c
+}
+
+func (_c *ToolClient_ListPrompts_Call) RunAndReturn(run func(ctx context.Context, account ids.AccountID) ([]prompts.Prompt, error)) *ToolClient_ListPrompts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListResources provides a mock function for the type ToolClient
+func (_mock *ToolClient) ListResources(ctx context.Context, account ids.AccountID) ([]resources.Resource, error) {
+	ret := _mock.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListResources")
+	}
+
+	var r0 []resources.Resource
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID) ([]resources.Resource, error)); ok {
+		return returnFunc(ctx, account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID) []resources.Resource); ok {
+		r0 = returnFunc(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]resources.Resource)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ids.AccountID) error); ok {
+		r1 = returnFunc(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ToolClient_ListResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListResources'
+type ToolClient_ListResources_Call struct {
+	*mock.Call
+}
+
+// ListResources is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ids.AccountID
+func (_e *ToolClient_Expecter) ListResources(ctx interface{}, account interface{}) *ToolClient_ListResources_Call {
+	return &ToolClient_ListResources_Call{Call: _e.mock.On("ListResources", ctx, account)}
+}
+
+func (_c *ToolClient_ListResources_Call) Run(run func(ctx context.Context, account ids.AccountID)) *ToolClient_ListResources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.AccountID
+		if args[1] != nil {
+			arg1 = args[1].(ids.AccountID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_ListResources_Call) Return(resources1 []resources.Resource, err error) *ToolClient_ListResources_Call {
+	_c.Call.Return(resources1, err)
+	return _c
+}
+
+func (_c *ToolClient_ListResources_Call) RunAndReturn(run func(ctx context.Context, account ids.AccountID) ([]resources.Resource, error)) *ToolClient_ListResources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadResource provides a mock function for the type ToolClient
+func (_mock *ToolClient) ReadResource(ctx context.Context, account ids.AccountID, uri string) ([]resources.Content, error) {
+	ret := _mock.Called(ctx, account, uri)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadResource")
+	}
+
+	var r0 []resources.Content
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID, string) ([]resources.Content, error)); ok {
+		return returnFunc(ctx, account, uri)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID, string) []resources.Content); ok {
+		r0 = returnFunc(ctx, account, uri)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]resources.Content)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ids.AccountID, string) error); ok {
+		r1 = returnFunc(ctx, account, uri)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ToolClient_ReadResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadResource'
+type ToolClient_ReadResource_Call struct {
+	*mock.Call
+}
+
+// ReadResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ids.AccountID
+//   - uri string
+func (_e *ToolClient_Expecter) ReadResource(ctx interface{}, account interface{}, uri interface{}) *ToolClient_ReadResource_Call {
+	return &ToolClient_ReadResource_Call{Call: _e.mock.On("ReadResource", ctx, account, uri)}
+}
+
+func (_c *ToolClient_ReadResource_Call) Run(run func(ctx context.Context, account ids.AccountID, uri string)) *ToolClient_ReadResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.AccountID
+		if args[1] != nil {
+			arg1 = args[1].(ids.AccountID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_ReadResource_Call) Return(contents []resources.Content, err error) *ToolClient_ReadResource_Call {
+	_c.Call.Return(contents, err)
+	return _c
+}
+
+func (_c *ToolClient_ReadResource_Call) RunAndReturn(run func(ctx context.Context, account ids.AccountID, uri string) ([]resources.Content, error)) *ToolClient_ReadResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribePromptsChanged provides a mock function for the type ToolClient
+func (_mock *ToolClient) SubscribePromptsChanged(handler toolclient.PromptsChangedHandler) func() {
+	ret := _mock.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribePromptsChanged")
+	}
+
+	var r0 func()
+	if returnFunc, ok := ret.Get(0).(func(toolclient.PromptsChangedHandler) func()); ok {
+		r0 = returnFunc(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+	return r0
+}
+
+// ToolClient_SubscribePromptsChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribePromptsChanged'
+type ToolClient_SubscribePromptsChanged_Call struct {
+	*mock.Call
+}
+
+// SubscribePromptsChanged is a helper method to define mock.On call
+//   - handler toolclient.PromptsChangedHandler
+func (_e *ToolClient_Expecter) SubscribePromptsChanged(handler interface{}) *ToolClient_SubscribePromptsChanged_Call {
+	return &ToolClient_SubscribePromptsChanged_Call{Call: _e.mock.On("SubscribePromptsChanged", handler)}
+}
+
+func (_c *ToolClient_SubscribePromptsChanged_Call) Run(run func(handler toolclient.PromptsChangedHandler)) *ToolClient_SubscribePromptsChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 toolclient.PromptsChangedHandler
+		if args[0] != nil {
+			arg0 = args[0].(toolclient.PromptsChangedHandler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_SubscribePromptsChanged_Call) Return(unsubscribe func()) *ToolClient_SubscribePromptsChanged_Call {
+	_c.Call.Return(unsubscribe)
+	return _c
+}
+
+func (_c *ToolClient_SubscribePromptsChanged_Call) RunAndReturn(run func(handler toolclient.PromptsChangedHandler) func()) *ToolClient_SubscribePromptsChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeResource provides a mock function for the type ToolClient
+func (_mock *ToolClient) SubscribeResource(ctx context.Context, account ids.AccountID, uri string) error {
+	ret := _mock.Called(ctx, account, uri)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeResource")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID, string) error); ok {
+		r0 = returnFunc(ctx, account, uri)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ToolClient_SubscribeResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeResource'
+type ToolClient_SubscribeResource_Call struct {
+	*mock.Call
+}
+
+// SubscribeResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ids.AccountID
+//   - uri string
+func (_e *ToolClient_Expecter) SubscribeResource(ctx interface{}, account interface{}, uri interface{}) *ToolClient_SubscribeResource_Call {
+	return &ToolClient_SubscribeResource_Call{Call: _e.mock.On("SubscribeResource", ctx, account, uri)}
+}
+
+func (_c *ToolClient_SubscribeResource_Call) Run(run func(ctx context.Context, account ids.AccountID, uri string)) *ToolClient_SubscribeResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.AccountID
+		if args[1] != nil {
+			arg1 = args[1].(ids.AccountID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_SubscribeResource_Call) Return(err error) *ToolClient_SubscribeResource_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ToolClient_SubscribeResource_Call) RunAndReturn(run func(ctx context.Context, account ids.AccountID, uri string) error) *ToolClient_SubscribeResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeResourcesChanged provides a mock function for the type ToolClient
+func (_mock *ToolClient) SubscribeResourcesChanged(handler toolclient.ResourcesChangedHandler) func() {
+	ret := _mock.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeResourcesChanged")
+	}
+
+	var r0 func()
+	if returnFunc, ok := ret.Get(0).(func(toolclient.ResourcesChangedHandler) func()); ok {
+		r0 = returnFunc(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+	return r0
+}
+
+// ToolClient_SubscribeResourcesChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeResourcesChanged'
+type ToolClient_SubscribeResourcesChanged_Call struct {
+	*mock.Call
+}
+
+// SubscribeResourcesChanged is a helper method to define mock.On call
+//   - handler toolclient.ResourcesChangedHandler
+func (_e *ToolClient_Expecter) SubscribeResourcesChanged(handler interface{}) *ToolClient_SubscribeResourcesChanged_Call {
+	return &ToolClient_SubscribeResourcesChanged_Call{Call: _e.mock.On("SubscribeResourcesChanged", handler)}
+}
+
+func (_c *ToolClient_SubscribeResourcesChanged_Call) Run(run func(handler toolclient.ResourcesChangedHandler)) *ToolClient_SubscribeResourcesChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 toolclient.ResourcesChangedHandler
+		if args[0] != nil {
+			arg0 = args[0].(toolclient.ResourcesChangedHandler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_SubscribeResourcesChanged_Call) Return(unsubscribe func()) *ToolClient_SubscribeResourcesChanged_Call {
+	_c.Call.Return(unsubscribe)
+	return _c
+}
+
+func (_c *ToolClient_SubscribeResourcesChanged_Call) RunAndReturn(run func(handler toolclient.ResourcesChangedHandler) func()) *ToolClient_SubscribeResourcesChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeToolsChanged provides a mock function for the type ToolClient
+func (_mock *ToolClient) SubscribeToolsChanged(handler toolclient.ToolsChangedHandler) func() {
+	ret := _mock.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeToolsChanged")
+	}
+
+	var r0 func()
+	if returnFunc, ok := ret.Get(0).(func(toolclient.ToolsChangedHandler) func()); ok {
+		r0 = returnFunc(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+	return r0
+}
+
+// ToolClient_SubscribeToolsChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeToolsChanged'
+type ToolClient_SubscribeToolsChanged_Call struct {
+	*mock.Call
+}
+
+// SubscribeToolsChanged is a helper method to define mock.On call
+//   - handler toolclient.ToolsChangedHandler
+func (_e *ToolClient_Expecter) SubscribeToolsChanged(handler interface{}) *ToolClient_SubscribeToolsChanged_Call {
+	return &ToolClient_SubscribeToolsChanged_Call{Call: _e.mock.On("SubscribeToolsChanged", handler)}
+}
+
+func (_c *ToolClient_SubscribeToolsChanged_Call) Run(run func(handler toolclient.ToolsChangedHandler)) *ToolClient_SubscribeToolsChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 toolclient.ToolsChangedHandler
+		if args[0] != nil {
+			arg0 = args[0].(toolclient.ToolsChangedHandler)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_SubscribeToolsChanged_Call) Return(unsubscribe func()) *ToolClient_SubscribeToolsChanged_Call {
+	_c.Call.Return(unsubscribe)
+	return _c
+}
+
+func (_c *ToolClient_SubscribeToolsChanged_Call) RunAndReturn(run func(handler toolclient.ToolsChangedHandler) func()) *ToolClient_SubscribeToolsChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnsubscribeResource provides a mock function for the type ToolClient
+func (_mock *ToolClient) UnsubscribeResource(ctx context.Context, account ids.AccountID, uri string) error {
+	ret := _mock.Called(ctx, account, uri)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsubscribeResource")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.AccountID, string) error); ok {
+		r0 = returnFunc(ctx, account, uri)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ToolClient_UnsubscribeResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsubscribeResource'
+type ToolClient_UnsubscribeResource_Call struct {
+	*mock.Call
+}
+
+// UnsubscribeResource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account ids.AccountID
+//   - uri string
+func (_e *ToolClient_Expecter) UnsubscribeResource(ctx interface{}, account interface{}, uri interface{}) *ToolClient_UnsubscribeResource_Call {
+	return &ToolClient_UnsubscribeResource_Call{Call: _e.mock.On("UnsubscribeResource", ctx, account, uri)}
+}
+
+func (_c *ToolClient_UnsubscribeResource_Call) Run(run func(ctx context.Context, account ids.AccountID, uri string)) *ToolClient_UnsubscribeResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.AccountID
+		if args[1] != nil {
+			arg1 = args[1].(ids.AccountID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ToolClient_UnsubscribeResource_Call) Return(err error) *ToolClient_UnsubscribeResource_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ToolClient_UnsubscribeResource_Call) RunAndReturn(run func(ctx context.Context, account ids.AccountID, uri string) error) *ToolClient_UnsubscribeResource_Call {
 	_c.Call.Return(run)
 	return _c
 }
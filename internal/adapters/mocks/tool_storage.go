@@ -318,6 +318,86 @@ func (_c *MockToolStorage_LookupTools_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// MatchTools provides a mock function for the type MockToolStorage
+func (_mock *MockToolStorage) MatchTools(ctx context.Context, user ids.UserID, embedding [1536]float32, limit int) ([]entities.ToolMatch, error) {
+	ret := _mock.Called(ctx, user, embedding, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatchTools")
+	}
+
+	var r0 []entities.ToolMatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.UserID, [1536]float32, int) ([]entities.ToolMatch, error)); ok {
+		return returnFunc(ctx, user, embedding, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ids.UserID, [1536]float32, int) []entities.ToolMatch); ok {
+		r0 = returnFunc(ctx, user, embedding, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ToolMatch)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ids.UserID, [1536]float32, int) error); ok {
+		r1 = returnFunc(ctx, user, embedding, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockToolStorage_MatchTools_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MatchTools'
+type MockToolStorage_MatchTools_Call struct {
+	*mock.Call
+}
+
+// MatchTools is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user ids.UserID
+//   - embedding [1536]float32
+//   - limit int
+func (_e *MockToolStorage_Expecter) MatchTools(ctx interface{}, user interface{}, embedding interface{}, limit interface{}) *MockToolStorage_MatchTools_Call {
+	return &MockToolStorage_MatchTools_Call{Call: _e.mock.On("MatchTools", ctx, user, embedding, limit)}
+}
+
+func (_c *MockToolStorage_MatchTools_Call) Run(run func(ctx context.Context, user ids.UserID, embedding [1536]float32, limit int)) *MockToolStorage_MatchTools_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ids.UserID
+		if args[1] != nil {
+			arg1 = args[1].(ids.UserID)
+		}
+		var arg2 [1536]float32
+		if args[2] != nil {
+			arg2 = args[2].([1536]float32)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockToolStorage_MatchTools_Call) Return(toolMatchs []entities.ToolMatch, err error) *MockToolStorage_MatchTools_Call {
+	_c.Call.Return(toolMatchs, err)
+	return _c
+}
+
+func (_c *MockToolStorage_MatchTools_Call) RunAndReturn(run func(ctx context.Context, user ids.UserID, embedding [1536]float32, limit int) ([]entities.ToolMatch, error)) *MockToolStorage_MatchTools_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveTool provides a mock function for the type MockToolStorage
 func (_mock *MockToolStorage) SaveTool(ctx context.Context, info entities.ToolReadOnly) error {
 	ret := _mock.Called(ctx, info)
@@ -4,6 +4,7 @@ package oauth
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -87,7 +88,7 @@ func (h *Handler) Exchange(
 		"code_verifier", base64.RawURLEncoding.EncodeToString(verifier),
 	))
 	if err != nil {
-		return nil, fmt.Errorf("exchanging token: %w", err)
+		return nil, fmt.Errorf("exchanging token: %w", credentialsError(err))
 	}
 
 	return token, nil
@@ -117,8 +118,19 @@ func (h *Handler) RefreshToken(
 
 	newToken, err := cfg.TokenSource(ctx, token).Token()
 	if err != nil {
-		return nil, fmt.Errorf("refreshing token: %w", err)
+		return nil, fmt.Errorf("refreshing token: %w", credentialsError(err))
 	}
 
 	return newToken, nil
 }
+
+// credentialsError marks errors, which mean that grant is rejected by server,
+// as [oauthhandler.ErrInvalidCredentials].
+func credentialsError(err error) error {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+		return fmt.Errorf("%w: %w", oauthhandler.ErrInvalidCredentials, err)
+	}
+
+	return err
+}
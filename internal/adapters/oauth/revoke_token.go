@@ -0,0 +1,142 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/quenbyako/cynosure/contrib/oauth-openapi/gen/go/oauth"
+	"golang.org/x/oauth2"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/oauthhandler"
+)
+
+// maxRevocationErrorBody limits, how much of error response is read.
+const maxRevocationErrorBody = 4 << 10
+
+// RevokeToken implements [oauthhandler.Port]. Revocation endpoint is taken
+// from authorization server metadata (RFC 8414) of the token endpoint origin.
+func (h *Handler) RevokeToken(
+	ctx context.Context, config *oauth2.Config, token *oauth2.Token,
+	opts ...oauthhandler.RevokeTokenOption,
+) error {
+	switch {
+	case config == nil:
+		return errInternalValidation("config is nil")
+	case token == nil || (token.AccessToken == "" && token.RefreshToken == ""):
+		return errInternalValidation("invalid token")
+	}
+
+	params := oauthhandler.RevokeTokenParams(opts...)
+
+	client := h.externalClient
+	if params.Internal() {
+		client = h.internalClient
+	}
+
+	endpoint, err := revocationEndpoint(ctx, client, config)
+	if err != nil {
+		return err
+	}
+
+	return revoke(ctx, client, endpoint, config, token)
+}
+
+func revocationEndpoint(
+	ctx context.Context, client *http.Client, config *oauth2.Config,
+) (*url.URL, error) {
+	tokenURL, err := url.Parse(config.Endpoint.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing token url: %w", err)
+	}
+
+	//nolint:exhaustruct // only origin is needed
+	issuer := &url.URL{Scheme: tokenURL.Scheme, Host: tokenURL.Host}
+
+	oauthClient, err := oauth.NewClientWithResponses("", oauth.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+
+	resp, err := oauthClient.GetAuthServerMetadataWithResponse(ctx, makeWellKnownEditor(issuer))
+	if err != nil {
+		return nil, fmt.Errorf("fetching auth server metadata: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil ||
+		resp.JSON200.RevocationEndpoint == nil {
+		return nil, oauthhandler.ErrRevocationNotSupported
+	}
+
+	endpoint, err := url.Parse(*resp.JSON200.RevocationEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse revocation endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// revoke sends RFC 7009 revocation request. Revoking refresh token also
+// invalidates access tokens, issued with it.
+func revoke(
+	ctx context.Context,
+	client *http.Client,
+	endpoint *url.URL,
+	config *oauth2.Config,
+	token *oauth2.Token,
+) error {
+	form := url.Values{
+		"token":           {token.RefreshToken},
+		"token_type_hint": {"refresh_token"},
+	}
+	if token.RefreshToken == "" {
+		form.Set("token", token.AccessToken)
+		form.Set("token_type_hint", "access_token")
+	}
+
+	if config.ClientSecret == "" {
+		form.Set("client_id", config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return fmt.Errorf("building revocation request: %w", err)
+	}
+
+	req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+
+	if config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("revoking token: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // body is read already
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return revocationError(resp)
+}
+
+func revocationError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRevocationErrorBody)) //nolint:errcheck // best effort
+
+	var oauthErr OAuthError
+	if err := json.Unmarshal(body, &oauthErr); err == nil && oauthErr.ErrorCode != "" {
+		return fmt.Errorf("revoking token: %w", oauthErr)
+	}
+
+	return errInternalValidation(
+		"unexpected status code %d when revoking token at %s", resp.StatusCode, resp.Request.URL,
+	)
+}
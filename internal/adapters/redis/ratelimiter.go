@@ -13,6 +13,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
 const (
@@ -22,17 +23,19 @@ const (
 // RateLimiter is a Redis-backed implementation of ratelimiter.Port.
 type RateLimiter struct {
 	tracer  ports.ObserveStack
+	rdb     redis.UniversalClient
 	limiter *redis_rate.Limiter
 	now     func() time.Time
-	limit   redis_rate.Limit
 }
 
-var _ ratelimiter.PortFactory = (*RateLimiter)(nil)
+var (
+	_ ratelimiter.PortFactory = (*RateLimiter)(nil)
+	_ ratelimiter.Port        = (*RateLimiter)(nil)
+)
 
 // NewRateLimiter creates a new Redis rate limiter.
 func NewRateLimiter(
 	rdb redis.UniversalClient,
-	limit, burst int,
 	now func() time.Time,
 	tracer core.Metrics,
 ) *RateLimiter {
@@ -46,41 +49,188 @@ func NewRateLimiter(
 	}
 
 	return &RateLimiter{
+		rdb:     rdb,
 		limiter: redis_rate.NewLimiter(rdb),
-		limit: redis_rate.Limit{
-			Rate:   limit,
-			Burst:  burst,
-			Period: time.Second,
-		},
-		now:    now,
-		tracer: observability,
+		now:     now,
+		tracer:  observability,
 	}
 }
 
 // RateLimiter returns ratelimiter.PortWrapped interface.
 func (r *RateLimiter) RateLimiter() ratelimiter.PortWrapped { return ratelimiter.Wrap(r, r.tracer) }
 
+// unlimited is reported as remaining amount of unlimited quota.
+const unlimited = -1
+
 // Consume consumes message quota for the given user.
-func (r *RateLimiter) Consume(ctx context.Context, user ids.UserID, count int) error {
-	// For testing purposes: if the mocked clock advances into the future relative to
-	// real time, we actually sleep to let the Redis backend naturally catch up.
-	if r.now != nil {
-		delay := time.Until(r.now())
-		if delay > 0 {
-			time.Sleep(delay)
-		}
+func (r *RateLimiter) Consume(
+	ctx context.Context, user ids.UserID, limit plans.Limit, count int,
+) (ratelimiter.Quota, error) {
+	if limit.Unlimited() {
+		return ratelimiter.Quota{Remaining: unlimited}, nil
+	}
+
+	return r.allow(ctx, "rate:user:"+user.ID().String(), limit, count)
+}
+
+// ConsumeAccount consumes quota of the given account.
+func (r *RateLimiter) ConsumeAccount(
+	ctx context.Context, account ids.AccountID, limit plans.Limit, count int,
+) (ratelimiter.Quota, error) {
+	if limit.Unlimited() {
+		return ratelimiter.Quota{Remaining: unlimited}, nil
+	}
+
+	return r.allow(ctx, "rate:account:"+account.ID().String(), limit, count)
+}
+
+func (r *RateLimiter) allow(
+	ctx context.Context, key string, limit plans.Limit, count int,
+) (ratelimiter.Quota, error) {
+	r.waitClock()
+
+	res, err := r.limiter.AllowN(ctx, key, redisLimit(limit), count)
+	if err != nil {
+		return ratelimiter.Quota{}, fmt.Errorf("redis allow n: %w", err)
+	}
+
+	if res.Allowed == 0 {
+		return ratelimiter.Quota{}, exceeded(res, count, limit)
+	}
+
+	return ratelimiter.Quota{Remaining: res.Remaining}, nil
+}
+
+// Reserve holds tokens from user token quota.
+func (r *RateLimiter) Reserve(
+	ctx context.Context, user ids.UserID, limit plans.Limit, tokens int,
+) (ratelimiter.Reservation, error) {
+	if limit.Unlimited() {
+		return ratelimiter.NewReservation(user, limit, tokens, unlimited), nil
 	}
 
-	key := "rate:user:" + user.ID().String()
+	r.waitClock()
 
-	res, err := r.limiter.AllowN(ctx, key, r.limit, count)
+	res, err := r.limiter.AllowN(ctx, tokensKey(user), redisLimit(limit), tokens)
 	if err != nil {
-		return fmt.Errorf("redis allow n: %w", err)
+		return ratelimiter.Reservation{}, fmt.Errorf("redis allow n: %w", err)
 	}
 
 	if res.Allowed == 0 {
-		return fmt.Errorf("%w for user %q", ratelimiter.ErrRateLimitExceeded, user.ID().String())
+		return ratelimiter.Reservation{}, exceeded(res, tokens, limit)
+	}
+
+	return ratelimiter.NewReservation(user, limit, tokens, res.Remaining), nil
+}
+
+// Commit returns unused tokens or takes overused ones.
+func (r *RateLimiter) Commit(ctx context.Context, reservation ratelimiter.Reservation, used int) error {
+	return r.settle(ctx, reservation, used-reservation.Tokens())
+}
+
+// Refund returns all reserved tokens.
+func (r *RateLimiter) Refund(ctx context.Context, reservation ratelimiter.Reservation) error {
+	return r.settle(ctx, reservation, -reservation.Tokens())
+}
+
+func (r *RateLimiter) settle(ctx context.Context, reservation ratelimiter.Reservation, delta int) error {
+	if reservation.Limit().Unlimited() || delta == 0 {
+		return nil
+	}
+
+	r.waitClock()
+
+	limit := redisLimit(reservation.Limit())
+
+	err := settleTokens.Run(ctx, r.rdb, []string{tokensStateKey(reservation.User())},
+		limit.Rate, limit.Period.Seconds(), delta,
+	).Err()
+	if err != nil {
+		return fmt.Errorf("redis settle tokens: %w", err)
 	}
 
 	return nil
 }
+
+// redisLimit refills whole burst evenly during the period.
+func redisLimit(limit plans.Limit) redis_rate.Limit {
+	return redis_rate.Limit{
+		Rate:   limit.Burst(),
+		Burst:  limit.Burst(),
+		Period: limit.Period(),
+	}
+}
+
+func exceeded(res *redis_rate.Result, requested int, limit plans.Limit) error {
+	err := &ratelimiter.LimitExceededError{
+		Remaining:  res.Remaining,
+		RetryAfter: 0,
+	}
+
+	// request above the burst is never allowed, and redis_rate reports
+	// meaningless retry time for it.
+	if requested <= limit.Burst() {
+		err.RetryAfter = res.RetryAfter
+	}
+
+	return err
+}
+
+// waitClock sleeps, if the mocked clock advances into the future relative to
+// real time, to let the Redis backend naturally catch up. Used for testing
+// purposes.
+func (r *RateLimiter) waitClock() {
+	if r.now != nil {
+		delay := time.Until(r.now())
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+	}
+}
+
+// redisRatePrefix is prepended by redis_rate to every key of the limiter.
+const redisRatePrefix = "rate:"
+
+func tokensKey(user ids.UserID) string { return "rate:tokens:" + user.ID().String() }
+
+// tokensStateKey is a key, where redis_rate keeps state of [tokensKey]
+// limiter: settlement must shift the same state, which reservation took.
+func tokensStateKey(user ids.UserID) string { return redisRatePrefix + tokensKey(user) }
+
+// settleTokens shifts GCRA state of redis_rate limiter by cost without any
+// checks: positive cost takes tokens (possibly in debt), negative returns
+// them. Returned tokens never exceed the burst, as state is never moved
+// before current time.
+var settleTokens = redis.NewScript(`
+redis.replicate_commands()
+
+local rate_limit_key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+
+local emission_interval = period / rate
+local increment = emission_interval * cost
+
+-- same epoch, as redis_rate uses.
+local jan_1_2017 = 1483228800
+local now = redis.call("TIME")
+now = (now[1] - jan_1_2017) + (now[2] / 1000000)
+
+local tat = redis.call("GET", rate_limit_key)
+if not tat then
+  tat = now
+else
+  tat = tonumber(tat)
+end
+
+local new_tat = math.max(math.max(tat, now) + increment, now)
+local reset_after = new_tat - now
+if reset_after > 0 then
+  redis.call("SET", rate_limit_key, new_tat, "EX", math.ceil(reset_after))
+else
+  redis.call("DEL", rate_limit_key)
+end
+
+return 0
+`)
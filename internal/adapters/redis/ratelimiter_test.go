@@ -146,6 +146,6 @@ func setupRedisLimiter(
 			return realStart.Add(elapsedMock)
 		}
 
-		return rr.NewRateLimiter(client, params.Limit, params.Burst, nowFn, nil), nil
+		return rr.NewRateLimiter(client, nowFn, nil), nil
 	}
 }
@@ -7,10 +7,30 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 )
 
+// DeleteAccount soft-deletes account with its tools. OAuth token is removed
+// completely: deleted account must not keep credentials.
 func (a *Accounts) DeleteAccount(ctx context.Context, account ids.AccountID) error {
-	if err := a.q.SoftDeleteAccount(ctx, account.ID()); err != nil {
+	transaction, err := a.tx.BeginTx(ctx, emptyTxOptions)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	//nolint:errcheck // Rollback is called in defer and it makes no sense to check the error
+	defer transaction.Rollback(ctx)
+
+	queries := a.q.WithTx(transaction)
+
+	if err := queries.SoftDeleteAccount(ctx, account.ID()); err != nil {
 		return fmt.Errorf("soft deleting account: %w", err)
 	}
 
+	if err := queries.DeleteAccountToken(ctx, account.ID()); err != nil {
+		return fmt.Errorf("deleting oauth token: %w", err)
+	}
+
+	if err := transaction.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
 	return nil
 }
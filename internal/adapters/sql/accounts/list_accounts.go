@@ -30,3 +30,32 @@ func (a *Accounts) ListAccounts(ctx context.Context, user ids.UserID) ([]ids.Acc
 
 	return result, nil
 }
+
+func (a *Accounts) ListActiveAccounts(ctx context.Context) ([]ids.AccountID, error) {
+	rows, err := a.q.ListActiveAccountIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing active accounts: %w", err)
+	}
+
+	result := make([]ids.AccountID, 0, len(rows))
+	for _, row := range rows {
+		user, err := ids.NewUserID(row.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user id for %s: %w", row.ID, err)
+		}
+
+		serverID, err := ids.NewServerID(row.ServerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server id for %s: %w", row.ID, err)
+		}
+
+		id, err := ids.NewAccountID(user, serverID, row.ID)
+		if err != nil {
+			return nil, fmt.Errorf("constructing account id for %s: %w", row.ID, err)
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
@@ -25,12 +25,14 @@ func (a *Accounts) SaveAccount(ctx context.Context, info entities.AccountReadOnl
 	queries := a.q.WithTx(transaction)
 
 	if err := queries.UpsertAccount(ctx, db.UpsertAccountParams{
-		ID:          info.ID().ID(),
-		UserID:      info.ID().User().ID(),
-		ServerID:    info.ID().Server().ID(),
-		Name:        info.Name(),
-		Description: info.Description(),
-		Embedding:   nil,
+		ID:              info.ID().ID(),
+		UserID:          info.ID().User().ID(),
+		ServerID:        info.ID().Server().ID(),
+		Name:            info.Name(),
+		Description:     info.Description(),
+		Embedding:       nil,
+		Status:          info.Status().String(),
+		SamplingAllowed: info.SamplingAllowed(),
 	}); err != nil {
 		return fmt.Errorf("upserting account: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/url"
 
 	"github.com/exaring/otelpgx"
@@ -13,19 +14,36 @@ import (
 
 	"github.com/quenbyako/cynosure/internal/adapters/sql/accounts"
 	"github.com/quenbyako/cynosure/internal/adapters/sql/agents"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/blobs"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/catalog"
 	"github.com/quenbyako/cynosure/internal/adapters/sql/errors"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/ledger"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/links"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/plans"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/prompts"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/resources"
 	"github.com/quenbyako/cynosure/internal/adapters/sql/servers"
 	"github.com/quenbyako/cynosure/internal/adapters/sql/threads"
 	"github.com/quenbyako/cynosure/internal/adapters/sql/tools"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/usage"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	domaintools "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 type Adapter struct {
 	accounts.Accounts
 	agents.Agents
+	blobs.Blobs
+	catalog.Catalog
+	ledger.Ledger
+	links.Links
+	plans.Plans
+	prompts.Prompts
+	resources.Resources
 	servers.Servers
 	threads.Threads
 	tools.Tools
+	usage.Usage
 
 	pool *pgxpool.Pool
 
@@ -33,16 +51,26 @@ type Adapter struct {
 }
 
 var (
-	_ ports.AccountStorageFactory = (*Adapter)(nil)
-	_ ports.AgentStorageFactory   = (*Adapter)(nil)
-	_ ports.ServerStorageFactory  = (*Adapter)(nil)
-	_ ports.ThreadStorageFactory  = (*Adapter)(nil)
-	_ ports.ToolStorageFactory    = (*Adapter)(nil)
-	_ io.Closer                   = (*Adapter)(nil)
+	_ ports.AccountStorageFactory  = (*Adapter)(nil)
+	_ ports.AgentStorageFactory    = (*Adapter)(nil)
+	_ ports.BlobStorageFactory     = (*Adapter)(nil)
+	_ ports.LedgerStorageFactory   = (*Adapter)(nil)
+	_ ports.LinkStorageFactory     = (*Adapter)(nil)
+	_ ports.PlanStorageFactory     = (*Adapter)(nil)
+	_ ports.PromptStorageFactory   = (*Adapter)(nil)
+	_ ports.ResourceStorageFactory = (*Adapter)(nil)
+	_ ports.ServerCatalogFactory   = (*Adapter)(nil)
+	_ ports.ServerStorageFactory   = (*Adapter)(nil)
+	_ ports.ThreadStorageFactory   = (*Adapter)(nil)
+	_ ports.ToolStorageFactory     = (*Adapter)(nil)
+	_ ports.UsageStorageFactory    = (*Adapter)(nil)
+	_ io.Closer                    = (*Adapter)(nil)
 )
 
 type newParams struct {
-	tracer trace.TracerProvider
+	tracer     trace.TracerProvider
+	logger     slog.Handler
+	schemaOpts []domaintools.SchemaOption
 }
 
 type NewOption func(*newParams)
@@ -51,9 +79,23 @@ func WithTrace(tracerProvider trace.TracerProvider) NewOption {
 	return func(p *newParams) { p.tracer = tracerProvider }
 }
 
+// WithLogger sets logger for rows, which are skipped on read. Logs are
+// discarded by default.
+func WithLogger(logger slog.Handler) NewOption {
+	return func(p *newParams) { p.logger = logger }
+}
+
+// WithSchemaOptions sets normalization of stored tool schemas. It must be the
+// same, as tool client uses for discovery.
+func WithSchemaOptions(opts ...domaintools.SchemaOption) NewOption {
+	return func(p *newParams) { p.schemaOpts = append(p.schemaOpts, opts...) }
+}
+
 func New(ctx context.Context, connString *url.URL, opts ...NewOption) (*Adapter, error) {
 	params := newParams{
-		tracer: noopTrace.NewTracerProvider(),
+		tracer:     noopTrace.NewTracerProvider(),
+		logger:     slog.DiscardHandler,
+		schemaOpts: nil,
 	}
 
 	for _, opt := range opts {
@@ -66,13 +108,21 @@ func New(ctx context.Context, connString *url.URL, opts ...NewOption) (*Adapter,
 	}
 
 	adapter := Adapter{
-		Accounts: accounts.New(pool),
-		Agents:   agents.New(pool),
-		Servers:  servers.New(pool),
-		Threads:  threads.New(pool),
-		Tools:    tools.New(pool),
-		pool:     pool,
-		trace:    params.tracer.Tracer(pkgName),
+		Accounts:  accounts.New(pool),
+		Agents:    agents.New(pool),
+		Blobs:     blobs.New(pool),
+		Catalog:   catalog.New(pool),
+		Ledger:    ledger.New(pool),
+		Links:     links.New(pool),
+		Plans:     plans.New(pool),
+		Prompts:   prompts.New(pool),
+		Resources: resources.New(pool),
+		Servers:   servers.New(pool),
+		Threads:   threads.New(pool),
+		Tools:     tools.New(pool, params.logger, params.schemaOpts...),
+		Usage:     usage.New(pool),
+		pool:      pool,
+		trace:     params.tracer.Tracer(pkgName),
 	}
 
 	if err := adapter.validate(); err != nil {
@@ -134,6 +184,20 @@ func (a *Adapter) AccountStorage() ports.AccountStorage { return a }
 
 func (a *Adapter) AgentStorage() ports.AgentStorage { return a }
 
+func (a *Adapter) BlobStorage() ports.BlobStorage { return a }
+
+func (a *Adapter) LedgerStorage() ports.LedgerStorage { return a }
+
+func (a *Adapter) LinkStorage() ports.LinkStorage { return a }
+
+func (a *Adapter) PlanStorage() ports.PlanStorage { return a }
+
+func (a *Adapter) PromptStorage() ports.PromptStorage { return a }
+
+func (a *Adapter) ResourceStorage() ports.ResourceStorage { return a }
+
+func (a *Adapter) ServerCatalog() ports.ServerCatalog { return a }
+
 func (a *Adapter) ServerStorage() ports.ServerStorage { return a }
 
 func (a *Adapter) ThreadStorage() ports.ThreadStorageWrapped {
@@ -141,3 +205,5 @@ func (a *Adapter) ThreadStorage() ports.ThreadStorageWrapped {
 }
 
 func (a *Adapter) ToolStorage() ports.ToolStorage { return a }
+
+func (a *Adapter) UsageStorage() ports.UsageStorage { return a }
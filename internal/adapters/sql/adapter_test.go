@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/testsuite"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 
 	. "github.com/quenbyako/cynosure/internal/adapters/sql"
 )
@@ -41,6 +42,42 @@ func TestAdapter(t *testing.T) {
 	t.Run("Servers", testsuite.RunServerStorageTests(adapter,
 		testsuite.WithServerStorageCleanup(cleaner(pool)),
 	))
+
+	t.Run("Catalog", testsuite.RunServerCatalogTests(adapter,
+		testsuite.WithServerCatalogCleanup(cleaner(pool)),
+	))
+
+	t.Run("Blobs", testsuite.RunBlobStorageTests(adapter,
+		testsuite.WithBlobStorageThreadSeeder(threadSeeder(pool)),
+		testsuite.WithBlobStorageCleanup(cleaner(pool)),
+	))
+
+	t.Run("Links", testsuite.RunLinkStorageTests(adapter,
+		testsuite.WithLinkStorageThreadSeeder(threadSeeder(pool)),
+		testsuite.WithLinkStorageCleanup(cleaner(pool)),
+	))
+
+	t.Run("Usage", testsuite.RunUsageStorageTests(adapter,
+		testsuite.WithUsageStorageCleanup(cleaner(pool)),
+	))
+
+	t.Run("Ledger", testsuite.RunLedgerStorageTests(adapter,
+		testsuite.WithLedgerStorageCleanup(cleaner(pool)),
+	))
+
+	t.Run("Plans", testsuite.RunPlanStorageTests(adapter,
+		testsuite.WithPlanStorageCleanup(cleaner(pool)),
+	))
+
+	t.Run("Prompts", testsuite.RunPromptStorageTests(adapter,
+		testsuite.WithPromptStorageAccountSeeder(accountSeeder(pool)),
+		testsuite.WithPromptStorageCleanup(cleaner(pool)),
+	))
+
+	t.Run("Resources", testsuite.RunResourceStorageTests(adapter,
+		testsuite.WithResourceStorageAccountSeeder(accountSeeder(pool)),
+		testsuite.WithResourceStorageCleanup(cleaner(pool)),
+	))
 }
 
 func seeder(pool *pgxpool.Pool) testsuite.AccountFixtureBuilder {
@@ -73,13 +110,62 @@ func seeder(pool *pgxpool.Pool) testsuite.AccountFixtureBuilder {
 	}
 }
 
+func accountSeeder(pool *pgxpool.Pool) testsuite.ResourceAccountFixtureBuilder {
+	return func(ctx context.Context, account ids.AccountID, active bool) error {
+		_, err := pool.Exec(ctx, `
+				INSERT INTO agents.mcp_servers (id, url)
+				VALUES ($1, $2)
+				ON CONFLICT DO NOTHING
+			`, account.Server().ID(), "http://"+account.Server().ID().String()+".test-server")
+		if err != nil {
+			return fmt.Errorf("inserting server: %w", err)
+		}
+
+		status := "active"
+		if !active {
+			status = "disabled"
+		}
+
+		_, err = pool.Exec(ctx, `
+				INSERT INTO agents.mcp_accounts (id, user_id, server_id, status, name, description)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, account.ID(), account.User().ID(), account.Server().ID(), status, "test", "test account")
+		if err != nil {
+			return fmt.Errorf("inserting account: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func threadSeeder(pool *pgxpool.Pool) testsuite.ThreadFixtureBuilder {
+	return func(ctx context.Context, thread ids.ThreadID) error {
+		_, err := pool.Exec(ctx, `
+				INSERT INTO agents.threads (id, user_id)
+				VALUES ($1, $2)
+			`, thread.String(), thread.User().ID())
+		if err != nil {
+			return fmt.Errorf("inserting thread: %w", err)
+		}
+
+		return nil
+	}
+}
+
 func cleaner(pool *pgxpool.Pool) func(context.Context) error {
 	return func(ctx context.Context) error {
 		tables := []string{
 			"agents.agent_settings",
 			"agents.mcp_accounts",
+			"agents.mcp_catalog",
+			"agents.mcp_prompts",
+			"agents.mcp_resources",
 			"agents.mcp_servers",
 			"agents.oauth_configs",
+			"agents.threads",
+			"agents.usage_daily",
+			"agents.usage_ledger",
+			"agents.user_plans",
 		}
 
 		for _, table := range tables {
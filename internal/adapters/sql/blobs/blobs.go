@@ -0,0 +1,35 @@
+// Package blobs implements SQL blob storage on top of postgres large objects.
+package blobs
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type conn interface {
+	db.DBTX
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// uniqueViolation is a postgres error code of unique constraint violation.
+const uniqueViolation = "23505"
+
+var emptyTxOptions pgx.TxOptions
+
+type Blobs struct {
+	tx conn
+	q  *db.Queries
+}
+
+var _ ports.BlobStorage = (*Blobs)(nil)
+
+func New(conn conn) Blobs {
+	return Blobs{
+		tx: conn,
+		q:  db.New(conn),
+	}
+}
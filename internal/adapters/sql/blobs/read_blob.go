@@ -0,0 +1,93 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func (b *Blobs) ReadBlob(
+	ctx context.Context, blob ids.BlobID, offset, length int64,
+) ([]byte, int64, error) {
+	transaction, err := b.tx.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, 0, fmt.Errorf("begin tx: %w", err)
+	}
+
+	//nolint:errcheck // read only transaction
+	defer transaction.Rollback(ctx)
+
+	row, err := b.q.WithTx(transaction).GetBlob(ctx, db.GetBlobParams{
+		ID:       blob.ID(),
+		ThreadID: blob.Thread().String(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, ports.ErrNotFound
+	} else if err != nil {
+		return nil, 0, fmt.Errorf("query blob: %w", err)
+	}
+
+	if offset >= row.Size || length <= 0 {
+		return []byte{}, row.Size, nil
+	}
+
+	chunk, err := readObject(ctx, transaction.LargeObjects(), row.ObjectID,
+		offset, min(length, row.Size-offset),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return chunk, row.Size, nil
+}
+
+// ReadAll returns the whole blob inside of the caller's transaction.
+//
+// Throws:
+//
+//   - [ports.ErrNotFound] if blob doesn't exist in the thread of given id.
+func ReadAll(ctx context.Context, transaction pgx.Tx, blob ids.BlobID) ([]byte, error) {
+	row, err := db.New(transaction).GetBlob(ctx, db.GetBlobParams{
+		ID:       blob.ID(),
+		ThreadID: blob.Thread().String(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("query blob: %w", err)
+	}
+
+	return readObject(ctx, transaction.LargeObjects(), row.ObjectID, 0, row.Size)
+}
+
+func readObject(
+	ctx context.Context, objects pgx.LargeObjects, objectID uint32, offset, length int64,
+) ([]byte, error) {
+	object, err := objects.Open(ctx, objectID, pgx.LargeObjectModeRead)
+	if err != nil {
+		return nil, fmt.Errorf("open large object: %w", err)
+	}
+
+	//nolint:errcheck // object is opened only for reading
+	defer object.Close()
+
+	if _, err := object.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek large object: %w", err)
+	}
+
+	chunk := make([]byte, length)
+
+	n, err := io.ReadFull(object, chunk)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("read large object: %w", err)
+	}
+
+	return chunk[:n], nil
+}
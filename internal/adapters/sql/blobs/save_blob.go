@@ -0,0 +1,80 @@
+package blobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func (b *Blobs) SaveBlob(ctx context.Context, blob ids.BlobID, data []byte) error {
+	// large objects could be used only inside of transaction.
+	transaction, err := b.tx.BeginTx(ctx, emptyTxOptions)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	//nolint:errcheck // it makes no sense to check the error in defer
+	defer transaction.Rollback(ctx)
+
+	if err := Write(ctx, transaction, blob, data); err != nil {
+		return err
+	}
+
+	if err := transaction.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// Write stores blob inside of the caller's transaction, so other storages
+// could save blob together with rows, which reference it.
+func Write(ctx context.Context, transaction pgx.Tx, blob ids.BlobID, data []byte) error {
+	objectID, err := writeObject(ctx, transaction.LargeObjects(), data)
+	if err != nil {
+		return err
+	}
+
+	err = db.New(transaction).InsertBlob(ctx, db.InsertBlobParams{
+		ID:       blob.ID(),
+		ThreadID: blob.Thread().String(),
+		ObjectID: objectID,
+		Size:     int64(len(data)),
+	})
+	if pgErr := new(pgconn.PgError); errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ports.ErrAlreadyExists
+	} else if err != nil {
+		return fmt.Errorf("insert blob: %w", err)
+	}
+
+	return nil
+}
+
+func writeObject(ctx context.Context, objects pgx.LargeObjects, data []byte) (uint32, error) {
+	objectID, err := objects.Create(ctx, 0)
+	if err != nil {
+		return 0, fmt.Errorf("create large object: %w", err)
+	}
+
+	object, err := objects.Open(ctx, objectID, pgx.LargeObjectModeWrite)
+	if err != nil {
+		return 0, fmt.Errorf("open large object: %w", err)
+	}
+
+	if _, err := object.Write(data); err != nil {
+		return 0, fmt.Errorf("write large object: %w", err)
+	}
+
+	if err := object.Close(); err != nil {
+		return 0, fmt.Errorf("close large object: %w", err)
+	}
+
+	return objectID, nil
+}
@@ -0,0 +1,22 @@
+// Package catalog implements SQL storage of public MCP server catalog.
+package catalog
+
+import (
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+const embeddingSize = 1536
+
+type Catalog struct {
+	q *db.Queries
+}
+
+var _ ports.ServerCatalog = (*Catalog)(nil)
+
+func New(conn db.DBTX) Catalog {
+	return Catalog{
+		q: db.New(conn),
+	}
+}
@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pgvector/pgvector-go"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+)
+
+//nolint:gocritic // hugeParam is architectural decision in domains
+func (c *Catalog) SaveCatalogEntry(
+	ctx context.Context,
+	entry catalog.Entry,
+	embedding [embeddingSize]float32,
+) error {
+	var vec *pgvector.Vector
+	if embedding != [embeddingSize]float32{} {
+		v := pgvector.NewVector(embedding[:])
+		vec = &v
+	}
+
+	err := c.q.UpsertCatalogEntry(ctx, db.UpsertCatalogEntryParams{
+		Name:        entry.Name(),
+		Title:       entry.Title(),
+		Url:         entry.Link().String(),
+		Description: entry.Description(),
+		AuthType:    entry.AuthType().String(),
+		Categories:  entry.Categories(),
+		Embedding:   vec,
+	})
+	if err != nil {
+		return fmt.Errorf("upsert catalog entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/pgvector/pgvector-go"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+)
+
+func (c *Catalog) SearchCatalog(ctx context.Context, query string, limit int) ([]catalog.Match, error) {
+	rows, err := c.q.SearchCatalog(ctx, db.SearchCatalogParams{
+		Query:      query,
+		LimitCount: int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search catalog: %w", err)
+	}
+
+	matches := make([]catalog.Match, len(rows))
+	for i, row := range rows {
+		entry, err := mapEntry(row.Name, row.Title, row.Url, row.Description, row.AuthType, row.Categories)
+		if err != nil {
+			return nil, err
+		}
+
+		matches[i] = catalog.NewMatch(entry, row.Rank)
+	}
+
+	return matches, nil
+}
+
+//nolint:gocritic // hugeParam is architectural decision in domains
+func (c *Catalog) LookupCatalog(
+	ctx context.Context,
+	embedding [embeddingSize]float32,
+	limit int,
+) ([]catalog.Match, error) {
+	vec := pgvector.NewVector(embedding[:])
+
+	rows, err := c.q.SearchCatalogByEmbedding(ctx, db.SearchCatalogByEmbeddingParams{
+		QueryEmbedding: &vec,
+		LimitCount:     int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search catalog by embedding: %w", err)
+	}
+
+	matches := make([]catalog.Match, len(rows))
+	for i, row := range rows {
+		entry, err := mapEntry(row.Name, row.Title, row.Url, row.Description, row.AuthType, row.Categories)
+		if err != nil {
+			return nil, err
+		}
+
+		matches[i] = catalog.NewMatch(entry, row.Similarity)
+	}
+
+	return matches, nil
+}
+
+func mapEntry(
+	name, title, rawURL, description, rawAuthType string,
+	categories []string,
+) (catalog.Entry, error) {
+	link, err := url.Parse(rawURL)
+	if err != nil {
+		return catalog.Entry{}, fmt.Errorf("invalid url of %q: %w", name, err)
+	}
+
+	authType, err := catalog.ParseAuthType(rawAuthType)
+	if err != nil {
+		return catalog.Entry{}, fmt.Errorf("invalid auth type of %q: %w", name, err)
+	}
+
+	entry, err := catalog.NewEntry(name, link, description,
+		catalog.WithTitle(title),
+		catalog.WithAuthType(authType),
+		catalog.WithCategories(categories...),
+	)
+	if err != nil {
+		return catalog.Entry{}, fmt.Errorf("map catalog entry: %w", err)
+	}
+
+	return entry, nil
+}
@@ -34,6 +34,8 @@ func AccountFromGetAccountRow(row *db.GetAccountRow) (*entities.Account, error)
 		accountID,
 		row.Name,
 		row.Description,
+		row.Status,
+		row.SamplingAllowed,
 		row.AccessToken,
 		row.Type,
 		row.RefreshToken,
@@ -63,6 +65,8 @@ func AccountFromGetAccountsBatchRow(row *db.GetAccountsBatchRow) (*entities.Acco
 		accountID,
 		row.Name,
 		row.Description,
+		row.Status,
+		row.SamplingAllowed,
 		row.AccessToken,
 		row.Type,
 		row.RefreshToken,
@@ -75,6 +79,8 @@ func buildAccount(
 	accountID ids.AccountID,
 	name string,
 	description string,
+	status string,
+	samplingAllowed bool,
 	accessToken *string,
 	tokenType *string,
 	refreshToken *string,
@@ -94,7 +100,17 @@ func buildAccount(
 		}
 	}
 
-	acc, err := entities.NewAccount(accountID, name, description, entities.WithAuthToken(token))
+	accountStatus, err := entities.ParseAccountStatus(status)
+	if err != nil {
+		return nil, fmt.Errorf("parsing account status: %w", err)
+	}
+
+	acc, err := entities.NewAccount(
+		accountID, name, description,
+		entities.WithAuthToken(token),
+		entities.WithAccountStatus(accountStatus),
+		entities.WithSamplingAllowed(samplingAllowed),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("creating account entity: %w", err)
 	}
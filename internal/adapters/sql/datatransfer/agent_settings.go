@@ -26,14 +26,22 @@ func ToDomainAgent(row db.AgentsAgentSetting) (*entities.Agent, error) {
 	// likely to have negative values in database, rather than extremely large.
 	maxContext := uint(max(0, row.MaxContext))
 
+	limits, err := BudgetFromJSON(row.Budget)
+	if err != nil {
+		return nil, err
+	}
+
 	agent, err := entities.NewModelSettings(
 		id,
 		row.Model,
+		entities.WithName(row.Name),
+		entities.WithDisabled(row.Disabled),
 		entities.WithSystemMessage(row.SystemMessage),
 		entities.WithTemperature(row.Temperature),
 		entities.WithTopP(row.TopP),
 		entities.WithStopWords(row.StopWords),
 		entities.WithMaxContext(maxContext),
+		entities.WithBudget(limits),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new model settings: %w", err)
@@ -57,14 +65,22 @@ func ToDBAgentParams(agent entities.AgentReadOnly) (db.UpsertAgentSettingsParams
 		stopWords = []string{}
 	}
 
+	limits, err := BudgetToJSON(agent.Budget())
+	if err != nil {
+		return db.UpsertAgentSettingsParams{}, err
+	}
+
 	return db.UpsertAgentSettingsParams{
 		ID:            agent.ID().ID(),
 		UserID:        agent.ID().UserID().ID(),
+		Name:          agent.Name(),
 		Model:         agent.Model(),
 		SystemMessage: agent.SystemMessage(),
 		Temperature:   max(0, temp),
 		TopP:          max(0, topP),
 		MaxContext:    int32(maxContext),
 		StopWords:     stopWords,
+		Budget:        limits,
+		Disabled:      agent.Disabled(),
 	}, nil
 }
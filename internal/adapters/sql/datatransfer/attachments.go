@@ -0,0 +1,148 @@
+package datatransfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+const (
+	attachmentImage = "image"
+	attachmentAudio = "audio"
+	attachmentVideo = "video"
+	attachmentFile  = "file"
+)
+
+// attachment is a storage format of single media content of tool result.
+type attachment struct {
+	Kind     string `json:"kind"`
+	URL      string `json:"url"`
+	MIMEType string `json:"mime_type"`
+	Name     string `json:"name,omitempty"`
+	// Blob references content of "data:" URL, which is stored as a blob
+	// instead of the row itself. URL is empty then.
+	Blob     string `json:"blob,omitempty"`
+	BlobType string `json:"blob_type,omitempty"`
+}
+
+type (
+	// SaveBlobFunc stores embedded content of the attachment and returns
+	// reference to it.
+	SaveBlobFunc func(data []byte) (uuid.UUID, error)
+	// LoadBlobFunc reads content, saved by [SaveBlobFunc].
+	LoadBlobFunc func(id uuid.UUID) ([]byte, error)
+)
+
+// AttachmentsToJSON converts media content into JSONB array. Content of
+// "data:" URLs is moved to blobs, so rows keep only references.
+func AttachmentsToJSON(items []messages.ChatContent, save SaveBlobFunc) ([]byte, error) {
+	res := make([]attachment, len(items))
+
+	for i, item := range items {
+		mediaType, params := item.Type()
+
+		res[i] = attachment{
+			Kind:     "",
+			URL:      item.URL().String(),
+			MIMEType: mime.FormatMediaType(mediaType, params),
+			Name:     "",
+			Blob:     "",
+			BlobType: "",
+		}
+
+		if dataType, data, ok := messages.DecodeDataURL(item.URL()); ok {
+			blob, err := save(data)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %d: save blob: %w", i, err)
+			}
+
+			res[i].URL, res[i].Blob, res[i].BlobType = "", blob.String(), dataType
+		}
+
+		switch item := item.(type) {
+		case *messages.ChatContentImageURL:
+			res[i].Kind = attachmentImage
+		case *messages.ChatContentAudioURL:
+			res[i].Kind = attachmentAudio
+		case *messages.ChatContentVideoURL:
+			res[i].Kind = attachmentVideo
+		case *messages.ChatContentFileURL:
+			res[i].Kind, res[i].Name = attachmentFile, item.Name()
+		default:
+			return nil, fmt.Errorf("%w: %T", ErrUnsupportedAttachment, item)
+		}
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("marshal attachments: %w", err)
+	}
+
+	return data, nil
+}
+
+// AttachmentsFromJSON restores media content, saved by [AttachmentsToJSON].
+func AttachmentsFromJSON(data []byte, load LoadBlobFunc) ([]messages.ChatContent, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var items []attachment
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("unmarshal attachments: %w", err)
+	}
+
+	res := make([]messages.ChatContent, len(items))
+
+	for i, item := range items {
+		address, err := attachmentURL(item, load)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: %w", i, err)
+		}
+
+		switch item.Kind {
+		case attachmentImage:
+			res[i] = messages.NewChatContentImageURL(*address, item.MIMEType, nil)
+		case attachmentAudio:
+			res[i] = messages.NewChatContentAudioURL(*address, item.MIMEType, nil)
+		case attachmentVideo:
+			res[i] = messages.NewChatContentVideoURL(*address, item.MIMEType, nil)
+		case attachmentFile:
+			res[i] = messages.NewChatContentFileURL(*address, item.Name, item.MIMEType, nil)
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedAttachment, item.Kind)
+		}
+	}
+
+	return res, nil
+}
+
+func attachmentURL(item attachment, load LoadBlobFunc) (*url.URL, error) {
+	if item.Blob == "" {
+		address, err := url.Parse(item.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url: %w", err)
+		}
+
+		return address, nil
+	}
+
+	blob, err := uuid.Parse(item.Blob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob id: %w", err)
+	}
+
+	data, err := load(blob)
+	if err != nil {
+		return nil, fmt.Errorf("load blob: %w", err)
+	}
+
+	address := messages.NewDataURL(item.BlobType, data)
+
+	return &address, nil
+}
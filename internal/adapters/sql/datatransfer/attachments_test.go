@@ -0,0 +1,47 @@
+package datatransfer_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+func TestAttachmentsBlobs(t *testing.T) {
+	t.Parallel()
+
+	image := messages.NewDataURL("image/png", []byte("\x89PNG-pixels"))
+	link, err := url.Parse("https://example.com/report.pdf")
+	require.NoError(t, err)
+
+	items := []messages.ChatContent{
+		messages.NewChatContentImageURL(image, "image/png", nil),
+		messages.NewChatContentFileURL(*link, "report.pdf", "application/pdf", nil),
+	}
+
+	blobs := make(map[uuid.UUID][]byte)
+
+	data, err := datatransfer.AttachmentsToJSON(items, func(data []byte) (uuid.UUID, error) {
+		id := uuid.New()
+		blobs[id] = data
+
+		return id, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, blobs, 1, "only embedded content is moved to blobs")
+	assert.NotContains(t, string(data), image.Opaque)
+	assert.Contains(t, string(data), link.String())
+
+	restored, err := datatransfer.AttachmentsFromJSON(data, func(id uuid.UUID) ([]byte, error) {
+		return blobs[id], nil
+	})
+	require.NoError(t, err)
+	require.Len(t, restored, 2)
+	assert.Equal(t, image.String(), restored[0].URL().String())
+	assert.Equal(t, link.String(), restored[1].URL().String())
+}
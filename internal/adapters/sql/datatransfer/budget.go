@@ -0,0 +1,68 @@
+package datatransfer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+)
+
+// limits is a storage format of agent budget. Missing periods and metrics
+// are not limited.
+type limits struct {
+	Run   limit `json:"run,omitzero"`
+	Day   limit `json:"day,omitzero"`
+	Month limit `json:"month,omitzero"`
+}
+
+type limit struct {
+	InputTokens  uint64 `json:"input_tokens,omitempty"`
+	OutputTokens uint64 `json:"output_tokens,omitempty"`
+	CostMicros   int64  `json:"cost_micros,omitempty"`
+}
+
+func limitToJSON(l budget.Limit) limit {
+	return limit{
+		InputTokens:  l.InputTokens(),
+		OutputTokens: l.OutputTokens(),
+		CostMicros:   int64(l.Cost()),
+	}
+}
+
+func (l limit) toDomain() budget.Limit {
+	return budget.NewLimit(l.InputTokens, l.OutputTokens, budget.Cost(l.CostMicros))
+}
+
+// BudgetToJSON converts agent budget into JSONB object.
+func BudgetToJSON(b budget.Limits) ([]byte, error) {
+	data, err := json.Marshal(limits{
+		Run:   limitToJSON(b.For(budget.PeriodRun)),
+		Day:   limitToJSON(b.For(budget.PeriodDay)),
+		Month: limitToJSON(b.For(budget.PeriodMonth)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal budget: %w", err)
+	}
+
+	return data, nil
+}
+
+// BudgetFromJSON converts JSONB object into agent budget.
+func BudgetFromJSON(data []byte) (budget.Limits, error) {
+	if len(data) == 0 {
+		return budget.Limits{}, nil
+	}
+
+	var res limits
+	if err := json.Unmarshal(data, &res); err != nil {
+		return budget.Limits{}, fmt.Errorf("unmarshal budget: %w", err)
+	}
+
+	return budget.NewLimits(res.Run.toDomain(), res.Day.toDomain(), res.Month.toDomain()), nil
+}
+
+// UsageFromRow converts summed usage. Negative sums are impossible due to
+// table constraints, so they are clamped.
+func UsageFromRow(inputTokens, outputTokens, costMicros int64) budget.Usage {
+	return budget.NewUsage(uint64(max(0, inputTokens)), uint64(max(0, outputTokens)), budget.Cost(costMicros))
+}
@@ -4,4 +4,8 @@ import (
 	"errors"
 )
 
-var ErrMaxContextOverflow = errors.New("max context messages overflowed int32")
+var (
+	ErrMaxContextOverflow    = errors.New("max context messages overflowed int32")
+	ErrUnsupportedAttachment = errors.New("unsupported attachment")
+	ErrUsageOverflow         = errors.New("usage overflowed int64")
+)
@@ -0,0 +1,57 @@
+package datatransfer
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+)
+
+// LedgerEntryToParams converts agent turn record into insert params.
+func LedgerEntryToParams(entry ledger.Entry) (db.AddLedgerEntryParams, error) {
+	stats := entry.Stats()
+	for _, value := range []uint64{stats.InputTokens, stats.OutputTokens, stats.ToolCalls, stats.Errors} {
+		if value > math.MaxInt64 {
+			return db.AddLedgerEntryParams{}, ErrUsageOverflow
+		}
+	}
+
+	return db.AddLedgerEntryParams{
+		UserID:       entry.Agent().UserID().ID(),
+		AgentID:      entry.Agent().ID(),
+		ThreadID:     entry.Thread().ID(),
+		Model:        entry.Model(),
+		InputTokens:  int64(stats.InputTokens),
+		OutputTokens: int64(stats.OutputTokens),
+		DurationMs:   stats.Duration.Milliseconds(),
+		ToolCalls:    int64(stats.ToolCalls),
+		Errors:       int64(stats.Errors),
+		CreatedAt: pgtype.Timestamptz{
+			Time:             entry.At(),
+			InfinityModifier: pgtype.Finite,
+			Valid:            true,
+		},
+	}, nil
+}
+
+// LedgerSummaryFromRow converts summed turns of the user. Negative sums are
+// impossible due to table constraints, so they are clamped.
+func LedgerSummaryFromRow(user ids.UserID, row db.ListLedgerSummariesRow) (ledger.Summary, error) {
+	agent, err := ids.NewAgentID(user, row.AgentID)
+	if err != nil {
+		return ledger.Summary{}, fmt.Errorf("invalid agent id: %w", err)
+	}
+
+	return ledger.NewSummary(row.Day.Time, agent, row.Model, uint64(max(0, row.Turns)), ledger.Stats{
+		InputTokens:  uint64(max(0, row.InputTokens)),
+		OutputTokens: uint64(max(0, row.OutputTokens)),
+		Duration:     time.Duration(row.DurationMs) * time.Millisecond,
+		ToolCalls:    uint64(max(0, row.ToolCalls)),
+		Errors:       uint64(max(0, row.Errors)),
+	}), nil
+}
@@ -14,7 +14,9 @@ import (
 
 // ThreadFromRows converts database rows into a domain aggregate.
 // It assumes rows are ordered by position ascending.
-func ThreadFromRows(rows []db.GetThreadWithMessagesRow) (*entities.Thread, error) {
+func ThreadFromRows(
+	rows []db.GetThreadWithMessagesRow, load LoadBlobFunc,
+) (*entities.Thread, error) {
 	if len(rows) == 0 {
 		return nil, errors.ErrEmptyResultSet
 	}
@@ -24,12 +26,17 @@ func ThreadFromRows(rows []db.GetThreadWithMessagesRow) (*entities.Thread, error
 		return nil, fmt.Errorf("invalid thread id: %w", err)
 	}
 
-	msgs, err := mapThreadMessages(rows)
+	msgs, err := mapThreadMessages(rows, load)
 	if err != nil {
 		return nil, err
 	}
 
-	thread, err := entities.NewThread(threadID, msgs)
+	var opts []entities.ThreadOption
+	if rows[0].Paused {
+		opts = append(opts, entities.WithPaused())
+	}
+
+	thread, err := entities.NewThread(threadID, msgs, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("new thread: %w", err)
 	}
@@ -37,7 +44,9 @@ func ThreadFromRows(rows []db.GetThreadWithMessagesRow) (*entities.Thread, error
 	return thread, nil
 }
 
-func mapThreadMessages(rows []db.GetThreadWithMessagesRow) ([]messages.Message, error) {
+func mapThreadMessages(
+	rows []db.GetThreadWithMessagesRow, load LoadBlobFunc,
+) ([]messages.Message, error) {
 	msgs := make([]messages.Message, 0, len(rows))
 	for i := range rows {
 		row := &rows[i]
@@ -45,7 +54,7 @@ func mapThreadMessages(rows []db.GetThreadWithMessagesRow) ([]messages.Message,
 			continue
 		}
 
-		m, err := messageFromRow(row)
+		m, err := messageFromRow(row, load)
 		if err != nil {
 			return nil, fmt.Errorf("mapping message index %d (pos %d): %w", i, *row.Position, err)
 		}
@@ -60,7 +69,9 @@ func mapThreadMessages(rows []db.GetThreadWithMessagesRow) ([]messages.Message,
 	return msgs, nil
 }
 
-func messageFromRow(row *db.GetThreadWithMessagesRow) (messages.Message, error) {
+func messageFromRow(
+	row *db.GetThreadWithMessagesRow, load LoadBlobFunc,
+) (messages.Message, error) {
 	if row.MsgType == nil {
 		return nil, errors.ErrMessageTypeNil
 	}
@@ -79,7 +90,7 @@ func messageFromRow(row *db.GetThreadWithMessagesRow) (messages.Message, error)
 	case "tool_request":
 		return mapToolRequest(row, mergeTag)
 	case "tool_result":
-		return mapToolResult(row, mergeTag)
+		return mapToolResult(row, mergeTag, load)
 	default:
 		return nil, fmt.Errorf("%w: %s", errors.ErrMessageTypeUnknown, *row.MsgType)
 	}
@@ -152,7 +163,9 @@ func mapToolRequest(row *db.GetThreadWithMessagesRow, mergeTag uint64) (messages
 	return msg, nil
 }
 
-func mapToolResult(row *db.GetThreadWithMessagesRow, mergeTag uint64) (messages.Message, error) {
+func mapToolResult(
+	row *db.GetThreadWithMessagesRow, mergeTag uint64, load LoadBlobFunc,
+) (messages.Message, error) {
 	if row.ResultContent == nil || row.ResultToolCallID == nil || row.ResultToolName == nil {
 		return nil, errors.ErrToolResultContentMissing // combine checks to shorten
 	}
@@ -171,9 +184,15 @@ func mapToolResult(row *db.GetThreadWithMessagesRow, mergeTag uint64) (messages.
 		return msg, nil
 	}
 
+	attachments, err := AttachmentsFromJSON(row.ResultAttachments, load)
+	if err != nil {
+		return nil, err
+	}
+
 	msg, err := messages.NewMessageToolResponse(
 		content, *row.ResultToolName, *row.ResultToolCallID,
 		messages.WithMessageToolResponseMergeTag(mergeTag),
+		messages.WithMessageToolResponseAttachments(attachments...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new tool response: %w", err)
@@ -3,6 +3,8 @@ package errors
 
 import (
 	"errors"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 )
 
 var (
@@ -40,7 +42,7 @@ var (
 	ErrOAuthTokenURLEmpty = errors.New("invalid oauth config: token URL is empty")
 
 	// ErrConcurrentModification is returned when a concurrent modification is detected.
-	ErrConcurrentModification = errors.New("concurrent modification")
+	ErrConcurrentModification = ports.ErrConcurrentModification
 
 	// ErrPoolNil is returned when pgxpool is nil.
 	ErrPoolNil = errors.New("pool is nil")
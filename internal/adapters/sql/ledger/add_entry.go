@@ -0,0 +1,22 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+)
+
+func (l *Ledger) AddEntry(ctx context.Context, entry ledger.Entry) error {
+	params, err := datatransfer.LedgerEntryToParams(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := l.q.AddLedgerEntry(ctx, params); err != nil {
+		return fmt.Errorf("add ledger entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+// Package ledger implements SQL storage of agent turns records.
+package ledger
+
+import (
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type Ledger struct {
+	q *db.Queries
+}
+
+var _ ports.LedgerStorage = (*Ledger)(nil)
+
+func New(conn db.DBTX) Ledger {
+	return Ledger{
+		q: db.New(conn),
+	}
+}
@@ -0,0 +1,36 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+)
+
+func (l *Ledger) ListSummaries(
+	ctx context.Context, user ids.UserID, since, until time.Time,
+) ([]ledger.Summary, error) {
+	rows, err := l.q.ListLedgerSummaries(ctx, db.ListLedgerSummariesParams{
+		UserID: user.ID(),
+		Since:  pgtype.Timestamptz{Time: since, InfinityModifier: pgtype.Finite, Valid: true},
+		Until:  pgtype.Timestamptz{Time: until, InfinityModifier: pgtype.Finite, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query ledger summaries: %w", err)
+	}
+
+	res := make([]ledger.Summary, len(rows))
+	for i, row := range rows {
+		if res[i], err = datatransfer.LedgerSummaryFromRow(user, row); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
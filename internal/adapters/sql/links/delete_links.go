@@ -0,0 +1,22 @@
+package links
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (l *Links) DeleteLinksBefore(ctx context.Context, before time.Time) error {
+	err := l.q.DeleteLinksBefore(ctx, pgtype.Timestamptz{
+		Time:             before,
+		InfinityModifier: pgtype.Finite,
+		Valid:            true,
+	})
+	if err != nil {
+		return fmt.Errorf("delete links: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package links
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func (l *Links) GetLink(ctx context.Context, link ids.LinkID) (*url.URL, error) {
+	raw, err := l.q.GetLink(ctx, link.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ports.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("query link: %w", err)
+	}
+
+	target, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse link url: %w", err)
+	}
+
+	return target, nil
+}
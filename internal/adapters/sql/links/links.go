@@ -0,0 +1,20 @@
+// Package links implements SQL storage of link references.
+package links
+
+import (
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type Links struct {
+	q *db.Queries
+}
+
+var _ ports.LinkStorage = (*Links)(nil)
+
+func New(conn db.DBTX) Links {
+	return Links{
+		q: db.New(conn),
+	}
+}
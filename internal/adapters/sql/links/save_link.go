@@ -0,0 +1,39 @@
+package links
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// uniqueViolation is a postgres error code of unique constraint violation.
+const uniqueViolation = "23505"
+
+func (l *Links) SaveLink(
+	ctx context.Context, link ids.LinkID, thread ids.ThreadID, target *url.URL,
+) (ids.LinkID, error) {
+	token, err := l.q.UpsertLink(ctx, db.UpsertLinkParams{
+		Token:    link.String(),
+		ThreadID: thread.String(),
+		Url:      target.String(),
+	})
+	if pgErr := new(pgconn.PgError); errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ids.LinkID{}, ports.ErrAlreadyExists
+	} else if err != nil {
+		return ids.LinkID{}, fmt.Errorf("upsert link: %w", err)
+	}
+
+	saved, err := ids.NewLinkID(token)
+	if err != nil {
+		return ids.LinkID{}, fmt.Errorf("parse saved token: %w", err)
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,20 @@
+// Package plans implements SQL storage of user rate limit plans.
+package plans
+
+import (
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type Plans struct {
+	q *db.Queries
+}
+
+var _ ports.PlanStorage = (*Plans)(nil)
+
+func New(conn db.DBTX) Plans {
+	return Plans{
+		q: db.New(conn),
+	}
+}
@@ -0,0 +1,38 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func (p *Plans) UserPlan(ctx context.Context, user ids.UserID) (string, error) {
+	plan, err := p.q.GetUserPlan(ctx, user.ID())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ports.ErrNotFound
+		}
+
+		return "", fmt.Errorf("query user plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+func (p *Plans) SetUserPlan(ctx context.Context, user ids.UserID, plan string) error {
+	err := p.q.SetUserPlan(ctx, db.SetUserPlanParams{
+		UserID: user.ID(),
+		Plan:   plan,
+	})
+	if err != nil {
+		return fmt.Errorf("set user plan: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+func (p *Prompts) ListPrompts(ctx context.Context, user ids.UserID) ([]prompts.Prompt, error) {
+	rows, err := p.q.ListUserPrompts(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("list prompts: %w", err)
+	}
+
+	res := make([]prompts.Prompt, 0, len(rows))
+	for i := range rows {
+		prompt, err := mapPrompt(user, &rows[i])
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, prompt)
+	}
+
+	return res, nil
+}
+
+func mapPrompt(user ids.UserID, row *db.ListUserPromptsRow) (prompts.Prompt, error) {
+	server, err := ids.NewServerID(row.ServerID)
+	if err != nil {
+		return prompts.Prompt{}, fmt.Errorf("parse server id: %w", err)
+	}
+
+	account, err := ids.NewAccountID(user, server, row.AccountID)
+	if err != nil {
+		return prompts.Prompt{}, fmt.Errorf("parse account id: %w", err)
+	}
+
+	var stored []argument
+	if err := json.Unmarshal(row.Arguments, &stored); err != nil {
+		return prompts.Prompt{}, fmt.Errorf("parse arguments of %q: %w", row.Name, err)
+	}
+
+	args := make([]prompts.Argument, 0, len(stored))
+	for _, raw := range stored {
+		arg, err := prompts.NewArgument(raw.Name, raw.Description, raw.Required)
+		if err != nil {
+			return prompts.Prompt{}, fmt.Errorf("map argument of %q: %w", row.Name, err)
+		}
+
+		args = append(args, arg)
+	}
+
+	prompt, err := prompts.New(account, row.Name,
+		prompts.WithTitle(row.Title),
+		prompts.WithDescription(row.Description),
+		prompts.WithArguments(args...),
+	)
+	if err != nil {
+		return prompts.Prompt{}, fmt.Errorf("map prompt %q: %w", row.Name, err)
+	}
+
+	return prompt, nil
+}
@@ -0,0 +1,39 @@
+// Package prompts implements SQL storage of MCP prompts metadata.
+package prompts
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type conn interface {
+	db.DBTX
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+var emptyTxOptions pgx.TxOptions
+
+type Prompts struct {
+	tx conn
+	q  *db.Queries
+}
+
+var _ ports.PromptStorage = (*Prompts)(nil)
+
+func New(conn conn) Prompts {
+	return Prompts{
+		tx: conn,
+		q:  db.New(conn),
+	}
+}
+
+// argument is stored representation of prompt argument.
+type argument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
@@ -0,0 +1,82 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+func (p *Prompts) SavePrompts(
+	ctx context.Context, account ids.AccountID, list []prompts.Prompt,
+) error {
+	keep := make([]string, 0, len(list))
+	for _, prompt := range list {
+		if prompt.Account() != account {
+			return ports.ErrInternal("prompt " + prompt.Name() + " belongs to another account")
+		}
+
+		keep = append(keep, prompt.Name())
+	}
+
+	transaction, err := p.tx.BeginTx(ctx, emptyTxOptions)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	//nolint:errcheck // it makes no sense to check the error in defer
+	defer transaction.Rollback(ctx)
+
+	q := p.q.WithTx(transaction)
+
+	err = q.DeletePromptsExcept(ctx, db.DeletePromptsExceptParams{
+		AccountID: account.ID(),
+		KeepNames: keep,
+	})
+	if err != nil {
+		return fmt.Errorf("delete stale prompts: %w", err)
+	}
+
+	for _, prompt := range list {
+		args, err := encodeArguments(prompt.Arguments())
+		if err != nil {
+			return fmt.Errorf("encode arguments of %q: %w", prompt.Name(), err)
+		}
+
+		err = q.UpsertPrompt(ctx, db.UpsertPromptParams{
+			AccountID:   account.ID(),
+			Name:        prompt.Name(),
+			Title:       prompt.Title(),
+			Description: prompt.Description(),
+			Arguments:   args,
+		})
+		if err != nil {
+			return fmt.Errorf("upsert prompt %q: %w", prompt.Name(), err)
+		}
+	}
+
+	if err := transaction.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func encodeArguments(args []prompts.Argument) ([]byte, error) {
+	stored := make([]argument, 0, len(args))
+	for _, arg := range args {
+		stored = append(stored, argument{
+			Name:        arg.Name(),
+			Description: arg.Description(),
+			Required:    arg.Required(),
+		})
+	}
+
+	//nolint:wrapcheck // caller wraps the error
+	return json.Marshal(stored)
+}
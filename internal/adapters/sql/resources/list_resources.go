@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+)
+
+func (r *Resources) ListResources(ctx context.Context, user ids.UserID) ([]resources.Resource, error) {
+	rows, err := r.q.ListUserResources(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("list resources: %w", err)
+	}
+
+	res := make([]resources.Resource, 0, len(rows))
+	for i := range rows {
+		resource, err := mapResource(user, &rows[i])
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, resource)
+	}
+
+	return res, nil
+}
+
+func mapResource(user ids.UserID, row *db.ListUserResourcesRow) (resources.Resource, error) {
+	server, err := ids.NewServerID(row.ServerID)
+	if err != nil {
+		return resources.Resource{}, fmt.Errorf("parse server id: %w", err)
+	}
+
+	account, err := ids.NewAccountID(user, server, row.AccountID)
+	if err != nil {
+		return resources.Resource{}, fmt.Errorf("parse account id: %w", err)
+	}
+
+	res, err := resources.New(account, row.Uri, row.Name,
+		resources.WithTitle(row.Title),
+		resources.WithDescription(row.Description),
+		resources.WithMIMEType(row.MimeType),
+		resources.WithSize(row.Size),
+	)
+	if err != nil {
+		return resources.Resource{}, fmt.Errorf("map resource %q: %w", row.Uri, err)
+	}
+
+	return res, nil
+}
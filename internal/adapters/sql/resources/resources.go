@@ -0,0 +1,32 @@
+// Package resources implements SQL storage of MCP resources metadata.
+package resources
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type conn interface {
+	db.DBTX
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+var emptyTxOptions pgx.TxOptions
+
+type Resources struct {
+	tx conn
+	q  *db.Queries
+}
+
+var _ ports.ResourceStorage = (*Resources)(nil)
+
+func New(conn conn) Resources {
+	return Resources{
+		tx: conn,
+		q:  db.New(conn),
+	}
+}
@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+)
+
+func (r *Resources) SaveResources(
+	ctx context.Context, account ids.AccountID, list []resources.Resource,
+) error {
+	keep := make([]string, 0, len(list))
+	for _, res := range list {
+		if res.Account() != account {
+			return ports.ErrInternal("resource " + res.URI() + " belongs to another account")
+		}
+
+		keep = append(keep, res.URI())
+	}
+
+	transaction, err := r.tx.BeginTx(ctx, emptyTxOptions)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	//nolint:errcheck // it makes no sense to check the error in defer
+	defer transaction.Rollback(ctx)
+
+	q := r.q.WithTx(transaction)
+
+	err = q.DeleteResourcesExcept(ctx, db.DeleteResourcesExceptParams{
+		AccountID: account.ID(),
+		KeepUris:  keep,
+	})
+	if err != nil {
+		return fmt.Errorf("delete stale resources: %w", err)
+	}
+
+	for _, res := range list {
+		err := q.UpsertResource(ctx, db.UpsertResourceParams{
+			AccountID:   account.ID(),
+			Uri:         res.URI(),
+			Name:        res.Name(),
+			Title:       res.Title(),
+			Description: res.Description(),
+			MimeType:    res.MIMEType(),
+			Size:        res.Size(),
+		})
+		if err != nil {
+			return fmt.Errorf("upsert resource %q: %w", res.URI(), err)
+		}
+	}
+
+	if err := transaction.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
@@ -31,7 +32,7 @@ func (t *Threads) CreateThread(ctx context.Context, thread entities.ThreadReadOn
 		return fmt.Errorf("create thread: %w", err)
 	}
 
-	if err := t.insertMessages(ctx, qtx, id.String(), thread.Messages(0)); err != nil {
+	if err := t.insertMessages(ctx, transaction, qtx, id.String(), thread.Messages(0)); err != nil {
 		return err
 	}
 
@@ -43,11 +44,12 @@ func (t *Threads) CreateThread(ctx context.Context, thread entities.ThreadReadOn
 }
 
 func (t *Threads) insertMessages(
-	ctx context.Context, qtx *db.Queries, threadID string, msgs []messages.Message,
+	ctx context.Context, transaction pgx.Tx, qtx *db.Queries,
+	threadID string, msgs []messages.Message,
 ) error {
 	for i, msg := range msgs {
 		pos := int64(i + 1)
-		if err := t.insertMessage(ctx, qtx, threadID, pos, msg); err != nil {
+		if err := t.insertMessage(ctx, transaction, qtx, threadID, pos, msg); err != nil {
 			return fmt.Errorf("insert message %d: %w", i, err)
 		}
 	}
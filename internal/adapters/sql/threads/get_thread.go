@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/quenbyako/cynosure/internal/adapters/sql/blobs"
 	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
@@ -11,7 +15,16 @@ import (
 )
 
 func (t *Threads) GetThread(ctx context.Context, id ids.ThreadID) (*entities.Thread, error) {
-	rows, err := t.q.GetThreadWithMessages(ctx, id.String())
+	// attachments are read as large objects, which require transaction.
+	transaction, err := t.tx.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+
+	//nolint:errcheck // read only transaction
+	defer transaction.Rollback(ctx)
+
+	rows, err := t.q.WithTx(transaction).GetThreadWithMessages(ctx, id.String())
 	if err != nil {
 		return nil, fmt.Errorf("query thread: %w", err)
 	}
@@ -20,7 +33,14 @@ func (t *Threads) GetThread(ctx context.Context, id ids.ThreadID) (*entities.Thr
 		return nil, ports.ErrNotFound
 	}
 
-	thread, err := datatransfer.ThreadFromRows(rows)
+	thread, err := datatransfer.ThreadFromRows(rows, func(blob uuid.UUID) ([]byte, error) {
+		blobID, err := ids.NewBlobID(id, blob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob id: %w", err)
+		}
+
+		return blobs.ReadAll(ctx, transaction, blobID)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("map thread: %w", err)
 	}
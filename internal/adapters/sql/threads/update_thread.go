@@ -6,12 +6,16 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
 
+	"github.com/quenbyako/cynosure/internal/adapters/sql/blobs"
+	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
 	errors1 "github.com/quenbyako/cynosure/internal/adapters/sql/errors"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
 )
 
@@ -32,7 +36,7 @@ func (t *Threads) UpdateThread(ctx context.Context, thread entities.ThreadReadOn
 	defer transaction.Rollback(ctx)
 
 	qtx := t.q.WithTx(transaction)
-	if err := t.processPendingEvents(ctx, qtx, thread); err != nil {
+	if err := t.processPendingEvents(ctx, transaction, qtx, thread); err != nil {
 		return err
 	}
 
@@ -44,34 +48,67 @@ func (t *Threads) UpdateThread(ctx context.Context, thread entities.ThreadReadOn
 }
 
 func (t *Threads) processPendingEvents(
-	ctx context.Context, qtx *db.Queries, thread entities.ThreadReadOnly,
+	ctx context.Context, transaction pgx.Tx, qtx *db.Queries, thread entities.ThreadReadOnly,
 ) error {
 	pending := thread.PendingEvents()
-	totalMessages := len(thread.Messages(0))
-	startPos := int64(totalMessages - len(pending))
-	currentPos := startPos
+	threadID := thread.ID().String()
+	// only added messages take positions, other events change thread itself.
+	currentPos := int64(len(thread.Messages(0)) - countAddedMessages(pending))
 
 	for _, event := range pending {
-		evt, ok := event.(entities.ThreadEventMessageAdded)
-		if !ok {
-			continue
+		switch evt := event.(type) {
+		case entities.ThreadEventMessageAdded:
+			currentPos++
+
+			err := t.insertMessage(ctx, transaction, qtx, threadID, currentPos, evt.Message())
+			if err != nil {
+				return fmt.Errorf("insert message at pos %d: %w", currentPos, err)
+			}
+		case entities.ThreadEventPaused:
+			if err := t.setPaused(ctx, qtx, threadID, true); err != nil {
+				return err
+			}
+		case entities.ThreadEventResumed:
+			if err := t.setPaused(ctx, qtx, threadID, false); err != nil {
+				return err
+			}
 		}
+	}
+
+	return nil
+}
 
-		currentPos++
+func countAddedMessages(events []entities.ThreadEvent) int {
+	count := 0
 
-		threadID := thread.ID().String()
-		if err := t.insertMessage(ctx, qtx, threadID, currentPos, evt.Message()); err != nil {
-			return fmt.Errorf("insert message at pos %d: %w", currentPos, err)
+	for _, event := range events {
+		if _, ok := event.(entities.ThreadEventMessageAdded); ok {
+			count++
 		}
 	}
 
+	return count
+}
+
+// setPaused changes pause mark of the thread. If mark is already set, thread
+// was paused or resumed by concurrent request, so the whole update is
+// rejected.
+func (t *Threads) setPaused(ctx context.Context, qtx *db.Queries, threadID string, paused bool) error {
+	updated, err := qtx.SetThreadPaused(ctx, db.SetThreadPausedParams{Paused: paused, ID: threadID})
+	if err != nil {
+		return fmt.Errorf("set thread paused: %w", err)
+	} else if updated == 0 {
+		return errors1.ErrConcurrentModification
+	}
+
 	return nil
 }
 
 var emptyUUID = pgtype.UUID{Valid: false, Bytes: [16]byte{}}
 
 func (t *Threads) insertMessage(
-	ctx context.Context, qtx *db.Queries, threadID string, pos int64, msg messages.Message,
+	ctx context.Context, transaction pgx.Tx, qtx *db.Queries,
+	threadID string, pos int64, msg messages.Message,
 ) error {
 	occPos := pos - 1
 	//nolint:gosec // mergeTag is assumed to fit in int64
@@ -86,11 +123,11 @@ func (t *Threads) insertMessage(
 		return t.insertMessageToolRequest(ctx, qtx, threadID, pos, occPos, mergeTag, msg)
 	case messages.MessageToolResponse:
 		return t.insertMessageToolResult(
-			ctx, qtx, threadID, pos, occPos, mergeTag, msg, false,
+			ctx, transaction, qtx, threadID, pos, occPos, mergeTag, msg, false,
 		)
 	case messages.MessageToolError:
 		return t.insertMessageToolResult(
-			ctx, qtx, threadID, pos, occPos, mergeTag, msg, true,
+			ctx, transaction, qtx, threadID, pos, occPos, mergeTag, msg, true,
 		)
 	default:
 		return errors1.ErrMessageTypeUnknown
@@ -161,7 +198,7 @@ func (t *Threads) insertMessageToolRequest(
 }
 
 func (t *Threads) insertMessageToolResult(
-	ctx context.Context, qtx *db.Queries, threadID string,
+	ctx context.Context, transaction pgx.Tx, qtx *db.Queries, threadID string,
 	pos, occPos, mergeTag int64, msg messages.MessageTool, isError bool,
 ) error {
 	reqPos, err := qtx.GetToolRequestPosition(ctx, db.GetToolRequestPositionParams{
@@ -171,10 +208,24 @@ func (t *Threads) insertMessageToolResult(
 		return fmt.Errorf("find tool request pos: %w", err)
 	}
 
+	var attachments []messages.ChatContent
+	if resp, ok := msg.(messages.MessageToolResponse); ok {
+		attachments = resp.Attachments()
+	}
+
+	attachmentsData, err := datatransfer.AttachmentsToJSON(attachments,
+		func(data []byte) (uuid.UUID, error) {
+			return saveAttachmentBlob(ctx, transaction, threadID, data)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("encode attachments: %w", err)
+	}
+
 	_, err = qtx.InsertMessageToolResult(ctx, db.InsertMessageToolResultParams{
 		RequestPosition: reqPos, ToolCallID: msg.ToolCallID(), IsError: isError,
-		Content: []byte(msg.Content()), Position: pos, ThreadID: threadID,
-		CurrentLastMessagePos: occPos, MergeTag: mergeTag,
+		Content: []byte(msg.Content()), Attachments: attachmentsData, Position: pos,
+		ThreadID: threadID, CurrentLastMessagePos: occPos, MergeTag: mergeTag,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -186,3 +237,25 @@ func (t *Threads) insertMessageToolResult(
 
 	return nil
 }
+
+// saveAttachmentBlob moves embedded content of the attachment to thread
+// blobs: inline base64 would bloat every read of the thread history.
+func saveAttachmentBlob(
+	ctx context.Context, transaction pgx.Tx, threadID string, data []byte,
+) (uuid.UUID, error) {
+	thread, err := ids.NewThreadIDFromString(threadID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid thread id: %w", err)
+	}
+
+	blob, err := ids.RandomBlobID(thread)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("new blob id: %w", err)
+	}
+
+	if err := blobs.Write(ctx, transaction, blob, data); err != nil {
+		return uuid.Nil, err
+	}
+
+	return blob.ID(), nil
+}
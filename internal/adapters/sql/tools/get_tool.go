@@ -30,5 +30,5 @@ func (t *Tools) GetTool(
 		return nil, fmt.Errorf("query tool: %w", err)
 	}
 
-	return mapToolFromGetRow(account, &row)
+	return t.mapToolFromGetRow(account, &row)
 }
@@ -18,8 +18,10 @@ func (t *Tools) ListTools(ctx context.Context, account ids.AccountID) ([]*entiti
 
 	tools := make([]*entities.Tool, 0, len(rows))
 	for i := range rows {
-		tool, err := mapToolFromListRow(account, &rows[i])
-		if err != nil {
+		tool, err := t.mapToolFromListRow(account, &rows[i])
+		if t.skipUnsupported(ctx, rows[i].ID, err) {
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
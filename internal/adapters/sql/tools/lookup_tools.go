@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/google/uuid"
 	"github.com/pgvector/pgvector-go"
@@ -20,14 +21,37 @@ func (t *Tools) LookupTools(
 	user ids.UserID,
 	embedding [embeddingSize]float32,
 	limit int,
-) (foundTools []*entities.Tool, err error) {
+) ([]*entities.Tool, error) {
+	matches, err := t.MatchTools(ctx, user, embedding, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]*entities.Tool, len(matches))
+	for i, match := range matches {
+		tools[i] = match.Tool()
+	}
+
+	return tools, nil
+}
+
+// MatchTools performs semantic search for relevant tools, and returns their
+// similarity to the query.
+//
+//nolint:gocritic // hugeParam is architectural decision in domains
+func (t *Tools) MatchTools(
+	ctx context.Context,
+	user ids.UserID,
+	embedding [embeddingSize]float32,
+	limit int,
+) ([]entities.ToolMatch, error) {
 	accs, err := t.q.ListAccountIDs(ctx, user.ID())
 	if err != nil {
 		return nil, fmt.Errorf("list accounts: %w", err)
 	}
 
 	if len(accs) == 0 {
-		return []*entities.Tool{}, nil
+		return []entities.ToolMatch{}, nil
 	}
 
 	accountIDs, accountMap := mapAccountsForSearch(user, accs)
@@ -42,14 +66,15 @@ func (t *Tools) LookupTools(
 		return nil, fmt.Errorf("search tools: %w", err)
 	}
 
-	return mapToolsFromSearchRows(accountMap, rows)
+	return t.mapMatchesFromSearchRows(ctx, accountMap, rows)
 }
 
-func mapToolsFromSearchRows(
+func (t *Tools) mapMatchesFromSearchRows(
+	ctx context.Context,
 	accMap map[uuid.UUID]ids.AccountID,
 	rows []db.SearchToolsByEmbeddingRow,
-) ([]*entities.Tool, error) {
-	tools := make([]*entities.Tool, 0, len(rows))
+) ([]entities.ToolMatch, error) {
+	matches := make([]entities.ToolMatch, 0, len(rows))
 
 	for i := range rows {
 		row := &rows[i]
@@ -59,15 +84,24 @@ func mapToolsFromSearchRows(
 			continue
 		}
 
-		tool, err := mapToolFromSearchRow(accID, row)
-		if err != nil {
+		tool, err := t.mapToolFromSearchRow(accID, row)
+		if t.skipUnsupported(ctx, row.ID, err) {
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
-		tools = append(tools, tool)
+		// cosine distance to zero vector is undefined, such tools are not
+		// relevant to anything.
+		similarity := row.Similarity
+		if math.IsNaN(similarity) {
+			similarity = 0
+		}
+
+		matches = append(matches, entities.NewToolMatch(tool, similarity))
 	}
 
-	return tools, nil
+	return matches, nil
 }
 
 func mapAccountsForSearch(
@@ -1,17 +1,21 @@
 package tools
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/pgvector/pgvector-go"
 	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
-func mapToolFromSearchRow(
+func (t *Tools) mapToolFromSearchRow(
 	accID ids.AccountID,
 	row *db.SearchToolsByEmbeddingRow,
 ) (*entities.Tool, error) {
@@ -22,14 +26,21 @@ func mapToolFromSearchRow(
 
 	embedding := mapEmbedding(row.Embedding)
 
+	input, output, err := mapSchemas(row.Input, row.Output, t.schemaOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	tool, err := entities.NewTool(
 		toolID,
 		row.AccountName,
 		row.Name,
 		row.Description,
-		row.Input,
-		row.Output,
+		input,
+		output,
 		entities.WithEmbedding(embedding),
+		entities.WithHints(tools.NewHints(row.ReadOnlyHint, row.IdempotentHint)),
+		entities.WithCacheTTL(time.Duration(row.CacheTtlSeconds)*time.Second),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("map tool: %w", err)
@@ -38,7 +49,7 @@ func mapToolFromSearchRow(
 	return tool, nil
 }
 
-func mapToolFromListRow(
+func (t *Tools) mapToolFromListRow(
 	account ids.AccountID,
 	row *db.ListToolsForAccountsRow,
 ) (*entities.Tool, error) {
@@ -49,14 +60,21 @@ func mapToolFromListRow(
 
 	embedding := mapEmbedding(row.Embedding)
 
+	input, output, err := mapSchemas(row.Input, row.Output, t.schemaOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	tool, err := entities.NewTool(
 		id,
 		row.AccountName,
 		row.Name,
 		row.Description,
-		row.Input,
-		row.Output,
+		input,
+		output,
 		entities.WithEmbedding(embedding),
+		entities.WithHints(tools.NewHints(row.ReadOnlyHint, row.IdempotentHint)),
+		entities.WithCacheTTL(time.Duration(row.CacheTtlSeconds)*time.Second),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("map tool: %w", err)
@@ -65,7 +83,10 @@ func mapToolFromListRow(
 	return tool, nil
 }
 
-func mapToolFromGetRow(account ids.AccountID, row *db.GetToolRow) (*entities.Tool, error) {
+func (t *Tools) mapToolFromGetRow(
+	account ids.AccountID,
+	row *db.GetToolRow,
+) (*entities.Tool, error) {
 	id, err := ids.NewToolID(account, row.ID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid tool id: %w", err)
@@ -73,14 +94,21 @@ func mapToolFromGetRow(account ids.AccountID, row *db.GetToolRow) (*entities.Too
 
 	embedding := mapEmbedding(row.Embedding)
 
+	input, output, err := mapSchemas(row.Input, row.Output, t.schemaOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	tool, err := entities.NewTool(
 		id,
 		row.AccountName,
 		row.Name,
 		row.Description,
-		json.RawMessage(row.Input),
-		json.RawMessage(row.Output),
+		input,
+		output,
 		entities.WithEmbedding(embedding),
+		entities.WithHints(tools.NewHints(row.ReadOnlyHint, row.IdempotentHint)),
+		entities.WithCacheTTL(time.Duration(row.CacheTtlSeconds)*time.Second),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new tool: %w", err)
@@ -89,6 +117,35 @@ func mapToolFromGetRow(account ids.AccountID, row *db.GetToolRow) (*entities.Too
 	return tool, nil
 }
 
+// mapSchemas normalizes stored schemas. Rows keep original schemas (older
+// ones keep normalized, normalization of which is idempotent), so changed
+// normalization rules apply to already discovered tools.
+func mapSchemas(input, output []byte, opts []tools.SchemaOption) (in, out tools.Schema, err error) {
+	if in, err = tools.NewInputSchema(input, opts...); err != nil {
+		return tools.Schema{}, tools.Schema{}, fmt.Errorf("invalid input schema: %w", err)
+	}
+
+	if out, err = tools.NewOutputSchema(output, opts...); err != nil {
+		return tools.Schema{}, tools.Schema{}, fmt.Errorf("invalid output schema: %w", err)
+	}
+
+	return in, out, nil
+}
+
+// skipUnsupported reports whether tool should be skipped from the list: its
+// stored schema is not supported by current normalization rules anymore.
+// Such tool is hidden until the next discovery of its server, instead of
+// breaking every other tool of the user.
+func (t *Tools) skipUnsupported(ctx context.Context, id uuid.UUID, err error) bool {
+	if !errors.Is(err, tools.ErrSchemaUnsupported) {
+		return false
+	}
+
+	t.log.WarnContext(ctx, "skipping tool with unsupported schema", "tool.id", id, "error", err)
+
+	return true
+}
+
 func mapEmbedding(vec *pgvector.Vector) [embeddingSize]float32 {
 	var embedding [embeddingSize]float32
 
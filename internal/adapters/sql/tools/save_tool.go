@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pgvector/pgvector-go"
 	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
@@ -17,13 +18,16 @@ func (t *Tools) SaveTool(ctx context.Context, tool entities.ToolReadOnly) error
 	toolID := tool.ID()
 
 	err := t.q.InsertAccountTool(ctx, db.InsertAccountToolParams{
-		ID:          toolID.ID(),
-		AccountID:   toolID.Account().ID(),
-		Name:        tool.Name(),
-		Description: tool.Description(),
-		Input:       tool.InputSchema(),
-		Output:      tool.OutputSchema(),
-		Embedding:   &embedVec,
+		ID:              toolID.ID(),
+		AccountID:       toolID.Account().ID(),
+		Name:            tool.Name(),
+		Description:     tool.Description(),
+		Input:           tool.InputSchema().Original(),
+		Output:          tool.OutputSchema().Original(),
+		Embedding:       &embedVec,
+		ReadOnlyHint:    tool.Hints().ReadOnly(),
+		IdempotentHint:  tool.Hints().Idempotent(),
+		CacheTtlSeconds: int32(tool.CacheTTL() / time.Second),
 	})
 	if err != nil {
 		return fmt.Errorf("upsert tool: %w", err)
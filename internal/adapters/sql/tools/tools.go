@@ -3,11 +3,13 @@ package tools
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/jackc/pgx/v5"
 	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 type conn interface {
@@ -20,15 +22,20 @@ const (
 )
 
 type Tools struct {
-	tx conn
-	q  *db.Queries
+	tx  conn
+	q   *db.Queries
+	log *slog.Logger
+	// schemas are stored as tools declared them, and normalized on read.
+	schemaOpts []tools.SchemaOption
 }
 
 var _ ports.ToolStorage = (*Tools)(nil)
 
-func New(conn conn) Tools {
+func New(conn conn, log slog.Handler, schemaOpts ...tools.SchemaOption) Tools {
 	return Tools{
-		tx: conn,
-		q:  db.New(conn),
+		tx:         conn,
+		q:          db.New(conn),
+		log:        slog.New(log),
+		schemaOpts: schemaOpts,
 	}
 }
@@ -0,0 +1,46 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func (u *Usage) AddUsage(
+	ctx context.Context, agent ids.AgentID, at time.Time, usage budget.Usage,
+) error {
+	if usage.InputTokens() > math.MaxInt64 || usage.OutputTokens() > math.MaxInt64 {
+		return datatransfer.ErrUsageOverflow
+	}
+
+	err := u.q.AddDailyUsage(ctx, db.AddDailyUsageParams{
+		AgentID:      agent.ID(),
+		UserID:       agent.UserID().ID(),
+		Day:          day(at),
+		InputTokens:  int64(usage.InputTokens()),
+		OutputTokens: int64(usage.OutputTokens()),
+		CostMicros:   int64(usage.Cost()),
+	})
+	if err != nil {
+		return fmt.Errorf("add daily usage: %w", err)
+	}
+
+	return nil
+}
+
+// day converts time to the calendar day in UTC, as usage is summed.
+func day(at time.Time) pgtype.Date {
+	return pgtype.Date{
+		Time:             budget.PeriodDay.Start(at),
+		InfinityModifier: pgtype.Finite,
+		Valid:            true,
+	}
+}
@@ -0,0 +1,41 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/adapters/sql/datatransfer"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func (u *Usage) AgentUsage(
+	ctx context.Context, agent ids.AgentID, since time.Time,
+) (budget.Usage, error) {
+	row, err := u.q.GetAgentUsageSince(ctx, db.GetAgentUsageSinceParams{
+		AgentID: agent.ID(),
+		Since:   day(since),
+	})
+	if err != nil {
+		return budget.Usage{}, fmt.Errorf("query agent usage: %w", err)
+	}
+
+	return datatransfer.UsageFromRow(row.InputTokens, row.OutputTokens, row.CostMicros), nil
+}
+
+func (u *Usage) UserUsage(
+	ctx context.Context, user ids.UserID, since time.Time,
+) (budget.Usage, error) {
+	row, err := u.q.GetUserUsageSince(ctx, db.GetUserUsageSinceParams{
+		UserID: user.ID(),
+		Since:  day(since),
+	})
+	if err != nil {
+		return budget.Usage{}, fmt.Errorf("query user usage: %w", err)
+	}
+
+	return datatransfer.UsageFromRow(row.InputTokens, row.OutputTokens, row.CostMicros), nil
+}
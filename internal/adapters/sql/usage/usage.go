@@ -0,0 +1,20 @@
+// Package usage implements SQL storage of agents usage.
+package usage
+
+import (
+	db "github.com/quenbyako/cynosure/contrib/db/gen/go"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+type Usage struct {
+	q *db.Queries
+}
+
+var _ ports.UsageStorage = (*Usage)(nil)
+
+func New(conn db.DBTX) Usage {
+	return Usage{
+		q: db.New(conn),
+	}
+}
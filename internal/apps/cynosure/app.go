@@ -12,6 +12,9 @@ type App struct {
 	telegramTaskRunner func(context.Context) error
 	accountsTaskRunner func(context.Context) error
 	ratelimiterCleanup func(context.Context) error
+	toolCacheCleanup   func(context.Context) error
+	linksCleanup       func(context.Context) error
+	mcpOutboundCleanup func(context.Context) error
 	tokenRefresherRun  func(context.Context) error
 	mcpAdapterClose    func() error
 }
@@ -24,6 +27,9 @@ func (a *App) Run(ctx context.Context) error {
 		a.telegramTaskRunner,
 		a.accountsTaskRunner,
 		a.ratelimiterCleanup,
+		a.toolCacheCleanup,
+		a.linksCleanup,
+		a.mcpOutboundCleanup,
 		a.tokenRefresherRun,
 	); err != nil {
 		errs = append(errs, err)
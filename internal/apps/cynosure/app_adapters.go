@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/quenbyako/cynosure/contrib/core-params/stdio"
+	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"google.golang.org/genai"
 
+	"github.com/quenbyako/cynosure/internal/adapters/filesystem"
 	"github.com/quenbyako/cynosure/internal/adapters/gemini"
 	"github.com/quenbyako/cynosure/internal/adapters/inmemory"
 	"github.com/quenbyako/cynosure/internal/adapters/mcp"
@@ -17,10 +20,15 @@ import (
 	"github.com/quenbyako/cynosure/internal/apps/cynosure/refreshtoken"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/oauthhandler"
+	domainplans "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
 func newSQLAdapter(ctx context.Context, params *appParams) (*sql.Adapter, error) {
-	adapter, err := sql.New(ctx, params.storage.databaseURL, sql.WithTrace(params.observability))
+	adapter, err := sql.New(ctx, params.storage.databaseURL,
+		sql.WithTrace(params.observability),
+		sql.WithLogger(otelslog.NewHandler("sql", otelslog.WithLoggerProvider(params.observability))),
+		sql.WithSchemaOptions(gemini.SchemaOptions()...),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing sql adapter: %w", err)
 	}
@@ -28,6 +36,19 @@ func newSQLAdapter(ctx context.Context, params *appParams) (*sql.Adapter, error)
 	return adapter, nil
 }
 
+func newBlobStorage(params *appParams, db *sql.Adapter) (ports.BlobStorage, error) {
+	if params.storage.blobDir == "" {
+		return db.BlobStorage(), nil
+	}
+
+	storage, err := filesystem.NewBlobStorage(params.storage.blobDir)
+	if err != nil {
+		return nil, fmt.Errorf("initializing blob storage: %w", err)
+	}
+
+	return storage, nil
+}
+
 func newOauthRefresher(
 	accounts ports.AccountStorage,
 	servers ports.ServerStorage,
@@ -46,11 +67,50 @@ func newMCPHandler(
 	params *appParams,
 	refresher *refreshtoken.RefreshConstructor,
 ) (*mcp.Handler, error) {
-	handler, err := mcp.New(ctx, refresher.Token, refresher.Build,
+	outputValidation := mcp.OutputValidationWarn
+	if params.mcpStrictOutput {
+		outputValidation = mcp.OutputValidationStrict
+	}
+
+	opts := []mcp.HandlerOption{
 		mcp.WithObservability(params.observability),
 		mcp.WithInternalHTTPClient(params.internalMcpClient),
 		mcp.WithExternalHTTPClient(params.externalMcpClient),
-	)
+		mcp.WithToolTimeout(params.mcpToolTimeout),
+		mcp.WithOutputValidation(outputValidation),
+		mcp.WithStdioLogger(otelslog.NewHandler("mcp-stdio",
+			otelslog.WithLoggerProvider(params.observability),
+		)),
+		mcp.WithSchemaOptions(gemini.SchemaOptions()...),
+	}
+
+	for _, override := range params.mcpTimeouts {
+		if override.tool == "" {
+			opts = append(opts, mcp.WithServerToolTimeout(override.server, override.timeout))
+		} else {
+			opts = append(opts, mcp.WithSingleToolTimeout(
+				override.server, override.tool, override.timeout,
+			))
+		}
+	}
+
+	for _, server := range params.mcpStdio.Servers() {
+		opts = append(opts, mcp.WithStdioServer(server.Name, newStdioServer(server)))
+	}
+
+	if server := params.mcpOutbound.server; server.Period() > 0 {
+		opts = append(opts, mcp.WithOutboundLimit(server.Limit(), server.Burst()))
+	}
+
+	if account := params.mcpOutbound.account; account.Period() > 0 {
+		opts = append(opts, mcp.WithAccountOutboundLimit(account.Limit(), account.Burst()))
+	}
+
+	if maxWait := params.mcpOutbound.maxWait; maxWait > 0 {
+		opts = append(opts, mcp.WithOutboundPolicy(mcp.OutboundWait, maxWait))
+	}
+
+	handler, err := mcp.New(ctx, refresher.Token, refresher.Build, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("initializing mcp handler: %w", err)
 	}
@@ -58,6 +118,25 @@ func newMCPHandler(
 	return handler, nil
 }
 
+func newStdioServer(server stdio.Server) mcp.StdioServer {
+	return mcp.StdioServer{
+		Command: server.Command,
+		Args:    server.Args,
+		Env:     server.Env,
+		Dir:     server.Dir,
+		Limits: mcp.StdioLimits{
+			Memory:    server.Memory,
+			CPUTime:   server.CPUTime,
+			OpenFiles: server.OpenFiles,
+		},
+		Restart: mcp.StdioRestartPolicy{
+			MaxRestarts: server.MaxRestarts,
+			Backoff:     server.Backoff,
+			Window:      server.Window,
+		},
+	}
+}
+
 func newGeminiModel(
 	ctx context.Context, params *appParams, log gemini.LogCallbacks,
 ) (
@@ -161,15 +240,22 @@ const (
 	ttlPeriodMultiplier = 2
 )
 
-func newRateLimiter(params *appParams) *inmemory.RateLimiter {
-	limit := params.rateLimit.Limit()
-	burst := params.rateLimit.Burst()
+// cached results are checked for expiration on read, cleanup only frees
+// memory.
+const toolCacheCleanupPeriod = time.Minute
 
-	return inmemory.NewRateLimiter(
-		limit,
-		burst,
-		params.rateLimit.Period()*ttlPeriodMultiplier,
-		time.Now,
-		params.observability,
-	)
+func newToolResultCache() *inmemory.ToolResultCache {
+	return inmemory.NewToolResultCache(toolCacheCleanupPeriod, time.Now)
+}
+
+func newRateLimiter(params *appParams, catalog domainplans.Catalog) *inmemory.RateLimiter {
+	// limits of the user are forgotten, when even the slowest one is refilled.
+	var period time.Duration
+
+	for _, name := range catalog.Names() {
+		plan, _ := catalog.Get(name)
+		period = max(period, plan.Messages().Period(), plan.Tokens().Period())
+	}
+
+	return inmemory.NewRateLimiter(period*ttlPeriodMultiplier, time.Now, params.observability)
 }
@@ -6,21 +6,38 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/quenbyako/core"
+	budgetparam "github.com/quenbyako/cynosure/contrib/core-params/budget"
 	"github.com/quenbyako/cynosure/contrib/core-params/ratelimit"
+	"github.com/quenbyako/cynosure/contrib/core-params/stdio"
+	"github.com/quenbyako/cynosure/contrib/core-params/timeouts"
 	"google.golang.org/grpc"
 
 	"github.com/quenbyako/cynosure/internal/adapters/inmemory"
 	"github.com/quenbyako/cynosure/internal/adapters/mcp"
 	"github.com/quenbyako/cynosure/internal/apps/cynosure/refreshtoken"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
 )
 
 const (
 	DefaultSoftLimit = 20
 	DefaultHardCap   = 50
+
+	DefaultMCPToolTimeout = 2 * time.Minute
+	// servers usually notify about changed tools, periodic re-discovery
+	// catches the rest.
+	DefaultMCPRediscoveryInterval = 6 * time.Hour
+	// read-only tools usually return lists, which rarely change during
+	// conversation.
+	DefaultToolCacheTTL = 5 * time.Minute
+	// references stay in thread history, so links are kept long after the
+	// last time, model saw them.
+	DefaultLinkTTL = 30 * 24 * time.Hour
 )
 
 type SecretGetter interface {
@@ -33,6 +50,13 @@ type (
 		gemini             geminiParams
 		internalMcpClient  http.RoundTripper
 		externalMcpClient  http.RoundTripper
+		mcpToolTimeout     time.Duration
+		mcpTimeouts        []mcpTimeoutOverride
+		mcpRediscovery     time.Duration
+		mcpStrictOutput    bool
+		mcpOutbound        mcpOutboundParams
+		mcpSampling        mcpSamplingParams
+		mcpStdio           stdio.Servers
 		observability      core.Metrics
 		grpcAddr           grpc.ServiceRegistrar
 		storage            storageParams
@@ -43,7 +67,14 @@ type (
 		constructionErrors []error
 		chat               chatParams
 		rateLimit          ratelimit.Policy
+		tokenRateLimit     ratelimit.Policy
+		plans              ratelimit.Plans
 		adminMCPID         ids.ServerID
+		// public address of http server, used in link redirects. Optional.
+		linksPublicAddr *url.URL
+		// limit of link redirects from single client address. Empty policy
+		// keeps default limit of the controller.
+		linksRateLimit ratelimit.Policy
 	}
 
 	oryParams struct {
@@ -63,6 +94,8 @@ type (
 		publicAddr *url.URL
 		register   func(http.Handler)
 		apiClient  http.RoundTripper
+		// whom users ask to upgrade their plan, optional.
+		supportContact string
 	}
 
 	geminiParams struct {
@@ -72,6 +105,9 @@ type (
 
 	storageParams struct {
 		databaseURL *url.URL
+		// directory for large tool outputs. If empty, outputs are stored in
+		// the database.
+		blobDir string
 	}
 
 	redisParams struct {
@@ -80,6 +116,17 @@ type (
 	chatParams struct {
 		softLimit uint
 		hardCap   uint
+		// ttl of cached results of read-only tools. Zero disables caching
+		// for them, opted-in tools are still cached.
+		toolCacheTTL time.Duration
+		// links, which were not used for this time, are deleted. Zero keeps
+		// them forever.
+		linkTTL time.Duration
+		// prices of models, used to calculate cost of responses. Models
+		// without price are free.
+		prices budgetparam.Prices
+		// budget of every user. Empty budget is not limited.
+		userBudget budgetparam.Limits
 	}
 )
 
@@ -203,6 +250,11 @@ func WithTelegramClient(client http.RoundTripper) AppOpts {
 	return func(p *appParams) { p.telegram.apiClient = client }
 }
 
+// WithSupportContact sets contact, which users ask to upgrade their plan.
+func WithSupportContact(contact string) AppOpts {
+	return func(p *appParams) { p.telegram.supportContact = contact }
+}
+
 func WithTelegramPublicAddr(addr *url.URL) AppOpts {
 	return func(p *appParams) { p.telegram.publicAddr = addr }
 }
@@ -227,6 +279,45 @@ func WithDatabaseURL(addr *url.URL) AppOpts {
 	return func(p *appParams) { p.storage.databaseURL = addr }
 }
 
+// WithBlobStorageDir stores large tool outputs in the local directory instead
+// of the database.
+func WithBlobStorageDir(dir string) AppOpts {
+	return func(p *appParams) { p.storage.blobDir = dir }
+}
+
+// WithLinksPublicAddr sets public address of http server. If set, links in
+// assistant messages lead through redirect endpoint instead of original urls.
+func WithLinksPublicAddr(addr *url.URL) AppOpts {
+	return func(p *appParams) { p.linksPublicAddr = addr }
+}
+
+// WithLinksRateLimit limits link redirects, requested from single client
+// address.
+func WithLinksRateLimit(limit ratelimit.Policy) AppOpts {
+	return func(p *appParams) { p.linksRateLimit = limit }
+}
+
+// WithToolCacheTTL sets how long results of read-only tools are reused.
+func WithToolCacheTTL(ttl time.Duration) AppOpts {
+	return func(p *appParams) { p.chat.toolCacheTTL = ttl }
+}
+
+// WithLinkTTL sets how long unused link references are kept.
+func WithLinkTTL(ttl time.Duration) AppOpts {
+	return func(p *appParams) { p.chat.linkTTL = ttl }
+}
+
+// WithModelPrices sets prices of models, which are used to calculate cost
+// budgets.
+func WithModelPrices(prices budgetparam.Prices) AppOpts {
+	return func(p *appParams) { p.chat.prices = prices }
+}
+
+// WithUserBudget sets token and cost budgets, applied to every user.
+func WithUserBudget(limits budgetparam.Limits) AppOpts {
+	return func(p *appParams) { p.chat.userBudget = limits }
+}
+
 func WithRedis(addr *url.URL) AppOpts {
 	return func(p *appParams) { p.redis.url = addr }
 }
@@ -235,6 +326,19 @@ func WithRateLimit(limit ratelimit.Policy) AppOpts {
 	return func(p *appParams) { p.rateLimit = limit }
 }
 
+// WithTokenRateLimit limits model tokens, spent by each user. Tokens are
+// reserved before the model call and settled after it.
+func WithTokenRateLimit(limit ratelimit.Policy) AppOpts {
+	return func(p *appParams) { p.tokenRateLimit = limit }
+}
+
+// WithPlans adds rate limit plans, which may be assigned to users. Users
+// without assigned plan use the "default" one, limited by [WithRateLimit] and
+// [WithTokenRateLimit].
+func WithPlans(plans ratelimit.Plans) AppOpts {
+	return func(p *appParams) { p.plans = plans }
+}
+
 func WithChatLimits(softLimit, hardCap uint) AppOpts {
 	return func(p *appParams) {
 		p.chat.softLimit = softLimit
@@ -282,6 +386,82 @@ func WithMCPTransports(internal, external http.RoundTripper) AppOpts {
 	}
 }
 
+// WithMCPToolTimeout sets default deadline for every MCP tool call.
+func WithMCPToolTimeout(timeout time.Duration) AppOpts {
+	return func(p *appParams) { p.mcpToolTimeout = timeout }
+}
+
+// WithMCPToolTimeoutOverrides overrides deadline of MCP tool calls for
+// specific servers, or for single tools of them. Tool override takes
+// precedence over server one.
+func WithMCPToolTimeoutOverrides(overrides timeouts.Overrides) AppOpts {
+	return func(p *appParams) {
+		for _, override := range overrides.Overrides() {
+			server, err := ids.NewServerIDFromString(override.Server)
+			if err != nil {
+				p.constructionErrors = append(p.constructionErrors,
+					fmt.Errorf("invalid server of MCP timeout override: %w", err))
+
+				continue
+			}
+
+			p.mcpTimeouts = append(p.mcpTimeouts, mcpTimeoutOverride{
+				server:  server,
+				tool:    override.Tool,
+				timeout: override.Timeout,
+			})
+		}
+	}
+}
+
+// WithMCPRediscoveryInterval sets, how often tools of every active MCP
+// account are discovered again. Zero disables periodic re-discovery.
+func WithMCPRediscoveryInterval(interval time.Duration) AppOpts {
+	return func(p *appParams) { p.mcpRediscovery = interval }
+}
+
+// WithMCPOutboundLimits limits tool calls, sent to every MCP server and by
+// every account. Empty policy is unlimited.
+func WithMCPOutboundLimits(server, account ratelimit.Policy) AppOpts {
+	return func(p *appParams) {
+		p.mcpOutbound.server, p.mcpOutbound.account = server, account
+	}
+}
+
+// WithMCPOutboundWait makes limited tool calls to wait up to maxWait before
+// failing. Zero fails them immediately, model receives retry delay instead.
+func WithMCPOutboundWait(maxWait time.Duration) AppOpts {
+	return func(p *appParams) { p.mcpOutbound.maxWait = maxWait }
+}
+
+// WithMCPStrictOutput makes structured tool results, which don't match
+// declared output schema, to be returned to the model as tool errors.
+func WithMCPStrictOutput(strict bool) AppOpts {
+	return func(p *appParams) { p.mcpStrictOutput = strict }
+}
+
+// WithMCPSampling allows MCP servers to request completions from agents of
+// their users. Policy is one of "deny", "internal" or "allow", and limit is
+// applied to every account separately. Empty limit is unlimited.
+func WithMCPSampling(policy string, limit ratelimit.Policy) AppOpts {
+	return func(p *appParams) {
+		var err error
+
+		p.mcpSampling.policy, err = sampling.ParsePolicy(policy)
+		if err != nil {
+			p.constructionErrors = append(p.constructionErrors, err)
+		}
+
+		p.mcpSampling.limit = limit
+	}
+}
+
+// WithMCPStdioServers defines local MCP servers, launched as subprocesses.
+// They are available only for internal servers with "stdio://<name>" links.
+func WithMCPStdioServers(servers stdio.Servers) AppOpts {
+	return func(p *appParams) { p.mcpStdio = servers }
+}
+
 func WithAdminMCPID(id string) AppOpts {
 	return func(p *appParams) {
 		var err error
@@ -326,18 +506,56 @@ func defaultParams() appParams {
 		mcpAddr:            nil,
 		constructionErrors: nil,
 		adminMCPID:         ids.ServerID{},
+		linksPublicAddr:    nil,
+		linksRateLimit:     ratelimit.Policy{},
 		rateLimit:          ratelimit.Policy{},
+		tokenRateLimit:     ratelimit.Policy{},
+		plans:              ratelimit.Plans{},
 		internalMcpClient:  nil,
 		externalMcpClient:  nil,
+		mcpToolTimeout:     DefaultMCPToolTimeout,
+		mcpTimeouts:        nil,
+		mcpRediscovery:     DefaultMCPRediscoveryInterval,
+		mcpStrictOutput:    false,
+		mcpOutbound: mcpOutboundParams{
+			server:  ratelimit.Policy{},
+			account: ratelimit.Policy{},
+			maxWait: 0,
+		},
+		mcpSampling: mcpSamplingParams{
+			policy: sampling.PolicyDeny,
+			limit:  ratelimit.Policy{},
+		},
+		mcpStdio: stdio.Servers{},
 	}
 }
 
+// mcpTimeoutOverride is a deadline of every tool of the server, or of single
+// tool, if tool is not empty.
+type mcpTimeoutOverride struct {
+	server  ids.ServerID
+	tool    string
+	timeout time.Duration
+}
+
+type mcpOutboundParams struct {
+	server  ratelimit.Policy
+	account ratelimit.Policy
+	maxWait time.Duration
+}
+
+type mcpSamplingParams struct {
+	policy sampling.Policy
+	limit  ratelimit.Policy
+}
+
 func defaultTelegramParams() telegramParams {
 	return telegramParams{
-		key:        nil,
-		publicAddr: nil,
-		register:   func(h http.Handler) {},
-		apiClient:  http.DefaultTransport,
+		key:            nil,
+		publicAddr:     nil,
+		register:       func(h http.Handler) {},
+		apiClient:      http.DefaultTransport,
+		supportContact: "",
 	}
 }
 
@@ -351,6 +569,7 @@ func defaultGeminiParams() geminiParams {
 func defaultStorageParams() storageParams {
 	return storageParams{
 		databaseURL: nil,
+		blobDir:     "",
 	}
 }
 
@@ -362,8 +581,12 @@ func defaultRedisParams() redisParams {
 
 func defaultChatParams() chatParams {
 	return chatParams{
-		softLimit: DefaultSoftLimit,
-		hardCap:   DefaultHardCap,
+		softLimit:    DefaultSoftLimit,
+		hardCap:      DefaultHardCap,
+		toolCacheTTL: DefaultToolCacheTTL,
+		linkTTL:      DefaultLinkTTL,
+		prices:       budgetparam.Prices{},
+		userBudget:   budgetparam.Limits{},
 	}
 }
 
@@ -385,10 +608,13 @@ func Build(ctx context.Context, opts ...AppOpts) (*App, error) {
 func connectDependencies(
 	params *appParams,
 	ratelimiter *inmemory.RateLimiter,
+	toolCache *inmemory.ToolResultCache,
 	refreshConstructor *refreshtoken.RefreshConstructor,
 	accountsUsecase *accounts.Usecase,
+	chatUsecase *chat.Usecase,
 	_ adminControllerWireBind,
 	_ oauthControllerWireBind,
+	_ linksControllerWireBind,
 	telegramController telegramControllerWireBind,
 	_ mcpControllerWireBind,
 	mcpHandler *mcp.Handler,
@@ -398,6 +624,9 @@ func connectDependencies(
 		accountsTaskRunner: accountsUsecase.Run,
 		tokenRefresherRun:  refreshConstructor.Run,
 		ratelimiterCleanup: ratelimiter.Cleanup,
+		toolCacheCleanup:   toolCache.Cleanup,
+		linksCleanup:       chatUsecase.CleanupLinks,
+		mcpOutboundCleanup: mcpHandler.Cleanup,
 		mcpAdapterClose:    mcpHandler.Close,
 	}, nil
 }
@@ -3,16 +3,22 @@ package cynosure
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	mcpraw "github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 
 	"github.com/quenbyako/cynosure/internal/controllers/admin"
+	"github.com/quenbyako/cynosure/internal/controllers/links"
 	"github.com/quenbyako/cynosure/internal/controllers/mcp"
 	"github.com/quenbyako/cynosure/internal/controllers/oauth"
 	"github.com/quenbyako/cynosure/internal/controllers/telegram"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/agents"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/catalog"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/users"
 	"github.com/quenbyako/cynosure/internal/logs"
 )
@@ -31,33 +37,62 @@ type (
 	telegramControllerWireBind struct {
 		runFunc func(context.Context) error
 	}
-	mcpControllerWireBind struct{}
+	mcpControllerWireBind   struct{}
+	linksControllerWireBind struct{}
 )
 
+// newHTTPMux registers single root handler on http server, which is shared by
+// all http controllers.
+func newHTTPMux(params *appParams) *http.ServeMux {
+	mux := http.NewServeMux()
+	params.httpAddr(mux)
+
+	return mux
+}
+
 func bindAdminController(
 	params *appParams,
 	usecase *accounts.Usecase,
+	usageUsecase *usage.Usecase,
+	plansUsecase *plans.Usecase,
+	catalogUsecase *catalog.Usecase,
 ) adminControllerWireBind {
-	admin.Register(usecase)(params.grpcAddr)
+	admin.Register(usecase, usageUsecase, plansUsecase, catalogUsecase)(params.grpcAddr)
 
 	return adminControllerWireBind{}
 }
 
 func bindOAuthController(
-	params *appParams,
+	mux *http.ServeMux,
 	usecase *accounts.Usecase,
 ) oauthControllerWireBind {
-	params.httpAddr(oauth.NewHandler(usecase))
+	mux.Handle("/", oauth.NewHandler(usecase))
 
 	return oauthControllerWireBind{}
 }
 
+func bindLinksController(
+	params *appParams,
+	mux *http.ServeMux,
+	usecase *chat.Usecase,
+) linksControllerWireBind {
+	var opts []links.NewOption
+	if limit := params.linksRateLimit; limit.Burst() > 0 {
+		opts = append(opts, links.WithRateLimit(limit.Limit(), limit.Burst()))
+	}
+
+	mux.Handle(links.PathPrefix, links.NewHandler(usecase, opts...))
+
+	return linksControllerWireBind{}
+}
+
 func bindTelegramController(
 	ctx context.Context,
 	params *appParams,
 	log *logs.BaseLogger,
 	chatUsecase *chat.Usecase,
 	usersUsecase *users.Usecase,
+	usageUsecase *usage.Usecase,
 ) (telegramControllerWireBind, error) {
 	telegramKey, err := params.telegram.key.Get(ctx)
 	if err != nil {
@@ -73,6 +108,8 @@ func bindTelegramController(
 		telegram.WithLogCallbacks(log),
 		telegram.WithTracer(params.observability),
 		telegram.WithClient(params.telegram.apiClient),
+		telegram.WithUsage(usageUsecase),
+		telegram.WithSupportContact(params.telegram.supportContact),
 	)
 	if err != nil {
 		return telegramControllerWireBind{}, fmt.Errorf("creating telegram controller: %w", err)
@@ -88,9 +125,13 @@ func bindTelegramController(
 func bindMCPController(
 	params *appParams,
 	usecase *accounts.Usecase,
+	agentsUsecase *agents.Usecase,
+	catalogUsecase *catalog.Usecase,
 ) (mcpControllerWireBind, error) {
 	handler, err := mcp.New(
 		usecase,
+		agentsUsecase,
+		catalogUsecase,
 		mcpImpl,
 		mcp.WithLogger(otelslog.NewHandler("mcp",
 			otelslog.WithLoggerProvider(params.observability),
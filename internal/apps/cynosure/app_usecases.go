@@ -3,14 +3,25 @@ package cynosure
 import (
 	"fmt"
 
+	budgetparam "github.com/quenbyako/cynosure/contrib/core-params/budget"
+	"github.com/quenbyako/cynosure/contrib/core-params/ratelimit"
+
+	"github.com/quenbyako/cynosure/internal/controllers/links"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/identitymanager"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/oauthhandler"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	domainplans "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/agents"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/catalog"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/users"
 )
 
@@ -26,7 +37,34 @@ func newChatUsecase(
 	account ports.AccountStorage,
 	models ports.AgentStorage,
 	limiter ratelimiter.PortWrapped,
+	blobs ports.BlobStorage,
+	linkStorage ports.LinkStorage,
+	resources ports.ResourceStorage,
+	promptStorage ports.PromptStorage,
+	toolCache ports.ToolResultCache,
+	usage ports.UsageStorage,
+	ledger ports.LedgerStorage,
+	planStorage ports.PlanStorage,
+	catalog domainplans.Catalog,
 ) (*chat.Usecase, error) {
+	opts := []chat.NewOption{
+		chat.WithObservability(params.observability),
+		chat.WithChatLimit(params.chat.softLimit),
+		chat.WithBlobStorage(blobs),
+		chat.WithLinkStorage(linkStorage, params.chat.linkTTL),
+		chat.WithResourceStorage(resources),
+		chat.WithPromptStorage(promptStorage),
+		chat.WithToolResultCache(toolCache, params.chat.toolCacheTTL),
+		chat.WithBudgets(usage, modelPrices(params.chat.prices), userBudget(params.chat.userBudget)),
+		chat.WithLedger(ledger),
+		chat.WithPlans(planStorage, catalog),
+		chat.WithSampling(params.mcpSampling.policy, planLimit(params.mcpSampling.limit)),
+	}
+
+	if params.linksPublicAddr != nil {
+		opts = append(opts, chat.WithLinkRedirect(params.linksPublicAddr.JoinPath(links.PathPrefix)))
+	}
+
 	usecase, err := chat.New(
 		storage,
 		model,
@@ -37,22 +75,79 @@ func newChatUsecase(
 		account,
 		models,
 		limiter,
-		chat.WithObservability(params.observability),
-		chat.WithChatLimit(params.chat.softLimit),
+		opts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create chat usecase: %w", err)
 	}
 
+	if params.mcpSampling.policy != sampling.PolicyDeny {
+		// handler is used as long as the tool client lives, so it's never
+		// unregistered.
+		tool.HandleSampling(usecase.Sample)
+	}
+
 	return usecase, nil
 }
 
+func modelPrices(prices budgetparam.Prices) budget.PriceTable {
+	table := make(budget.PriceTable, len(prices.Models()))
+	for model, price := range prices.Models() {
+		table[model] = budget.NewPrice(budget.CostFromUSD(price.Input), budget.CostFromUSD(price.Output))
+	}
+
+	return table
+}
+
+func userBudget(limits budgetparam.Limits) budget.Limits {
+	convert := func(l budgetparam.Limit) budget.Limit {
+		return budget.NewLimit(l.InputTokens, l.OutputTokens, budget.CostFromUSD(l.Cost))
+	}
+
+	return budget.NewLimits(convert(limits.Run()), convert(limits.Day()), convert(limits.Month()))
+}
+
+// defaultPlanName is a plan of users without assigned one.
+const defaultPlanName = "default"
+
+func newPlanCatalog(params *appParams) (domainplans.Catalog, error) {
+	fallback, err := domainplans.NewPlan(
+		defaultPlanName, planLimit(params.rateLimit), planLimit(params.tokenRateLimit),
+	)
+	if err != nil {
+		return domainplans.Catalog{}, fmt.Errorf("creating default plan: %w", err)
+	}
+
+	others := make([]domainplans.Plan, 0, len(params.plans.Plans()))
+	for _, raw := range params.plans.Plans() {
+		plan, err := domainplans.NewPlan(raw.Name, planLimit(raw.Messages), planLimit(raw.Tokens))
+		if err != nil {
+			return domainplans.Catalog{}, fmt.Errorf("creating plan %q: %w", raw.Name, err)
+		}
+
+		others = append(others, plan)
+	}
+
+	catalog, err := domainplans.NewCatalog(fallback, others...)
+	if err != nil {
+		return domainplans.Catalog{}, fmt.Errorf("creating plan catalog: %w", err)
+	}
+
+	return catalog, nil
+}
+
+func planLimit(policy ratelimit.Policy) domainplans.Limit {
+	return domainplans.NewLimit(policy.Burst(), policy.Period())
+}
+
 func newAccountsUsecase(
 	params *appParams,
 	servers ports.ServerStorage,
 	oauth oauthhandler.PortWrapped,
 	accountsPort ports.AccountStorage,
 	tools ports.ToolStorage,
+	resources ports.ResourceStorage,
+	promptStorage ports.PromptStorage,
 	index ports.ToolSemanticIndex,
 	toolClient toolclient.PortWrapped,
 	identities identitymanager.PortWrapped,
@@ -66,6 +161,9 @@ func newAccountsUsecase(
 		toolClient,
 		identities,
 		accounts.WithOAuthRedirectURL(params.ory.callback),
+		accounts.WithRediscoveryInterval(params.mcpRediscovery),
+		accounts.WithResourceStorage(resources),
+		accounts.WithPromptStorage(promptStorage),
 		accounts.WithTracerProvider(params.observability),
 	)
 	if err != nil {
@@ -102,3 +200,67 @@ func newUsersUsecase(
 
 	return usecase, nil
 }
+
+func newUsageUsecase(
+	params *appParams,
+	ledger ports.LedgerStorage,
+) (*usage.Usecase, error) {
+	usecase, err := usage.New(
+		ledger,
+		usage.WithTracerProvider(params.observability),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating usage usecase: %w", err)
+	}
+
+	return usecase, nil
+}
+
+func newPlansUsecase(
+	params *appParams,
+	storage ports.PlanStorage,
+	catalog domainplans.Catalog,
+) (*plans.Usecase, error) {
+	usecase, err := plans.New(
+		storage,
+		catalog,
+		plans.WithTracerProvider(params.observability),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating plans usecase: %w", err)
+	}
+
+	return usecase, nil
+}
+
+func newAgentsUsecase(
+	params *appParams,
+	storage ports.AgentStorage,
+) (*agents.Usecase, error) {
+	usecase, err := agents.New(
+		storage,
+		agents.WithTracerProvider(params.observability),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating agents usecase: %w", err)
+	}
+
+	return usecase, nil
+}
+
+func newCatalogUsecase(
+	params *appParams,
+	storage ports.ServerCatalog,
+	index ports.ToolSemanticIndex,
+) (*catalog.Usecase, error) {
+	usecase, err := catalog.New(
+		storage,
+		index,
+		catalog.WithTracerProvider(params.observability),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating catalog usecase: %w", err)
+	}
+
+	return usecase, nil
+}
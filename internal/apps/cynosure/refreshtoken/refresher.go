@@ -3,6 +3,7 @@ package refreshtoken
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -145,7 +146,13 @@ func (c *RefreshConstructor) getToken(
 	}
 
 	newToken, err := c.oauthPort.RefreshToken(ctx, config, token, opts...)
-	if err != nil {
+	if errors.Is(err, oauthhandler.ErrInvalidCredentials) {
+		// grant is rejected: only user can fix it by signing in again.
+		return nil, errors.Join(
+			fmt.Errorf("refreshing token: %w", err),
+			c.requireReauth(ctx, id),
+		)
+	} else if err != nil {
 		return nil, fmt.Errorf("refreshing token: %w", err)
 	}
 
@@ -175,6 +182,23 @@ func (c *RefreshConstructor) updateAccountToken(
 	return nil
 }
 
+func (c *RefreshConstructor) requireReauth(ctx context.Context, id ids.AccountID) error {
+	account, err := c.accounts.GetAccount(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if err := account.RequireReauth(); err != nil {
+		return fmt.Errorf("marking account: %w", err)
+	}
+
+	if err := c.accounts.SaveAccount(ctx, account); err != nil {
+		return fmt.Errorf("saving account: %w", err)
+	}
+
+	return nil
+}
+
 type tokenSource struct {
 	base *RefreshConstructor
 
@@ -32,12 +32,19 @@ var loggerConstructor = wire.NewSet(
 )
 
 var (
-	sqlAdapter = wire.NewSet(newSQLAdapter,
+	sqlAdapter = wire.NewSet(newSQLAdapter, newBlobStorage,
 		wire.Bind(new(ports.AgentStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.LinkStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.LedgerStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.PlanStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.PromptStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.ResourceStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.ServerCatalogFactory), new(*sql.Adapter)),
 		wire.Bind(new(ports.AccountStorageFactory), new(*sql.Adapter)),
 		wire.Bind(new(ports.ServerStorageFactory), new(*sql.Adapter)),
 		wire.Bind(new(ports.ThreadStorageFactory), new(*sql.Adapter)),
 		wire.Bind(new(ports.ToolStorageFactory), new(*sql.Adapter)),
+		wire.Bind(new(ports.UsageStorageFactory), new(*sql.Adapter)),
 	)
 	geminiAdapter = wire.NewSet(newGeminiModel,
 		wire.Bind(new(chatmodel.PortFactory), new(*gemini.GeminiModel)),
@@ -53,20 +60,29 @@ var (
 	oryAdapter     = wire.NewSet(newOryClient,
 		wire.Bind(new(identitymanager.PortFactory), new(*ory.Adapter)),
 	)
-	ratelimiterAdapter = wire.NewSet(newRateLimiter,
+	ratelimiterAdapter = wire.NewSet(newRateLimiter, newPlanCatalog,
 		wire.Bind(new(ratelimiter.PortFactory), new(*inmemory.RateLimiter)),
 	)
+	toolCacheAdapter = wire.NewSet(newToolResultCache,
+		wire.Bind(new(ports.ToolResultCacheFactory), new(*inmemory.ToolResultCache)),
+	)
 )
 
 var (
 	chatUsecase     = wire.NewSet(newChatUsecase)
 	accountsUsecase = wire.NewSet(newAccountsUsecase)
 	usersUsecase    = wire.NewSet(newUsersUsecase)
+	usageUsecase    = wire.NewSet(newUsageUsecase)
+	plansUsecase    = wire.NewSet(newPlansUsecase)
+	agentsUsecase   = wire.NewSet(newAgentsUsecase)
+	catalogUsecase  = wire.NewSet(newCatalogUsecase)
 )
 
 var controllersSet = wire.NewSet(
 	bindAdminController,
+	newHTTPMux,
 	bindOAuthController,
+	bindLinksController,
 	bindTelegramController,
 	bindMCPController,
 )
@@ -89,10 +105,15 @@ func buildApp(ctx context.Context, config *appParams) (*App, error) {
 		oauthAdapter,
 		oryAdapter,
 		ratelimiterAdapter,
+		toolCacheAdapter,
 
 		chatUsecase,
 		accountsUsecase,
 		usersUsecase,
+		usageUsecase,
+		plansUsecase,
+		agentsUsecase,
+		catalogUsecase,
 
 		controllersSet,
 
@@ -29,7 +29,12 @@ import (
 // Injectors from wire.go:
 
 func buildApp(ctx context.Context, config *appParams) (*App, error) {
-	rateLimiter := newRateLimiter(config)
+	catalog, err := newPlanCatalog(config)
+	if err != nil {
+		return nil, err
+	}
+	rateLimiter := newRateLimiter(config, catalog)
+	toolResultCache := newToolResultCache()
 	adapter, err := newSQLAdapter(ctx, config)
 	if err != nil {
 		return nil, err
@@ -40,6 +45,8 @@ func buildApp(ctx context.Context, config *appParams) (*App, error) {
 	portWrapped := oauthhandler.New(handler)
 	refreshConstructor := newOauthRefresher(accountStorage, serverStorage, portWrapped)
 	toolStorage := ports.NewToolStorage(adapter)
+	resourceStorage := ports.NewResourceStorage(adapter)
+	promptStorage := ports.NewPromptStorage(adapter)
 	baseLogger := newLogger(config)
 	geminiModel, err := newGeminiModel(ctx, config, baseLogger)
 	if err != nil {
@@ -56,33 +63,61 @@ func buildApp(ctx context.Context, config *appParams) (*App, error) {
 		return nil, err
 	}
 	identitymanagerPortWrapped := identitymanager.New(adapter2)
-	usecase, err := newAccountsUsecase(config, serverStorage, portWrapped, accountStorage, toolStorage, toolSemanticIndex, toolclientPortWrapped, identitymanagerPortWrapped)
+	usecase, err := newAccountsUsecase(config, serverStorage, portWrapped, accountStorage, toolStorage, resourceStorage, promptStorage, toolSemanticIndex, toolclientPortWrapped, identitymanagerPortWrapped)
+	if err != nil {
+		return nil, err
+	}
+	ledgerStorage := ports.NewLedgerStorage(adapter)
+	usecase2, err := newUsageUsecase(config, ledgerStorage)
+	if err != nil {
+		return nil, err
+	}
+	planStorage := ports.NewPlanStorage(adapter)
+	usecase3, err := newPlansUsecase(config, planStorage, catalog)
 	if err != nil {
 		return nil, err
 	}
-	cynosureAdminControllerWireBind := bindAdminController(config, usecase)
-	cynosureOauthControllerWireBind := bindOAuthController(config, usecase)
+	serverCatalog := ports.NewServerCatalog(adapter)
+	usecase4, err := newCatalogUsecase(config, serverCatalog, toolSemanticIndex)
+	if err != nil {
+		return nil, err
+	}
+	cynosureAdminControllerWireBind := bindAdminController(config, usecase, usecase2, usecase3, usecase4)
+	serveMux := newHTTPMux(config)
+	cynosureOauthControllerWireBind := bindOAuthController(serveMux, usecase)
 	threadStorageWrapped := ports.NewThreadStorage(adapter)
 	chatmodelPortWrapped := chatmodel.New(geminiModel)
 	agentStorage := ports.NewAgentStorage(adapter)
 	ratelimiterPortWrapped := ratelimiter.New(rateLimiter)
-	usecase2, err := newChatUsecase(config, threadStorageWrapped, chatmodelPortWrapped, toolclientPortWrapped, toolSemanticIndex, toolStorage, serverStorage, accountStorage, agentStorage, ratelimiterPortWrapped)
+	blobStorage, err := newBlobStorage(config, adapter)
+	if err != nil {
+		return nil, err
+	}
+	linkStorage := ports.NewLinkStorage(adapter)
+	usageStorage := ports.NewUsageStorage(adapter)
+	portsToolResultCache := ports.NewToolResultCache(toolResultCache)
+	usecase5, err := newChatUsecase(config, threadStorageWrapped, chatmodelPortWrapped, toolclientPortWrapped, toolSemanticIndex, toolStorage, serverStorage, accountStorage, agentStorage, ratelimiterPortWrapped, blobStorage, linkStorage, resourceStorage, promptStorage, portsToolResultCache, usageStorage, ledgerStorage, planStorage, catalog)
+	if err != nil {
+		return nil, err
+	}
+	cynosureLinksControllerWireBind := bindLinksController(config, serveMux, usecase5)
+	usecase6, err := newUsersUsecase(config, identitymanagerPortWrapped, agentStorage, accountStorage, serverStorage, toolStorage, toolclientPortWrapped, toolSemanticIndex)
 	if err != nil {
 		return nil, err
 	}
-	usecase3, err := newUsersUsecase(config, identitymanagerPortWrapped, agentStorage, accountStorage, serverStorage, toolStorage, toolclientPortWrapped, toolSemanticIndex)
+	cynosureTelegramControllerWireBind, err := bindTelegramController(ctx, config, baseLogger, usecase5, usecase6, usecase2)
 	if err != nil {
 		return nil, err
 	}
-	cynosureTelegramControllerWireBind, err := bindTelegramController(ctx, config, baseLogger, usecase2, usecase3)
+	usecase7, err := newAgentsUsecase(config, agentStorage)
 	if err != nil {
 		return nil, err
 	}
-	cynosureMcpControllerWireBind, err := bindMCPController(config, usecase)
+	cynosureMcpControllerWireBind, err := bindMCPController(config, usecase, usecase7, usecase4)
 	if err != nil {
 		return nil, err
 	}
-	app, err := connectDependencies(config, rateLimiter, refreshConstructor, usecase, cynosureAdminControllerWireBind, cynosureOauthControllerWireBind, cynosureTelegramControllerWireBind, cynosureMcpControllerWireBind, mcpHandler)
+	app, err := connectDependencies(config, rateLimiter, toolResultCache, refreshConstructor, usecase, usecase5, cynosureAdminControllerWireBind, cynosureOauthControllerWireBind, cynosureLinksControllerWireBind, cynosureTelegramControllerWireBind, cynosureMcpControllerWireBind, mcpHandler)
 	if err != nil {
 		return nil, err
 	}
@@ -96,24 +131,30 @@ var loggerConstructor = wire.NewSet(
 )
 
 var (
-	sqlAdapter         = wire.NewSet(newSQLAdapter, wire.Bind(new(ports.AgentStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.AccountStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ServerStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ThreadStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ToolStorageFactory), new(*sql.Adapter)))
+	sqlAdapter         = wire.NewSet(newSQLAdapter, newBlobStorage, wire.Bind(new(ports.AgentStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.LinkStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.LedgerStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.PlanStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.PromptStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ResourceStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ServerCatalogFactory), new(*sql.Adapter)), wire.Bind(new(ports.AccountStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ServerStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ThreadStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.ToolStorageFactory), new(*sql.Adapter)), wire.Bind(new(ports.UsageStorageFactory), new(*sql.Adapter)))
 	geminiAdapter      = wire.NewSet(newGeminiModel, wire.Bind(new(chatmodel.PortFactory), new(*gemini.GeminiModel)), wire.Bind(new(ports.ToolSemanticIndexFactory), new(*gemini.GeminiModel)))
 	oauthAdapter       = wire.NewSet(newOAuthHandler, wire.Bind(new(oauthhandler.Factory), new(*oauth.Handler)))
 	mcpAdapter         = wire.NewSet(newMCPHandler, wire.Bind(new(toolclient.PortFactory), new(*mcp.Handler)))
 	oauthRefresher     = wire.NewSet(newOauthRefresher)
 	oryAdapter         = wire.NewSet(newOryClient, wire.Bind(new(identitymanager.PortFactory), new(*ory.Adapter)))
-	ratelimiterAdapter = wire.NewSet(newRateLimiter, wire.Bind(new(ratelimiter.PortFactory), new(*inmemory.RateLimiter)))
+	ratelimiterAdapter = wire.NewSet(newRateLimiter, newPlanCatalog, wire.Bind(new(ratelimiter.PortFactory), new(*inmemory.RateLimiter)))
+	toolCacheAdapter   = wire.NewSet(newToolResultCache, wire.Bind(new(ports.ToolResultCacheFactory), new(*inmemory.ToolResultCache)))
 )
 
 var (
 	chatUsecase     = wire.NewSet(newChatUsecase)
 	accountsUsecase = wire.NewSet(newAccountsUsecase)
 	usersUsecase    = wire.NewSet(newUsersUsecase)
+	usageUsecase    = wire.NewSet(newUsageUsecase)
+	plansUsecase    = wire.NewSet(newPlansUsecase)
+	agentsUsecase   = wire.NewSet(newAgentsUsecase)
 )
 
 var controllersSet = wire.NewSet(
 	bindAdminController,
+	newHTTPMux,
 	bindOAuthController,
+	bindLinksController,
 	bindTelegramController,
 	bindMCPController,
 )
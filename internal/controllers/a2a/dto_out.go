@@ -21,6 +21,9 @@ func messagesTo(msg messages.Message) (res *a2a.Message, err error) {
 	case messages.MessageToolResponse:
 		return toolResponseMessage(msg)
 
+	case messages.MessageToolProgress:
+		return toolProgressMessage(msg), nil
+
 	default:
 		return nil, ErrInternalValidation("unknown message type: %T", msg)
 	}
@@ -99,6 +102,30 @@ func toolResponseMessage(msg messages.MessageToolResponse) (*a2a.Message, error)
 	}, nil
 }
 
+func toolProgressMessage(msg messages.MessageToolProgress) *a2a.Message {
+	metadata := map[string]*structpb.Value{
+		"tool":     structpb.NewStringValue(msg.ToolName()),
+		"reason":   structpb.NewStringValue("Tool progress"),
+		"progress": structpb.NewNumberValue(msg.Progress()),
+	}
+
+	if total, ok := msg.Total(); ok {
+		metadata["total"] = structpb.NewNumberValue(total)
+	}
+
+	return &a2a.Message{
+		Role: a2a.Role_ROLE_AGENT,
+		Content: []*a2a.Part{{
+			Part: &a2a.Part_Text{Text: msg.Message()},
+		}},
+		Metadata:   &structpb.Struct{Fields: metadata},
+		MessageId:  "",
+		ContextId:  "",
+		TaskId:     "",
+		Extensions: nil,
+	}
+}
+
 func argsToStruct(args map[string]json.RawMessage) (*structpb.Struct, error) {
 	argsRaw := make(map[string]any, len(args))
 	for key, value := range args {
@@ -1,9 +1,23 @@
 package a2a
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
 )
 
+// retryAfterHeader tells client, in how many seconds request may be repeated.
+const retryAfterHeader = "retry-after"
+
 type InternalValidationError string
 
 func (e InternalValidationError) Error() string {
@@ -13,3 +27,22 @@ func (e InternalValidationError) Error() string {
 func ErrInternalValidation(format string, a ...any) error {
 	return InternalValidationError(fmt.Sprintf(format, a...))
 }
+
+// limitExceeded converts exhausted plan quota to ResourceExhausted status
+// with retry-after header. Other errors are returned as is.
+func limitExceeded(ctx context.Context, err error) error {
+	var limitErr *chat.RateLimitError
+	if !errors.As(err, &limitErr) {
+		return err
+	}
+
+	if limitErr.RetryAfter > 0 {
+		seconds := int64(math.Ceil(limitErr.RetryAfter.Seconds()))
+
+		// headers are already sent, if stream was interrupted in the middle:
+		// status message still has retry time.
+		_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.FormatInt(seconds, 10)))
+	}
+
+	return status.Error(codes.ResourceExhausted, limitErr.Error())
+}
@@ -4,6 +4,7 @@ package a2a
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"strings"
@@ -97,12 +98,12 @@ func (h *Handler) SendMessage(
 		ctx, threadID, msg, chat.WithToolChoice(tools.ToolChoiceForbidden),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("generating response: %w", err)
+		return nil, limitExceeded(ctx, fmt.Errorf("generating response: %w", err))
 	}
 
 	parts, err := h.collectResponseParts(response)
 	if err != nil {
-		return nil, err
+		return nil, limitExceeded(ctx, err)
 	}
 
 	respMsg := h.makeSendMessageResponse(parts)
@@ -140,12 +141,14 @@ func (h *Handler) SendStreamingMessage(
 		srv.Context(), threadID, msg, chat.WithToolChoice(tools.ToolChoiceAllowed),
 	)
 	if err != nil {
-		return fmt.Errorf("generating response: %w", err)
+		return limitExceeded(srv.Context(), fmt.Errorf("generating response: %w", err))
 	}
 
 	for msg, contentErr := range response {
-		if err != nil {
-			return contentErr
+		if errors.Is(contentErr, chat.ErrTurnLimitReached) {
+			return h.sendInputRequired(srv, threadID)
+		} else if contentErr != nil {
+			return limitExceeded(srv.Context(), contentErr)
 		}
 
 		if err := h.sendStreamingPart(srv, msg); err != nil {
@@ -184,7 +187,10 @@ func (h *Handler) collectResponseParts(
 	parts := make([]*a2a.Part, 0)
 
 	for msg, err := range content {
-		if err != nil {
+		if errors.Is(err, chat.ErrTurnLimitReached) {
+			// summary is already collected, client continues by next message.
+			break
+		} else if err != nil {
 			return nil, fmt.Errorf("generating response: %w", err)
 		}
 
@@ -217,6 +223,31 @@ func (h *Handler) sendStreamingPart(
 	return nil
 }
 
+// sendInputRequired tells client, that agent loop stopped at turn limit. Any
+// next message to the same context continues the response.
+func (h *Handler) sendInputRequired(
+	srv grpc.ServerStreamingServer[a2a.StreamResponse], threadID ids.ThreadID,
+) error {
+	if err := srv.Send(&a2a.StreamResponse{
+		Payload: &a2a.StreamResponse_StatusUpdate{StatusUpdate: &a2a.TaskStatusUpdateEvent{
+			ContextId: threadID.String(),
+			Status: &a2a.TaskStatus{
+				State:     a2a.TaskState_TASK_STATE_INPUT_REQUIRED,
+				Update:    nil,
+				Timestamp: nil,
+			},
+			Final: true,
+
+			TaskId:   "",
+			Metadata: nil,
+		}},
+	}); err != nil {
+		return fmt.Errorf("sending status update to stream: %w", err)
+	}
+
+	return nil
+}
+
 // TaskSubscription implements a2a.A2AServiceServer.
 func (h *Handler) TaskSubscription(
 	req *a2a.TaskSubscriptionRequest, srv grpc.ServerStreamingServer[a2a.StreamResponse],
@@ -235,6 +266,10 @@ func messageToParts(msg messages.Message) ([]*a2a.Part, error) {
 	case messages.MessageToolResponse:
 		return toolResponseParts(msg)
 
+	case messages.MessageToolProgress:
+		// progress is meaningful only for streaming responses.
+		return nil, nil
+
 	default:
 		errMsg := fmt.Sprintf("content %T unexpected message type", msg)
 
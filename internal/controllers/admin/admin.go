@@ -3,30 +3,48 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	admin "github.com/quenbyako/cynosure/contrib/agent-proto/pkg/xelaj/agent/v1alpha1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	domaincatalog "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	domainplans "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/catalog"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
 )
 
 type Handler struct {
 	admin.UnsafeAdminServiceServer
 
 	accounts *accounts.Usecase
+	usage    *usage.Usecase
+	plans    *plans.Usecase
+	catalog  *catalog.Usecase
 }
 
 var _ admin.AdminServiceServer = (*Handler)(nil)
 
-func Register(usecase *accounts.Usecase) func(server grpc.ServiceRegistrar) {
+func Register(
+	accountsUsecase *accounts.Usecase,
+	usageUsecase *usage.Usecase,
+	plansUsecase *plans.Usecase,
+	catalogUsecase *catalog.Usecase,
+) func(server grpc.ServiceRegistrar) {
 	handler := &Handler{
 		UnsafeAdminServiceServer: nil,
-		accounts:                 usecase,
+		accounts:                 accountsUsecase,
+		usage:                    usageUsecase,
+		plans:                    plansUsecase,
+		catalog:                  catalogUsecase,
 	}
 
 	return func(server grpc.ServiceRegistrar) {
@@ -63,6 +81,141 @@ func (h *Handler) Authorize(
 	return responseFromDomain(link)
 }
 
+func (h *Handler) GetUsage(
+	ctx context.Context, req *admin.GetUsageRequest,
+) (*admin.GetUsageResponse, error) {
+	userID, err := ids.NewUserIDFromString(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+	}
+
+	report, err := h.usage.Report(ctx, userID, uint(req.GetDays()))
+	if errors.Is(err, usage.ErrInvalidPeriod) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to build usage report: %w", err)
+	}
+
+	summaries := make([]*admin.UsageSummary, 0, len(report.Summaries()))
+	for _, summary := range report.Summaries() {
+		stats := summary.Stats()
+
+		summaries = append(summaries, &admin.UsageSummary{
+			Day:          summary.Day().Format(time.DateOnly),
+			AgentId:      summary.Agent().ID().String(),
+			Model:        summary.Model(),
+			Turns:        summary.Turns(),
+			InputTokens:  stats.InputTokens,
+			OutputTokens: stats.OutputTokens,
+			DurationMs:   uint64(stats.Duration.Milliseconds()),
+			ToolCalls:    stats.ToolCalls,
+			Errors:       stats.Errors,
+		})
+	}
+
+	return &admin.GetUsageResponse{Summaries: summaries}, nil
+}
+
+func (h *Handler) SetUserPlan(
+	ctx context.Context, req *admin.SetUserPlanRequest,
+) (*admin.SetUserPlanResponse, error) {
+	userID, err := ids.NewUserIDFromString(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+	}
+
+	err = h.plans.AssignPlan(ctx, userID, req.GetPlan())
+	if errors.Is(err, plans.ErrUnknownPlan) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"%v, available plans: %v", err, h.plans.Plans(),
+		)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to assign plan: %w", err)
+	}
+
+	return &admin.SetUserPlanResponse{}, nil
+}
+
+func (h *Handler) GetUserPlan(
+	ctx context.Context, req *admin.GetUserPlanRequest,
+) (*admin.GetUserPlanResponse, error) {
+	userID, err := ids.NewUserIDFromString(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+	}
+
+	plan, err := h.plans.UserPlan(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user plan: %w", err)
+	}
+
+	return &admin.GetUserPlanResponse{
+		Plan:     plan.Name(),
+		Messages: limitFromDomain(plan.Messages()),
+		Tokens:   limitFromDomain(plan.Tokens()),
+	}, nil
+}
+
+func (h *Handler) ImportServerCatalog(
+	ctx context.Context, req *admin.ImportServerCatalogRequest,
+) (*admin.ImportServerCatalogResponse, error) {
+	res, err := h.catalog.Import(ctx, req.GetRegistry())
+	if errors.Is(err, catalog.ErrInvalidRegistry) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to import catalog: %w", err)
+	}
+
+	return &admin.ImportServerCatalogResponse{
+		Imported: uint32(res.Imported),
+		Skipped:  uint32(res.Skipped),
+	}, nil
+}
+
+func (h *Handler) SearchServerCatalog(
+	ctx context.Context, req *admin.SearchServerCatalogRequest,
+) (*admin.SearchServerCatalogResponse, error) {
+	search := h.catalog.SearchText
+	if req.GetSemantic() {
+		search = h.catalog.SearchSemantic
+	}
+
+	matches, err := search(ctx, req.GetQuery(), int(req.GetLimit()))
+	if errors.Is(err, catalog.ErrQueryRequired) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to search catalog: %w", err)
+	}
+
+	servers := make([]*admin.CatalogServer, len(matches))
+	for i, match := range matches {
+		servers[i] = catalogServerFromDomain(match)
+	}
+
+	return &admin.SearchServerCatalogResponse{Servers: servers}, nil
+}
+
+func catalogServerFromDomain(match domaincatalog.Match) *admin.CatalogServer {
+	entry := match.Entry()
+
+	return &admin.CatalogServer{
+		Name:        entry.Name(),
+		Title:       entry.Title(),
+		Url:         entry.Link().String(),
+		Description: entry.Description(),
+		AuthType:    entry.AuthType().String(),
+		Categories:  entry.Categories(),
+		Relevance:   match.Relevance(),
+	}
+}
+
+func limitFromDomain(limit domainplans.Limit) *admin.PlanLimit {
+	return &admin.PlanLimit{
+		Burst:         uint64(limit.Burst()),
+		PeriodSeconds: uint64(limit.Period() / time.Second),
+	}
+}
+
 func responseFromDomain(link accounts.AddAccountResponse) (*admin.AuthorizeResponse, error) {
 	switch link := link.(type) {
 	case accounts.AddAccountResponseAuthRequired:
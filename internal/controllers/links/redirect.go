@@ -0,0 +1,140 @@
+// Package links implements redirects for link references. Long urls in tool
+// outputs are replaced with short tokens before they reach the model, and
+// this controller leads the user to the original address.
+package links
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/time/rate"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+// PathPrefix is a path, where redirect endpoint is mounted. Token follows the
+// prefix: /l/<token>.
+const PathPrefix = "/l/"
+
+const (
+	defaultLimit = rate.Limit(1)
+	defaultBurst = 60
+	// maxClients is an amount of client addresses, which limiters are kept
+	// in memory.
+	maxClients = 10000
+	// clientTTL is a time, after which idle client limiter is dropped. Idle
+	// limiter is full anyway, so dropping it doesn't loosen the limit.
+	clientTTL = time.Hour
+)
+
+type Handler struct {
+	srv *chat.Usecase
+	// limiters of client addresses. Endpoint is not authenticated, so
+	// requests are limited by address to prevent token enumeration.
+	clients *expirable.LRU[string, *rate.Limiter]
+	// guards lookup and creation of client limiter.
+	mu    sync.Mutex
+	limit rate.Limit
+	burst int
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+type newParams struct {
+	limit rate.Limit
+	burst int
+}
+
+func buildNewParams(opts ...NewOption) newParams {
+	params := newParams{
+		limit: defaultLimit,
+		burst: defaultBurst,
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return params
+}
+
+type NewOption func(*newParams)
+
+// WithRateLimit limits redirects, requested from the single client address.
+// Default is 60 requests per minute.
+func WithRateLimit(limit rate.Limit, burst int) NewOption {
+	return func(p *newParams) {
+		p.limit = limit
+		p.burst = burst
+	}
+}
+
+func NewHandler(srv *chat.Usecase, opts ...NewOption) *Handler {
+	params := buildNewParams(opts...)
+
+	return &Handler{
+		srv:     srv,
+		clients: expirable.NewLRU[string, *rate.Limiter](maxClients, nil, clientTTL),
+		mu:      sync.Mutex{},
+		limit:   params.limit,
+		burst:   params.burst,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !h.allow(r) {
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+
+		return
+	}
+
+	link, err := ids.NewLinkID(strings.TrimPrefix(r.URL.Path, PathPrefix))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	target, err := h.srv.ResolveLink(r.Context(), link)
+	switch {
+	case errors.Is(err, chat.ErrLinkNotFound):
+		http.NotFound(w, r)
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	default:
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	}
+}
+
+// allow reports, whether client is allowed to make one more request. Clients
+// are identified by remote address, port is ignored.
+func (h *Handler) allow(r *http.Request) bool {
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+
+	h.mu.Lock()
+
+	limiter, ok := h.clients.Get(client)
+	if !ok {
+		limiter = rate.NewLimiter(h.limit, h.burst)
+		h.clients.Add(client, limiter)
+	}
+
+	h.mu.Unlock()
+
+	return limiter.Allow()
+}
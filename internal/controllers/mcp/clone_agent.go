@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	cloneAgentName = "clone_agent"
+	cloneAgentDesc = "Creates a new agent with the same prompt, model and parameters as the " +
+		"given one. Useful to experiment with agent without breaking the original."
+)
+
+type (
+	CloneAgentInput struct {
+		AgentID string `json:"agent_id"       jsonschema:"ID of the agent to clone"`
+		Name    string `json:"name,omitempty" jsonschema:"Name of the clone, defaults to the original name"`
+	}
+	CloneAgentOutput struct {
+		AgentID string `json:"agent_id" jsonschema:"ID of the created agent"`
+	}
+)
+
+func (c *Controller) CloneAgent(ctx context.Context, in CloneAgentInput) (CloneAgentOutput, error) {
+	userID, ok := FromContext(ctx)
+	if !ok {
+		return CloneAgentOutput{}, ErrUnauthorized
+	}
+
+	agentID, err := uuid.Parse(in.AgentID)
+	if err != nil {
+		return CloneAgentOutput{}, fmt.Errorf("invalid agent id: %w", err)
+	}
+
+	clone, err := c.agents.CloneAgent(ctx, userID, agentID, in.Name)
+	if err != nil {
+		return CloneAgentOutput{}, fmt.Errorf("cloning agent: %w", err)
+	}
+
+	return CloneAgentOutput{
+		AgentID: clone.ID().ID().String(),
+	}, nil
+}
@@ -10,10 +10,14 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/agents"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/catalog"
 )
 
 type Controller struct {
 	accounts *accounts.Usecase
+	agents   *agents.Usecase
+	catalog  *catalog.Usecase
 }
 
 type newParams struct {
@@ -73,6 +77,8 @@ func WithHTTPClient(client http.RoundTripper) NewOption {
 
 func New(
 	accountsUsecase *accounts.Usecase,
+	agentsUsecase *agents.Usecase,
+	catalogUsecase *catalog.Usecase,
 	impl mcp.Implementation,
 	opts ...NewOption,
 ) (
@@ -83,6 +89,8 @@ func New(
 
 	ctrl := &Controller{
 		accounts: accountsUsecase,
+		agents:   agentsUsecase,
+		catalog:  catalogUsecase,
 	}
 
 	if err := ctrl.validate(); err != nil {
@@ -124,6 +132,14 @@ func (c *Controller) validate() error {
 		return errors.New("accounts usecase is nil")
 	}
 
+	if c.agents == nil {
+		return errors.New("agents usecase is nil")
+	}
+
+	if c.catalog == nil {
+		return errors.New("catalog usecase is nil")
+	}
+
 	return nil
 }
 
@@ -134,16 +150,20 @@ func route(srv *mcp.Server, ctrl *Controller) {
 	register(srv, listMcpAccountsName, "", listMcpAccountsDesc, ctrl.ListMcpAccounts)
 	register(srv, disableMcpAccountName, "", disableMcpAccountDesc, ctrl.DisableMcpAccount)
 	register(srv, reactivateMcpAccountName, "", reactivateMcpAccountDesc, ctrl.ReactivateMcpAccount)
+	register(srv, deleteMcpAccountName, "", deleteMcpAccountDesc, ctrl.DeleteMcpAccount)
+	register(srv, setMcpSamplingName, "", setMcpSamplingDesc, ctrl.SetMcpSampling)
 
 	// --- Tools Discovery ---
 	register(srv, listMcpToolsName, "", listMcpToolsDesc, ctrl.ListMcpTools)
 	register(srv, searchMcpToolsName, "", searchMcpToolsDesc, ctrl.SearchMcpTools)
+	register(srv, setMcpToolCacheName, "", setMcpToolCacheDesc, ctrl.SetMcpToolCache)
 
 	// --- Agents Management ---
 	register(srv, createAgentName, "", createAgentDesc, ctrl.CreateAgent)
 	register(srv, updateAgentName, "", updateAgentDesc, ctrl.UpdateAgent)
 	register(srv, listAgentsName, "", listAgentsDesc, ctrl.ListAgents)
 	register(srv, disableAgentName, "", disableAgentDesc, ctrl.DisableAgent)
+	register(srv, cloneAgentName, "", cloneAgentDesc, ctrl.CloneAgent)
 }
 
 const (
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 )
 
 const (
@@ -32,7 +33,12 @@ func (c *Controller) CreateAgent(
 		return CreateAgentOutput{}, ErrUnauthorized
 	}
 
-	_ = userID
+	agent, err := c.agents.CreateAgent(ctx, userID, in.Name, in.ModelName, in.SystemPrompt)
+	if err != nil {
+		return CreateAgentOutput{}, fmt.Errorf("creating agent: %w", err)
+	}
 
-	return CreateAgentOutput{}, ErrUnimplemented
+	return CreateAgentOutput{
+		AgentID: agent.ID().ID().String(),
+	}, nil
 }
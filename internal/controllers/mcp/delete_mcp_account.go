@@ -0,0 +1,38 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	deleteMcpAccountName = "delete_mcp_account"
+	deleteMcpAccountDesc = "Deletes an MCP account with its tools. Stored OAuth token is " +
+		"revoked on the server, if it supports revocation."
+)
+
+type (
+	DeleteMcpAccountInput struct {
+		AccountID string `json:"account_id" jsonschema:"ID of the MCP account"`
+	}
+)
+
+func (c *Controller) DeleteMcpAccount(ctx context.Context, in DeleteMcpAccountInput) (struct{}, error) {
+	userID, ok := FromContext(ctx)
+	if !ok {
+		return struct{}{}, ErrUnauthorized
+	}
+
+	accountID, err := uuid.Parse(in.AccountID)
+	if err != nil {
+		return struct{}{}, fmt.Errorf("invalid account id: %w", err)
+	}
+
+	if err := c.accounts.DeleteAccount(ctx, userID, accountID); err != nil {
+		return struct{}{}, fmt.Errorf("deleting account: %w", err)
+	}
+
+	return struct{}{}, nil
+}
@@ -2,11 +2,14 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/google/uuid"
 )
 
 const (
 	disableAgentName = "disable_agent"
-	disableAgentDesc = "Deactivates an agent."
+	disableAgentDesc = "Deactivates an agent. The last active agent can't be disabled."
 )
 
 type (
@@ -21,7 +24,14 @@ func (c *Controller) DisableAgent(ctx context.Context, in DisableAgentInput) (st
 		return struct{}{}, ErrUnauthorized
 	}
 
-	_ = userID
+	agentID, err := uuid.Parse(in.AgentID)
+	if err != nil {
+		return struct{}{}, fmt.Errorf("invalid agent id: %w", err)
+	}
+
+	if err := c.agents.DisableAgent(ctx, userID, agentID); err != nil {
+		return struct{}{}, fmt.Errorf("disabling agent: %w", err)
+	}
 
-	return struct{}{}, ErrUnimplemented
+	return struct{}{}, nil
 }
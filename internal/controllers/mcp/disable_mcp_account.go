@@ -2,6 +2,9 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -27,7 +30,14 @@ func (c *Controller) DisableMcpAccount(
 		return struct{}{}, ErrUnauthorized
 	}
 
-	_ = userID
+	accountID, err := uuid.Parse(in.AccountID)
+	if err != nil {
+		return struct{}{}, fmt.Errorf("invalid account id: %w", err)
+	}
+
+	if err := c.accounts.DisableAccount(ctx, userID, accountID); err != nil {
+		return struct{}{}, fmt.Errorf("disabling account: %w", err)
+	}
 
-	return struct{}{}, ErrUnimplemented
+	return struct{}{}, nil
 }
@@ -17,4 +17,5 @@ var (
 	ErrNameRequired              = errors.New("name is required")
 	ErrDescriptionRequired       = errors.New("description is required")
 	ErrUnexpectedAccountResponse = errors.New("unexpected account response")
+	ErrUnknownSearchMode         = errors.New("unknown search mode")
 )
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 )
 
 const (
@@ -11,11 +12,14 @@ const (
 
 type (
 	ListAgentsOutput struct {
-		Agents []struct {
-			AgentID   string `json:"agent_id"`
-			Name      string `json:"name"`
-			ModelName string `json:"model_name"`
-		} `json:"agents"`
+		Agents []Agent `json:"agents"`
+	}
+
+	Agent struct {
+		AgentID   string `json:"agent_id"`
+		Name      string `json:"name"`
+		ModelName string `json:"model_name"`
+		Disabled  bool   `json:"disabled,omitempty"`
 	}
 )
 
@@ -25,7 +29,23 @@ func (c *Controller) ListAgents(ctx context.Context, _ struct{}) (ListAgentsOutp
 		return ListAgentsOutput{}, ErrUnauthorized
 	}
 
-	_ = userID
+	agentList, err := c.agents.ListAgents(ctx, userID)
+	if err != nil {
+		return ListAgentsOutput{}, fmt.Errorf("listing agents: %w", err)
+	}
+
+	result := ListAgentsOutput{
+		Agents: make([]Agent, 0, len(agentList)),
+	}
+
+	for _, agent := range agentList {
+		result.Agents = append(result.Agents, Agent{
+			AgentID:   agent.ID().ID().String(),
+			Name:      agent.Name(),
+			ModelName: agent.Model(),
+			Disabled:  agent.Disabled(),
+		})
+	}
 
-	return ListAgentsOutput{}, ErrUnimplemented
+	return result, nil
 }
@@ -9,8 +9,9 @@ import (
 
 const (
 	listMcpAccountsName = "list_mcp_accounts"
-	listMcpAccountsDesc = "Returns a list of registered and active MCP accounts for the " +
-		"current user."
+	listMcpAccountsDesc = "Returns a list of registered MCP accounts for the current user " +
+		"with their status: active, disabled or needs_reauth, and whether server may " +
+		"request completions on behalf of the user."
 )
 
 type (
@@ -22,6 +23,8 @@ type (
 		AccountID string `json:"account_id"`
 		ServerURL string `json:"server_url"`
 		Name      string `json:"name"`
+		Status    string `json:"status"`
+		Sampling  bool   `json:"sampling,omitempty"`
 	}
 )
 
@@ -75,5 +78,7 @@ func (c *Controller) mapAccount(
 		AccountID: acc.ID().ID().String(),
 		ServerURL: server.SSELink().String(),
 		Name:      acc.Name(),
+		Status:    acc.Status().String(),
+		Sampling:  acc.SamplingAllowed(),
 	}, nil
 }
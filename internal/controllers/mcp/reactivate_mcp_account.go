@@ -2,11 +2,15 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/google/uuid"
 )
 
 const (
 	reactivateMcpAccountName = "reactivate_mcp_account"
-	reactivateMcpAccountDesc = "Reactivates a previously disabled MCP account."
+	reactivateMcpAccountDesc = "Reactivates a previously disabled MCP account. If the server " +
+		"rejects stored credentials, returns a link to authorize the account again."
 )
 
 type (
@@ -30,7 +34,19 @@ func (c *Controller) ReactivateMcpAccount(
 		return ReactivateMcpAccountOutput{}, ErrUnauthorized
 	}
 
-	_ = userID
+	accountID, err := uuid.Parse(in.AccountID)
+	if err != nil {
+		return ReactivateMcpAccountOutput{}, fmt.Errorf("invalid account id: %w", err)
+	}
+
+	res, err := c.accounts.ReactivateAccount(ctx, userID, accountID)
+	if err != nil {
+		return ReactivateMcpAccountOutput{}, fmt.Errorf("reactivating account: %w", err)
+	}
+
+	if res.AuthLink == nil {
+		return ReactivateMcpAccountOutput{}, nil
+	}
 
-	return ReactivateMcpAccountOutput{}, ErrUnimplemented
+	return ReactivateMcpAccountOutput{AuthURL: res.AuthLink.String()}, nil
 }
@@ -2,23 +2,42 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+
+	domaincatalog "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
 )
 
 const (
 	searchMcpServersName = "search_mcp_servers"
-	searchMcpServersDesc = "Searches for registered public MCP servers using a text query."
+	searchMcpServersDesc = "Searches for public MCP servers in the catalog. Semantic search " +
+		"(default) finds servers by intent, e.g. \"manage my todo list\", text search " +
+		"matches words of names and descriptions. Pass found URL to authorize_mcp_server " +
+		"to connect."
+)
+
+const (
+	searchModeSemantic = "semantic"
+	searchModeText     = "text"
 )
 
 type (
 	SearchMcpServersInput struct {
 		Query string `json:"query"           jsonschema:"Search query text"`
 		Limit int    `json:"limit,omitempty" jsonschema:"Maximum number of results (default: 10)"`
+		Mode  string `json:"mode,omitempty"  jsonschema:"Search mode: semantic (default) or text"`
 	}
 	SearchMcpServersOutput struct {
-		Servers []struct {
-			URL  string `json:"url"`
-			Name string `json:"name"`
-		} `json:"servers"`
+		Servers []CatalogServer `json:"servers"`
+	}
+
+	CatalogServer struct {
+		URL         string   `json:"url"`
+		Name        string   `json:"name"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		AuthType    string   `json:"auth_type,omitempty"  jsonschema:"none, oauth or api_key"`
+		Categories  []string `json:"categories,omitempty"`
+		Relevance   float64  `json:"relevance"`
 	}
 )
 
@@ -29,12 +48,42 @@ func (c *Controller) SearchMcpServers(
 	SearchMcpServersOutput,
 	error,
 ) {
-	userID, ok := FromContext(ctx)
-	if !ok {
+	if _, ok := FromContext(ctx); !ok {
 		return SearchMcpServersOutput{}, ErrUnauthorized
 	}
 
-	_ = userID
+	var (
+		matches []domaincatalog.Match
+		err     error
+	)
+
+	switch in.Mode {
+	case "", searchModeSemantic:
+		matches, err = c.catalog.SearchSemantic(ctx, in.Query, in.Limit)
+	case searchModeText:
+		matches, err = c.catalog.SearchText(ctx, in.Query, in.Limit)
+	default:
+		return SearchMcpServersOutput{}, fmt.Errorf("%w: %q", ErrUnknownSearchMode, in.Mode)
+	}
+
+	if err != nil {
+		return SearchMcpServersOutput{}, fmt.Errorf("searching servers: %w", err)
+	}
+
+	servers := make([]CatalogServer, len(matches))
+	for i, match := range matches {
+		entry := match.Entry()
+
+		servers[i] = CatalogServer{
+			URL:         entry.Link().String(),
+			Name:        entry.Name(),
+			Title:       entry.Title(),
+			Description: entry.Description(),
+			AuthType:    entry.AuthType().String(),
+			Categories:  entry.Categories(),
+			Relevance:   match.Relevance(),
+		}
+	}
 
-	return SearchMcpServersOutput{}, ErrUnimplemented
+	return SearchMcpServersOutput{Servers: servers}, nil
 }
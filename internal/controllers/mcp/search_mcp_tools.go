@@ -2,11 +2,13 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 )
 
 const (
 	searchMcpToolsName = "search_mcp_tools"
-	searchMcpToolsDesc = "Search for tools across all active MCP accounts by query."
+	searchMcpToolsDesc = "Search for tools across all active MCP accounts by query. Results " +
+		"are ordered by relevance, which helps to pick tools to grant to a new agent."
 )
 
 type (
@@ -20,8 +22,11 @@ type (
 	}
 
 	SearchMCPTool struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+		Name        string  `json:"name"`
+		Description string  `json:"description"`
+		AccountID   string  `json:"account_id"`
+		AccountName string  `json:"account_name"`
+		Relevance   float64 `json:"relevance"   jsonschema:"Similarity to the query, from -1 to 1"`
 	}
 )
 
@@ -37,9 +42,21 @@ func (c *Controller) SearchMcpTools(
 		return SearchMcpToolsOutput{}, ErrUnauthorized
 	}
 
-	_ = userID
+	found, err := c.accounts.SearchTools(ctx, userID, in.Query, in.Limit)
+	if err != nil {
+		return SearchMcpToolsOutput{}, fmt.Errorf("searching tools: %w", err)
+	}
+
+	tools := make([]SearchMCPTool, len(found))
+	for i, res := range found {
+		tools[i] = SearchMCPTool{
+			Name:        res.Tool.Name(),
+			Description: res.Tool.Description(),
+			AccountID:   res.Tool.ID().Account().ID().String(),
+			AccountName: res.Tool.AccountName(),
+			Relevance:   res.Relevance,
+		}
+	}
 
-	return SearchMcpToolsOutput{
-		Tools: []SearchMCPTool{},
-	}, nil
+	return SearchMcpToolsOutput{Tools: tools}, nil
 }
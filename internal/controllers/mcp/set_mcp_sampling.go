@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	setMcpSamplingName = "set_mcp_sampling"
+	setMcpSamplingDesc = "Allows or forbids MCP server of the account to request completions " +
+		"on behalf of the user. Every completion spends tokens of the user."
+)
+
+type (
+	SetMcpSamplingInput struct {
+		AccountID string `json:"account_id" jsonschema:"ID of the MCP account"`
+		Allowed   bool   `json:"allowed"    jsonschema:"Whether server may request completions"`
+	}
+)
+
+func (c *Controller) SetMcpSampling(
+	ctx context.Context,
+	in SetMcpSamplingInput,
+) (
+	struct{},
+	error,
+) {
+	userID, ok := FromContext(ctx)
+	if !ok {
+		return struct{}{}, ErrUnauthorized
+	}
+
+	accountID, err := uuid.Parse(in.AccountID)
+	if err != nil {
+		return struct{}{}, fmt.Errorf("invalid account id: %w", err)
+	}
+
+	if err := c.accounts.SetSampling(ctx, userID, accountID, in.Allowed); err != nil {
+		return struct{}{}, fmt.Errorf("setting sampling: %w", err)
+	}
+
+	return struct{}{}, nil
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	setMcpToolCacheName = "set_mcp_tool_cache"
+	setMcpToolCacheDesc = "Allows reusing results of the tool for the given time. Useful for tools, " +
+		"which return rarely changing data. Zero ttl resets caching to the default: only " +
+		"read-only tools are cached."
+)
+
+type (
+	SetMcpToolCacheInput struct {
+		AccountID  string `json:"account_id"  jsonschema:"ID of the MCP account"`
+		ToolName   string `json:"tool_name"   jsonschema:"Name of the tool"`
+		TTLSeconds uint32 `json:"ttl_seconds" jsonschema:"How long results can be reused, in seconds"`
+	}
+
+	SetMcpToolCacheOutput struct {
+		ToolName   string `json:"tool_name"`
+		TTLSeconds uint32 `json:"ttl_seconds"`
+		ReadOnly   bool   `json:"read_only"`
+	}
+)
+
+func (c *Controller) SetMcpToolCache(
+	ctx context.Context,
+	in SetMcpToolCacheInput,
+) (
+	SetMcpToolCacheOutput,
+	error,
+) {
+	userID, ok := FromContext(ctx)
+	if !ok {
+		return SetMcpToolCacheOutput{}, ErrUnauthorized
+	}
+
+	accountID, err := uuid.Parse(in.AccountID)
+	if err != nil {
+		return SetMcpToolCacheOutput{}, fmt.Errorf("invalid account id: %w", err)
+	}
+
+	ttl := time.Duration(in.TTLSeconds) * time.Second
+
+	tool, err := c.accounts.SetToolCache(ctx, userID, accountID, in.ToolName, ttl)
+	if err != nil {
+		return SetMcpToolCacheOutput{}, fmt.Errorf("setting tool cache: %w", err)
+	}
+
+	return SetMcpToolCacheOutput{
+		ToolName:   tool.Name(),
+		TTLSeconds: in.TTLSeconds,
+		ReadOnly:   tool.Hints().ReadOnly(),
+	}, nil
+}
@@ -2,11 +2,17 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/agents"
 )
 
 const (
 	updateAgentName = "update_agent"
-	updateAgentDesc = "Updates parameters of an existing autonomous agent."
+	updateAgentDesc = "Updates parameters of an existing autonomous agent. Omitted parameters " +
+		"are left unchanged."
 )
 
 type (
@@ -24,7 +30,27 @@ func (c *Controller) UpdateAgent(ctx context.Context, in UpdateAgentInput) (stru
 		return struct{}{}, ErrUnauthorized
 	}
 
-	_ = userID
+	agentID, err := uuid.Parse(in.AgentID)
+	if err != nil {
+		return struct{}{}, fmt.Errorf("invalid agent id: %w", err)
+	}
+
+	var opts []agents.UpdateOption
+	if in.Name != "" {
+		opts = append(opts, agents.UpdateName(in.Name))
+	}
+
+	if in.SystemPrompt != "" {
+		opts = append(opts, agents.UpdateSystemMessage(in.SystemPrompt))
+	}
+
+	if in.ModelName != "" {
+		opts = append(opts, agents.UpdateModel(in.ModelName))
+	}
+
+	if _, err := c.agents.UpdateAgent(ctx, userID, agentID, opts...); err != nil {
+		return struct{}{}, fmt.Errorf("updating agent: %w", err)
+	}
 
-	return struct{}{}, ErrUnimplemented
+	return struct{}{}, nil
 }
@@ -0,0 +1,137 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"strconv"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+const defaultAttachmentName = "attachment"
+
+// sendAttachments sends media content of tool result as separate messages:
+// images as photos, audio as audio files and everything else as documents.
+// Failed attachment doesn't interrupt response streaming.
+func (h *Handler) sendAttachments(
+	ctx context.Context, chatID, threadID int, attachments []messages.ChatContent,
+) {
+	for _, attachment := range attachments {
+		if err := h.sendAttachment(ctx, chatID, threadID, attachment); err != nil {
+			h.log.ProcessMessageIssue(ctx, chatID, fmt.Errorf("sending attachment: %w", err))
+		}
+	}
+}
+
+func (h *Handler) sendAttachment(
+	ctx context.Context, chatID, threadID int, attachment messages.ChatContent,
+) error {
+	field := attachmentField(attachment)
+
+	body, contentType, err := attachmentBody(chatID, threadID, field, attachment)
+	if err != nil {
+		return err
+	}
+
+	switch field {
+	case "photo":
+		resp, err := h.client.PostSendPhotoWithBodyWithResponse(ctx, contentType, body)
+		if err != nil {
+			return fmt.Errorf("send photo: %w", err)
+		}
+
+		if resp.JSON200 == nil || !resp.JSON200.Ok {
+			return errAPI("send photo", resp.Status(), resp.JSONDefault)
+		}
+	case "audio":
+		resp, err := h.client.PostSendAudioWithBodyWithResponse(ctx, contentType, body)
+		if err != nil {
+			return fmt.Errorf("send audio: %w", err)
+		}
+
+		if resp.JSON200 == nil || !resp.JSON200.Ok {
+			return errAPI("send audio", resp.Status(), resp.JSONDefault)
+		}
+	default:
+		resp, err := h.client.PostSendDocumentWithBodyWithResponse(ctx, contentType, body)
+		if err != nil {
+			return fmt.Errorf("send document: %w", err)
+		}
+
+		if resp.JSON200 == nil || !resp.JSON200.Ok {
+			return errAPI("send document", resp.Status(), resp.JSONDefault)
+		}
+	}
+
+	return nil
+}
+
+func attachmentField(attachment messages.ChatContent) string {
+	switch attachment.(type) {
+	case *messages.ChatContentImageURL:
+		return "photo"
+	case *messages.ChatContentAudioURL:
+		return "audio"
+	default:
+		return "document"
+	}
+}
+
+// attachmentBody builds multipart request. Embedded content is uploaded as a
+// file, while external links are passed as is: telegram downloads them by
+// itself.
+func attachmentBody(
+	chatID, threadID int, field string, attachment messages.ChatContent,
+) (body *bytes.Buffer, contentType string, err error) {
+	body = new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+
+	if err := w.WriteField("chat_id", strconv.Itoa(chatID)); err != nil {
+		return nil, "", fmt.Errorf("writing chat id: %w", err)
+	}
+
+	if threadID > 0 {
+		if err := w.WriteField("message_thread_id", strconv.Itoa(threadID)); err != nil {
+			return nil, "", fmt.Errorf("writing thread id: %w", err)
+		}
+	}
+
+	if mimeType, data, ok := messages.DecodeDataURL(attachment.URL()); ok {
+		part, err := w.CreateFormFile(field, attachmentName(attachment, mimeType))
+		if err != nil {
+			return nil, "", fmt.Errorf("creating file part: %w", err)
+		}
+
+		if _, err := part.Write(data); err != nil {
+			return nil, "", fmt.Errorf("writing file part: %w", err)
+		}
+	} else if err := w.WriteField(field, attachment.URL().String()); err != nil {
+		return nil, "", fmt.Errorf("writing file url: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("closing multipart body: %w", err)
+	}
+
+	return body, w.FormDataContentType(), nil
+}
+
+func attachmentName(attachment messages.ChatContent, mimeType string) string {
+	if file, ok := attachment.(*messages.ChatContentFileURL); ok && file.Name() != "" {
+		return file.Name()
+	}
+
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return defaultAttachmentName
+	}
+
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return defaultAttachmentName + exts[0]
+	}
+
+	return defaultAttachmentName
+}
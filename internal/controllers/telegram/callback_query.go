@@ -0,0 +1,122 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	botapi "github.com/quenbyako/cynosure/contrib/tg-openapi/gen/go/botapi"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+const (
+	continueButtonText   = "Continue"
+	continueCallbackData = "continue"
+)
+
+// sendContinueButton offers user to continue response, which stopped at turn
+// limit.
+func (h *Handler) sendContinueButton(ctx context.Context, chatID, threadID int) {
+	var markup botapi.SendMessageJSONBody_ReplyMarkup
+	if err := markup.FromInlineKeyboardMarkup(botapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]botapi.InlineKeyboardButton{{{
+			Text:         continueButtonText,
+			CallbackData: ptr(continueCallbackData),
+		}}},
+	}); err != nil {
+		h.log.ProcessMessageIssue(ctx, chatID, fmt.Errorf("building continue button: %w", err))
+		return
+	}
+
+	var tgThreadID *int
+	if threadID > 0 {
+		tgThreadID = &threadID
+	}
+
+	//nolint:exhaustruct // too many optional fields.
+	params := botapi.SendMessageJSONRequestBody{
+		ChatId:          chatID,
+		Text:            "I've reached the limit of steps for one response. Should I continue?",
+		MessageThreadId: tgThreadID,
+		ReplyMarkup:     &markup,
+	}
+
+	resp, err := h.client.SendMessageWithResponse(ctx, params)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, chatID,
+			fmt.Errorf("sending continue button (network error): %w", err),
+		)
+
+		return
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		h.log.ProcessMessageIssue(ctx, chatID,
+			fmt.Errorf("sending continue button (api error %d): %s", resp.StatusCode(), string(resp.Body)),
+		)
+	}
+}
+
+func (h *Handler) processCallbackQuery(ctx context.Context, query *botapi.CallbackQuery) {
+	// button stops spinning only after answer, even if query is ignored.
+	h.answerCallbackQuery(ctx, query.Id)
+
+	if query.Data == nil || query.Message == nil {
+		return
+	}
+
+	msg, err := query.Message.AsMessage()
+	if err != nil || msg.Chat.Type != "private" {
+		return
+	}
+
+	// message with button is sent by bot, so user is taken from the query.
+	switch data := *query.Data; {
+	case data == continueCallbackData:
+		h.continueResponse(ctx, &query.From, &msg)
+	case strings.HasPrefix(data, promptCallbackPrefix):
+		h.selectPrompt(ctx, &query.From, &msg, strings.TrimPrefix(data, promptCallbackPrefix))
+	case strings.HasPrefix(data, elicitCallbackPrefix):
+		h.pressElicitButton(ctx, &msg, strings.TrimPrefix(data, elicitCallbackPrefix))
+	}
+}
+
+func (h *Handler) continueResponse(ctx context.Context, from *botapi.User, msg *botapi.Message) {
+	userID, err := h.identifyUser(ctx, from)
+	if err != nil {
+		h.handleUserIdentificationError(ctx, msg, err)
+		return
+	}
+
+	threadID, err := ids.NewThreadID(userID, h.formatThread(msg))
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("making thread id: %w", err))
+		return
+	}
+
+	//nolint:exhaustruct // user message is not needed to continue.
+	ok := h.pool.Submit(ctx, asyncProcessRequest{
+		threadID:   threadID,
+		chatID:     msg.Chat.Id,
+		tgThreadID: messageThreadID(msg),
+		resume:     true,
+	})
+	if !ok {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id,
+			ErrInternalValidation("failed to submit async request, pool is not working"),
+		)
+	}
+}
+
+func (h *Handler) answerCallbackQuery(ctx context.Context, queryID string) {
+	//nolint:exhaustruct // too many optional fields.
+	params := botapi.PostAnswerCallbackQueryJSONRequestBody{
+		CallbackQueryId: queryID,
+	}
+
+	if _, err := h.client.PostAnswerCallbackQueryWithResponse(ctx, params); err != nil {
+		h.log.ProcessMessageIssue(ctx, 0, fmt.Errorf("answering callback query: %w", err))
+	}
+}
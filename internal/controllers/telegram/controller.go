@@ -14,6 +14,7 @@ import (
 	noopTrace "go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/users"
 )
 
@@ -25,13 +26,20 @@ const (
 )
 
 type Handler struct {
-	log            LogCallbacks
-	tracer         trace.Tracer
-	srv            *chat.Usecase
-	users          *users.Usecase
-	client         *botapi.ClientWithResponses
-	pool           *taskpool.TaskPool[asyncProcessRequest]
+	log    LogCallbacks
+	tracer trace.Tracer
+	srv    *chat.Usecase
+	users  *users.Usecase
+	usage  *usage.Usecase
+	client *botapi.ClientWithResponses
+	pool   *taskpool.TaskPool[asyncProcessRequest]
+	// prompts, which arguments are being collected.
+	inputs *promptInputs
+	// forms of MCP servers, which are being filled.
+	elicits        *elicitInputs
 	updateInterval time.Duration
+	// whom users ask to upgrade their plan, optional.
+	supportContact string
 }
 
 var _ botapi.StrictWebhookInterface = (*Handler)(nil)
@@ -40,8 +48,10 @@ type newParams struct {
 	log            LogCallbacks
 	tracer         trace.TracerProvider
 	client         http.RoundTripper
+	usage          *usage.Usecase
 	updateInterval time.Duration
 	maxWorkers     int
+	supportContact string
 }
 
 func buildNewParams(opts ...NewOption) newParams {
@@ -51,6 +61,8 @@ func buildNewParams(opts ...NewOption) newParams {
 		tracer:         noopTrace.NewTracerProvider(),
 		client:         http.DefaultTransport,
 		maxWorkers:     defaultMaxWorkers,
+		usage:          nil,
+		supportContact: "",
 	}
 
 	for _, opt := range opts {
@@ -78,6 +90,18 @@ func WithTracer(tracer trace.TracerProvider) NewOption {
 	return func(h *newParams) { h.tracer = tracer }
 }
 
+// WithUsage enables /usage command, which reports resources, consumed by
+// user agents.
+func WithUsage(usecase *usage.Usecase) NewOption {
+	return func(h *newParams) { h.usage = usecase }
+}
+
+// WithSupportContact sets contact, e.g. telegram username, which users ask to
+// upgrade their plan. Without it, users are not told whom to ask.
+func WithSupportContact(contact string) NewOption {
+	return func(h *newParams) { h.supportContact = contact }
+}
+
 func WithMaxWorkers(maxWorkers int) NewOption {
 	return func(h *newParams) { h.maxWorkers = maxWorkers }
 }
@@ -113,8 +137,12 @@ func newHandler(
 		tracer:         params.tracer.Tracer(pkgName),
 		srv:            chatUsecase,
 		users:          usersUsecase,
+		usage:          params.usage,
 		client:         client,
 		updateInterval: params.updateInterval,
+		supportContact: params.supportContact,
+		inputs:         newPromptInputs(),
+		elicits:        newElicitInputs(),
 		pool:           nil,
 	}
 
@@ -128,8 +156,12 @@ func (h *Handler) Running() bool {
 }
 
 // Run starts the handler and blocks until the context is canceled or the
-// handler fails.
+// handler fails. While running, handler relays input requests of MCP servers
+// to users.
 func (h *Handler) Run(ctx context.Context) error {
+	unregister := h.srv.HandleElicitation(h.elicit)
+	defer unregister()
+
 	if err := h.pool.Run(ctx); err != nil {
 		return fmt.Errorf("running telegram controller task pool: %w", err)
 	}
@@ -0,0 +1,500 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	botapi "github.com/quenbyako/cynosure/contrib/tg-openapi/gen/go/botapi"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/elicitation"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+const (
+	elicitCallbackPrefix = "elicit:"
+	elicitSkipData       = "skip"
+	elicitAcceptData     = "accept"
+	elicitDeclineData    = "decline"
+	elicitCancelData     = "cancel"
+	// tool call is suspended, while user answers, so it's cancelled, if user
+	// doesn't answer in time.
+	elicitationTimeout = 10 * time.Minute
+)
+
+// elicitInput is a form of MCP server, which fields are asked one by one:
+// every next message of the user, or pressed button, is a value of the next
+// field.
+type elicitInput struct {
+	req    elicitation.Request
+	values map[string]any
+	// index of the field, which is asked right now.
+	next int
+	// receives the answer exactly once: input is removed from pending before
+	// it's answered.
+	done chan elicitation.Result
+}
+
+// elicitInputs keeps forms, which are being filled, per chat, and chats of
+// the threads, which responses are streamed right now.
+type elicitInputs struct {
+	pending map[promptInputKey]*elicitInput
+	chats   map[string]promptInputKey
+	mu      sync.Mutex
+}
+
+func newElicitInputs() *elicitInputs {
+	return &elicitInputs{
+		pending: make(map[promptInputKey]*elicitInput),
+		chats:   make(map[string]promptInputKey),
+		mu:      sync.Mutex{},
+	}
+}
+
+// bind remembers chat of the thread, while its response is streamed.
+func (e *elicitInputs) bind(thread ids.ThreadID, key promptInputKey) (unbind func()) {
+	e.mu.Lock()
+	e.chats[thread.String()] = key
+	e.mu.Unlock()
+
+	return func() {
+		e.mu.Lock()
+		delete(e.chats, thread.String())
+		e.mu.Unlock()
+	}
+}
+
+func (e *elicitInputs) chat(thread ids.ThreadID) (promptInputKey, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key, ok := e.chats[thread.String()]
+
+	return key, ok
+}
+
+// put starts waiting for answers in the chat. Form, which was waiting in the
+// same chat before, is cancelled.
+func (e *elicitInputs) put(key promptInputKey, input *elicitInput) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if previous, ok := e.pending[key]; ok && previous != input {
+		previous.done <- elicitation.Cancel()
+	}
+
+	e.pending[key] = input
+}
+
+func (e *elicitInputs) take(key promptInputKey) (*elicitInput, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	input, ok := e.pending[key]
+	if ok {
+		delete(e.pending, key)
+	}
+
+	return input, ok
+}
+
+// drop forgets the form, if it's still waiting. Returns false, if it's
+// already answered.
+func (e *elicitInputs) drop(key promptInputKey, input *elicitInput) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.pending[key] != input {
+		return false
+	}
+
+	delete(e.pending, key)
+
+	return true
+}
+
+// elicit asks user to fill the form of MCP server in the chat of the thread.
+// Implements [chat.ElicitationHandler].
+func (h *Handler) elicit(
+	ctx context.Context, thread ids.ThreadID, req elicitation.Request,
+) (elicitation.Result, error) {
+	key, ok := h.elicits.chat(thread)
+	if !ok {
+		// response is not streamed to telegram: nobody can answer.
+		return elicitation.Cancel(), nil
+	}
+
+	input := &elicitInput{
+		req:    req,
+		values: make(map[string]any),
+		next:   0,
+		done:   make(chan elicitation.Result, 1),
+	}
+
+	h.sendChatText(ctx, key.chatID, threadPtr(key.tgThreadID), formatElicitRequest(req))
+	h.continueElicitInput(ctx, key, input)
+
+	timer := time.NewTimer(elicitationTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-input.done:
+		return res, nil
+	case <-timer.C:
+		if !h.elicits.drop(key, input) {
+			return <-input.done, nil
+		}
+
+		h.sendChatText(ctx, key.chatID, threadPtr(key.tgThreadID),
+			"Request expired, server is told that you didn't answer.",
+		)
+
+		return elicitation.Cancel(), nil
+	case <-ctx.Done():
+		h.elicits.drop(key, input)
+
+		return elicitation.Result{}, fmt.Errorf("waiting for answer: %w", ctx.Err())
+	}
+}
+
+// fillElicitation uses message as a value of the asked field. Returns false,
+// if no form is waiting for answers in this chat.
+func (h *Handler) fillElicitation(ctx context.Context, msg *botapi.Message, text string) bool {
+	key := promptInputKeyOf(msg)
+
+	input, ok := h.elicits.take(key)
+	if !ok {
+		return false
+	}
+
+	if _, ok := currentField(input); !ok {
+		h.elicits.put(key, input)
+		h.sendText(ctx, msg, "Please, agree or decline the request with buttons above.")
+
+		return true
+	}
+
+	h.answerElicitField(ctx, key, input, text)
+
+	return true
+}
+
+// pressElicitButton handles buttons of the asked field. Buttons of fields,
+// which are already answered, are ignored.
+func (h *Handler) pressElicitButton(ctx context.Context, msg *botapi.Message, data string) {
+	key := promptInputKeyOf(msg)
+
+	input, ok := h.elicits.take(key)
+	if !ok {
+		h.sendText(ctx, msg, "This request is not active anymore.")
+		return
+	}
+
+	switch data {
+	case elicitAcceptData:
+		h.acceptElicitInput(ctx, key, input)
+		return
+	case elicitDeclineData:
+		h.finishElicitInput(ctx, key, input, elicitation.Decline())
+		return
+	case elicitCancelData:
+		h.finishElicitInput(ctx, key, input, elicitation.Cancel())
+		return
+	}
+
+	field, ok := currentField(input)
+
+	fieldIdx, choice, _ := strings.Cut(data, ":")
+	if !ok || fieldIdx != strconv.Itoa(input.next) {
+		h.elicits.put(key, input)
+		return
+	}
+
+	if choice == elicitSkipData {
+		h.skipElicitField(ctx, key, input)
+		return
+	}
+
+	choices := fieldChoices(field)
+
+	idx, err := strconv.Atoi(choice)
+	if err != nil || idx < 0 || idx >= len(choices) {
+		h.elicits.put(key, input)
+		return
+	}
+
+	h.answerElicitField(ctx, key, input, choices[idx].value)
+}
+
+// skipElicitation leaves optional field empty: "/skip". Returns false, if no
+// form is waiting for answers in this chat.
+func (h *Handler) skipElicitation(ctx context.Context, msg *botapi.Message) bool {
+	key := promptInputKeyOf(msg)
+
+	input, ok := h.elicits.take(key)
+	if !ok {
+		return false
+	}
+
+	if _, ok := currentField(input); !ok {
+		h.elicits.put(key, input)
+		h.sendText(ctx, msg, "Nothing to skip: agree or decline the request.")
+
+		return true
+	}
+
+	h.skipElicitField(ctx, key, input)
+
+	return true
+}
+
+// cancelElicitation dismisses the form: "/cancel". Returns false, if no form
+// is waiting for answers in this chat.
+func (h *Handler) cancelElicitation(ctx context.Context, msg *botapi.Message) bool {
+	key := promptInputKeyOf(msg)
+
+	input, ok := h.elicits.take(key)
+	if !ok {
+		return false
+	}
+
+	h.finishElicitInput(ctx, key, input, elicitation.Cancel())
+
+	return true
+}
+
+func (h *Handler) answerElicitField(
+	ctx context.Context, key promptInputKey, input *elicitInput, text string,
+) {
+	field, _ := currentField(input)
+
+	value, err := field.Parse(text)
+	if errors.Is(err, elicitation.ErrInvalidValue) {
+		h.elicits.put(key, input)
+		h.sendChatText(ctx, key.chatID, threadPtr(key.tgThreadID),
+			fmt.Sprintf("%s: %v. Please, try again.", field.Label(), err),
+		)
+
+		return
+	} else if err != nil {
+		h.log.ProcessMessageIssue(ctx, key.chatID, fmt.Errorf("parsing answer: %w", err))
+		h.finishElicitInput(ctx, key, input, elicitation.Cancel())
+
+		return
+	}
+
+	input.values[field.Name()] = value
+	input.next++
+
+	h.continueElicitInput(ctx, key, input)
+}
+
+func (h *Handler) skipElicitField(ctx context.Context, key promptInputKey, input *elicitInput) {
+	if field, _ := currentField(input); field.Required() {
+		h.elicits.put(key, input)
+		h.sendChatText(ctx, key.chatID, threadPtr(key.tgThreadID),
+			fmt.Sprintf("%q is required, it can't be skipped.", field.Label()),
+		)
+
+		return
+	}
+
+	input.next++
+
+	h.continueElicitInput(ctx, key, input)
+}
+
+// continueElicitInput asks for the next field, or sends the answer, if all
+// fields are filled. Form without fields is asked as confirmation.
+func (h *Handler) continueElicitInput(ctx context.Context, key promptInputKey, input *elicitInput) {
+	fields := input.req.Fields()
+
+	switch {
+	case len(fields) == 0:
+		h.elicits.put(key, input)
+		h.sendElicitQuestion(ctx, key, "Do you agree?", [][]botapi.InlineKeyboardButton{{
+			{Text: "Agree", CallbackData: ptr(elicitCallbackPrefix + elicitAcceptData)},
+			{Text: "Decline", CallbackData: ptr(elicitCallbackPrefix + elicitDeclineData)},
+		}})
+	case input.next < len(fields):
+		h.elicits.put(key, input)
+		h.sendElicitQuestion(ctx, key, formatFieldRequest(fields[input.next]),
+			fieldButtons(input.next, fields[input.next]),
+		)
+	default:
+		h.acceptElicitInput(ctx, key, input)
+	}
+}
+
+func (h *Handler) acceptElicitInput(ctx context.Context, key promptInputKey, input *elicitInput) {
+	res, err := input.req.Accept(input.values)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, key.chatID, fmt.Errorf("accepting answers: %w", err))
+		res = elicitation.Cancel()
+	}
+
+	h.finishElicitInput(ctx, key, input, res)
+}
+
+func (h *Handler) finishElicitInput(
+	ctx context.Context, key promptInputKey, input *elicitInput, res elicitation.Result,
+) {
+	input.done <- res
+
+	var text string
+
+	switch res.Action() {
+	case elicitation.ActionAccept:
+		text = "Thanks, your answer is sent."
+	case elicitation.ActionDecline:
+		text = "Request is declined."
+	default:
+		text = "Request is cancelled."
+	}
+
+	h.sendChatText(ctx, key.chatID, threadPtr(key.tgThreadID), text)
+}
+
+func (h *Handler) sendElicitQuestion(
+	ctx context.Context, key promptInputKey, text string, buttons [][]botapi.InlineKeyboardButton,
+) {
+	var markup botapi.SendMessageJSONBody_ReplyMarkup
+	if err := markup.FromInlineKeyboardMarkup(botapi.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
+	}); err != nil {
+		h.log.ProcessMessageIssue(ctx, key.chatID, fmt.Errorf("building elicitation buttons: %w", err))
+		return
+	}
+
+	//nolint:exhaustruct // too many optional fields.
+	params := botapi.SendMessageJSONRequestBody{
+		ChatId:          key.chatID,
+		Text:            text,
+		MessageThreadId: threadPtr(key.tgThreadID),
+		ReplyMarkup:     &markup,
+	}
+
+	resp, err := h.client.SendMessageWithResponse(ctx, params)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, key.chatID,
+			fmt.Errorf("sending elicitation question (network error): %w", err),
+		)
+
+		return
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		h.log.ProcessMessageIssue(ctx, key.chatID,
+			fmt.Errorf("sending elicitation question (api error %d): %s", resp.StatusCode(), string(resp.Body)),
+		)
+	}
+}
+
+// currentField returns the field, which is asked right now. Confirmation has
+// no fields at all.
+func currentField(input *elicitInput) (elicitation.Field, bool) {
+	fields := input.req.Fields()
+	if input.next >= len(fields) {
+		return elicitation.Field{}, false
+	}
+
+	return fields[input.next], true
+}
+
+type fieldChoice struct {
+	label, value string
+}
+
+// fieldChoices lists values, which may be chosen by buttons.
+func fieldChoices(field elicitation.Field) []fieldChoice {
+	switch field.Kind() {
+	case elicitation.KindBoolean:
+		return []fieldChoice{{label: "Yes", value: "yes"}, {label: "No", value: "no"}}
+	case elicitation.KindEnum:
+		options := field.Options()
+		choices := make([]fieldChoice, 0, len(options))
+
+		for _, option := range options {
+			choices = append(choices, fieldChoice{label: option.Label(), value: option.Value()})
+		}
+
+		return choices
+	default:
+		return nil
+	}
+}
+
+// fieldButtons offers choices of the field, one per row, and controls of
+// the form. Callback data refers to the field by index, so buttons of
+// previous questions are ignored.
+func fieldButtons(idx int, field elicitation.Field) [][]botapi.InlineKeyboardButton {
+	prefix := elicitCallbackPrefix + strconv.Itoa(idx) + ":"
+
+	choices := fieldChoices(field)
+	buttons := make([][]botapi.InlineKeyboardButton, 0, len(choices)+1)
+
+	for i, choice := range choices {
+		buttons = append(buttons, []botapi.InlineKeyboardButton{{
+			Text:         choice.label,
+			CallbackData: ptr(prefix + strconv.Itoa(i)),
+		}})
+	}
+
+	controls := make([]botapi.InlineKeyboardButton, 0, 3) //nolint:mnd // skip, decline, cancel
+	if !field.Required() {
+		controls = append(controls, botapi.InlineKeyboardButton{
+			Text: "Skip", CallbackData: ptr(prefix + elicitSkipData),
+		})
+	}
+
+	controls = append(controls,
+		botapi.InlineKeyboardButton{
+			Text: "Decline", CallbackData: ptr(elicitCallbackPrefix + elicitDeclineData),
+		},
+		botapi.InlineKeyboardButton{
+			Text: "Cancel", CallbackData: ptr(elicitCallbackPrefix + elicitCancelData),
+		},
+	)
+
+	return append(buttons, controls)
+}
+
+func formatElicitRequest(req elicitation.Request) string {
+	return "Connected server needs your input to continue:\n\n" + req.Message()
+}
+
+func formatFieldRequest(field elicitation.Field) string {
+	var text strings.Builder
+
+	text.WriteString(field.Label())
+
+	if field.Description() != "" {
+		fmt.Fprintf(&text, " (%s)", field.Description())
+	}
+
+	switch field.Kind() {
+	case elicitation.KindBoolean, elicitation.KindEnum:
+		text.WriteString(": choose one of the options.")
+	case elicitation.KindNumber:
+		text.WriteString(": send a number.")
+	case elicitation.KindInteger:
+		text.WriteString(": send a whole number.")
+	default:
+		text.WriteString(": send your answer.")
+	}
+
+	return text.String()
+}
+
+func threadPtr(tgThreadID int) *int {
+	if tgThreadID > 0 {
+		return &tgThreadID
+	}
+
+	return nil
+}
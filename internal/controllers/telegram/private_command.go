@@ -47,13 +47,21 @@ func (h *Handler) processCommand(ctx context.Context, msg *botapi.Message) {
 	switch {
 	case cmdStr == "/start" || strings.HasPrefix(cmdStr, "/start@"):
 		h.handleStart(ctx, msg)
+	case cmdStr == "/usage" || strings.HasPrefix(cmdStr, "/usage@"):
+		h.handleUsage(ctx, msg, commandArgs(&commandEntity, text))
+	case cmdStr == promptsCommand || strings.HasPrefix(cmdStr, promptsCommand+"@"):
+		h.handlePrompts(ctx, msg)
+	case cmdStr == "/skip" || strings.HasPrefix(cmdStr, "/skip@"):
+		h.handleSkipArgument(ctx, msg)
+	case cmdStr == "/cancel" || strings.HasPrefix(cmdStr, "/cancel@"):
+		h.handleCancelPrompt(ctx, msg)
 	default:
 		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("unknown command: %s", cmdStr))
 	}
 }
 
 func (h *Handler) handleStart(ctx context.Context, msg *botapi.Message) {
-	userID, err := h.identifyUser(ctx, msg)
+	userID, err := h.identifyUser(ctx, msg.From)
 	if err != nil {
 		h.handleUserIdentificationError(ctx, msg, err)
 		return
@@ -104,6 +112,16 @@ func extractEntity(entity *botapi.MessageEntity, text string) (string, bool) {
 	return res, true
 }
 
+// commandArgs returns text after the command.
+func commandArgs(entity *botapi.MessageEntity, text string) string {
+	u16 := utf16.Encode([]rune(text))
+	if entity.Offset+entity.Length > len(u16) {
+		return ""
+	}
+
+	return strings.TrimSpace(string(utf16.Decode(u16[entity.Offset+entity.Length:])))
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/identitymanager"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
 )
 
 func (h *Handler) processMessage(ctx context.Context, msg *botapi.Message) {
@@ -20,7 +21,7 @@ func (h *Handler) processMessage(ctx context.Context, msg *botapi.Message) {
 		return
 	}
 
-	userID, err := h.identifyUser(ctx, msg)
+	userID, err := h.identifyUser(ctx, msg.From)
 	if err != nil {
 		h.handleUserIdentificationError(ctx, msg, err)
 		return
@@ -41,6 +42,14 @@ func (h *Handler) processMessage(ctx context.Context, msg *botapi.Message) {
 		return
 	}
 
+	if h.fillElicitation(ctx, msg, text) {
+		return
+	}
+
+	if h.fillPromptArgument(ctx, msg, text) {
+		return
+	}
+
 	if err := h.dispatchProcessing(ctx, msg, threadID, text); err != nil {
 		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, err)
 	}
@@ -59,16 +68,11 @@ func (h *Handler) dispatchProcessing(
 		return fmt.Errorf("making user message: %w", err)
 	}
 
-	var msgThreadID int
-	if msg.MessageThreadId != nil && *msg.MessageThreadId > 0 {
-		msgThreadID = *msg.MessageThreadId
-	}
-
 	ok := h.pool.Submit(ctx, asyncProcessRequest{
 		userMessage: userMessage,
 		threadID:    threadID,
 		chatID:      msg.Chat.Id,
-		tgThreadID:  msgThreadID,
+		tgThreadID:  messageThreadID(msg),
 	})
 	if !ok {
 		// TODO: add metrics to detect, how many messages were dropped due to non running pool.
@@ -96,16 +100,52 @@ type asyncProcessRequest struct {
 	threadID    ids.ThreadID
 	chatID      int
 	tgThreadID  int
+	// continues paused response instead of answering userMessage.
+	resume bool
+	// runs the prompt instead of answering userMessage.
+	prompt *promptCall
 }
 
 func (h *Handler) asyncProcess(ctx context.Context, req asyncProcessRequest) {
-	h.log.ProcessMessageStart(ctx, req.chatID, req.userMessage.Content())
+	switch {
+	case req.resume:
+		h.log.ProcessMessageStart(ctx, req.chatID, continueButtonText)
+	case req.prompt != nil:
+		h.log.ProcessMessageStart(ctx, req.chatID, promptsCommand+" "+req.prompt.name)
+	default:
+		h.log.ProcessMessageStart(ctx, req.chatID, req.userMessage.Content())
+	}
 
 	startTime := time.Now()
 
-	response, err := h.srv.GenerateResponse(ctx, req.threadID, req.userMessage)
-	if err != nil {
-		h.log.ProcessMessageIssue(ctx, req.chatID, fmt.Errorf("processing new message: %w", err))
+	// tools may ask user for input, while response is streamed.
+	unbind := h.elicits.bind(req.threadID, promptInputKey{
+		chatID: req.chatID, tgThreadID: req.tgThreadID,
+	})
+	defer unbind()
+
+	response, err := h.generateResponse(ctx, req)
+
+	var limitErr *chat.RateLimitError
+
+	switch {
+	case errors.Is(err, chat.ErrThreadNotPaused):
+		// button was pressed twice, or user already moved on.
+		return
+	case errors.As(err, &limitErr):
+		h.sendPlanLimitMessage(ctx, req.chatID, &req.tgThreadID, limitErr)
+
+		return
+	case err != nil && req.prompt != nil:
+		if text, ok := promptErrorText(err); ok {
+			h.sendChatText(ctx, req.chatID, &req.tgThreadID, text)
+
+			return
+		}
+
+		fallthrough
+	case err != nil:
+		h.log.ProcessMessageIssue(ctx, req.chatID, err)
 
 		h.sendErrorMessage(ctx, req.chatID, &req.tgThreadID)
 
@@ -118,6 +158,37 @@ func (h *Handler) asyncProcess(ctx context.Context, req asyncProcessRequest) {
 	h.log.ProcessMessageSuccess(ctx, req.chatID, duration.String())
 }
 
+func (h *Handler) generateResponse(
+	ctx context.Context, req asyncProcessRequest,
+) (iter.Seq2[messages.Message, error], error) {
+	if req.resume {
+		response, err := h.srv.ContinueResponse(ctx, req.threadID)
+		if err != nil {
+			return nil, fmt.Errorf("continuing response: %w", err)
+		}
+
+		return response, nil
+	}
+
+	if req.prompt != nil {
+		response, err := h.srv.RunPrompt(ctx, req.threadID,
+			req.prompt.account, req.prompt.name, req.prompt.args,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("running prompt: %w", err)
+		}
+
+		return response, nil
+	}
+
+	response, err := h.srv.GenerateResponse(ctx, req.threadID, req.userMessage)
+	if err != nil {
+		return nil, fmt.Errorf("processing new message: %w", err)
+	}
+
+	return response, nil
+}
+
 func (h *Handler) streamToTelegram(
 	ctx context.Context, chatID, threadID int, response iter.Seq2[messages.Message, error],
 ) {
@@ -126,12 +197,18 @@ func (h *Handler) streamToTelegram(
 	state := h.processStream(ctx, chatID, threadID, response, limiter)
 
 	h.finalizeStreaming(ctx, chatID, threadID, state, limiter)
+
+	if state.paused {
+		h.sendContinueButton(ctx, chatID, threadID)
+	}
 }
 
 type streamState struct {
 	accumulated  string
 	lastSentText string
 	tgMsgID      int
+	// response stopped at turn limit, and may be continued.
+	paused bool
 }
 
 func (h *Handler) processStream(
@@ -140,7 +217,16 @@ func (h *Handler) processStream(
 	limiter *rate.Limiter,
 ) (state streamState) {
 	for res, err := range response {
-		if err != nil {
+		var limitErr *chat.RateLimitError
+
+		if errors.Is(err, chat.ErrTurnLimitReached) {
+			state.paused = true
+			break
+		} else if errors.As(err, &limitErr) {
+			h.sendPlanLimitMessage(ctx, chatID, &threadID, limitErr)
+
+			break
+		} else if err != nil {
 			h.log.ProcessMessageIssue(ctx, chatID, fmt.Errorf("streaming response: %w", err))
 
 			h.sendErrorMessage(ctx, chatID, &threadID)
@@ -173,6 +259,10 @@ func (h *Handler) handleStreamItem(
 		return state, false
 	}
 
+	if resp, isResponse := res.(messages.MessageToolResponse); isResponse {
+		h.sendAttachments(ctx, chatID, threadID, resp.Attachments())
+	}
+
 	state.accumulated = next
 
 	if state.accumulated == "" || state.accumulated == state.lastSentText {
@@ -244,6 +334,8 @@ func (h *Handler) accumulateMessage(
 		return "\n\nTool request: " + res.ToolName(), true
 	case messages.MessageToolResponse:
 		return "\n\nTool response: " + string(res.Content()), true
+	case messages.MessageToolProgress:
+		return "\n\n" + formatToolProgress(res), true
 	case messages.MessageUser:
 		return "", true
 	default:
@@ -254,3 +346,20 @@ func (h *Handler) accumulateMessage(
 		return "", false
 	}
 }
+
+// formatToolProgress renders live status line of running tool, e.g.
+// "Searching Gmail… 40%".
+func formatToolProgress(msg messages.MessageToolProgress) string {
+	status := msg.Message()
+	if status == "" {
+		status = "Running " + msg.ToolName()
+	}
+
+	status += "…"
+
+	if percent, ok := msg.Percent(); ok {
+		status += fmt.Sprintf(" %.0f%%", percent)
+	}
+
+	return status
+}
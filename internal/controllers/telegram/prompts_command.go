@@ -0,0 +1,368 @@
+package telegram
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	botapi "github.com/quenbyako/cynosure/contrib/tg-openapi/gen/go/botapi"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+const (
+	promptsCommand       = "/prompts"
+	promptCallbackPrefix = "prompt:"
+	// inline keyboard must stay readable, longer lists are truncated.
+	maxPromptButtons = 30
+	// menu must fit into one message, so long descriptions are cut.
+	maxPromptDescription = 100
+	// arguments are forgotten, if user doesn't answer in time: otherwise
+	// regular message, sent days later, would become an argument.
+	promptInputTimeout = 10 * time.Minute
+	// callback data is limited to 64 bytes, so prompts are referenced by
+	// short hash of account and name.
+	promptKeyLength = 12
+)
+
+// promptCall is a prompt, which arguments are collected, and which is ready
+// to be answered by agent.
+type promptCall struct {
+	account ids.AccountID
+	name    string
+	args    map[string]string
+}
+
+// promptInput is a prompt, which arguments are asked one by one: every next
+// message of the user in the same chat is a value of the next argument.
+type promptInput struct {
+	threadID ids.ThreadID
+	prompt   prompts.Prompt
+	args     map[string]string
+	// index of the argument, which is asked right now.
+	next    int
+	expires time.Time
+}
+
+type promptInputKey struct {
+	chatID, tgThreadID int
+}
+
+// promptInputs keeps prompts, which arguments are being collected, per chat.
+type promptInputs struct {
+	pending map[promptInputKey]*promptInput
+	mu      sync.Mutex
+}
+
+func newPromptInputs() *promptInputs {
+	return &promptInputs{
+		pending: make(map[promptInputKey]*promptInput),
+		mu:      sync.Mutex{},
+	}
+}
+
+func (p *promptInputs) put(key promptInputKey, input *promptInput) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	input.expires = time.Now().Add(promptInputTimeout)
+	p.pending[key] = input
+}
+
+// take removes pending input of the chat. Expired input is dropped.
+func (p *promptInputs) take(key promptInputKey) (*promptInput, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	input, ok := p.pending[key]
+	if !ok {
+		return nil, false
+	}
+
+	delete(p.pending, key)
+
+	if time.Now().After(input.expires) {
+		return nil, false
+	}
+
+	return input, true
+}
+
+// handlePrompts shows prompts of user accounts as buttons: "/prompts".
+func (h *Handler) handlePrompts(ctx context.Context, msg *botapi.Message) {
+	userID, err := h.identifyUser(ctx, msg.From)
+	if err != nil {
+		h.handleUserIdentificationError(ctx, msg, err)
+		return
+	}
+
+	list, err := h.srv.ListPrompts(ctx, userID)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("listing prompts: %w", err))
+		h.sendErrorMessage(ctx, msg.Chat.Id, msg.MessageThreadId)
+
+		return
+	}
+
+	if len(list) == 0 {
+		h.sendText(ctx, msg, "No prompts yet: connected MCP servers don't expose any.")
+		return
+	}
+
+	h.sendPromptMenu(ctx, msg, list)
+}
+
+func (h *Handler) sendPromptMenu(ctx context.Context, msg *botapi.Message, list []prompts.Prompt) {
+	var text strings.Builder
+
+	text.WriteString("Choose a prompt to run:\n")
+
+	buttons := make([][]botapi.InlineKeyboardButton, 0, min(len(list), maxPromptButtons))
+
+	for _, prompt := range list[:min(len(list), maxPromptButtons)] {
+		fmt.Fprintf(&text, "\n• %s", promptLabel(prompt))
+
+		if desc := []rune(prompt.Description()); len(desc) > maxPromptDescription {
+			fmt.Fprintf(&text, " — %s…", string(desc[:maxPromptDescription]))
+		} else if len(desc) > 0 {
+			fmt.Fprintf(&text, " — %s", string(desc))
+		}
+
+		buttons = append(buttons, []botapi.InlineKeyboardButton{{
+			Text:         promptLabel(prompt),
+			CallbackData: ptr(promptCallbackPrefix + promptKey(prompt)),
+		}})
+	}
+
+	if len(list) > maxPromptButtons {
+		fmt.Fprintf(&text, "\n\n…and %d more.", len(list)-maxPromptButtons)
+	}
+
+	var markup botapi.SendMessageJSONBody_ReplyMarkup
+	if err := markup.FromInlineKeyboardMarkup(botapi.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
+	}); err != nil {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("building prompt menu: %w", err))
+		return
+	}
+
+	//nolint:exhaustruct // too many optional fields.
+	params := botapi.SendMessageJSONRequestBody{
+		ChatId:          msg.Chat.Id,
+		Text:            text.String(),
+		MessageThreadId: msg.MessageThreadId,
+		ReplyMarkup:     &markup,
+	}
+
+	resp, err := h.client.SendMessageWithResponse(ctx, params)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id,
+			fmt.Errorf("sending prompt menu (network error): %w", err),
+		)
+
+		return
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id,
+			fmt.Errorf("sending prompt menu (api error %d): %s", resp.StatusCode(), string(resp.Body)),
+		)
+	}
+}
+
+// selectPrompt starts collecting arguments of the prompt, chosen from the
+// menu. Prompts are listed again: menu might be sent long time ago.
+func (h *Handler) selectPrompt(ctx context.Context, from *botapi.User, msg *botapi.Message, key string) {
+	userID, err := h.identifyUser(ctx, from)
+	if err != nil {
+		h.handleUserIdentificationError(ctx, msg, err)
+		return
+	}
+
+	threadID, err := ids.NewThreadID(userID, h.formatThread(msg))
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("making thread id: %w", err))
+		return
+	}
+
+	list, err := h.srv.ListPrompts(ctx, userID)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("listing prompts: %w", err))
+		h.sendErrorMessage(ctx, msg.Chat.Id, msg.MessageThreadId)
+
+		return
+	}
+
+	for _, prompt := range list {
+		if promptKey(prompt) == key {
+			h.continuePromptInput(ctx, msg, &promptInput{
+				threadID: threadID,
+				prompt:   prompt,
+				args:     make(map[string]string),
+				next:     0,
+				expires:  time.Time{},
+			})
+
+			return
+		}
+	}
+
+	h.sendText(ctx, msg, "This prompt is not available anymore, see "+promptsCommand+".")
+}
+
+// fillPromptArgument uses message as a value of the asked argument. Returns
+// false, if no prompt is waiting for arguments in this chat.
+func (h *Handler) fillPromptArgument(ctx context.Context, msg *botapi.Message, text string) bool {
+	input, ok := h.inputs.take(promptInputKeyOf(msg))
+	if !ok {
+		return false
+	}
+
+	input.args[input.prompt.Arguments()[input.next].Name()] = text
+	input.next++
+
+	h.continuePromptInput(ctx, msg, input)
+
+	return true
+}
+
+// handleSkipArgument leaves optional argument empty: "/skip". Forms of MCP
+// servers are answered first: they suspend running tool call.
+func (h *Handler) handleSkipArgument(ctx context.Context, msg *botapi.Message) {
+	if h.skipElicitation(ctx, msg) {
+		return
+	}
+
+	input, ok := h.inputs.take(promptInputKeyOf(msg))
+	if !ok {
+		h.sendText(ctx, msg, "Nothing to skip: choose a prompt with "+promptsCommand+".")
+		return
+	}
+
+	if arg := input.prompt.Arguments()[input.next]; arg.Required() {
+		h.inputs.put(promptInputKeyOf(msg), input)
+		h.sendText(ctx, msg, fmt.Sprintf("Argument %q is required, it can't be skipped.", arg.Name()))
+
+		return
+	}
+
+	input.next++
+
+	h.continuePromptInput(ctx, msg, input)
+}
+
+// handleCancelPrompt forgets prompt, which arguments are being collected:
+// "/cancel". Like "/skip", it applies to forms of MCP servers first.
+func (h *Handler) handleCancelPrompt(ctx context.Context, msg *botapi.Message) {
+	if h.cancelElicitation(ctx, msg) {
+		return
+	}
+
+	if _, ok := h.inputs.take(promptInputKeyOf(msg)); !ok {
+		h.sendText(ctx, msg, "Nothing to cancel.")
+		return
+	}
+
+	h.sendText(ctx, msg, "Prompt is canceled.")
+}
+
+// continuePromptInput asks for the next argument, or runs the prompt, if
+// all arguments are collected.
+func (h *Handler) continuePromptInput(ctx context.Context, msg *botapi.Message, input *promptInput) {
+	args := input.prompt.Arguments()
+
+	if input.next < len(args) {
+		h.inputs.put(promptInputKeyOf(msg), input)
+		h.sendText(ctx, msg, formatArgumentRequest(input.prompt, args[input.next]))
+
+		return
+	}
+
+	key := promptInputKeyOf(msg)
+
+	ok := h.pool.Submit(ctx, asyncProcessRequest{
+		userMessage: messages.MessageUser{},
+		threadID:    input.threadID,
+		chatID:      key.chatID,
+		tgThreadID:  key.tgThreadID,
+		resume:      false,
+		prompt: &promptCall{
+			account: input.prompt.Account(),
+			name:    input.prompt.Name(),
+			args:    input.args,
+		},
+	})
+	if !ok {
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id,
+			ErrInternalValidation("failed to submit async request, pool is not working"),
+		)
+	}
+}
+
+func formatArgumentRequest(prompt prompts.Prompt, arg prompts.Argument) string {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "%s: send %s", promptLabel(prompt), arg.Name())
+
+	if arg.Description() != "" {
+		fmt.Fprintf(&text, " (%s)", arg.Description())
+	}
+
+	text.WriteString(".\n\n")
+
+	if !arg.Required() {
+		text.WriteString("It's optional, send /skip to leave it empty. ")
+	}
+
+	text.WriteString("Send /cancel to stop.")
+
+	return text.String()
+}
+
+// promptErrorText explains errors, which are caused by the prompt itself,
+// not by cynosure.
+func promptErrorText(err error) (string, bool) {
+	switch {
+	case errors.Is(err, chat.ErrPromptNotFound):
+		return "This prompt is not available anymore, see " + promptsCommand + ".", true
+	case errors.Is(err, toolclient.ErrPromptRejected):
+		return "Server rejected the prompt or its arguments.", true
+	case errors.Is(err, prompts.ErrMissingArgument), errors.Is(err, prompts.ErrUnknownArgument):
+		return "Arguments of the prompt were changed by server, please, choose it again.", true
+	case errors.Is(err, chat.ErrEmptyPrompt):
+		return "Server returned an empty prompt.", true
+	case errors.Is(err, messages.ErrMessageTooLarge):
+		return "Prompt is too large to be sent to the agent.", true
+	default:
+		return "", false
+	}
+}
+
+// promptLabel is a human-readable name of the prompt.
+func promptLabel(prompt prompts.Prompt) string {
+	if prompt.Title() != "" {
+		return prompt.Title()
+	}
+
+	return prompt.Name()
+}
+
+func promptKey(prompt prompts.Prompt) string {
+	sum := sha256.Sum256([]byte(prompt.Account().ID().String() + "/" + prompt.Name()))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:promptKeyLength])
+}
+
+func promptInputKeyOf(msg *botapi.Message) promptInputKey {
+	return promptInputKey{chatID: msg.Chat.Id, tgThreadID: messageThreadID(msg)}
+}
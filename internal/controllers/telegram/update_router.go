@@ -37,6 +37,9 @@ func (h *Handler) SendUpdate(
 
 	case update.Message != nil:
 		h.processMessage(ctx, update.Message)
+
+	case update.CallbackQuery != nil:
+		h.processCallbackQuery(ctx, update.CallbackQuery)
 	}
 
 	return noContentResponse{}, nil
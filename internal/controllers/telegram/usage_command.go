@@ -0,0 +1,148 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	botapi "github.com/quenbyako/cynosure/contrib/tg-openapi/gen/go/botapi"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
+)
+
+const defaultUsageDays = 7
+
+// handleUsage reports usage of user agents for the last days: "/usage" for a
+// week, "/usage 30" for 30 days.
+func (h *Handler) handleUsage(ctx context.Context, msg *botapi.Message, args string) {
+	if h.usage == nil {
+		h.sendText(ctx, msg, "Usage reports are not available.")
+		return
+	}
+
+	days := uint(defaultUsageDays)
+
+	if args != "" {
+		parsed, err := strconv.ParseUint(args, 10, 16)
+		if err != nil {
+			h.sendText(ctx, msg, "Usage: /usage [days], for example /usage 30")
+			return
+		}
+
+		days = uint(parsed)
+	}
+
+	userID, err := h.identifyUser(ctx, msg.From)
+	if err != nil {
+		h.handleUserIdentificationError(ctx, msg, err)
+		return
+	}
+
+	report, err := h.usage.Report(ctx, userID, days)
+	switch {
+	case errors.Is(err, usage.ErrInvalidPeriod):
+		h.sendText(ctx, msg, "Period must be from 1 to 366 days.")
+	case err != nil:
+		h.log.ProcessMessageIssue(ctx, msg.Chat.Id, fmt.Errorf("building usage report: %w", err))
+		h.sendErrorMessage(ctx, msg.Chat.Id, msg.MessageThreadId)
+	default:
+		h.sendText(ctx, msg, formatUsageReport(report, days))
+	}
+}
+
+func (h *Handler) sendText(ctx context.Context, msg *botapi.Message, text string) {
+	h.sendChatText(ctx, msg.Chat.Id, msg.MessageThreadId, text)
+}
+
+func (h *Handler) sendChatText(ctx context.Context, chatID int, threadID *int, text string) {
+	//nolint:exhaustruct // too many optional fields.
+	params := botapi.SendMessageJSONRequestBody{
+		ChatId:          chatID,
+		Text:            text,
+		MessageThreadId: threadID,
+	}
+
+	resp, err := h.client.SendMessageWithResponse(ctx, params)
+	if err != nil {
+		h.log.ProcessMessageIssue(ctx, chatID, fmt.Errorf("sending message (network): %w", err))
+
+		return
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		h.log.ProcessMessageIssue(ctx, chatID,
+			fmt.Errorf("sending message (api error %d): %s", resp.StatusCode(), string(resp.Body)),
+		)
+	}
+}
+
+// formatUsageReport lists usage by days and models. Agents are not shown:
+// user knows them by names, which are not part of the report.
+func formatUsageReport(report usage.Report, days uint) string {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "Usage for the last %d days (UTC):\n", days)
+
+	if len(report.Summaries()) == 0 {
+		text.WriteString("\nNothing yet.")
+
+		return text.String()
+	}
+
+	var (
+		day    time.Time
+		models []string
+		byDay  map[string]modelUsage
+	)
+
+	flush := func() {
+		fmt.Fprintf(&text, "\n%s\n", day.Format(time.DateOnly))
+
+		for _, model := range models {
+			fmt.Fprintf(&text, "  %s: %s\n", model, byDay[model].format())
+		}
+	}
+
+	for _, summary := range report.Summaries() {
+		if !summary.Day().Equal(day) {
+			if byDay != nil {
+				flush()
+			}
+
+			day, models, byDay = summary.Day(), nil, make(map[string]modelUsage)
+		}
+
+		current, ok := byDay[summary.Model()]
+		if !ok {
+			models = append(models, summary.Model())
+		}
+
+		byDay[summary.Model()] = modelUsage{
+			turns: current.turns + summary.Turns(),
+			stats: current.stats.Add(summary.Stats()),
+		}
+	}
+
+	flush()
+
+	total := modelUsage{turns: report.Turns(), stats: report.Total()}
+	fmt.Fprintf(&text, "\nTotal: %s", total.format())
+
+	return text.String()
+}
+
+type modelUsage struct {
+	turns uint64
+	stats ledger.Stats
+}
+
+func (u modelUsage) format() string {
+	return fmt.Sprintf("%d turns, %d input / %d output tokens, %d tool calls, %d errors",
+		u.turns, u.stats.InputTokens, u.stats.OutputTokens, u.stats.ToolCalls, u.stats.Errors,
+	)
+}
@@ -5,25 +5,27 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	botapi "github.com/quenbyako/cynosure/contrib/tg-openapi/gen/go/botapi"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
 )
 
-func (h *Handler) identifyUser(ctx context.Context, msg *botapi.Message) (ids.UserID, error) {
+func (h *Handler) identifyUser(ctx context.Context, from *botapi.User) (ids.UserID, error) {
 	var nickname, firstName, lastName string
-	if msg.From.Username != nil {
-		nickname = *msg.From.Username
+	if from.Username != nil {
+		nickname = *from.Username
 	}
 
-	firstName = msg.From.FirstName
-	if msg.From.LastName != nil {
-		lastName = *msg.From.LastName
+	firstName = from.FirstName
+	if from.LastName != nil {
+		lastName = *from.LastName
 	}
 
-	userID, err := h.users.EnsureUser(ctx, strconv.Itoa(msg.From.Id), nickname, firstName, lastName)
+	userID, err := h.users.EnsureUser(ctx, strconv.Itoa(from.Id), nickname, firstName, lastName)
 	if err != nil {
 		return ids.UserID{}, fmt.Errorf("looking up user by telegram id: %w", err)
 	}
@@ -86,6 +88,44 @@ func (h *Handler) sendErrorMessage(ctx context.Context, chatID int, threadID *in
 	}
 }
 
+// sendPlanLimitMessage tells user, which quota of their plan is exhausted,
+// and when they may continue.
+func (h *Handler) sendPlanLimitMessage(
+	ctx context.Context, chatID int, tgThreadID *int, limitErr *chat.RateLimitError,
+) {
+	plan := "your plan"
+	if limitErr.Plan != "" {
+		plan = fmt.Sprintf("your %q plan", limitErr.Plan)
+	}
+
+	var text string
+	if limitErr.RetryAfter > 0 {
+		retryAt := time.Now().Add(limitErr.RetryAfter).UTC()
+		text = fmt.Sprintf(
+			"You've reached the %s limit of %s. You can continue in %v (at %s UTC).",
+			limitErr.Kind, plan, limitErr.RetryAfter.Round(time.Second), retryAt.Format("15:04"),
+		)
+	} else {
+		text = fmt.Sprintf("This request needs more %s than %s allows.", limitErr.Kind, plan)
+		if h.supportContact != "" {
+			text += fmt.Sprintf(" Please, contact %s to upgrade it.", h.supportContact)
+		}
+	}
+
+	//nolint:exhaustruct // too many optional fields.
+	params := botapi.SendMessageJSONRequestBody{
+		ChatId:          chatID,
+		Text:            text,
+		MessageThreadId: tgThreadID,
+	}
+
+	if _, err := h.client.SendMessageWithResponse(ctx, params); err != nil {
+		h.log.ProcessMessageIssue(ctx, chatID,
+			fmt.Errorf("sending plan limit message: %w", err),
+		)
+	}
+}
+
 func (h *Handler) sendTooLargeMessage(ctx context.Context, chatID int, tgThreadID *int) {
 	text := "Your message is too long, please shorten it and try again."
 
@@ -169,6 +209,16 @@ func (h *Handler) formatThread(msg *botapi.Message) string {
 	return thread
 }
 
+// messageThreadID returns forum topic of the message, or zero, if message is
+// not in a topic.
+func messageThreadID(msg *botapi.Message) int {
+	if msg.MessageThreadId != nil && *msg.MessageThreadId > 0 {
+		return *msg.MessageThreadId
+	}
+
+	return 0
+}
+
 type noContentResponse struct{}
 
 func (noContentResponse) VisitSendUpdateResponse(w http.ResponseWriter) error {
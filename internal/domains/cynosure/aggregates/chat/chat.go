@@ -226,6 +226,34 @@ func (c *Chat) SetAgent(ctx context.Context, agentID ids.AgentID) error {
 	return nil
 }
 
+// Paused reports, that previous response stopped at turn limit and may be
+// continued.
+func (c *Chat) Paused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.thread.Paused()
+}
+
+// Pause persists, that agent loop stopped at turn limit.
+func (c *Chat) Pause(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.thread.Pause() {
+		return nil
+	}
+
+	if err := c.storage.UpdateThread(ctx, c.thread); err != nil {
+		c.thread.Reset()
+		return fmt.Errorf("saving paused thread: %w", err)
+	}
+
+	c.thread.ClearEvents()
+
+	return nil
+}
+
 // SetToolboxContext updates the limit of messages to be used for building the
 // toolbox. This is useful for agents that need to change the context limit
 // dynamically.
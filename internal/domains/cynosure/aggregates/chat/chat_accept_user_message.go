@@ -13,6 +13,9 @@ import (
 // When a user speaks, the semantic context of the conversation changes
 // significantly. Therefore, this is the ONLY point in the cycle where we
 // perform expensive RAG operations.
+//
+// New message also resumes paused thread: user either continues the previous
+// response, or moves on to something else.
 func (c *Chat) AcceptUserMessage(ctx context.Context, message messages.MessageUser) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -21,6 +24,8 @@ func (c *Chat) AcceptUserMessage(ctx context.Context, message messages.MessageUs
 		return fmt.Errorf("adding user message to thread: %w", err)
 	}
 
+	c.thread.Resume()
+
 	toolbox, err := c.buildToolbox(ctx, c.toolboxContextLimit)
 	if err != nil {
 		c.thread.Reset() // Rollback: remove the message we just added
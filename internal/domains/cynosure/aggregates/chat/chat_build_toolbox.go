@@ -126,6 +126,7 @@ func buildRawTools(
 		rawTool, err := tools.NewRawTool(
 			tool.Name(), tool.Description(), tool.InputSchema(), tool.OutputSchema(),
 			tool.ID(), desc.slug, desc.desc,
+			tools.WithRawToolHints(tool.Hints()),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("creating raw tool %q: %w", tool.Name(), err)
@@ -15,6 +15,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 func TestChat_AcceptUserMessage_RAG_Orchestration(t *testing.T) {
@@ -79,8 +80,13 @@ func (f *chatFixture) tool(name string) *entities.Tool {
 	tID, err := ids.NewToolID(accID, uuid.New())
 	require.NoError(f.t, err)
 
-	schema := json.RawMessage(`{"type":"object","properties":{}}`)
-	tool, err := entities.NewTool(tID, "test-account", name, "desc", schema, schema)
+	input, err := tools.NewInputSchema(json.RawMessage(`{"type":"object","properties":{}}`))
+	require.NoError(f.t, err)
+
+	output, err := tools.NewOutputSchema(json.RawMessage(`{"type":"object","properties":{}}`))
+	require.NoError(f.t, err)
+
+	tool, err := entities.NewTool(tID, "test-account", name, "desc", input, output)
 	require.NoError(f.t, err)
 
 	return tool
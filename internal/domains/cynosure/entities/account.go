@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"fmt"
+
 	"golang.org/x/oauth2"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
@@ -15,7 +17,55 @@ type Account struct {
 	description string
 	pendingEvents[AccountEvent]
 	id     ids.AccountID
-	_valid bool
+	status AccountStatus
+	// sampling is set, when user allowed server of the account to request
+	// completions on their behalf.
+	sampling bool
+	_valid   bool
+}
+
+// AccountStatus defines, whether tools of the account may be used.
+type AccountStatus uint8
+
+const (
+	// AccountStatusActive is a default status: tools are available for
+	// agents.
+	AccountStatusActive AccountStatus = iota
+	// AccountStatusDisabled is set by user. Tools are hidden from agents,
+	// until account is reactivated.
+	AccountStatusDisabled
+	// AccountStatusNeedsReauth means, that server rejected token of the
+	// account, and user must sign in again.
+	AccountStatusNeedsReauth
+	// AccountStatusDeleted is a final status: account can't be reactivated.
+	AccountStatusDeleted
+)
+
+func (s AccountStatus) String() string {
+	switch s {
+	case AccountStatusActive:
+		return "active"
+	case AccountStatusDisabled:
+		return "disabled"
+	case AccountStatusNeedsReauth:
+		return "needs_reauth"
+	case AccountStatusDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("AccountStatus(%d)", uint8(s))
+	}
+}
+
+// ParseAccountStatus parses status from its [AccountStatus.String]
+// representation.
+func ParseAccountStatus(s string) (AccountStatus, error) {
+	for status := AccountStatusActive; status <= AccountStatusDeleted; status++ {
+		if status.String() == s {
+			return status, nil
+		}
+	}
+
+	return 0, ErrInternalValidation("unknown account status %q", s)
 }
 
 var (
@@ -29,6 +79,14 @@ func WithAuthToken(token *oauth2.Token) NewAccountOption {
 	return func(c *Account) { c.token = token }
 }
 
+func WithAccountStatus(status AccountStatus) NewAccountOption {
+	return func(c *Account) { c.status = status }
+}
+
+func WithSamplingAllowed(allowed bool) NewAccountOption {
+	return func(c *Account) { c.sampling = allowed }
+}
+
 func NewAccount(
 	id ids.AccountID,
 	name, description string,
@@ -39,6 +97,8 @@ func NewAccount(
 		name:        name,
 		description: description,
 		token:       nil,
+		status:      AccountStatusActive,
+		sampling:    false,
 
 		pendingEvents: pendingEvents[AccountEvent]{},
 		_valid:        false,
@@ -69,6 +129,10 @@ func (c *Account) validate() error {
 		return ErrInternalValidation("description is required")
 	}
 
+	if c.status > AccountStatusDeleted {
+		return ErrInternalValidation("unknown status %v", c.status)
+	}
+
 	return nil
 }
 
@@ -79,12 +143,16 @@ type AccountReadOnly interface {
 	Token() *oauth2.Token
 	Name() string
 	Description() string
+	Status() AccountStatus
+	SamplingAllowed() bool
 }
 
-func (c *Account) ID() ids.AccountID    { return c.id }
-func (c *Account) Token() *oauth2.Token { return c.token }
-func (c *Account) Name() string         { return c.name }
-func (c *Account) Description() string  { return c.description }
+func (c *Account) ID() ids.AccountID     { return c.id }
+func (c *Account) Token() *oauth2.Token  { return c.token }
+func (c *Account) Name() string          { return c.name }
+func (c *Account) Description() string   { return c.description }
+func (c *Account) Status() AccountStatus { return c.status }
+func (c *Account) SamplingAllowed() bool { return c.sampling }
 
 // WRITE
 
@@ -109,6 +177,59 @@ func (c *Account) UpdateToken(token *oauth2.Token) error {
 	return nil
 }
 
+// Disable hides tools of the account from agents. Disabling already disabled
+// account is no-op.
+func (c *Account) Disable() error {
+	return c.setStatus(AccountStatusDisabled)
+}
+
+// Activate makes tools of the account available again.
+func (c *Account) Activate() error {
+	return c.setStatus(AccountStatusActive)
+}
+
+// RequireReauth marks, that token of the account was rejected, and user must
+// sign in again.
+func (c *Account) RequireReauth() error {
+	return c.setStatus(AccountStatusNeedsReauth)
+}
+
+func (c *Account) setStatus(status AccountStatus) error {
+	if c.status == AccountStatusDeleted {
+		return ErrInternalValidation("account is deleted")
+	}
+
+	if c.status == status {
+		return nil
+	}
+
+	c.status = status
+	c.pendingEvents = append(c.pendingEvents, AccountEventStatusChanged{
+		status: status,
+	})
+
+	return nil
+}
+
+// AllowSampling sets, whether server of the account may request completions
+// on behalf of the user. Setting the same value is no-op.
+func (c *Account) AllowSampling(allowed bool) error {
+	if c.status == AccountStatusDeleted {
+		return ErrInternalValidation("account is deleted")
+	}
+
+	if c.sampling == allowed {
+		return nil
+	}
+
+	c.sampling = allowed
+	c.pendingEvents = append(c.pendingEvents, AccountEventSamplingChanged{
+		allowed: allowed,
+	})
+
+	return nil
+}
+
 // EVENTS
 
 // AccountEvent is an unify interface to aggregate all event types related to
@@ -117,6 +238,8 @@ func (c *Account) UpdateToken(token *oauth2.Token) error {
 // Available types:
 //
 //   - [AccountEventTokenUpdated]
+//   - [AccountEventStatusChanged]
+//   - [AccountEventSamplingChanged]
 type AccountEvent interface {
 	_AccountEvent()
 }
@@ -128,3 +251,19 @@ type AccountEventTokenUpdated struct {
 func (e AccountEventTokenUpdated) _AccountEvent() {}
 
 func (e AccountEventTokenUpdated) Token() *oauth2.Token { return e.token }
+
+type AccountEventStatusChanged struct {
+	status AccountStatus
+}
+
+func (e AccountEventStatusChanged) _AccountEvent() {}
+
+func (e AccountEventStatusChanged) Status() AccountStatus { return e.status }
+
+type AccountEventSamplingChanged struct {
+	allowed bool
+}
+
+func (e AccountEventSamplingChanged) _AccountEvent() {}
+
+func (e AccountEventSamplingChanged) Allowed() bool { return e.allowed }
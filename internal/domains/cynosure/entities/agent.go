@@ -3,6 +3,7 @@ package entities
 import (
 	"slices"
 
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 )
 
@@ -19,6 +20,8 @@ type Agent struct {
 	// попробовать отыскать openai-совместимые апи для разных моделей чтоб можно
 	// было их легко подключать.
 	model string
+	// name is human-readable name, which user picks to tell agents apart.
+	name string
 
 	// system message for model
 	systemMessage string
@@ -47,8 +50,14 @@ type Agent struct {
 	// If value is zero, it means, that agent doesn't have any limit and all
 	// messages in session will be provided.
 	maxContext uint
-	id         ids.AgentID
-	_valid     bool
+	// budget caps tokens and money, which agent may spend. Zero limits mean,
+	// that only user budget applies.
+	budget budget.Limits
+	id     ids.AgentID
+	// disabled agents are kept for history of threads, but can't respond
+	// anymore.
+	disabled bool
+	_valid   bool
 }
 
 var (
@@ -58,6 +67,14 @@ var (
 
 type NewModelSettingsOption func(*Agent)
 
+func WithName(name string) NewModelSettingsOption {
+	return func(a *Agent) { a.name = name }
+}
+
+func WithDisabled(disabled bool) NewModelSettingsOption {
+	return func(a *Agent) { a.disabled = disabled }
+}
+
 func WithSystemMessage(message string) NewModelSettingsOption {
 	return func(a *Agent) { a.systemMessage = message }
 }
@@ -78,6 +95,10 @@ func WithMaxContext(limit uint) NewModelSettingsOption {
 	return func(a *Agent) { a.maxContext = limit }
 }
 
+func WithBudget(limits budget.Limits) NewModelSettingsOption {
+	return func(a *Agent) { a.budget = limits }
+}
+
 func NewModelSettings(
 	id ids.AgentID,
 	model string,
@@ -86,12 +107,15 @@ func NewModelSettings(
 	agent := &Agent{
 		id:            id,
 		model:         model,
+		name:          "",
 		systemMessage: "",
 		temperature:   -1,
 		topP:          -1,
 		maxContext:    0,
+		budget:        budget.Limits{},
 		stopWords:     nil,
 		pendingEvents: nil,
+		disabled:      false,
 		_valid:        false,
 	}
 	for _, opt := range opts {
@@ -131,20 +155,26 @@ func (c *Agent) ClearEvents()                { c.pendingEvents = c.pendingEvents
 type AgentReadOnly interface {
 	ID() ids.AgentID
 	Model() string
+	Name() string
+	Disabled() bool
 	SystemMessage() string
 	Temperature() (float32, bool)
 	TopP() (float32, bool)
 	StopWords() []string
 	MaxContext() (uint, bool)
+	Budget() budget.Limits
 }
 
 func (c *Agent) ID() ids.AgentID              { return c.id }
 func (c *Agent) Model() string                { return c.model }
+func (c *Agent) Name() string                 { return c.name }
+func (c *Agent) Disabled() bool               { return c.disabled }
 func (c *Agent) SystemMessage() string        { return c.systemMessage }
 func (c *Agent) Temperature() (float32, bool) { return c.temperature, c.temperature > 0 }
 func (c *Agent) TopP() (float32, bool)        { return c.topP, c.topP > 0 }
 func (c *Agent) StopWords() []string          { return slices.Clone(c.stopWords) }
 func (c *Agent) MaxContext() (uint, bool)     { return c.maxContext, c.maxContext > 0 }
+func (c *Agent) Budget() budget.Limits        { return c.budget }
 
 // WRITE
 
@@ -162,13 +192,55 @@ func (c *Agent) SetSystemMessage(message string) error {
 	return nil
 }
 
+func (c *Agent) SetName(name string) error {
+	c.name = name
+
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	c.pendingEvents = append(c.pendingEvents, &AgentEventNameUpdated{name: name})
+
+	return nil
+}
+
+func (c *Agent) SetModel(model string) error {
+	prev := c.model
+	c.model = model
+
+	if err := c.Validate(); err != nil {
+		c.model = prev
+
+		return err
+	}
+
+	c.pendingEvents = append(c.pendingEvents, &AgentEventModelUpdated{model: model})
+
+	return nil
+}
+
+// Disable deactivates agent. Disabling already disabled agent is no-op.
+func (c *Agent) Disable() {
+	if c.disabled {
+		return
+	}
+
+	c.disabled = true
+	c.pendingEvents = append(c.pendingEvents, &AgentEventDisabled{})
+}
+
 // EVENTS
 
 type AgentEvent interface {
 	_AgentEvent()
 }
 
-var _ AgentEvent = (*AgentEventSystemMessageUpdated)(nil)
+var (
+	_ AgentEvent = (*AgentEventSystemMessageUpdated)(nil)
+	_ AgentEvent = (*AgentEventNameUpdated)(nil)
+	_ AgentEvent = (*AgentEventModelUpdated)(nil)
+	_ AgentEvent = (*AgentEventDisabled)(nil)
+)
 
 type AgentEventSystemMessageUpdated struct {
 	msg string
@@ -177,3 +249,23 @@ type AgentEventSystemMessageUpdated struct {
 func (e *AgentEventSystemMessageUpdated) _AgentEvent() {}
 
 func (e *AgentEventSystemMessageUpdated) Message() string { return e.msg }
+
+type AgentEventNameUpdated struct {
+	name string
+}
+
+func (e *AgentEventNameUpdated) _AgentEvent() {}
+
+func (e *AgentEventNameUpdated) Name() string { return e.name }
+
+type AgentEventModelUpdated struct {
+	model string
+}
+
+func (e *AgentEventModelUpdated) _AgentEvent() {}
+
+func (e *AgentEventModelUpdated) Model() string { return e.model }
+
+type AgentEventDisabled struct{}
+
+func (e *AgentEventDisabled) _AgentEvent() {}
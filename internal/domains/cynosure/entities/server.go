@@ -90,6 +90,12 @@ func (c *ServerConfig) Validate() error {
 		return ErrInternalValidation("SSE link is nil")
 	}
 
+	// local servers run commands on the host, so only administrator can add
+	// them, the same way as other internal servers.
+	if tools.IsStdioLink(c.sseLink) && !c.internal {
+		return ErrInternalValidation("stdio server %q must be internal", c.sseLink.Host)
+	}
+
 	if err := c.validateConfig(c.authConfig); err != nil {
 		return err
 	}
@@ -13,7 +13,9 @@ type Thread struct {
 	pendingEvents[ThreadEvent]
 	id      ids.ThreadID
 	agentID ids.AgentID
-	_valid  bool
+	// agent loop stopped at turn limit, and user may continue it.
+	paused bool
+	_valid bool
 }
 
 var (
@@ -27,6 +29,11 @@ func WithAgent(id ids.AgentID) ThreadOption {
 	return func(t *Thread) { t.agentID = id }
 }
 
+// WithPaused restores thread, which stopped at turn limit.
+func WithPaused() ThreadOption {
+	return func(t *Thread) { t.paused = true }
+}
+
 func NewThread(
 	id ids.ThreadID,
 	history []messages.Message,
@@ -37,6 +44,7 @@ func NewThread(
 		messages:      history,
 		pendingEvents: nil,
 		agentID:       ids.AgentID{},
+		paused:        false,
 		_valid:        false,
 	}
 	for _, opt := range opts {
@@ -74,6 +82,11 @@ func (c *Thread) validateMessages(history []messages.Message) error {
 		if !msg.Valid() {
 			return ErrInternalValidation("message %d is invalid", i)
 		}
+
+		// progress is transient, it never becomes part of the history.
+		if _, ok := msg.(messages.MessageToolProgress); ok {
+			return ErrInternalValidation("message %d is a tool progress", i)
+		}
 	}
 
 	return nil
@@ -101,11 +114,16 @@ type ThreadReadOnly interface {
 	ID() ids.ThreadID
 	Messages(limit uint) []messages.Message
 	AgentID() ids.AgentID
+	Paused() bool
 }
 
 func (c *Thread) ID() ids.ThreadID     { return c.id }
 func (c *Thread) AgentID() ids.AgentID { return c.agentID }
 
+// Paused reports, that agent loop stopped at turn limit before completing the
+// answer.
+func (c *Thread) Paused() bool { return c.paused }
+
 // Messages returns messages history trimmed to the given limit using
 // Sliding Window pattern with Tool-Safety guarantees.
 //
@@ -235,6 +253,32 @@ func (c *Thread) SetAgent(agentID ids.AgentID) bool {
 	return true
 }
 
+// Pause marks thread as stopped at turn limit. Returns false, if thread is
+// already paused.
+func (c *Thread) Pause() bool {
+	if c.paused {
+		return false
+	}
+
+	c.paused = true
+	c.pendingEvents = append(c.pendingEvents, ThreadEventPaused{})
+
+	return true
+}
+
+// Resume clears pause, so agent loop can run again. Returns false, if thread
+// is not paused.
+func (c *Thread) Resume() bool {
+	if !c.paused {
+		return false
+	}
+
+	c.paused = false
+	c.pendingEvents = append(c.pendingEvents, ThreadEventResumed{})
+
+	return true
+}
+
 // EVENTS
 
 type ThreadEvent interface{ undo(c *Thread) }
@@ -275,6 +319,14 @@ func (e ThreadEventAgentSet) AgentID() ids.AgentID { return e.agentID }
 
 func (e ThreadEventAgentSet) undo(c *Thread) { c.agentID = e.previous }
 
+type ThreadEventPaused struct{}
+
+func (e ThreadEventPaused) undo(c *Thread) { c.paused = false }
+
+type ThreadEventResumed struct{}
+
+func (e ThreadEventResumed) undo(c *Thread) { c.paused = true }
+
 func cloneWithAppend[S ~[]T, T any](other S, others ...T) S {
 	res := make([]T, len(other), len(other)+len(others))
 	copy(res, other)
@@ -354,3 +354,44 @@ func (invalidMsg) MergeTag() uint64 { return 0 }
 func (invalidMsg) Validate() error {
 	return fmt.Errorf("invalid: %w", entities.ErrInternalValidation("invalid"))
 }
+
+func TestThread_Pause(t *testing.T) {
+	threadID, err := ids.RandomThreadID(ids.RandomUserID())
+	require.NoError(t, err)
+
+	thread, err := entities.NewThread(threadID, []messages.Message{user(t, "first")})
+	require.NoError(t, err)
+	assert.False(t, thread.Paused())
+
+	t.Run("Pause and resume", func(t *testing.T) {
+		assert.True(t, thread.Pause())
+		assert.False(t, thread.Pause())
+		assert.True(t, thread.Paused())
+
+		assert.True(t, thread.Resume())
+		assert.False(t, thread.Resume())
+		assert.False(t, thread.Paused())
+
+		events := thread.PendingEvents()
+		require.Len(t, events, 2)
+		assert.IsType(t, entities.ThreadEventPaused{}, events[0])
+		assert.IsType(t, entities.ThreadEventResumed{}, events[1])
+	})
+
+	t.Run("Undo pause", func(t *testing.T) {
+		thread.ClearEvents()
+
+		thread.Pause()
+		thread.Reset()
+		assert.False(t, thread.Paused())
+	})
+
+	t.Run("Restore paused thread", func(t *testing.T) {
+		restored, err := entities.NewThread(
+			threadID, []messages.Message{user(t, "first")}, entities.WithPaused(),
+		)
+		require.NoError(t, err)
+		assert.True(t, restored.Paused())
+		assert.True(t, restored.Synchronized())
+	})
+}
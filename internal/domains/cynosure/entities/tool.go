@@ -3,12 +3,11 @@ package entities
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-
-	"github.com/getkin/kin-openapi/openapi3"
+	"time"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 const (
@@ -24,12 +23,16 @@ type Tool struct {
 	accountName              string
 	name                     string
 	description              string
-	inputSchema              json.RawMessage
-	outputSchema             json.RawMessage
+	inputSchema              tools.Schema
+	outputSchema             tools.Schema
 	pendingEvents[ToolEvent] // meta field for tracking updates
 	embedding                [embeddingSize]float32
 	id                       ids.ToolID
-	_valid                   bool // indicates if the tool info is valid
+	hints                    tools.Hints
+	// user setting: results of the tool could be reused during this time.
+	// Zero means, that user didn't set it.
+	cacheTTL time.Duration
+	_valid   bool // indicates if the tool info is valid
 }
 
 var (
@@ -39,6 +42,16 @@ var (
 
 type ToolOption func(*Tool)
 
+// WithHints sets behavior hints, declared by tool server.
+func WithHints(hints tools.Hints) ToolOption {
+	return func(t *Tool) { t.hints = hints }
+}
+
+// WithCacheTTL sets, how long results of the tool could be reused.
+func WithCacheTTL(ttl time.Duration) ToolOption {
+	return func(t *Tool) { t.cacheTTL = ttl }
+}
+
 //nolint:gocritic // hugeparam: large array passed by value, architecture constraint
 func WithEmbedding(embedding [embeddingSize]float32) ToolOption {
 	return func(t *Tool) { t.embedding = embedding }
@@ -47,30 +60,7 @@ func WithEmbedding(embedding [embeddingSize]float32) ToolOption {
 func NewTool(
 	id ids.ToolID,
 	accountName, name, description string,
-	inputSchema, outputSchema json.RawMessage,
-	opts ...ToolOption,
-) (*Tool, error) {
-	normalizedInput, err := normalizeInputSchema(inputSchema)
-	if err != nil {
-		return nil, err
-	}
-
-	normalizedOutput, err := normalizeOutputSchema(outputSchema)
-	if err != nil {
-		return nil, err
-	}
-
-	return newToolFromNormalized(
-		id, accountName, name, description,
-		normalizedInput, normalizedOutput,
-		opts...,
-	)
-}
-
-func newToolFromNormalized(
-	id ids.ToolID,
-	accountName, name, description string,
-	normalizedInput, normalizedOutput json.RawMessage,
+	inputSchema, outputSchema tools.Schema,
 	opts ...ToolOption,
 ) (*Tool, error) {
 	tool := Tool{
@@ -78,11 +68,13 @@ func newToolFromNormalized(
 		accountName:   accountName,
 		name:          name,
 		description:   description,
-		inputSchema:   normalizedInput,
-		outputSchema:  normalizedOutput,
+		inputSchema:   inputSchema,
+		outputSchema:  outputSchema,
 		_valid:        false,
 		pendingEvents: nil,
 		embedding:     [embeddingSize]float32{},
+		hints:         tools.Hints{},
+		cacheTTL:      0,
 	}
 	for _, opt := range opts {
 		opt(&tool)
@@ -106,40 +98,19 @@ func (t *Tool) Validate() error {
 		return ErrInternalValidation("description is required, but empty")
 	}
 
-	return nil
-}
-
-// NORMALIZATION
-
-func normalizeInputSchema(schema json.RawMessage) (json.RawMessage, error) {
-	return normalizeSchema(schema, openapi3.TypeObject)
-}
-
-func normalizeOutputSchema(schema json.RawMessage) (json.RawMessage, error) {
-	return normalizeSchema(schema, "")
-}
-
-func normalizeSchema(schema json.RawMessage, verifyRoot string) (json.RawMessage, error) {
-	var parsedSchema openapi3.Schema
-	if err := json.Unmarshal(schema, &parsedSchema); err != nil {
-		return nil, fmt.Errorf("cannot parse schema: %w", err)
+	if err := t.inputSchema.Validate(); err != nil {
+		return ErrInternalValidation("invalid input schema: %v", err)
 	}
 
-	if verifyRoot != "" && !parsedSchema.Type.Is(verifyRoot) {
-		return nil, ErrInternalValidation(
-			"invalid schema type: %s, must be only object",
-			parsedSchema.Type,
-		)
+	if err := t.outputSchema.Validate(); err != nil {
+		return ErrInternalValidation("invalid output schema: %v", err)
 	}
 
-	schema, err := json.Marshal(&parsedSchema)
-	if err != nil {
-		// TODO: guarantee somehow that parsed schema will always be a valid
-		// json for serialization. This error should NEVER EVER exist.
-		return nil, fmt.Errorf("cannot marshal schema back: %w", err)
+	if t.cacheTTL < 0 {
+		return ErrInternalValidation("cache ttl must not be negative")
 	}
 
-	return schema, nil
+	return nil
 }
 
 // READ
@@ -149,18 +120,22 @@ type ToolReadOnly interface {
 	AccountName() string
 	Name() string
 	Description() string
-	InputSchema() json.RawMessage
-	OutputSchema() json.RawMessage
+	InputSchema() tools.Schema
+	OutputSchema() tools.Schema
 	Embedding() [embeddingSize]float32
+	Hints() tools.Hints
+	CacheTTL() time.Duration
 }
 
 func (t *Tool) ID() ids.ToolID                    { return t.id }
 func (t *Tool) AccountName() string               { return t.accountName }
 func (t *Tool) Name() string                      { return t.name }
 func (t *Tool) Description() string               { return t.description }
-func (t *Tool) InputSchema() json.RawMessage      { return t.inputSchema }
-func (t *Tool) OutputSchema() json.RawMessage     { return t.outputSchema }
+func (t *Tool) InputSchema() tools.Schema         { return t.inputSchema }
+func (t *Tool) OutputSchema() tools.Schema        { return t.outputSchema }
 func (t *Tool) Embedding() [embeddingSize]float32 { return t.embedding }
+func (t *Tool) Hints() tools.Hints                { return t.hints }
+func (t *Tool) CacheTTL() time.Duration           { return t.cacheTTL }
 
 // WRITE
 
@@ -175,6 +150,38 @@ func (t *Tool) SetEmbedding(embedding [embeddingSize]float32) {
 	})
 }
 
+// SetCacheTTL sets, how long results of the tool could be reused. Zero
+// disables caching, unless tool is declared as cacheable by its server.
+func (t *Tool) SetCacheTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return ErrInternalValidation("cache ttl must not be negative")
+	}
+
+	previous := t.cacheTTL
+	t.cacheTTL = ttl
+
+	t.pendingEvents = append(t.pendingEvents, ToolEventCacheTTLUpdated{
+		previous: previous,
+		ttl:      ttl,
+	})
+
+	return nil
+}
+
+// ToolMatch is a tool, found by semantic search.
+type ToolMatch struct {
+	tool *Tool
+	// cosine similarity between query and tool description, from -1 to 1.
+	relevance float64
+}
+
+func NewToolMatch(tool *Tool, relevance float64) ToolMatch {
+	return ToolMatch{tool: tool, relevance: relevance}
+}
+
+func (m ToolMatch) Tool() *Tool        { return m.tool }
+func (m ToolMatch) Relevance() float64 { return m.relevance }
+
 // EVENTS
 
 // ToolEvent defines event for tool entity.
@@ -182,6 +189,7 @@ func (t *Tool) SetEmbedding(embedding [embeddingSize]float32) {
 // Implements by:
 //
 //   - [ToolEventEmbeddingUpdated]
+//   - [ToolEventCacheTTLUpdated]
 type ToolEvent interface {
 	_ToolEvent()
 }
@@ -196,3 +204,12 @@ func (e ToolEventEmbeddingUpdated) _ToolEvent() {}
 
 //nolint:gocritic // hugeparam, architecture mistake.
 func (e ToolEventEmbeddingUpdated) Embedding() [embeddingSize]float32 { return e.embedding }
+
+type ToolEventCacheTTLUpdated struct {
+	previous time.Duration
+	ttl      time.Duration
+}
+
+func (e ToolEventCacheTTLUpdated) _ToolEvent() {}
+
+func (e ToolEventCacheTTLUpdated) TTL() time.Duration { return e.ttl }
@@ -35,6 +35,15 @@ type AccountStorageRead interface {
 	//  -  [TestSaveAccount] — checking that account is retrievable after saving
 	ListAccounts(ctx context.Context, user ids.UserID) ([]ids.AccountID, error)
 
+	// ListActiveAccounts returns IDs of active accounts of every user. Empty
+	// slice if none exist. Disabled accounts and accounts, which require
+	// reauthorization, are skipped.
+	//
+	// See next test suites to find how it works:
+	//
+	//  -  [TestAccountStatus] — only active accounts are listed
+	ListActiveAccounts(ctx context.Context) ([]ids.AccountID, error)
+
 	// GetAccount retrieves full account info.
 	//
 	// See next test suites to find how it works:
@@ -66,14 +75,17 @@ type AccountStorageWrite interface {
 	//
 	//  -  [TestSaveAccount] — persisting account with tools and retrieving it
 	//     back
+	//  -  [TestAccountStatus] — persisting account status
 	SaveAccount(ctx context.Context, info entities.AccountReadOnly) error
 
-	// DeleteAccount soft-deletes account (marks as deleted, keeps data on
-	// disk). Idempotent operation. Does not cascade to related entities like
-	// threads or messages.
+	// DeleteAccount soft-deletes account and its tools (marks as deleted,
+	// keeps data on disk), and removes OAuth token of the account. Idempotent
+	// operation. Does not cascade to related entities like threads or
+	// messages. Deleted account is not retrievable anymore.
 	//
 	// See next test suites to find how it works:
 	//
-	//  -  (tested in integration tests, no dedicated suite yet)
+	//  -  [TestDeleteAccount] — deleting account and verifying it's no longer
+	//     retrievable
 	DeleteAccount(ctx context.Context, account ids.AccountID) error
 }
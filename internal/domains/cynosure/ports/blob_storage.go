@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// BlobStorage keeps large tool outputs, which don't fit into the thread
+// history. Model receives only preview of such output, and reads the rest of
+// it by pages.
+type BlobStorage interface {
+	// SaveBlob stores data under given id. Blobs are immutable: saving the
+	// same id twice is not supported.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSaveAndReadBlob] — storing blob and reading it back
+	//
+	// Throws:
+	//
+	//  - [ErrAlreadyExists] if blob with this id already exists.
+	SaveBlob(ctx context.Context, blob ids.BlobID, data []byte) error
+
+	// ReadBlob returns up to length bytes of the blob, starting from offset,
+	// and total size of the blob. Reading beyond the end of the blob is not an
+	// error, it returns empty chunk.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSaveAndReadBlob] — storing blob and reading it back
+	//  - [TestReadBlobRange] — reading blob by pages
+	//  - [TestReadBlobOtherThread] — blobs are isolated between threads
+	//
+	// Throws:
+	//
+	//  - [ErrNotFound] if blob doesn't exist in the thread of given id.
+	ReadBlob(ctx context.Context, blob ids.BlobID, offset, length int64) ([]byte, int64, error)
+}
+
+type BlobStorageFactory interface {
+	BlobStorage() BlobStorage
+}
+
+func NewBlobStorage(factory BlobStorageFactory) BlobStorage {
+	return factory.BlobStorage()
+}
@@ -4,4 +4,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
 )
 
-var ErrHistoryTooLong = messages.ErrInternalValidation("history is too long")
+var (
+	ErrHistoryTooLong         = messages.ErrInternalValidation("history is too long")
+	ErrInvalidMaxOutputTokens = messages.ErrInternalValidation("negative max output tokens")
+)
@@ -24,6 +24,16 @@ func WithStreamToolChoice(choice tools.ToolChoice) StreamOption {
 	return streamFunc(func(p *streamParams) { p.toolChoice = choice })
 }
 
+// WithStreamMaxOutputTokens limits amount of tokens, which model may
+// generate in the response. Zero keeps default limit of the model.
+//
+// Applies to:
+//
+//   - [ChatModel.Stream]
+func WithStreamMaxOutputTokens(limit int) StreamOption {
+	return streamFunc(func(p *streamParams) { p.maxOutputTokens = limit })
+}
+
 type (
 	StreamOption interface{ applyStream(p *streamParams) }
 
@@ -46,7 +56,8 @@ type streamRequiredParams struct {
 type streamParams struct {
 	tools tools.Toolbox
 	streamRequiredParams
-	toolChoice tools.ToolChoice
+	toolChoice      tools.ToolChoice
+	maxOutputTokens int
 }
 
 func StreamParams(
@@ -71,3 +82,4 @@ func (s *streamParams) Input() []messages.Message        { return s.input }
 func (s *streamParams) Settings() entities.AgentReadOnly { return s.settings }
 func (s *streamParams) Toolbox() tools.Toolbox           { return s.tools }
 func (s *streamParams) ToolChoice() tools.ToolChoice     { return s.toolChoice }
+func (s *streamParams) MaxOutputTokens() int             { return s.maxOutputTokens }
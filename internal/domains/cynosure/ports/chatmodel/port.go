@@ -25,6 +25,7 @@ type Port interface {
 	//
 	//  - [WithStreamToolbox] — sets the toolbox for newly creating tools.
 	//  - [WithStreamToolChoice] — sets the tool choice for newly creating tools.
+	//  - [WithStreamMaxOutputTokens] — limits length of the response.
 	//
 	// See next test suites to find how it works:
 	//
@@ -54,10 +55,15 @@ func defaultStreamParams(required streamRequiredParams) streamParams {
 		streamRequiredParams: required,
 		tools:                tools.Toolbox{},
 		toolChoice:           tools.ToolChoiceAllowed,
+		maxOutputTokens:      0,
 	}
 }
 
 func (s *streamParams) validate() error {
+	if s.maxOutputTokens < 0 {
+		return ErrInvalidMaxOutputTokens
+	}
+
 	return nil
 }
 
@@ -10,6 +10,10 @@ var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
 
+	// ErrConcurrentModification indicates that entity was changed by another
+	// request after it was read, and changes were not saved.
+	ErrConcurrentModification = errors.New("concurrent modification")
+
 	ErrToolsNotCached = errors.New("tools were not cached")
 
 	ErrAuthUnsupported = errors.New(
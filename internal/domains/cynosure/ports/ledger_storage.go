@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+)
+
+// LedgerStorage keeps record of every agent turn: tokens, time and tool calls,
+// consumed by agents. Entries are never changed, so ledger is used for usage
+// reports. Budgets are checked against [UsageStorage] instead: turn is saved
+// to both storages separately, and if one of them fails, reports and budgets
+// may differ by this turn.
+type LedgerStorage interface {
+	// AddEntry appends entry to the ledger.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestAddAndListEntries] — entries are summed by day, agent and model
+	AddEntry(ctx context.Context, entry ledger.Entry) error
+
+	// ListSummaries returns entries of all user agents in [since, until)
+	// period, summed by calendar day (UTC), agent and model. Summaries are
+	// sorted by day, then by agent and model. Unknown user has no summaries.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestAddAndListEntries] — entries are summed by day, agent and model
+	//  - [TestListSummariesPeriod] — entries outside of period are skipped
+	ListSummaries(
+		ctx context.Context, user ids.UserID, since, until time.Time,
+	) ([]ledger.Summary, error)
+}
+
+type LedgerStorageFactory interface {
+	LedgerStorage() LedgerStorage
+}
+
+func NewLedgerStorage(factory LedgerStorageFactory) LedgerStorage {
+	return factory.LedgerStorage()
+}
@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// LinkStorage keeps urls, which were replaced with short tokens before
+// passing content to the model.
+type LinkStorage interface {
+	// SaveLink binds token to the url, found in the thread, and returns token,
+	// which must be used for the url. If url was already saved in the thread,
+	// its existing token is returned and marked as used, so the same url keeps
+	// the same token across turns.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSaveAndGetLink] — storing link and resolving it back
+	//  - [TestSaveLinkConflict] — token can't be rebound to other url
+	//  - [TestSaveLinkReuse] — url of the thread keeps its token
+	//
+	// Throws:
+	//
+	//  - [ErrAlreadyExists] if token is already bound to another url.
+	SaveLink(
+		ctx context.Context, link ids.LinkID, thread ids.ThreadID, target *url.URL,
+	) (ids.LinkID, error)
+
+	// GetLink resolves token to the original url.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSaveAndGetLink] — storing link and resolving it back
+	//
+	// Throws:
+	//
+	//  - [ErrNotFound] if token is unknown.
+	GetLink(ctx context.Context, link ids.LinkID) (*url.URL, error)
+
+	// DeleteLinksBefore removes links, which were not saved since the given
+	// time. References to them are not resolved anymore.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestDeleteLinksBefore] — only stale links are removed
+	DeleteLinksBefore(ctx context.Context, before time.Time) error
+}
+
+type LinkStorageFactory interface {
+	LinkStorage() LinkStorage
+}
+
+func NewLinkStorage(factory LinkStorageFactory) LinkStorage {
+	return factory.LinkStorage()
+}
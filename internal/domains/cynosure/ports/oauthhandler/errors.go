@@ -23,6 +23,10 @@ var (
 	// Use case should prompt user to re-authenticate.
 	ErrInvalidCredentials = errors.New("invalid credentials")
 
+	// ErrRevocationNotSupported indicates that authorization server doesn't
+	// declare revocation endpoint. Tokens will expire by themselves.
+	ErrRevocationNotSupported = errors.New("token revocation not supported")
+
 	// ErrProtocolNotSupported indicates that server doesn't support any known
 	// protocols.
 	ErrProtocolNotSupported = errors.New("protocol not supported")
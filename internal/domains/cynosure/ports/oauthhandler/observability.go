@@ -75,6 +75,21 @@ func (o *observable) exchange(
 	return ctx, &spanCallback{span: span}
 }
 
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) revokeToken(
+	ctx context.Context, clientID, tokenURL string,
+) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.oauth.revoke_token",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			semconv.URLFull(tokenURL),
+			attribute.Key("cynosure.oauth.client_id").String(clientID),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
 // log callbacks
 
 // generic span
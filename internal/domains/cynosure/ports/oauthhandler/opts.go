@@ -22,6 +22,7 @@ func WithSuggestedProtectedResource(link *url.URL) RegisterClientOption {
 //
 //   - [Port.RefreshToken]
 //   - [Port.Exchange]
+//   - [Port.RevokeToken]
 func WithInternalConnection() internalConnectionOption {
 	return internalConnectionOption{}
 }
@@ -30,6 +31,7 @@ type internalConnectionOption struct{}
 
 func (i internalConnectionOption) applyRegisterClient(p *registerClientParams) { p.internal = true }
 func (i internalConnectionOption) applyRefreshToken(p *refreshTokenParams)     { p.internal = true }
+func (i internalConnectionOption) applyRevokeToken(p *revokeTokenParams)       { p.internal = true }
 
 // ========================================================================== //
 //                                [types]                                     //
@@ -38,18 +40,22 @@ func (i internalConnectionOption) applyRefreshToken(p *refreshTokenParams)     {
 type (
 	RegisterClientOption interface{ applyRegisterClient(p *registerClientParams) }
 	RefreshTokenOption   interface{ applyRefreshToken(p *refreshTokenParams) }
+	RevokeTokenOption    interface{ applyRevokeToken(p *revokeTokenParams) }
 
 	registerClientFunc func(*registerClientParams)
 	refreshTokenFunc   func(*refreshTokenParams)
+	revokeTokenFunc    func(*revokeTokenParams)
 )
 
 var (
 	_ RegisterClientOption = registerClientFunc(nil)
 	_ RefreshTokenOption   = refreshTokenFunc(nil)
+	_ RevokeTokenOption    = revokeTokenFunc(nil)
 )
 
 func (f registerClientFunc) applyRegisterClient(p *registerClientParams) { f(p) }
 func (f refreshTokenFunc) applyRefreshToken(p *refreshTokenParams)       { f(p) }
+func (f revokeTokenFunc) applyRevokeToken(p *revokeTokenParams)          { f(p) }
 
 // ========================================================================== //
 //                           [Port.RegisterClient]                            //
@@ -95,3 +101,23 @@ func RefreshTokenParams(opts ...RefreshTokenOption) *refreshTokenParams {
 }
 
 func (p *refreshTokenParams) Internal() bool { return p.internal }
+
+// ========================================================================== //
+//                             [Port.RevokeToken]                             //
+// ========================================================================== //
+
+type revokeTokenParams struct {
+	internal bool
+}
+
+// RevokeTokenParams creates a new set of parameters for [Port.RevokeToken].
+func RevokeTokenParams(opts ...RevokeTokenOption) *revokeTokenParams {
+	p := defaultRevokeTokenParams()
+	for _, opt := range opts {
+		opt.applyRevokeToken(p)
+	}
+
+	return p
+}
+
+func (p *revokeTokenParams) Internal() bool { return p.internal }
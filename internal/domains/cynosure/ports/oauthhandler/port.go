@@ -65,6 +65,24 @@ type Port interface {
 	Exchange(
 		ctx context.Context, config *oauth2.Config, code string, verifier []byte,
 	) (*oauth2.Token, error)
+
+	// RevokeToken invalidates token on authorization server (RFC 7009).
+	// Revocation endpoint is discovered from authorization server metadata.
+	// Refresh token is revoked, if it's set, otherwise access token.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestRevokeToken] — revoking tokens with and without revocation
+	//    support
+	//
+	// Throws:
+	//
+	//  - [ErrRevocationNotSupported] if server doesn't declare revocation
+	//    endpoint.
+	RevokeToken(
+		ctx context.Context, config *oauth2.Config, token *oauth2.Token,
+		opts ...RevokeTokenOption,
+	) error
 }
 
 func defaultRegisterClientParams() *registerClientParams {
@@ -79,3 +97,9 @@ func defaultRefreshTokenParams() *refreshTokenParams {
 		internal: false,
 	}
 }
+
+func defaultRevokeTokenParams() *revokeTokenParams {
+	return &revokeTokenParams{
+		internal: false,
+	}
+}
@@ -508,6 +508,101 @@ func (s *OAuthHandlerTestSuite) TestExchange(t *testing.T) {
 	// TODO: implement tests for Exchange
 }
 
+// TestRevokeToken tests revoking tokens with and without revocation support.
+func (s *OAuthHandlerTestSuite) TestRevokeToken(t *testing.T) {
+	const revokePath = "/revoke"
+
+	newServer := func(t *testing.T, withRevocation bool, revoked *url.Values) *httptest.Server {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case wellKnownOAuthAuthorizationServerPath:
+				meta := map[string]any{
+					"issuer":         "http://" + r.Host,
+					"token_endpoint": "http://" + r.Host + "/token",
+				}
+				if withRevocation {
+					meta["revocation_endpoint"] = "http://" + r.Host + revokePath
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				//nolint:errcheck,gosec // makes no sense to check error here
+				json.NewEncoder(w).Encode(meta)
+			case revokePath:
+				if err := r.ParseForm(); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				*revoked = r.PostForm
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	config := func(srv *httptest.Server) *oauth2.Config {
+		//nolint:exhaustruct // only required fields
+		return &oauth2.Config{
+			ClientID: "client",
+			Endpoint: oauth2.Endpoint{TokenURL: srv.URL + "/token"},
+		}
+	}
+
+	t.Run("revokes_refresh_token", func(t *testing.T) {
+		var revoked url.Values
+
+		srv := newServer(t, true, &revoked)
+
+		//nolint:exhaustruct // only required fields
+		token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}
+
+		err := s.adapter.RevokeToken(
+			t.Context(), config(srv), token, oauthhandler.WithInternalConnection(),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "refresh", revoked.Get("token"))
+		assert.Equal(t, "refresh_token", revoked.Get("token_type_hint"))
+		assert.Equal(t, "client", revoked.Get("client_id"))
+	})
+
+	t.Run("revokes_access_token", func(t *testing.T) {
+		var revoked url.Values
+
+		srv := newServer(t, true, &revoked)
+
+		//nolint:exhaustruct // only required fields
+		token := &oauth2.Token{AccessToken: "access"}
+
+		err := s.adapter.RevokeToken(
+			t.Context(), config(srv), token, oauthhandler.WithInternalConnection(),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "access", revoked.Get("token"))
+		assert.Equal(t, "access_token", revoked.Get("token_type_hint"))
+	})
+
+	t.Run("revocation_not_supported", func(t *testing.T) {
+		var revoked url.Values
+
+		srv := newServer(t, false, &revoked)
+
+		//nolint:exhaustruct // only required fields
+		token := &oauth2.Token{AccessToken: "access"}
+
+		err := s.adapter.RevokeToken(
+			t.Context(), config(srv), token, oauthhandler.WithInternalConnection(),
+		)
+		require.ErrorIs(t, err, oauthhandler.ErrRevocationNotSupported)
+		assert.Nil(t, revoked)
+	})
+}
+
 func must[T any](v T, err error) T {
 	if err != nil {
 		panic(err) //nolint:forbidigo
@@ -79,3 +79,17 @@ func (t *portWrapped) Exchange(
 	//nolint:wrapcheck // should not wrap adapter errors
 	return res, err
 }
+
+func (t *portWrapped) RevokeToken(
+	ctx context.Context, config *oauth2.Config, token *oauth2.Token,
+	opts ...RevokeTokenOption,
+) (err error) {
+	ctx, span := t.t.revokeToken(ctx, config.ClientID, config.Endpoint.TokenURL)
+	defer span.end()
+
+	err = t.w.RevokeToken(ctx, config, token, opts...)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return err
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// PlanStorage keeps names of rate limit plans, assigned to users. Plans
+// themselves are configured by operator, storage only remembers the choice.
+type PlanStorage interface {
+	// UserPlan returns name of the plan, assigned to the user.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSetAndGetUserPlan] — assigned plan is returned
+	//
+	// Throws:
+	//
+	//  - [ErrNotFound] if user has no assigned plan.
+	UserPlan(ctx context.Context, user ids.UserID) (string, error)
+
+	// SetUserPlan assigns plan to the user, replacing previous one.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSetAndGetUserPlan] — plan is replaced on second assignment
+	SetUserPlan(ctx context.Context, user ids.UserID, plan string) error
+}
+
+type PlanStorageFactory interface {
+	PlanStorage() PlanStorage
+}
+
+func NewPlanStorage(factory PlanStorageFactory) PlanStorage {
+	return factory.PlanStorage()
+}
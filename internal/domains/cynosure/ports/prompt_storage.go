@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+// PromptStorage keeps metadata of prompts, exposed by MCP server accounts.
+// Templates are never stored: prompts are always rendered by the server.
+type PromptStorage interface {
+	PromptStorageRead
+	PromptStorageWrite
+}
+
+type PromptStorageRead interface {
+	// ListPrompts returns prompts of all active accounts of the user. Prompts
+	// of disabled, unauthorized or deleted accounts are hidden. Empty result
+	// is not an error.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestListPrompts] — hiding prompts of inactive accounts
+	ListPrompts(ctx context.Context, user ids.UserID) ([]prompts.Prompt, error)
+}
+
+type PromptStorageWrite interface {
+	// SavePrompts replaces prompts of the account with given list: prompts,
+	// which are not in the list, are removed. All prompts must belong to the
+	// account.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSavePrompts] — replacing prompts of the account
+	SavePrompts(ctx context.Context, account ids.AccountID, list []prompts.Prompt) error
+}
+
+type PromptStorageFactory interface {
+	PromptStorage() PromptStorage
+}
+
+func NewPromptStorage(factory PromptStorageFactory) PromptStorage {
+	return factory.PromptStorage()
+}
@@ -2,8 +2,31 @@ package ratelimiter
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
 // ErrRateLimitExceeded occurs when the account has reached its assigned
 // message quota.
 var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+// LimitExceededError describes exhausted quota. It matches
+// [ErrRateLimitExceeded].
+type LimitExceededError struct {
+	// Remaining amount, which may be consumed right now, but it's less than
+	// requested.
+	Remaining int
+	// RetryAfter is a time to wait, until requested amount is available.
+	// Zero, if request exceeds the whole limit and will never be allowed.
+	RetryAfter time.Duration
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.RetryAfter <= 0 {
+		return ErrRateLimitExceeded.Error() + ": request exceeds the whole limit"
+	}
+
+	return fmt.Sprintf("%v: retry after %v", ErrRateLimitExceeded, e.RetryAfter)
+}
+
+func (e *LimitExceededError) Unwrap() error { return ErrRateLimitExceeded }
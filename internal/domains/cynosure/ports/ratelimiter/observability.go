@@ -8,11 +8,15 @@ import (
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
 const (
 	cynosureUserID            attribute.Key = "cynosure.user_id"
+	cynosureAccountID         attribute.Key = "cynosure.account_id"
 	cynosureRatelimiterAmount attribute.Key = "cynosure.ratelimiter.amount"
+	cynosureRatelimiterUsed   attribute.Key = "cynosure.ratelimiter.used"
+	cynosureRatelimiterLimit  attribute.Key = "cynosure.ratelimiter.limit"
 )
 
 type observable struct {
@@ -33,12 +37,13 @@ func newObservable(stack ports.ObserveStack) *observable {
 
 //nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
 func (o *observable) consume(
-	ctx context.Context, user ids.UserID, amount int,
+	ctx context.Context, user ids.UserID, limit plans.Limit, amount int,
 ) (context.Context, span) {
 	ctx, span := o.t.Start(ctx, "cynosure.ports.ratelimiter.consume",
 		trace.WithSpanKind(trace.SpanKindInternal),
 		trace.WithAttributes(
 			cynosureUserID.String(user.ID().String()),
+			cynosureRatelimiterLimit.String(limit.String()),
 			cynosureRatelimiterAmount.Int(amount),
 		),
 	)
@@ -46,6 +51,69 @@ func (o *observable) consume(
 	return ctx, &spanCallback{span: span}
 }
 
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) consumeAccount(
+	ctx context.Context, account ids.AccountID, limit plans.Limit, amount int,
+) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.ratelimiter.consume_account",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			cynosureAccountID.String(account.ID().String()),
+			cynosureRatelimiterLimit.String(limit.String()),
+			cynosureRatelimiterAmount.Int(amount),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) reserve(
+	ctx context.Context, user ids.UserID, limit plans.Limit, tokens int,
+) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.ratelimiter.reserve",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			cynosureUserID.String(user.ID().String()),
+			cynosureRatelimiterLimit.String(limit.String()),
+			cynosureRatelimiterAmount.Int(tokens),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) commit(
+	ctx context.Context, reservation Reservation, used int,
+) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.ratelimiter.commit",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			cynosureUserID.String(reservation.User().ID().String()),
+			cynosureRatelimiterAmount.Int(reservation.Tokens()),
+			cynosureRatelimiterUsed.Int(used),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) refund(
+	ctx context.Context, reservation Reservation,
+) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.ratelimiter.refund",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			cynosureUserID.String(reservation.User().ID().String()),
+			cynosureRatelimiterAmount.Int(reservation.Tokens()),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
 // generic span
 
 type span interface {
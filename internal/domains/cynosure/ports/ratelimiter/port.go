@@ -5,14 +5,79 @@ import (
 	"context"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
-// Port manages rate limiting of messages for given users.
+// Port manages rate limiting of messages and model tokens for given users, and
+// of requests, made by MCP servers of their accounts. Limits are passed with
+// every call, as they depend on user plan or configuration.
 type Port interface {
 	// Consume consumes message quota for the given user.
 	//
 	// Throws:
 	//
-	//  - [ErrRateLimitExceeded] if user has reached its assigned quota.
-	Consume(ctx context.Context, user ids.UserID, n int) error
+	//  - [ErrRateLimitExceeded] if user has reached its assigned quota. Error
+	//    is always a [*LimitExceededError].
+	Consume(ctx context.Context, user ids.UserID, limit plans.Limit, n int) (Quota, error)
+
+	// ConsumeAccount consumes quota of the single account, e.g. completions,
+	// requested by its MCP server. Account quota is independent from quota
+	// of its owner.
+	//
+	// Throws:
+	//
+	//  - [ErrRateLimitExceeded] if account has reached its quota. Error is
+	//    always a [*LimitExceededError].
+	ConsumeAccount(ctx context.Context, account ids.AccountID, limit plans.Limit, n int) (Quota, error)
+
+	// Reserve holds estimated amount of tokens from user token quota before
+	// the model call. Reservation must be settled with [Port.Commit] or
+	// [Port.Refund], otherwise reserved tokens are simply spent.
+	//
+	// Throws:
+	//
+	//  - [ErrRateLimitExceeded] if user doesn't have enough tokens left. Error
+	//    is always a [*LimitExceededError].
+	Reserve(ctx context.Context, user ids.UserID, limit plans.Limit, tokens int) (Reservation, error)
+
+	// Commit settles reservation with actually used amount of tokens:
+	// unused tokens are returned to the quota, overuse is taken from it, even
+	// if quota is already exhausted.
+	Commit(ctx context.Context, reservation Reservation, used int) error
+
+	// Refund returns all reserved tokens, e.g. when model call failed.
+	Refund(ctx context.Context, reservation Reservation) error
+}
+
+// Quota is a state of user limit after successful operation.
+type Quota struct {
+	// Remaining amount, which may be consumed right now. Unlimited quota
+	// reports -1.
+	Remaining int
+}
+
+// Reservation is an amount of tokens, held from user quota until the model
+// call is finished.
+type Reservation struct {
+	user      ids.UserID
+	limit     plans.Limit
+	tokens    int
+	remaining int
 }
+
+func NewReservation(user ids.UserID, limit plans.Limit, tokens, remaining int) Reservation {
+	return Reservation{
+		user:      user,
+		limit:     limit,
+		tokens:    tokens,
+		remaining: remaining,
+	}
+}
+
+func (r Reservation) User() ids.UserID   { return r.user }
+func (r Reservation) Limit() plans.Limit { return r.limit }
+func (r Reservation) Tokens() int        { return r.tokens }
+
+// Remaining returns tokens, left after reservation. Unlimited quota reports
+// -1.
+func (r Reservation) Remaining() int { return r.remaining }
@@ -0,0 +1,28 @@
+Feature: Account Rate Limiting
+  In order to protect users from greedy MCP servers
+  As a Product Owner
+  I want to limit requests of each connected account separately
+
+  Scenario: Account reaches its limit
+    Given rate limit is set to 1 message per second with burst 2
+    And  there is a random user "User A"
+    And  user "User A" has a random account "Server A"
+    When account "Server A" consumes 2 request
+    Then 0 messages remain
+    When account "Server A" consumes 1 request
+    Then rate limit exceeded error is returned
+    And  retry is allowed in at most 1s
+
+  Scenario: Account limits are independent from user and other accounts
+    Given rate limit is set to 1 message per second with burst 1
+    And  there is a random user "User A"
+    And  user "User A" has a random account "Server A"
+    And  user "User A" has a random account "Server B"
+    When account "Server A" consumes 1 request
+    Then operation is successful
+    When account "Server B" consumes 1 request
+    Then operation is successful
+    When user "User A" consumes 1 message
+    Then operation is successful
+    When account "Server A" consumes 1 request
+    Then rate limit exceeded error is returned
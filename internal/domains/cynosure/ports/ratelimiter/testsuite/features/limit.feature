@@ -26,3 +26,14 @@ Feature: Rate Limiting
     Then operation is successful
     When user "User A" consumes 1 message
     Then rate limit exceeded error is returned
+
+  Scenario: Remaining quota and retry time are reported
+    Given rate limit is set to 1 message per second with burst 3
+    And  there is a random user "User A"
+    When user "User A" consumes 1 message
+    Then 2 messages remain
+    When user "User A" consumes 2 message
+    Then 0 messages remain
+    When user "User A" consumes 1 message
+    Then rate limit exceeded error is returned
+    And  retry is allowed in at most 1s
@@ -0,0 +1,71 @@
+Feature: Token Reservations
+  In order to protect the system from expensive model calls
+  As a Product Owner
+  I want users to reserve model tokens before the call and settle them after it
+
+  Scenario: Reservation over the token quota is rejected
+    Given token limit is set to 10 tokens per second with burst 100
+    And  there is a random user "User A"
+    When user "User A" reserves 80 tokens
+    Then operation is successful
+    When user "User A" reserves 30 tokens
+    Then rate limit exceeded error is returned
+    And  retry is allowed in at most 1s
+
+  Scenario: Refunded tokens are available again
+    Given token limit is set to 10 tokens per second with burst 100
+    And  there is a random user "User A"
+    When user "User A" reserves 80 tokens
+    Then operation is successful
+    When user "User A" refunds reservation
+    Then operation is successful
+    When user "User A" reserves 100 tokens
+    Then operation is successful
+
+  Scenario: Refund restores remaining tokens
+    Given token limit is set to 10 tokens per second with burst 100
+    And  there is a random user "User A"
+    When user "User A" reserves 80 tokens
+    Then 20 tokens remain
+    When user "User A" refunds reservation
+    Then operation is successful
+    When user "User A" reserves 10 tokens
+    Then 90 tokens remain
+
+  Scenario: Unused tokens are returned on commit
+    Given token limit is set to 10 tokens per second with burst 100
+    And  there is a random user "User A"
+    When user "User A" reserves 80 tokens
+    Then operation is successful
+    When user "User A" commits 20 used tokens
+    Then operation is successful
+    When user "User A" reserves 80 tokens
+    Then operation is successful
+
+  Scenario: Overused tokens are taken even over the quota
+    Given token limit is set to 10 tokens per second with burst 100
+    And  there is a random user "User A"
+    When user "User A" reserves 50 tokens
+    Then operation is successful
+    When user "User A" commits 150 used tokens
+    Then operation is successful
+    When time passes for 2s
+    And  user "User A" reserves 1 tokens
+    Then rate limit exceeded error is returned
+
+  Scenario: Tokens are restored with time
+    Given token limit is set to 10 tokens per second with burst 100
+    And  there is a random user "User A"
+    When user "User A" reserves 100 tokens
+    Then operation is successful
+    When user "User A" reserves 10 tokens
+    Then rate limit exceeded error is returned
+    When time passes for 1s
+    And  user "User A" reserves 10 tokens
+    Then operation is successful
+
+  Scenario: Tokens are not limited without token limit
+    Given rate limit is set to 1 message per second with burst 1
+    And  there is a random user "User A"
+    When user "User A" reserves 1000000 tokens
+    Then operation is successful
@@ -13,6 +13,7 @@ import (
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
 //go:embed features/*.feature
@@ -20,14 +21,15 @@ var features embed.FS
 
 var (
 	errUserNotFound    = errors.New("user not found")
+	errAccountNotFound = errors.New("account not found")
 	errExpectedSuccess = errors.New("expected success")
 	errExpectedError   = errors.New("expected error")
+	errNoReservation   = errors.New("user has no reservation")
+	errUnexpectedQuota = errors.New("unexpected quota")
 )
 
 type SetupParams struct {
-	Now   func() time.Time
-	Limit int
-	Burst int
+	Now func() time.Time
 }
 
 type setupFunc func(context.Context, SetupParams) (ratelimiter.Port, error)
@@ -80,9 +82,15 @@ type godogState struct {
 
 	adapter     ratelimiter.Port
 	currentTime time.Time
-
-	users   map[string]ids.UserID
-	lastErr error
+	// limits of the plan, used in scenario.
+	messages plans.Limit
+	tokens   plans.Limit
+
+	users        map[string]ids.UserID
+	accounts     map[string]ids.AccountID
+	reservations map[string]ratelimiter.Reservation
+	lastQuota    ratelimiter.Quota
+	lastErr      error
 }
 
 func (s *godogState) InitializeScenario(setup setupFunc) func(*godog.ScenarioContext) {
@@ -101,36 +109,67 @@ func (s *godogState) InitializeScenario(setup setupFunc) func(*godog.ScenarioCon
 			s.createUser)
 		ctx.When(`^user "([^"]*)" consumes (\d+) message$`,
 			s.consumeMessages)
+		ctx.Given(`^user "([^"]*)" has a random account "([^"]*)"$`,
+			s.createAccount)
+		ctx.When(`^account "([^"]*)" consumes (\d+) request$`,
+			s.consumeAccountRequests)
 		ctx.When(`^time passes for ([-+]?(?:[0-9]*(?:\.[0-9]*)?[a-z]+)+)$`,
 			s.timePasses)
+		ctx.Given(`^token limit is set to (\d+) tokens per second with burst (\d+)$`,
+			s.setupTokenLimiter)
+		ctx.When(`^user "([^"]*)" reserves (\d+) tokens$`,
+			s.reserveTokens)
+		ctx.When(`^user "([^"]*)" commits (\d+) used tokens$`,
+			s.commitTokens)
+		ctx.When(`^user "([^"]*)" refunds reservation$`,
+			s.refundTokens)
 		ctx.Then(`^operation is successful$`,
 			s.assertSuccess)
 		ctx.Then(`^rate limit exceeded error is returned$`,
 			s.assertLimitError)
+		ctx.Then(`^(\d+) messages? remains?$`,
+			s.assertRemaining)
+		ctx.Then(`^(\d+) tokens? remains?$`,
+			s.assertRemaining)
+		ctx.Then(`^retry is allowed in at most ([-+]?(?:[0-9]*(?:\.[0-9]*)?[a-z]+)+)$`,
+			s.assertRetryAfter)
 	}
 }
 
 func (s *godogState) reset() {
 	*s = godogState{
-		setup:       s.setup,
-		adapter:     nil,
-		currentTime: time.Unix(0, 0),
-		users:       make(map[string]ids.UserID),
-		lastErr:     nil,
+		setup:        s.setup,
+		adapter:      nil,
+		currentTime:  time.Unix(0, 0),
+		users:        make(map[string]ids.UserID),
+		accounts:     make(map[string]ids.AccountID),
+		reservations: make(map[string]ratelimiter.Reservation),
+		lastQuota:    ratelimiter.Quota{Remaining: 0},
+		lastErr:      nil,
 	}
 }
 
-func (s *godogState) buildAdapter(ctx context.Context, params SetupParams) (err error) {
-	s.adapter, err = s.setup(ctx, params)
+func (s *godogState) buildAdapter(ctx context.Context) (err error) {
+	s.adapter, err = s.setup(ctx, SetupParams{
+		Now: func() time.Time { return s.currentTime },
+	})
+
 	return err
 }
 
-func (s *godogState) setupLimiter(ctx context.Context, limit, burst int) (err error) {
-	return s.buildAdapter(ctx, SetupParams{
-		Now:   func() time.Time { return s.currentTime },
-		Limit: limit,
-		Burst: burst,
-	})
+func (s *godogState) setupLimiter(ctx context.Context, limit, burst int) error {
+	// limit of N messages per second with burst B refills B messages in B/N
+	// seconds.
+	s.messages = plans.NewLimit(burst, time.Duration(burst)*time.Second/time.Duration(limit))
+
+	return s.buildAdapter(ctx)
+}
+
+func (s *godogState) setupTokenLimiter(ctx context.Context, limit, burst int) error {
+	s.messages = plans.NewLimit(1, time.Second)
+	s.tokens = plans.NewLimit(burst, time.Duration(burst)*time.Second/time.Duration(limit))
+
+	return s.buildAdapter(ctx)
 }
 
 func (s *godogState) createUser(name string) error {
@@ -138,6 +177,22 @@ func (s *godogState) createUser(name string) error {
 	return nil
 }
 
+func (s *godogState) createAccount(userName, name string) error {
+	user, ok := s.users[userName]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUserNotFound, userName)
+	}
+
+	account, err := ids.RandomAccountID(user, ids.RandomServerID())
+	if err != nil {
+		return fmt.Errorf("creating account: %w", err)
+	}
+
+	s.accounts[name] = account
+
+	return nil
+}
+
 func (s *godogState) timePasses(durStr string) error {
 	dur, err := time.ParseDuration(durStr)
 	if err != nil {
@@ -155,7 +210,57 @@ func (s *godogState) consumeMessages(name string, count int) error {
 		return fmt.Errorf("%w: %q", errUserNotFound, name)
 	}
 
-	s.lastErr = s.adapter.Consume(context.Background(), user, count)
+	s.lastQuota, s.lastErr = s.adapter.Consume(context.Background(), user, s.messages, count)
+
+	return nil
+}
+
+func (s *godogState) consumeAccountRequests(name string, count int) error {
+	account, ok := s.accounts[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errAccountNotFound, name)
+	}
+
+	s.lastQuota, s.lastErr = s.adapter.ConsumeAccount(context.Background(), account, s.messages, count)
+
+	return nil
+}
+
+func (s *godogState) reserveTokens(name string, tokens int) error {
+	user, ok := s.users[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUserNotFound, name)
+	}
+
+	reservation, err := s.adapter.Reserve(context.Background(), user, s.tokens, tokens)
+	if err == nil {
+		s.reservations[name] = reservation
+		s.lastQuota = ratelimiter.Quota{Remaining: reservation.Remaining()}
+	}
+
+	s.lastErr = err
+
+	return nil
+}
+
+func (s *godogState) commitTokens(name string, used int) error {
+	reservation, ok := s.reservations[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errNoReservation, name)
+	}
+
+	s.lastErr = s.adapter.Commit(context.Background(), reservation, used)
+
+	return nil
+}
+
+func (s *godogState) refundTokens(name string) error {
+	reservation, ok := s.reservations[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errNoReservation, name)
+	}
+
+	s.lastErr = s.adapter.Refund(context.Background(), reservation)
 
 	return nil
 }
@@ -179,3 +284,37 @@ func (s *godogState) assertLimitError() error {
 
 	return nil
 }
+
+func (s *godogState) assertRemaining(remaining int) error {
+	if err := s.assertSuccess(); err != nil {
+		return err
+	}
+
+	if s.lastQuota.Remaining != remaining {
+		return fmt.Errorf("%w: expected %d remaining, got %d",
+			errUnexpectedQuota, remaining, s.lastQuota.Remaining,
+		)
+	}
+
+	return nil
+}
+
+func (s *godogState) assertRetryAfter(durStr string) error {
+	limit, err := time.ParseDuration(durStr)
+	if err != nil {
+		return fmt.Errorf("%w: parse duration: %w", godog.ErrAmbiguous, err)
+	}
+
+	var exceeded *ratelimiter.LimitExceededError
+	if !errors.As(s.lastErr, &exceeded) {
+		return fmt.Errorf("expected LimitExceededError, got: %w", s.lastErr)
+	}
+
+	if exceeded.RetryAfter <= 0 || exceeded.RetryAfter > limit {
+		return fmt.Errorf("%w: expected retry in (0, %v], got %v",
+			errUnexpectedQuota, limit, exceeded.RetryAfter,
+		)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
 )
 
 // PortWrapped provides the interface for the wrapped rate limiter.
@@ -36,11 +37,65 @@ func Wrap(client Port, observable ports.ObserveStack) PortWrapped {
 }
 
 // Consume consumes rate limit of messages for the given user.
-func (t *portWrapped) Consume(ctx context.Context, user ids.UserID, n int) (err error) {
-	ctx, span := t.t.consume(ctx, user, n)
+func (t *portWrapped) Consume(
+	ctx context.Context, user ids.UserID, limit plans.Limit, n int,
+) (quota Quota, err error) {
+	ctx, span := t.t.consume(ctx, user, limit, n)
 	defer span.end()
 
-	err = t.w.Consume(ctx, user, n)
+	quota, err = t.w.Consume(ctx, user, limit, n)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return quota, err
+}
+
+// ConsumeAccount consumes rate limit of the account.
+func (t *portWrapped) ConsumeAccount(
+	ctx context.Context, account ids.AccountID, limit plans.Limit, n int,
+) (quota Quota, err error) {
+	ctx, span := t.t.consumeAccount(ctx, account, limit, n)
+	defer span.end()
+
+	quota, err = t.w.ConsumeAccount(ctx, account, limit, n)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return quota, err
+}
+
+// Reserve holds tokens from user quota before the model call.
+func (t *portWrapped) Reserve(
+	ctx context.Context, user ids.UserID, limit plans.Limit, tokens int,
+) (reservation Reservation, err error) {
+	ctx, span := t.t.reserve(ctx, user, limit, tokens)
+	defer span.end()
+
+	reservation, err = t.w.Reserve(ctx, user, limit, tokens)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return reservation, err
+}
+
+// Commit settles reservation with actually used tokens.
+func (t *portWrapped) Commit(ctx context.Context, reservation Reservation, used int) (err error) {
+	ctx, span := t.t.commit(ctx, reservation, used)
+	defer span.end()
+
+	err = t.w.Commit(ctx, reservation, used)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return err
+}
+
+// Refund returns all reserved tokens.
+func (t *portWrapped) Refund(ctx context.Context, reservation Reservation) (err error) {
+	ctx, span := t.t.refund(ctx, reservation)
+	defer span.end()
+
+	err = t.w.Refund(ctx, reservation)
 	span.recordError(err)
 
 	//nolint:wrapcheck // should not wrap adapter errors
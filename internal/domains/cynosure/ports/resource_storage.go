@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+)
+
+// ResourceStorage keeps metadata of resources, exposed by MCP server accounts.
+// Content of resources is never stored: it's always read from the server.
+type ResourceStorage interface {
+	ResourceStorageRead
+	ResourceStorageWrite
+}
+
+type ResourceStorageRead interface {
+	// ListResources returns resources of all active accounts of the user.
+	// Resources of disabled, unauthorized or deleted accounts are hidden.
+	// Empty result is not an error.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestListResources] — hiding resources of inactive accounts
+	ListResources(ctx context.Context, user ids.UserID) ([]resources.Resource, error)
+}
+
+type ResourceStorageWrite interface {
+	// SaveResources replaces resources of the account with given list:
+	// resources, which are not in the list, are removed. All resources must
+	// belong to the account.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSaveResources] — replacing resources of the account
+	SaveResources(ctx context.Context, account ids.AccountID, list []resources.Resource) error
+}
+
+type ResourceStorageFactory interface {
+	ResourceStorage() ResourceStorage
+}
+
+func NewResourceStorage(factory ResourceStorageFactory) ResourceStorage {
+	return factory.ResourceStorage()
+}
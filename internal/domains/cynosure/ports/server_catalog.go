@@ -0,0 +1,64 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+)
+
+// ServerCatalog keeps public MCP servers, which users may connect to. Entries
+// are imported by operator and identified by name. Catalog is independent from
+// [ServerStorage]: server config is created only when user connects to it.
+type ServerCatalog interface {
+	ServerCatalogRead
+	ServerCatalogWrite
+}
+
+type ServerCatalogRead interface {
+	// SearchCatalog performs full-text search over names, titles,
+	// descriptions and categories of entries. Results are ordered by rank,
+	// most relevant first. Empty result is not an error.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSearchCatalog] — finding entries by words of description
+	SearchCatalog(ctx context.Context, query string, limit int) ([]catalog.Match, error)
+
+	// LookupCatalog performs semantic search of entries. Relevance of result
+	// is cosine similarity to query embedding.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestLookupCatalog] — ranking entries by embedding similarity
+	//
+	// Parameters:
+	//  - embedding: Query vector from ToolSemanticIndex.BuildToolEmbedding
+	//  - limit: Maximum number of results (top-K)
+	LookupCatalog(
+		ctx context.Context,
+		embedding [embeddingSize]float32,
+		limit int,
+	) ([]catalog.Match, error)
+}
+
+type ServerCatalogWrite interface {
+	// SaveCatalogEntry creates or updates entry by its name (upsert) with
+	// embedding of its description.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSaveCatalogEntry] — updating entry, imported twice
+	SaveCatalogEntry(
+		ctx context.Context,
+		entry catalog.Entry,
+		embedding [embeddingSize]float32,
+	) error
+}
+
+type ServerCatalogFactory interface {
+	ServerCatalog() ServerCatalog
+}
+
+func NewServerCatalog(factory ServerCatalogFactory) ServerCatalog {
+	return factory.ServerCatalog()
+}
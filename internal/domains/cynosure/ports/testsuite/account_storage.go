@@ -110,6 +110,57 @@ func (s *AccountStorageTestSuite) TestSaveAccount(t *testing.T) {
 	})
 }
 
+func (s *AccountStorageTestSuite) TestAccountStatus(t *testing.T) {
+	fixture, account := s.setupSaveAccountTest(t)
+
+	require.NoError(t, s.adapter.SaveAccount(t.Context(), account), "failed to save account")
+
+	activeIDs, err := s.adapter.ListActiveAccounts(t.Context())
+	require.NoError(t, err, "failed to list active accounts")
+	require.Contains(t, activeIDs, fixture.AccountID, "active account not found in list")
+
+	require.NoError(t, account.Disable())
+	require.NoError(t, s.adapter.SaveAccount(t.Context(), account), "failed to save account")
+
+	got, err := s.adapter.GetAccount(t.Context(), fixture.AccountID)
+	require.NoError(t, err, "failed to get account")
+	require.Equal(t, entities.AccountStatusDisabled, got.Status(), "account status mismatch")
+	require.False(t, got.SamplingAllowed(), "sampling must not be allowed by default")
+
+	require.NoError(t, account.AllowSampling(true))
+	require.NoError(t, s.adapter.SaveAccount(t.Context(), account), "failed to save account")
+
+	got, err = s.adapter.GetAccount(t.Context(), fixture.AccountID)
+	require.NoError(t, err, "failed to get account")
+	require.True(t, got.SamplingAllowed(), "sampling flag mismatch")
+
+	// disabled accounts are still listed: user must be able to reactivate them.
+	accountIDs, err := s.adapter.ListAccounts(t.Context(), fixture.AccountID.User())
+	require.NoError(t, err, "failed to list accounts")
+	require.Contains(t, accountIDs, fixture.AccountID, "disabled account not found in list")
+
+	activeIDs, err = s.adapter.ListActiveAccounts(t.Context())
+	require.NoError(t, err, "failed to list active accounts")
+	require.NotContains(t, activeIDs, fixture.AccountID, "disabled account must not be active")
+}
+
+func (s *AccountStorageTestSuite) TestDeleteAccount(t *testing.T) {
+	fixture, account := s.setupSaveAccountTest(t)
+
+	require.NoError(t, s.adapter.SaveAccount(t.Context(), account), "failed to save account")
+	require.NoError(t, s.adapter.DeleteAccount(t.Context(), fixture.AccountID))
+
+	_, err := s.adapter.GetAccount(t.Context(), fixture.AccountID)
+	require.ErrorIs(t, err, ports.ErrNotFound, "deleted account must not be retrievable")
+
+	accountIDs, err := s.adapter.ListAccounts(t.Context(), fixture.AccountID.User())
+	require.NoError(t, err, "failed to list accounts")
+	require.NotContains(t, accountIDs, fixture.AccountID, "deleted account must not be listed")
+
+	// idempotent
+	require.NoError(t, s.adapter.DeleteAccount(t.Context(), fixture.AccountID))
+}
+
 func (s *AccountStorageTestSuite) setupSaveAccountTest(
 	t *testing.T,
 ) (SaveAccountFixture, *entities.Account) {
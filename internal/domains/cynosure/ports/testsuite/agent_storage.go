@@ -85,6 +85,7 @@ func (s *ModelSettingsStorageTestSuite) TestSaveModel(t *testing.T) {
 	model := must(entities.NewModelSettings(
 		modelID,
 		"oompa-loompa-6000",
+		entities.WithName("Oompa-Loompa"),
 		entities.WithSystemMessage("You are a helpful Oompa-Loompa... Wait, what?"),
 		entities.WithTemperature(temperature),
 		entities.WithTopP(topP),
@@ -101,6 +102,8 @@ func (s *ModelSettingsStorageTestSuite) TestSaveModel(t *testing.T) {
 		require.NoError(t, err, "failed to get model")
 		require.NotNil(t, retrieved)
 		require.Equal(t, model.Model(), retrieved.Model())
+		require.Equal(t, model.Name(), retrieved.Name())
+		require.False(t, retrieved.Disabled())
 		require.Equal(t, model.SystemMessage(), retrieved.SystemMessage())
 		require.Equal(t, asResult(model.Temperature()), asResult(retrieved.Temperature()))
 		require.Equal(t, asResult(model.TopP()), asResult(retrieved.TopP()))
@@ -121,6 +124,17 @@ func (s *ModelSettingsStorageTestSuite) TestSaveModel(t *testing.T) {
 		require.Empty(t, models, "should not find models for other user")
 	})
 
+	t.Run("disabling_model", func(t *testing.T) {
+		model.Disable()
+
+		err := s.adapter.SaveAgent(t.Context(), model)
+		require.NoError(t, err, "failed to save disabled model")
+
+		retrieved, err := s.adapter.GetAgent(t.Context(), modelID)
+		require.NoError(t, err, "failed to get model")
+		require.True(t, retrieved.Disabled())
+	})
+
 	t.Run("deleting_model", func(t *testing.T) {
 		err := s.adapter.DeleteAgent(t.Context(), modelID)
 		require.NoError(t, err, "failed to delete model")
@@ -0,0 +1,144 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// RunBlobStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunBlobStorageTests(
+	a ports.BlobStorage, opts ...BlobStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &BlobStorageTestSuite{
+		adapter:      a,
+		threadSeeder: nil,
+		cleanup:      nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type BlobStorageTestSuite struct {
+	adapter ports.BlobStorage
+
+	threadSeeder ThreadFixtureBuilder
+	cleanup      CleanupFunc
+}
+
+var _ afterTest = (*BlobStorageTestSuite)(nil)
+
+type BlobStorageTestSuiteOption func(*BlobStorageTestSuite)
+
+// ThreadFixtureBuilder prepares thread, which owns blobs, if adapter requires
+// it to exist.
+type ThreadFixtureBuilder = func(ctx context.Context, thread ids.ThreadID) error
+
+func WithBlobStorageThreadSeeder(f ThreadFixtureBuilder) BlobStorageTestSuiteOption {
+	return func(s *BlobStorageTestSuite) { s.threadSeeder = f }
+}
+
+func WithBlobStorageCleanup(f CleanupFunc) BlobStorageTestSuiteOption {
+	return func(s *BlobStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *BlobStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *BlobStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+func (s *BlobStorageTestSuite) randomThread(t *testing.T) ids.ThreadID {
+	t.Helper()
+
+	thread := must(ids.RandomThreadID(ids.RandomUserID()))
+
+	if s.threadSeeder != nil {
+		require.NoError(t, s.threadSeeder(t.Context(), thread), "failed to seed thread")
+	}
+
+	return thread
+}
+
+// TestSaveAndReadBlob tests that saved blob is returned as is.
+func (s *BlobStorageTestSuite) TestSaveAndReadBlob(t *testing.T) {
+	blob := must(ids.RandomBlobID(s.randomThread(t)))
+	data := []byte(`{"items": ["first", "second", "third"]}`)
+
+	require.NoError(t, s.adapter.SaveBlob(t.Context(), blob, data))
+
+	chunk, size, err := s.adapter.ReadBlob(t.Context(), blob, 0, int64(len(data)))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), size)
+	require.Equal(t, data, chunk)
+
+	err = s.adapter.SaveBlob(t.Context(), blob, data)
+	require.ErrorIs(t, err, ports.ErrAlreadyExists)
+}
+
+// TestReadBlobRange tests paging through the blob.
+func (s *BlobStorageTestSuite) TestReadBlobRange(t *testing.T) {
+	blob := must(ids.RandomBlobID(s.randomThread(t)))
+	data := []byte("0123456789")
+
+	require.NoError(t, s.adapter.SaveBlob(t.Context(), blob, data))
+
+	for _, tt := range []struct {
+		name           string
+		offset, length int64
+		want           string
+	}{
+		{name: "head", offset: 0, length: 4, want: "0123"},
+		{name: "middle", offset: 4, length: 4, want: "4567"},
+		{name: "tail is shorter", offset: 8, length: 4, want: "89"},
+		{name: "beyond the end", offset: 20, length: 4, want: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk, size, err := s.adapter.ReadBlob(t.Context(), blob, tt.offset, tt.length)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(data)), size)
+			require.Equal(t, tt.want, string(chunk))
+		})
+	}
+}
+
+// TestReadBlobOtherThread tests that blob is not visible from other threads.
+func (s *BlobStorageTestSuite) TestReadBlobOtherThread(t *testing.T) {
+	blob := must(ids.RandomBlobID(s.randomThread(t)))
+	require.NoError(t, s.adapter.SaveBlob(t.Context(), blob, []byte("secret")))
+
+	stolen := must(ids.NewBlobID(s.randomThread(t), blob.ID()))
+
+	_, _, err := s.adapter.ReadBlob(t.Context(), stolen, 0, 1)
+	require.ErrorIs(t, err, ports.ErrNotFound)
+
+	missing := must(ids.RandomBlobID(blob.Thread()))
+
+	_, _, err = s.adapter.ReadBlob(t.Context(), missing, 0, 1)
+	require.ErrorIs(t, err, ports.ErrNotFound)
+}
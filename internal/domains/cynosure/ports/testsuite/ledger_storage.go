@@ -0,0 +1,139 @@
+package testsuite
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+)
+
+// RunLedgerStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunLedgerStorageTests(
+	a ports.LedgerStorage, opts ...LedgerStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &LedgerStorageTestSuite{
+		adapter: a,
+		cleanup: nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type LedgerStorageTestSuite struct {
+	adapter ports.LedgerStorage
+
+	cleanup CleanupFunc
+}
+
+var _ afterTest = (*LedgerStorageTestSuite)(nil)
+
+type LedgerStorageTestSuiteOption func(*LedgerStorageTestSuite)
+
+func WithLedgerStorageCleanup(f CleanupFunc) LedgerStorageTestSuiteOption {
+	return func(s *LedgerStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *LedgerStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *LedgerStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+// TestAddAndListEntries tests that entries are summed by day, agent and model.
+func (s *LedgerStorageTestSuite) TestAddAndListEntries(t *testing.T) {
+	user := ids.RandomUserID()
+	thread := must(ids.RandomThreadID(user))
+	first := must(ids.RandomAgentID(user))
+	second := must(ids.RandomAgentID(user))
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	stats := ledger.Stats{
+		InputTokens:  100,
+		OutputTokens: 10,
+		Duration:     time.Second,
+		ToolCalls:    2,
+		Errors:       1,
+	}
+
+	for _, entry := range []ledger.Entry{
+		ledger.NewEntry(thread, first, "model-a", day.Add(time.Hour), stats),
+		ledger.NewEntry(thread, first, "model-a", day.Add(2*time.Hour), stats),
+		ledger.NewEntry(thread, first, "model-b", day.Add(3*time.Hour), stats),
+		ledger.NewEntry(thread, second, "model-a", day.Add(4*time.Hour), stats),
+		ledger.NewEntry(thread, first, "model-a", day.AddDate(0, 0, 1), stats),
+	} {
+		require.NoError(t, s.adapter.AddEntry(t.Context(), entry))
+	}
+
+	got, err := s.adapter.ListSummaries(t.Context(), user, day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+
+	// agents are sorted by id, so order of them is random.
+	firstDay := []ledger.Summary{
+		ledger.NewSummary(day, first, "model-a", 2, stats.Add(stats)),
+		ledger.NewSummary(day, first, "model-b", 1, stats),
+	}
+	if second.ID().String() < first.ID().String() {
+		firstDay = append([]ledger.Summary{ledger.NewSummary(day, second, "model-a", 1, stats)}, firstDay...)
+	} else {
+		firstDay = append(firstDay, ledger.NewSummary(day, second, "model-a", 1, stats))
+	}
+
+	require.Equal(t, append(firstDay,
+		ledger.NewSummary(day.AddDate(0, 0, 1), first, "model-a", 1, stats),
+	), got)
+
+	got, err = s.adapter.ListSummaries(t.Context(), ids.RandomUserID(), day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+// TestListSummariesPeriod tests that entries outside of requested period are
+// not counted.
+func (s *LedgerStorageTestSuite) TestListSummariesPeriod(t *testing.T) {
+	user := ids.RandomUserID()
+	thread := must(ids.RandomThreadID(user))
+	agent := must(ids.RandomAgentID(user))
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	stats := ledger.Stats{InputTokens: 100, OutputTokens: 10}
+
+	for _, at := range []time.Time{
+		day.Add(-time.Minute),
+		day,
+		day.Add(time.Hour),
+		day.AddDate(0, 0, 1),
+	} {
+		require.NoError(t, s.adapter.AddEntry(t.Context(), ledger.NewEntry(thread, agent, "model", at, stats)))
+	}
+
+	got, err := s.adapter.ListSummaries(t.Context(), user, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, []ledger.Summary{
+		ledger.NewSummary(day, agent, "model", 2, stats.Add(stats)),
+	}, got)
+}
@@ -0,0 +1,166 @@
+package testsuite
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// RunLinkStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunLinkStorageTests(
+	a ports.LinkStorage, opts ...LinkStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &LinkStorageTestSuite{
+		adapter:      a,
+		threadSeeder: nil,
+		cleanup:      nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type LinkStorageTestSuite struct {
+	adapter ports.LinkStorage
+
+	threadSeeder ThreadFixtureBuilder
+	cleanup      CleanupFunc
+}
+
+var _ afterTest = (*LinkStorageTestSuite)(nil)
+
+type LinkStorageTestSuiteOption func(*LinkStorageTestSuite)
+
+func WithLinkStorageThreadSeeder(f ThreadFixtureBuilder) LinkStorageTestSuiteOption {
+	return func(s *LinkStorageTestSuite) { s.threadSeeder = f }
+}
+
+func WithLinkStorageCleanup(f CleanupFunc) LinkStorageTestSuiteOption {
+	return func(s *LinkStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *LinkStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *LinkStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+func (s *LinkStorageTestSuite) randomThread(t *testing.T) ids.ThreadID {
+	t.Helper()
+
+	thread := must(ids.RandomThreadID(ids.RandomUserID()))
+
+	if s.threadSeeder != nil {
+		require.NoError(t, s.threadSeeder(t.Context(), thread), "failed to seed thread")
+	}
+
+	return thread
+}
+
+// TestSaveAndGetLink tests that saved link resolves to the original url, and
+// saving it again is allowed.
+func (s *LinkStorageTestSuite) TestSaveAndGetLink(t *testing.T) {
+	thread := s.randomThread(t)
+	target := must(url.Parse("https://example.com/files/report.pdf?token=abc&page=2"))
+	link := ids.RandomLinkID()
+
+	for range 2 {
+		saved, err := s.adapter.SaveLink(t.Context(), link, thread, target)
+		require.NoError(t, err)
+		require.Equal(t, link, saved)
+	}
+
+	got, err := s.adapter.GetLink(t.Context(), link)
+	require.NoError(t, err)
+	require.Equal(t, target.String(), got.String())
+
+	_, err = s.adapter.GetLink(t.Context(), ids.RandomLinkID())
+	require.ErrorIs(t, err, ports.ErrNotFound)
+}
+
+// TestSaveLinkConflict tests that token, bound to one url, is not rebound to
+// another one.
+func (s *LinkStorageTestSuite) TestSaveLinkConflict(t *testing.T) {
+	thread := s.randomThread(t)
+	target := must(url.Parse("https://example.com/first"))
+	link := ids.RandomLinkID()
+
+	_, err := s.adapter.SaveLink(t.Context(), link, thread, target)
+	require.NoError(t, err)
+
+	second := must(url.Parse("https://example.com/second"))
+
+	_, err = s.adapter.SaveLink(t.Context(), link, thread, second)
+	require.ErrorIs(t, err, ports.ErrAlreadyExists)
+
+	got, err := s.adapter.GetLink(t.Context(), link)
+	require.NoError(t, err)
+	require.Equal(t, target.String(), got.String())
+}
+
+// TestSaveLinkReuse tests that url, saved in the thread again, keeps its first
+// token, while other threads get their own.
+func (s *LinkStorageTestSuite) TestSaveLinkReuse(t *testing.T) {
+	thread := s.randomThread(t)
+	target := must(url.Parse("https://example.com/files/report.pdf"))
+	link := ids.RandomLinkID()
+
+	_, err := s.adapter.SaveLink(t.Context(), link, thread, target)
+	require.NoError(t, err)
+
+	saved, err := s.adapter.SaveLink(t.Context(), ids.RandomLinkID(), thread, target)
+	require.NoError(t, err)
+	require.Equal(t, link, saved, "url must keep its token in the thread")
+
+	other := ids.RandomLinkID()
+	saved, err = s.adapter.SaveLink(t.Context(), other, s.randomThread(t), target)
+	require.NoError(t, err)
+	require.Equal(t, other, saved, "tokens are not shared between threads")
+}
+
+// TestDeleteLinksBefore tests that only links, which were not saved since the
+// given time, are removed.
+func (s *LinkStorageTestSuite) TestDeleteLinksBefore(t *testing.T) {
+	thread := s.randomThread(t)
+	link := ids.RandomLinkID()
+
+	target := must(url.Parse("https://example.com/stale"))
+
+	_, err := s.adapter.SaveLink(t.Context(), link, thread, target)
+	require.NoError(t, err)
+
+	require.NoError(t, s.adapter.DeleteLinksBefore(t.Context(), time.Now().Add(-time.Hour)))
+
+	_, err = s.adapter.GetLink(t.Context(), link)
+	require.NoError(t, err, "recently used link must be kept")
+
+	require.NoError(t, s.adapter.DeleteLinksBefore(t.Context(), time.Now().Add(time.Hour)))
+
+	_, err = s.adapter.GetLink(t.Context(), link)
+	require.ErrorIs(t, err, ports.ErrNotFound)
+}
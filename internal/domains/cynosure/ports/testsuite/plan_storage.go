@@ -0,0 +1,88 @@
+package testsuite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// RunPlanStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunPlanStorageTests(
+	a ports.PlanStorage, opts ...PlanStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &PlanStorageTestSuite{
+		adapter: a,
+		cleanup: nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type PlanStorageTestSuite struct {
+	adapter ports.PlanStorage
+
+	cleanup CleanupFunc
+}
+
+var _ afterTest = (*PlanStorageTestSuite)(nil)
+
+type PlanStorageTestSuiteOption func(*PlanStorageTestSuite)
+
+func WithPlanStorageCleanup(f CleanupFunc) PlanStorageTestSuiteOption {
+	return func(s *PlanStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *PlanStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *PlanStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+// TestSetAndGetUserPlan tests that assigned plan is returned, and that second
+// assignment replaces the first one.
+func (s *PlanStorageTestSuite) TestSetAndGetUserPlan(t *testing.T) {
+	user := ids.RandomUserID()
+
+	_, err := s.adapter.UserPlan(t.Context(), user)
+	require.ErrorIs(t, err, ports.ErrNotFound)
+
+	require.NoError(t, s.adapter.SetUserPlan(t.Context(), user, "free"))
+
+	got, err := s.adapter.UserPlan(t.Context(), user)
+	require.NoError(t, err)
+	require.Equal(t, "free", got)
+
+	require.NoError(t, s.adapter.SetUserPlan(t.Context(), user, "pro"))
+
+	got, err = s.adapter.UserPlan(t.Context(), user)
+	require.NoError(t, err)
+	require.Equal(t, "pro", got)
+
+	// other users are not affected.
+	_, err = s.adapter.UserPlan(t.Context(), ids.RandomUserID())
+	require.ErrorIs(t, err, ports.ErrNotFound)
+}
@@ -0,0 +1,141 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+// RunPromptStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunPromptStorageTests(
+	a ports.PromptStorage, opts ...PromptStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &PromptStorageTestSuite{
+		adapter:       a,
+		accountSeeder: nil,
+		cleanup:       nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type PromptStorageTestSuite struct {
+	adapter ports.PromptStorage
+
+	accountSeeder PromptAccountFixtureBuilder
+	cleanup       CleanupFunc
+}
+
+var _ afterTest = (*PromptStorageTestSuite)(nil)
+
+type PromptStorageTestSuiteOption func(*PromptStorageTestSuite)
+
+// PromptAccountFixtureBuilder prepares account, which owns prompts. Same as
+// for resources, suite requests both active and inactive accounts.
+type PromptAccountFixtureBuilder = func(ctx context.Context, account ids.AccountID, active bool) error
+
+func WithPromptStorageAccountSeeder(f PromptAccountFixtureBuilder) PromptStorageTestSuiteOption {
+	return func(s *PromptStorageTestSuite) { s.accountSeeder = f }
+}
+
+func WithPromptStorageCleanup(f CleanupFunc) PromptStorageTestSuiteOption {
+	return func(s *PromptStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *PromptStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	if s.accountSeeder == nil {
+		return errors.New("account seeder is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *PromptStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+func (s *PromptStorageTestSuite) randomAccount(t *testing.T, user ids.UserID, active bool) ids.AccountID {
+	t.Helper()
+
+	account := must(ids.RandomAccountID(user, ids.RandomServerID()))
+	require.NoError(t, s.accountSeeder(t.Context(), account, active), "failed to seed account")
+
+	return account
+}
+
+// TestSavePrompts tests that saved list replaces previous prompts of the
+// account: stale prompts are removed, existing are updated with arguments.
+func (s *PromptStorageTestSuite) TestSavePrompts(t *testing.T) {
+	user := ids.RandomUserID()
+	account := s.randomAccount(t, user, true)
+
+	require.NoError(t, s.adapter.SavePrompts(t.Context(), account, []prompts.Prompt{
+		must(prompts.New(account, "code_review")),
+		must(prompts.New(account, "old")),
+	}))
+
+	updated := must(prompts.New(account, "code_review",
+		prompts.WithTitle("Code review"),
+		prompts.WithDescription("Reviews the code"),
+		prompts.WithArguments(
+			must(prompts.NewArgument("code", "Code to review", true)),
+			must(prompts.NewArgument("language", "", false)),
+		),
+	))
+	require.NoError(t, s.adapter.SavePrompts(t.Context(), account, []prompts.Prompt{updated}))
+
+	list, err := s.adapter.ListPrompts(t.Context(), user)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.True(t, updated.Equal(list[0]), "got %+v", list[0])
+
+	require.NoError(t, s.adapter.SavePrompts(t.Context(), account, nil))
+
+	list, err = s.adapter.ListPrompts(t.Context(), user)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+// TestListPrompts tests that only prompts of active accounts of the user are
+// listed.
+func (s *PromptStorageTestSuite) TestListPrompts(t *testing.T) {
+	user := ids.RandomUserID()
+	active := s.randomAccount(t, user, true)
+	inactive := s.randomAccount(t, user, false)
+	foreign := s.randomAccount(t, ids.RandomUserID(), true)
+
+	for _, account := range []ids.AccountID{active, inactive, foreign} {
+		require.NoError(t, s.adapter.SavePrompts(t.Context(), account, []prompts.Prompt{
+			must(prompts.New(account, "code_review")),
+		}))
+	}
+
+	list, err := s.adapter.ListPrompts(t.Context(), user)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, active, list[0].Account())
+}
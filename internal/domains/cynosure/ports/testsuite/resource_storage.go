@@ -0,0 +1,139 @@
+package testsuite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+)
+
+// RunResourceStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunResourceStorageTests(
+	a ports.ResourceStorage, opts ...ResourceStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &ResourceStorageTestSuite{
+		adapter:       a,
+		accountSeeder: nil,
+		cleanup:       nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type ResourceStorageTestSuite struct {
+	adapter ports.ResourceStorage
+
+	accountSeeder ResourceAccountFixtureBuilder
+	cleanup       CleanupFunc
+}
+
+var _ afterTest = (*ResourceStorageTestSuite)(nil)
+
+type ResourceStorageTestSuiteOption func(*ResourceStorageTestSuite)
+
+// ResourceAccountFixtureBuilder prepares account, which owns resources.
+// Resources of inactive accounts must be hidden by the storage, so suite
+// requests both kinds of accounts.
+type ResourceAccountFixtureBuilder = func(ctx context.Context, account ids.AccountID, active bool) error
+
+func WithResourceStorageAccountSeeder(f ResourceAccountFixtureBuilder) ResourceStorageTestSuiteOption {
+	return func(s *ResourceStorageTestSuite) { s.accountSeeder = f }
+}
+
+func WithResourceStorageCleanup(f CleanupFunc) ResourceStorageTestSuiteOption {
+	return func(s *ResourceStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *ResourceStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	if s.accountSeeder == nil {
+		return errors.New("account seeder is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *ResourceStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+func (s *ResourceStorageTestSuite) randomAccount(t *testing.T, user ids.UserID, active bool) ids.AccountID {
+	t.Helper()
+
+	account := must(ids.RandomAccountID(user, ids.RandomServerID()))
+	require.NoError(t, s.accountSeeder(t.Context(), account, active), "failed to seed account")
+
+	return account
+}
+
+// TestSaveResources tests that saved list replaces previous resources of the
+// account: stale resources are removed, existing are updated.
+func (s *ResourceStorageTestSuite) TestSaveResources(t *testing.T) {
+	user := ids.RandomUserID()
+	account := s.randomAccount(t, user, true)
+
+	require.NoError(t, s.adapter.SaveResources(t.Context(), account, []resources.Resource{
+		must(resources.New(account, "file:///README.md", "readme")),
+		must(resources.New(account, "file:///old.txt", "old")),
+	}))
+
+	updated := must(resources.New(account, "file:///README.md", "readme",
+		resources.WithTitle("Project README"),
+		resources.WithMIMEType("text/markdown"),
+		resources.WithSize(42),
+	))
+	require.NoError(t, s.adapter.SaveResources(t.Context(), account, []resources.Resource{updated}))
+
+	list, err := s.adapter.ListResources(t.Context(), user)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.True(t, updated.Equal(list[0]), "got %+v", list[0])
+
+	require.NoError(t, s.adapter.SaveResources(t.Context(), account, nil))
+
+	list, err = s.adapter.ListResources(t.Context(), user)
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+// TestListResources tests that only resources of active accounts of the user
+// are listed.
+func (s *ResourceStorageTestSuite) TestListResources(t *testing.T) {
+	user := ids.RandomUserID()
+	active := s.randomAccount(t, user, true)
+	inactive := s.randomAccount(t, user, false)
+	foreign := s.randomAccount(t, ids.RandomUserID(), true)
+
+	for _, account := range []ids.AccountID{active, inactive, foreign} {
+		require.NoError(t, s.adapter.SaveResources(t.Context(), account, []resources.Resource{
+			must(resources.New(account, "file:///README.md", "readme")),
+		}))
+	}
+
+	list, err := s.adapter.ListResources(t.Context(), user)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, active, list[0].Account())
+}
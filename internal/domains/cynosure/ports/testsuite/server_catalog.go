@@ -0,0 +1,154 @@
+package testsuite
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+)
+
+const catalogEmbeddingSize = 1536
+
+// RunServerCatalogTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunServerCatalogTests(
+	a ports.ServerCatalog, opts ...ServerCatalogTestSuiteOption,
+) func(t *testing.T) {
+	suite := &ServerCatalogTestSuite{
+		adapter: a,
+		cleanup: nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type ServerCatalogTestSuite struct {
+	adapter ports.ServerCatalog
+
+	cleanup CleanupFunc
+}
+
+var _ afterTest = (*ServerCatalogTestSuite)(nil)
+
+type ServerCatalogTestSuiteOption func(*ServerCatalogTestSuite)
+
+func WithServerCatalogCleanup(f CleanupFunc) ServerCatalogTestSuiteOption {
+	return func(s *ServerCatalogTestSuite) { s.cleanup = f }
+}
+
+func (s *ServerCatalogTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *ServerCatalogTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+// TestSaveCatalogEntry tests that entry, saved twice, is updated instead of
+// duplicated.
+func (s *ServerCatalogTestSuite) TestSaveCatalogEntry(t *testing.T) {
+	entry := catalogEntry(t, "com.todoist/mcp", "Todoist", "Manage tasks and projects.")
+	require.NoError(t, s.adapter.SaveCatalogEntry(t.Context(), entry, catalogEmbedding(0)))
+
+	updated := catalogEntry(t, "com.todoist/mcp", "Todoist", "Manage tasks, projects and labels.",
+		catalog.WithAuthType(catalog.AuthTypeOAuth),
+	)
+	require.NoError(t, s.adapter.SaveCatalogEntry(t.Context(), updated, catalogEmbedding(0)))
+
+	found, err := s.adapter.SearchCatalog(t.Context(), "todoist", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "Manage tasks, projects and labels.", found[0].Entry().Description())
+	require.Equal(t, catalog.AuthTypeOAuth, found[0].Entry().AuthType())
+	require.Equal(t, []string{"productivity"}, found[0].Entry().Categories())
+}
+
+// TestSearchCatalog tests full-text search by words of description and by
+// part of the name.
+func (s *ServerCatalogTestSuite) TestSearchCatalog(t *testing.T) {
+	for i, entry := range []catalog.Entry{
+		catalogEntry(t, "com.todoist/mcp", "Todoist", "Manage tasks and projects."),
+		catalogEntry(t, "io.github.example/weather", "Weather", "Forecasts for any city."),
+	} {
+		require.NoError(t, s.adapter.SaveCatalogEntry(t.Context(), entry, catalogEmbedding(i)))
+	}
+
+	found, err := s.adapter.SearchCatalog(t.Context(), "forecasts", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "io.github.example/weather", found[0].Entry().Name())
+
+	found, err = s.adapter.SearchCatalog(t.Context(), "todo", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "Todoist", found[0].Entry().Title())
+
+	found, err = s.adapter.SearchCatalog(t.Context(), "spreadsheets", 10)
+	require.NoError(t, err)
+	require.Empty(t, found)
+}
+
+// TestLookupCatalog tests that entries are ranked by embedding similarity.
+func (s *ServerCatalogTestSuite) TestLookupCatalog(t *testing.T) {
+	for i, entry := range []catalog.Entry{
+		catalogEntry(t, "com.todoist/mcp", "Todoist", "Manage tasks and projects."),
+		catalogEntry(t, "io.github.example/weather", "Weather", "Forecasts for any city."),
+	} {
+		require.NoError(t, s.adapter.SaveCatalogEntry(t.Context(), entry, catalogEmbedding(i)))
+	}
+
+	found, err := s.adapter.LookupCatalog(t.Context(), catalogEmbedding(1), 1)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "io.github.example/weather", found[0].Entry().Name())
+	require.InDelta(t, 1, found[0].Relevance(), 1e-6)
+}
+
+func catalogEntry(
+	t *testing.T, name, title, description string, opts ...catalog.EntryOption,
+) catalog.Entry {
+	t.Helper()
+
+	link, err := url.Parse("https://" + title + ".example.com/mcp")
+	require.NoError(t, err)
+
+	opts = append([]catalog.EntryOption{
+		catalog.WithTitle(title),
+		catalog.WithCategories("productivity"),
+	}, opts...)
+
+	entry, err := catalog.NewEntry(name, link, description, opts...)
+	require.NoError(t, err)
+
+	return entry
+}
+
+// catalogEmbedding returns unit vector along the axis, so different axes are
+// orthogonal.
+func catalogEmbedding(axis int) [catalogEmbeddingSize]float32 {
+	var embedding [catalogEmbeddingSize]float32
+	embedding[axis] = 1
+
+	return embedding
+}
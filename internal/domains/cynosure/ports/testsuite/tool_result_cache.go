@@ -0,0 +1,110 @@
+package testsuite
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+// RunToolResultCacheTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunToolResultCacheTests(
+	a ports.ToolResultCache, opts ...ToolResultCacheTestSuiteOption,
+) func(t *testing.T) {
+	suite := &ToolResultCacheTestSuite{
+		adapter: a,
+		cleanup: nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type ToolResultCacheTestSuite struct {
+	adapter ports.ToolResultCache
+
+	cleanup CleanupFunc
+}
+
+var _ afterTest = (*ToolResultCacheTestSuite)(nil)
+
+type ToolResultCacheTestSuiteOption func(*ToolResultCacheTestSuite)
+
+func WithToolResultCacheCleanup(f CleanupFunc) ToolResultCacheTestSuiteOption {
+	return func(s *ToolResultCacheTestSuite) { s.cleanup = f }
+}
+
+func (s *ToolResultCacheTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *ToolResultCacheTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+func randomResultKey(args string) tools.ResultKey {
+	tool := must(ids.RandomToolID(must(ids.RandomAccountID(ids.RandomUserID(), ids.RandomServerID()))))
+
+	return must(tools.NewResultKey(tool, map[string]json.RawMessage{"query": json.RawMessage(args)}))
+}
+
+// TestSetAndGetToolResult tests that stored result is returned for the same
+// key only.
+func (s *ToolResultCacheTestSuite) TestSetAndGetToolResult(t *testing.T) {
+	key := randomResultKey(`"projects"`)
+	result := must(messages.NewMessageToolResponse(
+		json.RawMessage(`{"projects":["cynosure"]}`), "list_projects", "call_1",
+	))
+
+	before := time.Now()
+
+	require.NoError(t, s.adapter.SetToolResult(t.Context(), key, result, time.Hour))
+
+	got, cachedAt, err := s.adapter.GetToolResult(t.Context(), key)
+	require.NoError(t, err)
+	require.JSONEq(t, string(result.Content()), string(got.Content()))
+	require.Equal(t, result.ToolName(), got.ToolName())
+	require.WithinDuration(t, before, cachedAt, time.Minute)
+
+	_, _, err = s.adapter.GetToolResult(t.Context(), randomResultKey(`"other"`))
+	require.ErrorIs(t, err, ports.ErrNotFound)
+}
+
+// TestToolResultExpires tests that result is not returned after its ttl.
+func (s *ToolResultCacheTestSuite) TestToolResultExpires(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+
+	key := randomResultKey(`"projects"`)
+	result := must(messages.NewMessageToolResponse(json.RawMessage(`{}`), "list_projects", "call_1"))
+
+	require.NoError(t, s.adapter.SetToolResult(t.Context(), key, result, ttl))
+
+	require.Eventually(t, func() bool {
+		_, _, err := s.adapter.GetToolResult(t.Context(), key)
+		return errors.Is(err, ports.ErrNotFound)
+	}, time.Second, ttl/2)
+}
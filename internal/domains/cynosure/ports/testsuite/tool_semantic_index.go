@@ -12,6 +12,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 const (
@@ -336,8 +337,8 @@ func (s *ToolSemanticIndexTestSuite) buildTool(
 		"test-account",
 		name,
 		description,
-		schema,
-		responseSchema,
+		must(tools.NewInputSchema(schema)),
+		must(tools.NewOutputSchema(responseSchema)),
 	)
 
 	require.NoError(t, err, "failed to create tool: %q", name)
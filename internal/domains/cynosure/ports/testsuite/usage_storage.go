@@ -0,0 +1,111 @@
+package testsuite
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// RunUsageStorageTests runs tests for the given adapter. These tests are
+// predefined and REQUIRED to be used for ANY adapter implementation.
+func RunUsageStorageTests(
+	a ports.UsageStorage, opts ...UsageStorageTestSuiteOption,
+) func(t *testing.T) {
+	suite := &UsageStorageTestSuite{
+		adapter: a,
+		cleanup: nil,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+
+	if err := suite.validate(); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return runSuite(suite)
+}
+
+type UsageStorageTestSuite struct {
+	adapter ports.UsageStorage
+
+	cleanup CleanupFunc
+}
+
+var _ afterTest = (*UsageStorageTestSuite)(nil)
+
+type UsageStorageTestSuiteOption func(*UsageStorageTestSuite)
+
+func WithUsageStorageCleanup(f CleanupFunc) UsageStorageTestSuiteOption {
+	return func(s *UsageStorageTestSuite) { s.cleanup = f }
+}
+
+func (s *UsageStorageTestSuite) validate() error {
+	if s.adapter == nil {
+		return errors.New("adapter is nil") //nolint:err113 // ok for tests
+	}
+
+	return nil
+}
+
+func (s *UsageStorageTestSuite) afterTest(t *testing.T) {
+	t.Helper()
+
+	if s.cleanup != nil {
+		if err := s.cleanup(t.Context()); err != nil {
+			t.Fatalf("cleanup failed: %v", err)
+		}
+	}
+}
+
+// TestAddAndGetUsage tests that usage is summed for the agent, and for all
+// agents of the user.
+func (s *UsageStorageTestSuite) TestAddAndGetUsage(t *testing.T) {
+	user := ids.RandomUserID()
+	first := must(ids.RandomAgentID(user))
+	second := must(ids.RandomAgentID(user))
+	now := time.Now()
+
+	require.NoError(t, s.adapter.AddUsage(t.Context(), first, now, budget.NewUsage(100, 10, 5)))
+	require.NoError(t, s.adapter.AddUsage(t.Context(), first, now, budget.NewUsage(200, 20, 7)))
+	require.NoError(t, s.adapter.AddUsage(t.Context(), second, now, budget.NewUsage(1000, 100, 50)))
+
+	since := budget.PeriodDay.Start(now)
+
+	got, err := s.adapter.AgentUsage(t.Context(), first, since)
+	require.NoError(t, err)
+	require.Equal(t, budget.NewUsage(300, 30, 12), got)
+
+	got, err = s.adapter.UserUsage(t.Context(), user, since)
+	require.NoError(t, err)
+	require.Equal(t, budget.NewUsage(1300, 130, 62), got)
+
+	got, err = s.adapter.UserUsage(t.Context(), ids.RandomUserID(), since)
+	require.NoError(t, err)
+	require.Equal(t, budget.Usage{}, got)
+}
+
+// TestUsageSince tests that usage of days before requested one is not
+// counted.
+func (s *UsageStorageTestSuite) TestUsageSince(t *testing.T) {
+	agent := must(ids.RandomAgentID(ids.RandomUserID()))
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+
+	require.NoError(t, s.adapter.AddUsage(t.Context(), agent, yesterday, budget.NewUsage(100, 10, 5)))
+	require.NoError(t, s.adapter.AddUsage(t.Context(), agent, today, budget.NewUsage(200, 20, 7)))
+
+	got, err := s.adapter.AgentUsage(t.Context(), agent, budget.PeriodDay.Start(today))
+	require.NoError(t, err)
+	require.Equal(t, budget.NewUsage(200, 20, 7), got)
+
+	got, err = s.adapter.AgentUsage(t.Context(), agent, budget.PeriodDay.Start(yesterday))
+	require.NoError(t, err)
+	require.Equal(t, budget.NewUsage(300, 30, 12), got)
+}
@@ -39,11 +39,17 @@ type ThreadStorage interface {
 	// Implements Optimistic Concurrency Control - fails if thread was modified
 	// concurrently (detects conflicts via last_message_pos). Does not validate
 	// message content or tool arguments - validation happens in domain layer.
+	// Pause and resume are conditional too: thread is resumed only once, even
+	// if several requests resume it at the same time.
 	//
 	// See next test suites to find how it works:
 	//
 	//  - [TestUpdateThread] — persisting messages with OCC conflict detection
 	//  - [TestConcurrentModification] — verifying OCC prevents data loss
+	//
+	// Throws:
+	//
+	//  - [ErrConcurrentModification] if thread was changed after it was read.
 	UpdateThread(ctx context.Context, thread entities.ThreadReadOnly) error
 }
 
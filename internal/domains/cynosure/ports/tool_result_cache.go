@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+// ToolResultCache keeps results of tool calls, which can be safely reused
+// for the same arguments: read-only tools, or tools, which user opted in.
+type ToolResultCache interface {
+	// GetToolResult returns cached result and the time, when it was stored.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSetAndGetToolResult] — storing result and getting it back
+	//  - [TestToolResultExpires] — expired results are not returned
+	//
+	// Throws:
+	//
+	//  - [ErrNotFound] if result is not cached or already expired.
+	GetToolResult(ctx context.Context, key tools.ResultKey) (messages.MessageToolResponse, time.Time, error)
+
+	// SetToolResult stores result for the given time. Existing result for the
+	// same key is replaced.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSetAndGetToolResult] — storing result and getting it back
+	//  - [TestToolResultExpires] — expired results are not returned
+	SetToolResult(
+		ctx context.Context, key tools.ResultKey, result messages.MessageToolResponse, ttl time.Duration,
+	) error
+}
+
+type ToolResultCacheFactory interface {
+	ToolResultCache() ToolResultCache
+}
+
+func NewToolResultCache(factory ToolResultCacheFactory) ToolResultCache {
+	return factory.ToolResultCache()
+}
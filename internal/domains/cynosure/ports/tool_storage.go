@@ -56,6 +56,20 @@ type ToolStorageRead interface {
 		embedding [embeddingSize]float32,
 		limit int,
 	) ([]*entities.Tool, error)
+
+	// MatchTools works like LookupTools, but also returns relevance of every
+	// tool: cosine similarity to query embedding, calculated by the storage.
+	// Tools without embedding have zero relevance.
+	//
+	// Parameters:
+	//  - embedding: Query vector from ToolSemanticIndex.BuildToolEmbedding
+	//  - limit: Maximum number of results (top-K)
+	MatchTools(
+		ctx context.Context,
+		user ids.UserID,
+		embedding [embeddingSize]float32,
+		limit int,
+	) ([]entities.ToolMatch, error)
 }
 
 type ToolStorageWrite interface {
@@ -17,6 +17,23 @@ var (
 	// ErrProtocolNotSupported indicates that server doesn't support any known
 	// protocols.
 	ErrProtocolNotSupported = errors.New("protocol not supported")
+
+	// ErrResourceNotFound indicates that server doesn't know requested
+	// resource.
+	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrSubscriptionNotSupported indicates that server can't notify about
+	// resource updates.
+	ErrSubscriptionNotSupported = errors.New("resource subscriptions not supported")
+
+	// ErrPromptRejected indicates that server doesn't know requested prompt,
+	// or its arguments are invalid. MCP doesn't distinguish these cases.
+	ErrPromptRejected = errors.New("prompt rejected")
+
+	// ErrSamplingRejected is returned by [SamplingHandler], when client
+	// refuses to sample: server is not approved, or its quota is exhausted.
+	// Server receives it as user rejection.
+	ErrSamplingRejected = errors.New("sampling rejected")
 )
 
 type RequiresAuthError struct {
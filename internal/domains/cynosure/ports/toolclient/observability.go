@@ -94,6 +94,62 @@ func (o *observable) executeTool(
 	return ctx, &executeToolSpan{spanCallback: spanCallback{span: span}}
 }
 
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) listResources(ctx context.Context, accountID string) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.tool.list_resources",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attrAccountID.String(accountID),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+// resourceCall traces operations with single resource: reading and
+// (un)subscribing.
+//
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) resourceCall(
+	ctx context.Context,
+	name, accountID, uri string,
+) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.tool."+name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			semconv.McpResourceURI(uri),
+			attrAccountID.String(accountID),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) listPrompts(ctx context.Context, accountID string) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.tool.list_prompts",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attrAccountID.String(accountID),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) getPrompt(ctx context.Context, accountID, name string) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.ports.tool.get_prompt",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			semconv.GenAIPromptName(name),
+			attrAccountID.String(accountID),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
 // log callbacks
 
 // generic span
@@ -1,9 +1,31 @@
 package toolclient
 
 import (
+	"context"
+	"encoding/json"
+
 	"golang.org/x/oauth2"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/elicitation"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
 )
 
+// ProgressHandler receives intermediate progress of the tool call. It may be
+// called from any goroutine, but never after [Port.ExecuteTool] returns.
+type ProgressHandler = func(messages.MessageToolProgress)
+
+// OversizeHandler receives tool result, which is too large to be stored in
+// thread history, and returns its replacement, which is passed to the model
+// instead.
+type OversizeHandler = func(ctx context.Context, content json.RawMessage) (json.RawMessage, error)
+
+// ElicitationHandler asks user to fill the form, which server requested in
+// the middle of the tool call. Tool call is suspended, until handler returns.
+// Error means that request can't be relayed to the user at all.
+type ElicitationHandler = func(
+	ctx context.Context, req elicitation.Request,
+) (elicitation.Result, error)
+
 // WithToolIDBuilder sets the tool ID builder for newly creating tools.
 //
 // Applies to:
@@ -32,15 +54,52 @@ func WithInternalTransport() DiscoverToolsOption {
 	return discoverToolsFunc(func(p *discoverToolsParams) { p.serverInternal = true })
 }
 
+// WithProgressHandler sets callback for progress notifications of the tool
+// call.
+//
+// Applies to:
+//
+//   - [ToolClient.ExecuteTool]
+func WithProgressHandler(handler ProgressHandler) ExecuteToolOption {
+	return executeToolFunc(func(p *executeToolParams) { p.progress = handler })
+}
+
+// WithOversizeHandler sets callback for tool results, which exceed message
+// size limit. Without handler, such results are replaced with tool error.
+//
+// Applies to:
+//
+//   - [ToolClient.ExecuteTool]
+func WithOversizeHandler(handler OversizeHandler) ExecuteToolOption {
+	return executeToolFunc(func(p *executeToolParams) { p.oversize = handler })
+}
+
+// WithElicitationHandler sets callback for user input, which server requests
+// during the tool call. Without handler, client doesn't support elicitation,
+// and server must proceed without the input.
+//
+// Applies to:
+//
+//   - [ToolClient.ExecuteTool]
+func WithElicitationHandler(handler ElicitationHandler) ExecuteToolOption {
+	return executeToolFunc(func(p *executeToolParams) { p.elicitation = handler })
+}
+
 type (
 	DiscoverToolsOption interface{ applyDiscoverTools(p *discoverToolsParams) }
+	ExecuteToolOption   interface{ applyExecuteTool(p *executeToolParams) }
 
 	discoverToolsFunc func(*discoverToolsParams)
+	executeToolFunc   func(*executeToolParams)
 )
 
-var _ DiscoverToolsOption = discoverToolsFunc(nil)
+var (
+	_ DiscoverToolsOption = discoverToolsFunc(nil)
+	_ ExecuteToolOption   = executeToolFunc(nil)
+)
 
 func (f discoverToolsFunc) applyDiscoverTools(p *discoverToolsParams) { f(p) }
+func (f executeToolFunc) applyExecuteTool(p *executeToolParams)       { f(p) }
 
 // ========================================================================== //
 //                          [ToolClient.DiscoverTools]                        //
@@ -68,3 +127,30 @@ func resolvedDiscoverToolsParams(value discoverToolsParams) DiscoverToolsOption
 func (s *discoverToolsParams) ToolIDBuilder() ToolIDBuilder { return s.toolIDBuilder }
 func (s *discoverToolsParams) Token() *oauth2.Token         { return s.token }
 func (s *discoverToolsParams) Internal() bool               { return s.serverInternal }
+
+// ========================================================================== //
+//                           [ToolClient.ExecuteTool]                         //
+// ========================================================================== //
+
+type executeToolParams struct {
+	progress    ProgressHandler
+	oversize    OversizeHandler
+	elicitation ElicitationHandler
+}
+
+func ExecuteToolParams(opts ...ExecuteToolOption) executeToolParams {
+	p := defaultExecuteToolParams()
+	for _, opt := range opts {
+		opt.applyExecuteTool(&p)
+	}
+
+	return p
+}
+
+func resolvedExecuteToolParams(value executeToolParams) ExecuteToolOption {
+	return executeToolFunc(func(p *executeToolParams) { *p = value })
+}
+
+func (s *executeToolParams) ProgressHandler() ProgressHandler       { return s.progress }
+func (s *executeToolParams) OversizeHandler() OversizeHandler       { return s.oversize }
+func (s *executeToolParams) ElicitationHandler() ElicitationHandler { return s.elicitation }
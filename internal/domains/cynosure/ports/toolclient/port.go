@@ -10,12 +10,34 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 // ToolIDBuilder is a function that creates a tool ID for newly creating tools.
 type ToolIDBuilder = func(account ids.AccountID, name string) (ids.ToolID, error)
 
+// ToolsChangedHandler receives account, whose server reported, that list of
+// its tools was changed.
+type ToolsChangedHandler = func(ctx context.Context, account ids.AccountID)
+
+// ResourcesChangedHandler receives account, whose server reported changes of
+// resources. URI is empty, if list of resources was changed, otherwise it's
+// URI of subscribed resource, which content was updated.
+type ResourcesChangedHandler = func(ctx context.Context, account ids.AccountID, uri string)
+
+// PromptsChangedHandler receives account, whose server reported, that list of
+// its prompts was changed.
+type PromptsChangedHandler = func(ctx context.Context, account ids.AccountID)
+
+// SamplingHandler answers completion, requested by server of the account.
+// Returning [ErrSamplingRejected] tells server, that request was declined.
+type SamplingHandler = func(
+	ctx context.Context, account ids.AccountID, req sampling.Request,
+) (sampling.Result, error)
+
 // Port executes MCP (Model Context Protocol) operations: tool discovery and
 // tool execution. Abstracts MCP server connections, protocol handling, and
 // account-based access control.
@@ -51,9 +73,19 @@ type Port interface {
 	// MCP tool execution phase. Does not validate argument schemas - validation
 	// happens in domain layer.
 	//
+	// Options:
+	//
+	//  - [WithProgressHandler] — receives intermediate progress of the call,
+	//    if server reports it.
+	//  - [WithOversizeHandler] — replaces results, which are too large for
+	//    thread history.
+	//  - [WithElicitationHandler] — asks user for input, which server
+	//    requests during the call.
+	//
 	// See next test suites to find how it works:
 	//
 	//  - [TestExecuteTool] — executing tool calls and handling results
+	//  - [TestElicitation] — relaying server requests for user input
 	//
 	// Throws:
 	//
@@ -67,7 +99,137 @@ type Port interface {
 		tool entities.ToolReadOnly,
 		args map[string]json.RawMessage,
 		toolCallID string,
+		opts ...ExecuteToolOption,
 	) (messages.MessageTool, error)
+
+	// SubscribeToolsChanged registers handler for changes of tool lists.
+	// Implements MCP "notifications/tools/list_changed": handler is called
+	// for every connected account, which server sent this notification.
+	// Handler may be called from any goroutine, and must not block.
+	//
+	// Returned function unsubscribes handler, no calls happen after it
+	// returns.
+	SubscribeToolsChanged(handler ToolsChangedHandler) (unsubscribe func())
+
+	// ListResources retrieves metadata of resources, exposed by server of the
+	// account. Implements MCP "resources/list". Returns empty slice, if
+	// server doesn't support resources.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestResources] — listing and reading resources
+	//
+	// Throws:
+	//
+	//  - [ErrServerUnreachable] if server connection fails.
+	//  - [ErrInvalidCredentials] if OAuth token is invalid or expired.
+	ListResources(ctx context.Context, account ids.AccountID) ([]resources.Resource, error)
+
+	// ReadResource retrieves content of the resource. Implements MCP
+	// "resources/read". Content may consist of several parts.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestResources] — listing and reading resources
+	//
+	// Throws:
+	//
+	//  - [ErrResourceNotFound] if server doesn't know the resource.
+	//  - [ErrServerUnreachable] if server connection fails.
+	//  - [ErrInvalidCredentials] if OAuth token is invalid or expired.
+	ReadResource(
+		ctx context.Context, account ids.AccountID, uri string,
+	) ([]resources.Content, error)
+
+	// SubscribeResource asks server to notify about updates of the resource.
+	// Implements MCP "resources/subscribe". Updates are passed to handlers,
+	// registered by [Port.SubscribeResourcesChanged].
+	//
+	// Throws:
+	//
+	//  - [ErrSubscriptionNotSupported] if server doesn't support resource
+	//    subscriptions.
+	//  - [ErrServerUnreachable] if server connection fails.
+	SubscribeResource(ctx context.Context, account ids.AccountID, uri string) error
+
+	// UnsubscribeResource cancels subscription, made by
+	// [Port.SubscribeResource]. Implements MCP "resources/unsubscribe".
+	//
+	// Throws:
+	//
+	//  - [ErrSubscriptionNotSupported] if server doesn't support resource
+	//    subscriptions.
+	//  - [ErrServerUnreachable] if server connection fails.
+	UnsubscribeResource(ctx context.Context, account ids.AccountID, uri string) error
+
+	// SubscribeResourcesChanged registers handler for changes of resources.
+	// Implements MCP "notifications/resources/list_changed" and
+	// "notifications/resources/updated". Handler may be called from any
+	// goroutine, and must not block.
+	//
+	// Returned function unsubscribes handler, no calls happen after it
+	// returns.
+	SubscribeResourcesChanged(handler ResourcesChangedHandler) (unsubscribe func())
+
+	// ListPrompts retrieves metadata of prompts, exposed by server of the
+	// account. Implements MCP "prompts/list". Returns empty slice, if server
+	// doesn't support prompts.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestPrompts] — listing and rendering prompts
+	//
+	// Throws:
+	//
+	//  - [ErrServerUnreachable] if server connection fails.
+	//  - [ErrInvalidCredentials] if OAuth token is invalid or expired.
+	ListPrompts(ctx context.Context, account ids.AccountID) ([]prompts.Prompt, error)
+
+	// GetPrompt renders prompt template with given arguments. Implements MCP
+	// "prompts/get". Arguments are not validated: server decides, which of
+	// them are required.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestPrompts] — listing and rendering prompts
+	//
+	// Throws:
+	//
+	//  - [ErrPromptRejected] if server doesn't know the prompt, or rejects
+	//    its arguments.
+	//  - [ErrServerUnreachable] if server connection fails.
+	//  - [ErrInvalidCredentials] if OAuth token is invalid or expired.
+	GetPrompt(
+		ctx context.Context, account ids.AccountID, name string, args map[string]string,
+	) ([]prompts.Message, error)
+
+	// SubscribePromptsChanged registers handler for changes of prompt lists.
+	// Implements MCP "notifications/prompts/list_changed". Handler may be
+	// called from any goroutine, and must not block.
+	//
+	// Returned function unsubscribes handler, no calls happen after it
+	// returns.
+	SubscribePromptsChanged(handler PromptsChangedHandler) (unsubscribe func())
+
+	// HandleSampling registers handler of completions, requested by servers.
+	// Implements MCP "sampling/createMessage": sampling capability is
+	// announced to every server, but requests are rejected, until handler is
+	// registered. Only one handler is used: next registration replaces
+	// previous one. Handler may be called from any goroutine, and may block
+	// until completion is generated.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestSampling] — answering completions, requested by server
+	//
+	// Returned function unregisters handler, if it wasn't replaced yet.
+	// Requests, which are already running, are not canceled.
+	HandleSampling(handler SamplingHandler) (unregister func())
+
+	// ForgetAccount drops connection state of the deleted account: pooled
+	// session and outbound limits. Account, which is used after it, starts
+	// from scratch.
+	ForgetAccount(account ids.AccountID)
 }
 
 func defaultDiscoverToolsParams() discoverToolsParams {
@@ -79,3 +241,11 @@ func defaultDiscoverToolsParams() discoverToolsParams {
 		serverInternal: false,
 	}
 }
+
+func defaultExecuteToolParams() executeToolParams {
+	return executeToolParams{
+		progress:    nil,
+		oversize:    nil,
+		elicitation: nil,
+	}
+}
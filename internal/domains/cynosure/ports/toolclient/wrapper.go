@@ -9,6 +9,8 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
@@ -67,11 +69,14 @@ func (t *toolClientWrapped) ExecuteTool(
 	tool entities.ToolReadOnly,
 	args map[string]json.RawMessage,
 	toolCallID string,
+	opts ...ExecuteToolOption,
 ) (messages.MessageTool, error) {
+	params := ExecuteToolParams(opts...)
+
 	ctx, span := t.t.executeTool(ctx, tool.Name(), args, toolCallID)
 	defer span.end()
 
-	res, err := t.w.ExecuteTool(ctx, tool, args, toolCallID)
+	res, err := t.w.ExecuteTool(ctx, tool, args, toolCallID, resolvedExecuteToolParams(params))
 	span.recordError(err)
 
 	if res != nil {
@@ -81,3 +86,101 @@ func (t *toolClientWrapped) ExecuteTool(
 	//nolint:wrapcheck // should not wrap adapter errors
 	return res, err
 }
+
+func (t *toolClientWrapped) SubscribeToolsChanged(handler ToolsChangedHandler) func() {
+	return t.w.SubscribeToolsChanged(handler)
+}
+
+func (t *toolClientWrapped) ListResources(
+	ctx context.Context, account ids.AccountID,
+) ([]resources.Resource, error) {
+	ctx, span := t.t.listResources(ctx, account.ID().String())
+	defer span.end()
+
+	res, err := t.w.ListResources(ctx, account)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return res, err
+}
+
+func (t *toolClientWrapped) ReadResource(
+	ctx context.Context, account ids.AccountID, uri string,
+) ([]resources.Content, error) {
+	ctx, span := t.t.resourceCall(ctx, "read_resource", account.ID().String(), uri)
+	defer span.end()
+
+	res, err := t.w.ReadResource(ctx, account, uri)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return res, err
+}
+
+func (t *toolClientWrapped) SubscribeResource(
+	ctx context.Context, account ids.AccountID, uri string,
+) error {
+	ctx, span := t.t.resourceCall(ctx, "subscribe_resource", account.ID().String(), uri)
+	defer span.end()
+
+	err := t.w.SubscribeResource(ctx, account, uri)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return err
+}
+
+func (t *toolClientWrapped) UnsubscribeResource(
+	ctx context.Context, account ids.AccountID, uri string,
+) error {
+	ctx, span := t.t.resourceCall(ctx, "unsubscribe_resource", account.ID().String(), uri)
+	defer span.end()
+
+	err := t.w.UnsubscribeResource(ctx, account, uri)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return err
+}
+
+func (t *toolClientWrapped) SubscribeResourcesChanged(handler ResourcesChangedHandler) func() {
+	return t.w.SubscribeResourcesChanged(handler)
+}
+
+func (t *toolClientWrapped) ListPrompts(
+	ctx context.Context, account ids.AccountID,
+) ([]prompts.Prompt, error) {
+	ctx, span := t.t.listPrompts(ctx, account.ID().String())
+	defer span.end()
+
+	res, err := t.w.ListPrompts(ctx, account)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return res, err
+}
+
+func (t *toolClientWrapped) GetPrompt(
+	ctx context.Context, account ids.AccountID, name string, args map[string]string,
+) ([]prompts.Message, error) {
+	ctx, span := t.t.getPrompt(ctx, account.ID().String(), name)
+	defer span.end()
+
+	res, err := t.w.GetPrompt(ctx, account, name, args)
+	span.recordError(err)
+
+	//nolint:wrapcheck // should not wrap adapter errors
+	return res, err
+}
+
+func (t *toolClientWrapped) SubscribePromptsChanged(handler PromptsChangedHandler) func() {
+	return t.w.SubscribePromptsChanged(handler)
+}
+
+func (t *toolClientWrapped) HandleSampling(handler SamplingHandler) func() {
+	return t.w.HandleSampling(handler)
+}
+
+func (t *toolClientWrapped) ForgetAccount(account ids.AccountID) {
+	t.w.ForgetAccount(account)
+}
@@ -0,0 +1,49 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// UsageStorage keeps tokens and money, spent by agents, summed by calendar
+// days (UTC). Budgets are checked against these sums, so they survive
+// restarts. It's the only source of spending for budgets: [LedgerStorage]
+// keeps the same turns for reports, but it's never used to enforce limits.
+type UsageStorage interface {
+	// AddUsage adds usage of the agent to the sums of the day, which contains
+	// given time.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestAddAndGetUsage] — usage is summed per agent and per user
+	//  - [TestUsageSince] — days before requested one are not counted
+	AddUsage(ctx context.Context, agent ids.AgentID, at time.Time, usage budget.Usage) error
+
+	// AgentUsage returns usage of the agent since the day, which contains
+	// given time. Unknown agent has zero usage.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestAddAndGetUsage] — usage is summed per agent and per user
+	//  - [TestUsageSince] — days before requested one are not counted
+	AgentUsage(ctx context.Context, agent ids.AgentID, since time.Time) (budget.Usage, error)
+
+	// UserUsage returns usage of all user agents since the day, which
+	// contains given time. Unknown user has zero usage.
+	//
+	// See next test suites to find how it works:
+	//
+	//  - [TestAddAndGetUsage] — usage is summed per agent and per user
+	UserUsage(ctx context.Context, user ids.UserID, since time.Time) (budget.Usage, error)
+}
+
+type UsageStorageFactory interface {
+	UsageStorage() UsageStorage
+}
+
+func NewUsageStorage(factory UsageStorageFactory) UsageStorage {
+	return factory.UsageStorage()
+}
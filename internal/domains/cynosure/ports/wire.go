@@ -6,9 +6,18 @@ import "github.com/goforj/wire"
 
 var WirePorts = wire.NewSet(
 	NewAgentStorage,
+	NewBlobStorage,
+	NewLedgerStorage,
+	NewLinkStorage,
+	NewPlanStorage,
+	NewPromptStorage,
+	NewResourceStorage,
 	NewAccountStorage,
 	NewServerStorage,
+	NewServerCatalog,
 	NewThreadStorage,
 	NewToolStorage,
 	NewToolSemanticIndex,
+	NewToolResultCache,
+	NewUsageStorage,
 )
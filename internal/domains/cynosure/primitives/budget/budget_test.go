@@ -0,0 +1,77 @@
+package budget_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+)
+
+func TestPriceTable_Usage(t *testing.T) {
+	t.Parallel()
+
+	prices := PriceTable{
+		"flash": NewPrice(CostFromUSD(0.3), CostFromUSD(2.5)),
+	}
+
+	usage := prices.Usage("flash", 100_000, 10_000)
+	require.Equal(t, CostFromUSD(0.055), usage.Cost())
+	require.Equal(t, uint64(100_000), usage.InputTokens())
+	require.Equal(t, uint64(10_000), usage.OutputTokens())
+
+	// unknown models are not priced, but tokens are still counted.
+	usage = prices.Usage("unknown", 100_000, 10_000)
+	require.Equal(t, Cost(0), usage.Cost())
+	require.Equal(t, uint64(100_000), usage.InputTokens())
+}
+
+func TestLimit_Exceeded(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		limit  Limit
+		used   Usage
+		metric Metric
+		want   bool
+	}{{
+		name:  "unlimited",
+		limit: NewLimit(0, 0, 0),
+		used:  NewUsage(1_000_000, 1_000_000, CostFromUSD(100)),
+	}, {
+		name:  "below",
+		limit: NewLimit(1000, 100, CostFromUSD(1)),
+		used:  NewUsage(999, 99, CostFromUSD(0.99)),
+	}, {
+		name:   "input tokens reached",
+		limit:  NewLimit(1000, 100, 0),
+		used:   NewUsage(1000, 10, 0),
+		metric: MetricInputTokens,
+		want:   true,
+	}, {
+		name:   "cost exceeded",
+		limit:  NewLimit(0, 0, CostFromUSD(1)),
+		used:   NewUsage(10, 10, CostFromUSD(1.5)),
+		metric: MetricCost,
+		want:   true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			metric, exceeded := tt.limit.Exceeded(tt.used)
+			require.Equal(t, tt.want, exceeded)
+			require.Equal(t, tt.metric, metric)
+		})
+	}
+}
+
+func TestPeriod_Start(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.March, 14, 15, 9, 26, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	require.Equal(t, time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC), PeriodDay.Start(now))
+	require.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), PeriodMonth.Start(now))
+}
@@ -0,0 +1,20 @@
+package budget
+
+import (
+	"fmt"
+	"math"
+)
+
+const microsInUSD = 1_000_000
+
+// Cost is an amount of money in millionths of US dollar. Integer amount keeps
+// sums of many small turns exact.
+type Cost int64
+
+// CostFromUSD converts dollars to cost, rounding to the nearest micro dollar.
+func CostFromUSD(usd float64) Cost { return Cost(math.Round(usd * microsInUSD)) }
+
+// USD returns cost in dollars.
+func (c Cost) USD() float64 { return float64(c) / microsInUSD }
+
+func (c Cost) String() string { return fmt.Sprintf("$%.4f", c.USD()) }
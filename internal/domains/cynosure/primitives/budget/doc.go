@@ -0,0 +1,2 @@
+// Package budget defines limits of tokens and money, which agents may spend.
+package budget
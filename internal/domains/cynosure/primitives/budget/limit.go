@@ -0,0 +1,137 @@
+package budget
+
+import "time"
+
+// Period is a time window, in which usage is summed.
+type Period uint8
+
+const (
+	_           Period = iota
+	PeriodRun          // single response of the agent
+	PeriodDay          // calendar day, UTC
+	PeriodMonth        // calendar month, UTC
+)
+
+// Periods lists all periods, which limits are checked for.
+func Periods() []Period { return []Period{PeriodRun, PeriodDay, PeriodMonth} }
+
+func (p Period) String() string {
+	switch p {
+	case PeriodRun:
+		return "run"
+	case PeriodDay:
+		return "day"
+	case PeriodMonth:
+		return "month"
+	default:
+		return "unknown"
+	}
+}
+
+// Start returns beginning of calendar period, which contains given time. Run
+// has no calendar bounds, so it starts at the given time.
+func (p Period) Start(now time.Time) time.Time {
+	now = now.UTC()
+
+	switch p {
+	case PeriodDay:
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	case PeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return now
+	}
+}
+
+// Metric is a measured part of usage.
+type Metric uint8
+
+const (
+	_                  Metric = iota
+	MetricInputTokens         // input tokens
+	MetricOutputTokens        // output tokens
+	MetricCost                // cost
+)
+
+func (m Metric) String() string {
+	switch m {
+	case MetricInputTokens:
+		return "input tokens"
+	case MetricOutputTokens:
+		return "output tokens"
+	case MetricCost:
+		return "cost"
+	default:
+		return "unknown"
+	}
+}
+
+// Limit caps usage in single period. Zero value of any field means, that this
+// metric is not limited.
+type Limit struct {
+	inputTokens  uint64
+	outputTokens uint64
+	cost         Cost
+}
+
+func NewLimit(inputTokens, outputTokens uint64, cost Cost) Limit {
+	return Limit{
+		inputTokens:  inputTokens,
+		outputTokens: outputTokens,
+		cost:         cost,
+	}
+}
+
+func (l Limit) InputTokens() uint64  { return l.inputTokens }
+func (l Limit) OutputTokens() uint64 { return l.outputTokens }
+func (l Limit) Cost() Cost           { return l.cost }
+
+// Empty reports whether nothing is limited.
+func (l Limit) Empty() bool { return l == Limit{} }
+
+// Exceeded returns first metric, which usage reached. Reaching the limit
+// counts as exceeding it: next turn would spend more anyway.
+func (l Limit) Exceeded(used Usage) (Metric, bool) {
+	switch {
+	case l.inputTokens > 0 && used.inputTokens >= l.inputTokens:
+		return MetricInputTokens, true
+	case l.outputTokens > 0 && used.outputTokens >= l.outputTokens:
+		return MetricOutputTokens, true
+	case l.cost > 0 && used.cost >= l.cost:
+		return MetricCost, true
+	default:
+		return 0, false
+	}
+}
+
+// Limits caps usage in every period.
+type Limits struct {
+	run   Limit
+	day   Limit
+	month Limit
+}
+
+func NewLimits(run, day, month Limit) Limits {
+	return Limits{
+		run:   run,
+		day:   day,
+		month: month,
+	}
+}
+
+// For returns limit of the period.
+func (l Limits) For(period Period) Limit {
+	switch period {
+	case PeriodRun:
+		return l.run
+	case PeriodDay:
+		return l.day
+	case PeriodMonth:
+		return l.month
+	default:
+		return Limit{}
+	}
+}
+
+// Empty reports whether nothing is limited in any period.
+func (l Limits) Empty() bool { return l == Limits{} }
@@ -0,0 +1,34 @@
+package budget
+
+const tokensPerPrice = 1_000_000
+
+// Price is a cost of million tokens, as providers usually publish it.
+type Price struct {
+	input  Cost
+	output Cost
+}
+
+func NewPrice(input, output Cost) Price {
+	return Price{
+		input:  input,
+		output: output,
+	}
+}
+
+func (p Price) Input() Cost  { return p.input }
+func (p Price) Output() Cost { return p.output }
+
+// Cost returns cost of the given amount of tokens.
+func (p Price) Cost(inputTokens, outputTokens uint64) Cost {
+	//nolint:gosec // token counts of single turn are far below int64 overflow.
+	return (Cost(inputTokens)*p.input + Cost(outputTokens)*p.output) / tokensPerPrice
+}
+
+// PriceTable maps model names to their prices.
+type PriceTable map[string]Price
+
+// Usage prices tokens, spent by the model. Models without price are free, so
+// only token limits apply to them.
+func (t PriceTable) Usage(model string, inputTokens, outputTokens uint64) Usage {
+	return NewUsage(inputTokens, outputTokens, t[model].Cost(inputTokens, outputTokens))
+}
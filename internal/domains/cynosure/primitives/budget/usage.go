@@ -0,0 +1,29 @@
+package budget
+
+// Usage is an amount of tokens and money, spent by model.
+type Usage struct {
+	inputTokens  uint64
+	outputTokens uint64
+	cost         Cost
+}
+
+func NewUsage(inputTokens, outputTokens uint64, cost Cost) Usage {
+	return Usage{
+		inputTokens:  inputTokens,
+		outputTokens: outputTokens,
+		cost:         cost,
+	}
+}
+
+func (u Usage) InputTokens() uint64  { return u.inputTokens }
+func (u Usage) OutputTokens() uint64 { return u.outputTokens }
+func (u Usage) Cost() Cost           { return u.cost }
+
+// Add returns sum of both usages.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		inputTokens:  u.inputTokens + other.inputTokens,
+		outputTokens: u.outputTokens + other.outputTokens,
+		cost:         u.cost + other.cost,
+	}
+}
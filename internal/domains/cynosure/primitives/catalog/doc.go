@@ -0,0 +1,3 @@
+// Package catalog defines entries of public MCP server catalog, which users
+// may connect to.
+package catalog
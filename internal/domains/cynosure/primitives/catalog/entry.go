@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrInvalidName is returned, when entry has no name.
+	ErrInvalidName = errors.New("catalog entry name is required")
+
+	// ErrInvalidLink is returned, when entry link is not an absolute HTTP(S)
+	// URL.
+	ErrInvalidLink = errors.New("catalog entry link must be absolute http(s) URL")
+
+	// ErrUnknownAuthType is returned, when auth type can't be parsed.
+	ErrUnknownAuthType = errors.New("unknown auth type")
+)
+
+// AuthType describes, how user authorizes on the server. Zero value means
+// that server didn't declare it.
+type AuthType uint8
+
+const (
+	AuthTypeUnknown AuthType = iota
+	AuthTypeNone
+	AuthTypeOAuth
+	AuthTypeAPIKey
+)
+
+func (a AuthType) String() string {
+	switch a {
+	case AuthTypeNone:
+		return "none"
+	case AuthTypeOAuth:
+		return "oauth"
+	case AuthTypeAPIKey:
+		return "api_key"
+	default:
+		return ""
+	}
+}
+
+// ParseAuthType parses result of [AuthType.String]. Empty string is
+// [AuthTypeUnknown].
+func ParseAuthType(s string) (AuthType, error) {
+	for _, a := range []AuthType{AuthTypeUnknown, AuthTypeNone, AuthTypeOAuth, AuthTypeAPIKey} {
+		if a.String() == s {
+			return a, nil
+		}
+	}
+
+	return AuthTypeUnknown, fmt.Errorf("%w: %q", ErrUnknownAuthType, s)
+}
+
+// Entry is a public MCP server, listed in catalog. Name is a unique identifier
+// of the entry, e.g. "com.todoist/mcp".
+type Entry struct {
+	link        *url.URL
+	name        string
+	title       string
+	description string
+	categories  []string
+	authType    AuthType
+}
+
+type EntryOption func(*Entry)
+
+func WithTitle(title string) EntryOption {
+	return func(e *Entry) { e.title = title }
+}
+
+func WithAuthType(authType AuthType) EntryOption {
+	return func(e *Entry) { e.authType = authType }
+}
+
+// WithCategories sets categories of the entry. Categories are normalized to
+// lowercase, empty and duplicate ones are dropped.
+func WithCategories(categories ...string) EntryOption {
+	return func(e *Entry) { e.categories = normalizeCategories(categories) }
+}
+
+func NewEntry(name string, link *url.URL, description string, opts ...EntryOption) (Entry, error) {
+	entry := Entry{
+		link:        link,
+		name:        strings.TrimSpace(name),
+		title:       "",
+		description: description,
+		categories:  []string{},
+		authType:    AuthTypeUnknown,
+	}
+	for _, opt := range opts {
+		opt(&entry)
+	}
+
+	if entry.name == "" {
+		return Entry{}, ErrInvalidName
+	}
+
+	if link == nil || !link.IsAbs() || (link.Scheme != "http" && link.Scheme != "https") {
+		return Entry{}, fmt.Errorf("%w: %v", ErrInvalidLink, link)
+	}
+
+	return entry, nil
+}
+
+func (e Entry) Name() string         { return e.name }
+func (e Entry) Description() string  { return e.description }
+func (e Entry) AuthType() AuthType   { return e.authType }
+func (e Entry) Categories() []string { return slices.Clone(e.categories) }
+
+func (e Entry) Link() *url.URL {
+	// cloning to avoid external modifications
+	cloned := *e.link
+
+	return &cloned
+}
+
+// Title returns human-readable name of the server. If it's not set, name is
+// returned.
+func (e Entry) Title() string {
+	if e.title == "" {
+		return e.name
+	}
+
+	return e.title
+}
+
+// IndexText returns text, describing the entry for semantic search.
+func (e Entry) IndexText() string {
+	text := e.Title() + "\n" + e.description
+	if len(e.categories) > 0 {
+		text += "\nCategories: " + strings.Join(e.categories, ", ")
+	}
+
+	return text
+}
+
+func normalizeCategories(categories []string) []string {
+	res := make([]string, 0, len(categories))
+	for _, c := range categories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c != "" && !slices.Contains(res, c) {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// Match is an entry, found by catalog search. Higher relevance is better, its
+// scale depends on search kind.
+type Match struct {
+	entry     Entry
+	relevance float64
+}
+
+func NewMatch(entry Entry, relevance float64) Match {
+	return Match{entry: entry, relevance: relevance}
+}
+
+func (m Match) Entry() Entry       { return m.entry }
+func (m Match) Relevance() float64 { return m.relevance }
@@ -0,0 +1,41 @@
+package catalog_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+)
+
+func TestNewEntry(t *testing.T) {
+	link, err := url.Parse("https://ai.todoist.net/mcp")
+	require.NoError(t, err)
+
+	entry, err := NewEntry("com.todoist/mcp", link, "Manage tasks.",
+		WithCategories("Productivity", " tasks ", "productivity", ""),
+		WithAuthType(AuthTypeOAuth),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "com.todoist/mcp", entry.Title())
+	require.Equal(t, []string{"productivity", "tasks"}, entry.Categories())
+	require.Equal(t, "com.todoist/mcp\nManage tasks.\nCategories: productivity, tasks", entry.IndexText())
+
+	_, err = NewEntry(" ", link, "")
+	require.ErrorIs(t, err, ErrInvalidName)
+
+	_, err = NewEntry("local", &url.URL{Scheme: "file", Path: "/tmp/server"}, "")
+	require.ErrorIs(t, err, ErrInvalidLink)
+}
+
+func TestParseAuthType(t *testing.T) {
+	for _, authType := range []AuthType{AuthTypeUnknown, AuthTypeNone, AuthTypeOAuth, AuthTypeAPIKey} {
+		parsed, err := ParseAuthType(authType.String())
+		require.NoError(t, err)
+		require.Equal(t, authType, parsed)
+	}
+
+	_, err := ParseAuthType("basic")
+	require.ErrorIs(t, err, ErrUnknownAuthType)
+}
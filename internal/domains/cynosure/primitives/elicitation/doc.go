@@ -0,0 +1,3 @@
+// Package elicitation defines MCP elicitation: structured input, which
+// connected servers request from the user in the middle of a tool call.
+package elicitation
@@ -0,0 +1,88 @@
+package elicitation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/elicitation"
+)
+
+func TestFieldParse(t *testing.T) {
+	minimum, maximum := 1.0, 10.0
+
+	count, err := elicitation.NewField("count", elicitation.KindInteger,
+		elicitation.WithBounds(&minimum, &maximum),
+	)
+	require.NoError(t, err)
+
+	value, err := count.Parse(" 5 ")
+	require.NoError(t, err)
+	require.Equal(t, int64(5), value)
+
+	_, err = count.Parse("11")
+	require.ErrorIs(t, err, elicitation.ErrInvalidValue)
+
+	_, err = count.Parse("five")
+	require.ErrorIs(t, err, elicitation.ErrInvalidValue)
+
+	color, err := elicitation.NewField("color", elicitation.KindEnum,
+		elicitation.WithOptions(
+			elicitation.NewOption("r", "Red"),
+			elicitation.NewOption("g", ""),
+		),
+	)
+	require.NoError(t, err)
+
+	value, err = color.Parse("red")
+	require.NoError(t, err)
+	require.Equal(t, "r", value)
+
+	value, err = color.Parse("g")
+	require.NoError(t, err)
+	require.Equal(t, "g", value)
+
+	_, err = color.Parse("blue")
+	require.ErrorIs(t, err, elicitation.ErrInvalidValue)
+
+	confirm, err := elicitation.NewField("confirm", elicitation.KindBoolean)
+	require.NoError(t, err)
+
+	value, err = confirm.Parse("Yes")
+	require.NoError(t, err)
+	require.Equal(t, true, value)
+
+	_, err = elicitation.NewField("color", elicitation.KindEnum)
+	require.ErrorIs(t, err, elicitation.ErrInvalidOptions)
+}
+
+func TestRequestAccept(t *testing.T) {
+	name, err := elicitation.NewField("name", elicitation.KindString, elicitation.WithRequired())
+	require.NoError(t, err)
+
+	age, err := elicitation.NewField("age", elicitation.KindInteger)
+	require.NoError(t, err)
+
+	req, err := elicitation.NewRequest("Who are you?", name, age)
+	require.NoError(t, err)
+
+	res, err := req.Accept(map[string]any{"name": "Alice"})
+	require.NoError(t, err)
+	require.Equal(t, elicitation.ActionAccept, res.Action())
+	require.Equal(t, map[string]any{"name": "Alice"}, res.Content())
+
+	_, err = req.Accept(map[string]any{"age": int64(30)})
+	require.ErrorIs(t, err, elicitation.ErrMissingValue)
+
+	_, err = req.Accept(map[string]any{"name": "Alice", "email": "alice@example.com"})
+	require.ErrorIs(t, err, elicitation.ErrUnknownField)
+
+	_, err = elicitation.NewRequest("Who are you?", name, name)
+	require.ErrorIs(t, err, elicitation.ErrDuplicateField)
+
+	_, err = elicitation.NewRequest("", name)
+	require.ErrorIs(t, err, elicitation.ErrEmptyMessage)
+
+	require.Equal(t, elicitation.ActionCancel, elicitation.Cancel().Action())
+	require.Empty(t, elicitation.Decline().Content())
+}
@@ -0,0 +1,248 @@
+package elicitation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidName is returned, when field has no name.
+	ErrInvalidName = errors.New("field name is required")
+
+	// ErrInvalidKind is returned, when field kind is not known.
+	ErrInvalidKind = errors.New("invalid field kind")
+
+	// ErrInvalidOptions is returned, when enum field has no options, or field
+	// of other kind has them.
+	ErrInvalidOptions = errors.New("invalid field options")
+
+	// ErrInvalidValue is returned, when user answer doesn't match the field.
+	ErrInvalidValue = errors.New("invalid field value")
+)
+
+// Kind is a type of value, which field accepts. Servers may request only
+// primitive values: nested objects are not allowed by MCP.
+type Kind uint8
+
+const (
+	KindString Kind = iota + 1
+	KindNumber
+	KindInteger
+	KindBoolean
+	// KindEnum is a string, which must be one of field options.
+	KindEnum
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindString:
+		return "string"
+	case KindNumber:
+		return "number"
+	case KindInteger:
+		return "integer"
+	case KindBoolean:
+		return "boolean"
+	case KindEnum:
+		return "enum"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint8(k))
+	}
+}
+
+// Option is a single value of enum field.
+type Option struct {
+	value string
+	title string
+}
+
+// NewOption creates enum option. Title is shown to the user instead of the
+// value, if set.
+func NewOption(value, title string) Option {
+	return Option{value: value, title: title}
+}
+
+func (o Option) Value() string { return o.value }
+
+// Label is a human-readable name of the option.
+func (o Option) Label() string {
+	if o.title != "" {
+		return o.title
+	}
+
+	return o.value
+}
+
+// Field describes a single value, which server asks from the user.
+type Field struct {
+	name        string
+	title       string
+	description string
+	kind        Kind
+	required    bool
+	options     []Option
+	// bounds of string length, or of number value.
+	minimum, maximum *float64
+}
+
+type FieldOption func(*Field)
+
+func WithTitle(title string) FieldOption {
+	return func(f *Field) { f.title = title }
+}
+
+func WithDescription(description string) FieldOption {
+	return func(f *Field) { f.description = description }
+}
+
+func WithRequired() FieldOption {
+	return func(f *Field) { f.required = true }
+}
+
+// WithOptions sets allowed values of [KindEnum] field.
+func WithOptions(options ...Option) FieldOption {
+	return func(f *Field) { f.options = slices.Clone(options) }
+}
+
+// WithBounds limits length of string field, or value of number field. Nil
+// bound is not checked.
+func WithBounds(minimum, maximum *float64) FieldOption {
+	return func(f *Field) { f.minimum, f.maximum = minimum, maximum }
+}
+
+// NewField creates field of the form.
+func NewField(name string, kind Kind, opts ...FieldOption) (Field, error) {
+	field := Field{
+		name:        name,
+		title:       "",
+		description: "",
+		kind:        kind,
+		required:    false,
+		options:     nil,
+		minimum:     nil,
+		maximum:     nil,
+	}
+
+	for _, opt := range opts {
+		opt(&field)
+	}
+
+	if err := field.validate(); err != nil {
+		return Field{}, err
+	}
+
+	return field, nil
+}
+
+func (f Field) validate() error {
+	switch {
+	case f.name == "":
+		return ErrInvalidName
+	case f.kind < KindString || f.kind > KindEnum:
+		return fmt.Errorf("%w: %v", ErrInvalidKind, f.kind)
+	case (f.kind == KindEnum) != (len(f.options) > 0):
+		return fmt.Errorf("%w: %q", ErrInvalidOptions, f.name)
+	default:
+		return nil
+	}
+}
+
+func (f Field) Name() string        { return f.name }
+func (f Field) Title() string       { return f.title }
+func (f Field) Description() string { return f.description }
+func (f Field) Kind() Kind          { return f.kind }
+func (f Field) Required() bool      { return f.required }
+func (f Field) Options() []Option   { return slices.Clone(f.options) }
+
+// Label is a human-readable name of the field.
+func (f Field) Label() string {
+	if f.title != "" {
+		return f.title
+	}
+
+	return f.name
+}
+
+// Parse converts answer of the user into the value of the field. Enum values
+// are matched by value or by label, booleans accept "yes" and "no" too.
+func (f Field) Parse(text string) (any, error) {
+	text = strings.TrimSpace(text)
+
+	switch f.kind {
+	case KindString:
+		return f.parseString(text)
+	case KindNumber:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidValue, text)
+		}
+
+		if err := f.checkBounds(value, "value"); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case KindInteger:
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an integer", ErrInvalidValue, text)
+		}
+
+		if err := f.checkBounds(float64(value), "value"); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case KindBoolean:
+		return parseBool(text)
+	case KindEnum:
+		return f.parseEnum(text)
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKind, f.kind)
+	}
+}
+
+func (f Field) parseString(text string) (any, error) {
+	if err := f.checkBounds(float64(utf8.RuneCountInString(text)), "length"); err != nil {
+		return nil, err
+	}
+
+	return text, nil
+}
+
+func (f Field) parseEnum(text string) (any, error) {
+	for _, option := range f.options {
+		if option.value == text || strings.EqualFold(option.Label(), text) {
+			return option.value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q is not one of the options", ErrInvalidValue, text)
+}
+
+func (f Field) checkBounds(value float64, what string) error {
+	switch {
+	case f.minimum != nil && value < *f.minimum:
+		return fmt.Errorf("%w: %s must be at least %v", ErrInvalidValue, what, *f.minimum)
+	case f.maximum != nil && value > *f.maximum:
+		return fmt.Errorf("%w: %s must be at most %v", ErrInvalidValue, what, *f.maximum)
+	default:
+		return nil
+	}
+}
+
+func parseBool(text string) (any, error) {
+	switch strings.ToLower(text) {
+	case "yes", "true":
+		return true, nil
+	case "no", "false":
+		return false, nil
+	default:
+		return nil, fmt.Errorf("%w: %q is neither yes nor no", ErrInvalidValue, text)
+	}
+}
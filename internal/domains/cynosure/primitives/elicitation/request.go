@@ -0,0 +1,119 @@
+package elicitation
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+var (
+	// ErrEmptyMessage is returned, when server doesn't explain, what it asks
+	// for.
+	ErrEmptyMessage = errors.New("elicitation message is required")
+
+	// ErrDuplicateField is returned, when form declares same field twice.
+	ErrDuplicateField = errors.New("duplicate elicitation field")
+
+	// ErrMissingValue is returned, when required field is not answered.
+	ErrMissingValue = errors.New("required field is not answered")
+
+	// ErrUnknownField is returned, when answer contains field, which is not
+	// declared by the form.
+	ErrUnknownField = errors.New("unknown elicitation field")
+)
+
+// Request is a form, which server asks user to fill. Form without fields is
+// a confirmation: user only accepts or declines it.
+type Request struct {
+	message string
+	fields  []Field
+}
+
+// NewRequest creates elicitation request. Fields are asked in the given
+// order.
+func NewRequest(message string, fields ...Field) (Request, error) {
+	if message == "" {
+		return Request{}, ErrEmptyMessage
+	}
+
+	seen := make(map[string]struct{}, len(fields))
+
+	for _, field := range fields {
+		if _, ok := seen[field.name]; ok {
+			return Request{}, fmt.Errorf("%w: %q", ErrDuplicateField, field.name)
+		}
+
+		seen[field.name] = struct{}{}
+	}
+
+	return Request{
+		message: message,
+		fields:  slices.Clone(fields),
+	}, nil
+}
+
+func (r Request) Message() string { return r.message }
+func (r Request) Fields() []Field { return slices.Clone(r.fields) }
+
+// Accept creates result with answers of the user. Values must be parsed by
+// [Field.Parse]: they are not checked again.
+func (r Request) Accept(values map[string]any) (Result, error) {
+	for name := range values {
+		if !slices.ContainsFunc(r.fields, func(f Field) bool { return f.name == name }) {
+			return Result{}, fmt.Errorf("%w: %q", ErrUnknownField, name)
+		}
+	}
+
+	for _, field := range r.fields {
+		if _, ok := values[field.name]; field.required && !ok {
+			return Result{}, fmt.Errorf("%w: %q", ErrMissingValue, field.name)
+		}
+	}
+
+	return Result{action: ActionAccept, content: maps.Clone(values)}, nil
+}
+
+// Action is the decision of the user about the request.
+type Action uint8
+
+const (
+	// ActionCancel means that user dismissed the request without explicit
+	// choice, e.g. didn't answer in time. It's the zero value.
+	ActionCancel Action = iota
+	// ActionAccept means that user filled the form.
+	ActionAccept
+	// ActionDecline means that user explicitly refused to answer.
+	ActionDecline
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCancel:
+		return "cancel"
+	case ActionAccept:
+		return "accept"
+	case ActionDecline:
+		return "decline"
+	default:
+		return fmt.Sprintf("Action(%d)", uint8(a))
+	}
+}
+
+// Result is the answer, which is sent back to server.
+type Result struct {
+	action  Action
+	content map[string]any
+}
+
+// Decline creates result, where user refused to answer.
+func Decline() Result { return Result{action: ActionDecline, content: nil} }
+
+// Cancel creates result, where user dismissed the request.
+func Cancel() Result { return Result{action: ActionCancel, content: nil} }
+
+func (r Result) Action() Action { return r.action }
+
+// Content returns answers of the user, keyed by field name. It's empty,
+// unless request is accepted.
+func (r Result) Content() map[string]any { return maps.Clone(r.content) }
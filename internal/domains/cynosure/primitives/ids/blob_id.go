@@ -0,0 +1,62 @@
+package ids
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// BlobID identifies large tool output, which is stored outside of the thread
+// history. Blob belongs to the thread, where it was produced, so it can't be
+// read from other threads, even if the model knows its id.
+type BlobID struct {
+	id     uuid.UUID
+	thread ThreadID
+
+	_valid bool
+}
+
+func RandomBlobID(thread ThreadID) (BlobID, error) {
+	return NewBlobID(thread, uuid.New())
+}
+
+func NewBlobIDFromString(thread ThreadID, id string) (BlobID, error) {
+	blobID, err := uuid.Parse(id)
+	if err != nil {
+		return BlobID{}, fmt.Errorf("parsing blob id: %w", err)
+	}
+
+	return NewBlobID(thread, blobID)
+}
+
+func NewBlobID(thread ThreadID, id uuid.UUID) (BlobID, error) {
+	blob := BlobID{
+		id:     id,
+		thread: thread,
+		_valid: false,
+	}
+
+	if err := blob.validate(); err != nil {
+		return BlobID{}, err
+	}
+
+	blob._valid = true
+
+	return blob, nil
+}
+
+func (u BlobID) Valid() bool { return u._valid || u.validate() == nil }
+func (u BlobID) validate() error {
+	switch {
+	case u.id == uuid.Nil:
+		return ErrInternalValidation("blob id cannot be nil")
+	case !u.thread.Valid():
+		return ErrInternalValidation("thread id is invalid")
+	default:
+		return nil
+	}
+}
+
+func (u BlobID) ID() uuid.UUID    { return u.id }
+func (u BlobID) Thread() ThreadID { return u.thread }
+func (u BlobID) String() string   { return u.id.String() }
@@ -0,0 +1,67 @@
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+)
+
+// LinkTokenLength is an amount of base32 characters in link token. Tokens
+// are resolved without authentication, so they carry 128 random bits, which
+// can't be guessed or enumerated.
+const LinkTokenLength = 26
+
+// linkTokenBytes is an amount of random bytes, encoded in the token.
+const linkTokenBytes = 16
+
+var linkEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// LinkID is a short token, which replaces long url in content, passed to the
+// model. Token is random, so knowing the url (or the thread) doesn't help to
+// find its token.
+type LinkID struct {
+	token string
+
+	_valid bool
+}
+
+// RandomLinkID returns a new random LinkID.
+// crypto/rand.Read never returns an error, so this never fails.
+func RandomLinkID() LinkID {
+	token := make([]byte, linkTokenBytes)
+	_, _ = rand.Read(token)
+
+	return LinkID{
+		token:  linkEncoding.EncodeToString(token),
+		_valid: true,
+	}
+}
+
+func NewLinkID(token string) (LinkID, error) {
+	link := LinkID{
+		token:  token,
+		_valid: false,
+	}
+
+	if err := link.validate(); err != nil {
+		return LinkID{}, err
+	}
+
+	link._valid = true
+
+	return link, nil
+}
+
+func (l LinkID) Valid() bool { return l._valid || l.validate() == nil }
+func (l LinkID) validate() error {
+	switch {
+	case len(l.token) != LinkTokenLength:
+		return ErrInternalValidation("link token must be %v characters long", LinkTokenLength)
+	case strings.Trim(l.token, "abcdefghijklmnopqrstuvwxyz234567") != "":
+		return ErrInternalValidation("link token contains invalid characters")
+	default:
+		return nil
+	}
+}
+
+func (l LinkID) String() string { return l.token }
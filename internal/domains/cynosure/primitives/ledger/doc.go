@@ -0,0 +1,3 @@
+// Package ledger defines records of resources, consumed by agents: tokens,
+// time and tool calls.
+package ledger
@@ -0,0 +1,33 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// Entry is a record of single agent turn: model call and tool calls, requested
+// by model.
+type Entry struct {
+	at     time.Time
+	thread ids.ThreadID
+	agent  ids.AgentID
+	model  string
+	stats  Stats
+}
+
+func NewEntry(thread ids.ThreadID, agent ids.AgentID, model string, at time.Time, stats Stats) Entry {
+	return Entry{
+		at:     at,
+		thread: thread,
+		agent:  agent,
+		model:  model,
+		stats:  stats,
+	}
+}
+
+func (e Entry) At() time.Time        { return e.at }
+func (e Entry) Thread() ids.ThreadID { return e.thread }
+func (e Entry) Agent() ids.AgentID   { return e.agent }
+func (e Entry) Model() string        { return e.model }
+func (e Entry) Stats() Stats         { return e.stats }
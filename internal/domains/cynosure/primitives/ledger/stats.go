@@ -0,0 +1,27 @@
+package ledger
+
+import (
+	"time"
+)
+
+// Stats are resources, consumed during one or several agent turns.
+type Stats struct {
+	InputTokens  uint64
+	OutputTokens uint64
+	// time, spent by model.
+	Duration  time.Duration
+	ToolCalls uint64
+	// failed model calls and tool calls.
+	Errors uint64
+}
+
+// Add returns sum of both stats.
+func (s Stats) Add(other Stats) Stats {
+	return Stats{
+		InputTokens:  s.InputTokens + other.InputTokens,
+		OutputTokens: s.OutputTokens + other.OutputTokens,
+		Duration:     s.Duration + other.Duration,
+		ToolCalls:    s.ToolCalls + other.ToolCalls,
+		Errors:       s.Errors + other.Errors,
+	}
+}
@@ -0,0 +1,34 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// Summary is a sum of ledger entries of the agent and model in one calendar
+// day (UTC).
+type Summary struct {
+	day   time.Time
+	agent ids.AgentID
+	model string
+	turns uint64
+	stats Stats
+}
+
+func NewSummary(day time.Time, agent ids.AgentID, model string, turns uint64, stats Stats) Summary {
+	return Summary{
+		day:   day,
+		agent: agent,
+		model: model,
+		turns: turns,
+		stats: stats,
+	}
+}
+
+// Day returns start of the day in UTC.
+func (s Summary) Day() time.Time     { return s.day }
+func (s Summary) Agent() ids.AgentID { return s.agent }
+func (s Summary) Model() string      { return s.model }
+func (s Summary) Turns() uint64      { return s.turns }
+func (s Summary) Stats() Stats       { return s.stats }
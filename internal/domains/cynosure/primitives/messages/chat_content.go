@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"mime"
 	"net/url"
+	"strings"
 )
 
 type ChatContent interface {
@@ -62,6 +63,16 @@ type ChatContentAudioURL struct {
 
 func (c *ChatContentAudioURL) _ChatContent() {}
 
+func NewChatContentAudioURL(
+	address url.URL, mimeType string, extra map[string]json.RawMessage,
+) *ChatContentAudioURL {
+	return &ChatContentAudioURL{
+		extra:    extra,
+		mimeType: mimeType,
+		address:  address,
+	}
+}
+
 func (c *ChatContentAudioURL) Type() (mediaType string, params map[string]string) {
 	//nolint:errcheck // handled via default values
 	mediaType, params, _ = mime.ParseMediaType(c.mimeType)
@@ -79,6 +90,16 @@ type ChatContentVideoURL struct {
 
 func (c *ChatContentVideoURL) _ChatContent() {}
 
+func NewChatContentVideoURL(
+	address url.URL, mimeType string, extra map[string]json.RawMessage,
+) *ChatContentVideoURL {
+	return &ChatContentVideoURL{
+		extra:    extra,
+		mimeType: mimeType,
+		address:  address,
+	}
+}
+
 func (c *ChatContentVideoURL) Type() (mediaType string, params map[string]string) {
 	//nolint:errcheck // handled via default values
 	mediaType, params, _ = mime.ParseMediaType(c.mimeType)
@@ -97,6 +118,17 @@ type ChatContentFileURL struct {
 
 func (c *ChatContentFileURL) _ChatContent() {}
 
+func NewChatContentFileURL(
+	address url.URL, name, mimeType string, extra map[string]json.RawMessage,
+) *ChatContentFileURL {
+	return &ChatContentFileURL{
+		extra:    extra,
+		name:     name,
+		mimeType: mimeType,
+		address:  address,
+	}
+}
+
 func (c *ChatContentFileURL) Type() (mediaType string, params map[string]string) {
 	//nolint:errcheck // handled via default values
 	mediaType, params, _ = mime.ParseMediaType(c.mimeType)
@@ -116,6 +148,17 @@ type ChatContentImageURL struct {
 
 func (c *ChatContentImageURL) _ChatContent() {}
 
+func NewChatContentImageURL(
+	address url.URL, mimeType string, extra map[string]json.RawMessage,
+) *ChatContentImageURL {
+	return &ChatContentImageURL{
+		extra:    extra,
+		mimeType: mimeType,
+		address:  address,
+		detail:   ImageURLDetailAuto,
+	}
+}
+
 func (c *ChatContentImageURL) Type() (mediaType string, params map[string]string) {
 	//nolint:errcheck // handled via default values
 	mediaType, params, _ = mime.ParseMediaType(c.mimeType)
@@ -135,6 +178,41 @@ const (
 	ImageURLDetailAuto                   // auto
 )
 
+// NewDataURL embeds binary content into "data:" URL (RFC 2397), so it could
+// be passed as a regular content address.
+func NewDataURL(mimeType string, data []byte) url.URL {
+	//nolint:exhaustruct // intentional data URL
+	return url.URL{
+		Scheme: "data",
+		Opaque: mimeType + ";base64," + base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+// DecodeDataURL extracts content, embedded with [NewDataURL]. Reports false,
+// if address is not a valid base64 "data:" URL.
+func DecodeDataURL(address *url.URL) (mimeType string, data []byte, ok bool) {
+	if address == nil || address.Scheme != "data" {
+		return "", nil, false
+	}
+
+	header, payload, found := strings.Cut(address.Opaque, ",")
+	if !found {
+		return "", nil, false
+	}
+
+	mimeType, found = strings.CutSuffix(header, ";base64")
+	if !found {
+		return "", nil, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, false
+	}
+
+	return mimeType, data, true
+}
+
 func clone[T any](v *T) *T {
 	t := new(T)
 	*t = *v
@@ -18,6 +18,7 @@ const (
 //   - [MessageToolRequest]
 //   - [MessageToolResponse]
 //   - [MessageToolError]
+//   - [MessageToolProgress]
 //   - [MessageAssistant]
 //   - [MessageUser]
 type Message interface {
@@ -33,6 +34,7 @@ var (
 	_ Message = MessageToolRequest{}
 	_ Message = MessageToolResponse{}
 	_ Message = MessageToolError{}
+	_ Message = MessageToolProgress{}
 	_ Message = MessageAssistant{}
 	_ Message = MessageUser{}
 )
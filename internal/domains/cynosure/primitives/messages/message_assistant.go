@@ -88,6 +88,20 @@ func (am MessageAssistant) Content() string          { return am.content }
 func (am MessageAssistant) AgentID() ids.AgentID     { return am.agentID }
 func (am MessageAssistant) ProtocolMetadata() []byte { return bytes.Clone(am.protocolMetadata) }
 
+// WithContent returns copy of the message with replaced text content.
+func (am MessageAssistant) WithContent(content string) (MessageAssistant, error) {
+	am.content = content
+	am._valid = false
+
+	if err := am.Validate(); err != nil {
+		return MessageAssistant{}, err
+	}
+
+	am._valid = true
+
+	return am, nil
+}
+
 func (am MessageAssistant) Format(
 	ctx context.Context,
 	vs map[string]any,
@@ -0,0 +1,108 @@
+package messages
+
+import (
+	"math"
+)
+
+// MessageToolProgress reports intermediate progress of a running tool call.
+//
+// Unlike other messages, it is transient: it MUST NOT be stored in thread
+// history and it is never sent to the model. It exists only to notify user
+// that long-running tool is still alive.
+type MessageToolProgress struct {
+	toolName   string
+	toolCallID string
+	message    string
+	progress   float64
+	total      float64
+	mergeTag   uint64
+	_valid     bool // Indicates that struct correctly initialized
+}
+
+func (tm MessageToolProgress) _Message() {}
+
+type NewMessageToolProgressOpt func(*MessageToolProgress)
+
+// WithMessageToolProgressTotal sets expected total amount of work. Without
+// total, progress value is only increasing counter, and percentage can't be
+// calculated.
+func WithMessageToolProgressTotal(total float64) NewMessageToolProgressOpt {
+	return func(m *MessageToolProgress) { m.total = total }
+}
+
+// WithMessageToolProgressMessage sets human readable description of current
+// tool stage.
+func WithMessageToolProgressMessage(message string) NewMessageToolProgressOpt {
+	return func(m *MessageToolProgress) { m.message = message }
+}
+
+func WithMessageToolProgressMergeTag(mergeTag uint64) NewMessageToolProgressOpt {
+	return func(m *MessageToolProgress) { m.mergeTag = mergeTag }
+}
+
+func NewMessageToolProgress(
+	progress float64,
+	toolName, toolCallID string,
+	opts ...NewMessageToolProgressOpt,
+) (
+	MessageToolProgress,
+	error,
+) {
+	message := MessageToolProgress{
+		toolName:   toolName,
+		toolCallID: toolCallID,
+		message:    "",
+		progress:   progress,
+		total:      0,
+		mergeTag:   0,
+		_valid:     false,
+	}
+
+	for _, opt := range opts {
+		opt(&message)
+	}
+
+	if err := message.Validate(); err != nil {
+		return MessageToolProgress{}, err
+	}
+
+	message._valid = true
+
+	return message, nil
+}
+
+func (tm MessageToolProgress) Valid() bool { return tm._valid || tm.Validate() == nil }
+func (tm MessageToolProgress) Validate() error {
+	switch {
+	case tm.toolName == "":
+		return ErrInternalValidation("tool name cannot be empty")
+	case tm.toolCallID == "":
+		return ErrInternalValidation("tool call ID cannot be empty")
+	case math.IsNaN(tm.progress) || tm.progress < 0:
+		return ErrInternalValidation("progress must be non-negative number")
+	case math.IsNaN(tm.total) || tm.total < 0:
+		return ErrInternalValidation("total must be non-negative number")
+	case len(tm.message) > maxMessageLength:
+		return ErrMessageTooLarge
+	default:
+		return nil
+	}
+}
+
+func (tm MessageToolProgress) MergeTag() uint64   { return tm.mergeTag }
+func (tm MessageToolProgress) ToolName() string   { return tm.toolName }
+func (tm MessageToolProgress) ToolCallID() string { return tm.toolCallID }
+func (tm MessageToolProgress) Message() string    { return tm.message }
+func (tm MessageToolProgress) Progress() float64  { return tm.progress }
+
+// Total returns expected total amount of work, if server reported it.
+func (tm MessageToolProgress) Total() (float64, bool) { return tm.total, tm.total > 0 }
+
+// Percent returns progress in range [0, 100], if total is known.
+func (tm MessageToolProgress) Percent() (float64, bool) {
+	if tm.total <= 0 {
+		return 0, false
+	}
+
+	return math.Min(tm.progress/tm.total, 1) * 100, true //nolint:mnd // percents
+}
@@ -2,14 +2,26 @@ package messages
 
 import (
 	"encoding/json"
+	"slices"
+)
+
+const (
+	// maxAttachments limits amount of media files in a single tool result.
+	maxAttachments = 10
+	// maxAttachmentSize limits size of single attachment address. Embedded
+	// content bigger than that is almost certainly rejected by model
+	// providers and chat clients.
+	maxAttachmentSize = 10 << 20
 )
 
 type MessageToolResponse struct {
-	toolName   string
-	toolCallID string
-	content    json.RawMessage
-	mergeTag   uint64
-	_valid     bool // Indicates that struct correctly initialized
+	toolName    string
+	toolCallID  string
+	content     json.RawMessage
+	mergeTag    uint64
+	structured  bool
+	attachments []ChatContent
+	_valid      bool // Indicates that struct correctly initialized
 }
 
 func (tm MessageToolResponse) _Message()     {}
@@ -21,6 +33,18 @@ func WithMessageToolResponseMergeTag(mergeTag uint64) NewMessageToolResponseOpt
 	return func(m *MessageToolResponse) { m.mergeTag = mergeTag }
 }
 
+// WithMessageToolResponseAttachments adds media content (images, audio, files),
+// returned by the tool in addition to its json result.
+func WithMessageToolResponseAttachments(attachments ...ChatContent) NewMessageToolResponseOpt {
+	return func(m *MessageToolResponse) { m.attachments = append(m.attachments, attachments...) }
+}
+
+// WithMessageToolResponseStructured marks content as structured result, which
+// is a json object, conforming to the output schema of the tool.
+func WithMessageToolResponseStructured() NewMessageToolResponseOpt {
+	return func(m *MessageToolResponse) { m.structured = true }
+}
+
 func NewMessageToolResponse(
 	content json.RawMessage,
 	toolName, toolCallID string,
@@ -30,11 +54,13 @@ func NewMessageToolResponse(
 	error,
 ) {
 	message := MessageToolResponse{
-		toolName:   toolName,
-		toolCallID: toolCallID,
-		content:    content,
-		mergeTag:   0,
-		_valid:     false,
+		toolName:    toolName,
+		toolCallID:  toolCallID,
+		content:     content,
+		mergeTag:    0,
+		structured:  false,
+		attachments: nil,
+		_valid:      false,
 	}
 
 	for _, opt := range opts {
@@ -61,12 +87,92 @@ func (tm MessageToolResponse) Validate() error {
 		return ErrInternalValidation("content must be valid JSON")
 	case len(tm.content) > maxMessageLength:
 		return ErrMessageTooLarge
+	case tm.structured && !isJSONObject(tm.content):
+		return ErrInternalValidation("structured content must be a JSON object")
 	default:
-		return nil
+		return validateAttachments(tm.attachments)
 	}
 }
 
+func validateAttachments(attachments []ChatContent) error {
+	if len(attachments) > maxAttachments {
+		return ErrInternalValidation("too many attachments: %d, max %d", len(attachments), maxAttachments)
+	}
+
+	for i, attachment := range attachments {
+		switch attachment.(type) {
+		case nil:
+			return ErrInternalValidation("attachment %d is nil", i)
+		case *ChatContentText:
+			return ErrInternalValidation("attachment %d: text must be passed as content", i)
+		}
+
+		if len(attachment.URL().String()) > maxAttachmentSize {
+			return ErrMessageTooLarge
+		}
+	}
+
+	return nil
+}
+
 func (tm MessageToolResponse) MergeTag() uint64         { return tm.mergeTag }
 func (tm MessageToolResponse) ToolName() string         { return tm.toolName }
 func (tm MessageToolResponse) ToolCallID() string       { return tm.toolCallID }
 func (tm MessageToolResponse) Content() json.RawMessage { return tm.content }
+
+// WithContent returns copy of the message with replaced content. Rest of the
+// message, including attachments and structured flag, is kept as is.
+func (tm MessageToolResponse) WithContent(content json.RawMessage) (MessageToolResponse, error) {
+	tm.content = content
+	tm._valid = false
+
+	if err := tm.Validate(); err != nil {
+		return MessageToolResponse{}, err
+	}
+
+	tm._valid = true
+
+	return tm, nil
+}
+
+// WithToolCallID returns copy of the message, which answers another call of
+// the same tool.
+func (tm MessageToolResponse) WithToolCallID(toolCallID string) (MessageToolResponse, error) {
+	tm.toolCallID = toolCallID
+	tm._valid = false
+
+	if err := tm.Validate(); err != nil {
+		return MessageToolResponse{}, err
+	}
+
+	tm._valid = true
+
+	return tm, nil
+}
+
+// Attachments returns media content of the result. Unlike json content, it's
+// not always visible for the model: only if model supports multimodal tool
+// results.
+func (tm MessageToolResponse) Attachments() []ChatContent { return slices.Clone(tm.attachments) }
+
+// Structured returns fields of the result, if tool returned structured
+// content, validated against its output schema. Unstructured results (like
+// plain text) are not decoded.
+func (tm MessageToolResponse) Structured() (map[string]json.RawMessage, bool) {
+	if !tm.structured {
+		return nil, false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(tm.content, &fields); err != nil {
+		return nil, false
+	}
+
+	return fields, true
+}
+
+func isJSONObject(content json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+
+	return json.Unmarshal(content, &fields) == nil && fields != nil
+}
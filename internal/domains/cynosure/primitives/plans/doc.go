@@ -0,0 +1,2 @@
+// Package plans defines rate limit plans, which users are subscribed to.
+package plans
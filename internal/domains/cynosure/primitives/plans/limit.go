@@ -0,0 +1,32 @@
+package plans
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limit allows burst of messages or tokens in the period, refilling them
+// evenly. Zero value is unlimited.
+type Limit struct {
+	burst  int
+	period time.Duration
+}
+
+func NewLimit(burst int, period time.Duration) Limit {
+	if burst <= 0 || period <= 0 {
+		return Limit{burst: 0, period: 0}
+	}
+
+	return Limit{burst: burst, period: period}
+}
+
+func (l Limit) Burst() int            { return l.burst }
+func (l Limit) Period() time.Duration { return l.period }
+func (l Limit) Unlimited() bool       { return l.burst == 0 }
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%d/%v", l.burst, l.period)
+}
@@ -0,0 +1,103 @@
+package plans
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+var (
+	// ErrInvalidName is returned, when plan name is not a short lowercase
+	// identifier.
+	ErrInvalidName = errors.New("plan name must match " + namePattern)
+
+	// ErrDuplicatePlan is returned, when catalog contains two plans with the
+	// same name.
+	ErrDuplicatePlan = errors.New("duplicate plan")
+)
+
+const namePattern = "^[a-z0-9_-]{1,32}$"
+
+var nameRegex = regexp.MustCompile(namePattern)
+
+// Plan is a named set of limits, e.g. "free" or "pro".
+type Plan struct {
+	name     string
+	messages Limit
+	tokens   Limit
+}
+
+func NewPlan(name string, messages, tokens Limit) (Plan, error) {
+	if !nameRegex.MatchString(name) {
+		return Plan{}, fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	return Plan{
+		name:     name,
+		messages: messages,
+		tokens:   tokens,
+	}, nil
+}
+
+func (p Plan) Name() string { return p.name }
+
+// Messages limits user messages.
+func (p Plan) Messages() Limit { return p.messages }
+
+// Tokens limits model tokens, spent on responses.
+func (p Plan) Tokens() Limit { return p.tokens }
+
+// Catalog is a set of plans, available for assignment. Users without
+// assigned plan, or with plan, which is not available anymore, are using the
+// default one. Zero catalog has only unnamed unlimited default plan.
+type Catalog struct {
+	fallback Plan
+	plans    map[string]Plan
+}
+
+func NewCatalog(fallback Plan, others ...Plan) (Catalog, error) {
+	catalog := Catalog{
+		fallback: fallback,
+		plans:    map[string]Plan{fallback.Name(): fallback},
+	}
+
+	for _, plan := range others {
+		if _, ok := catalog.plans[plan.Name()]; ok {
+			return Catalog{}, fmt.Errorf("%w: %q", ErrDuplicatePlan, plan.Name())
+		}
+
+		catalog.plans[plan.Name()] = plan
+	}
+
+	return catalog, nil
+}
+
+func (c Catalog) Default() Plan { return c.fallback }
+
+// Get returns plan by its name.
+func (c Catalog) Get(name string) (Plan, bool) {
+	plan, ok := c.plans[name]
+	return plan, ok
+}
+
+// Resolve returns plan by its name, or the default plan, if it's unknown.
+func (c Catalog) Resolve(name string) Plan {
+	if plan, ok := c.plans[name]; ok {
+		return plan
+	}
+
+	return c.fallback
+}
+
+// Names returns sorted names of all plans.
+func (c Catalog) Names() []string {
+	names := make([]string, 0, len(c.plans))
+	for name := range c.plans {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
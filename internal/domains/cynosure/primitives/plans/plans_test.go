@@ -0,0 +1,36 @@
+package plans_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+)
+
+func TestCatalog_Resolve(t *testing.T) {
+	t.Parallel()
+
+	free, err := NewPlan("free", NewLimit(20, time.Hour), NewLimit(100_000, time.Hour))
+	require.NoError(t, err)
+
+	pro, err := NewPlan("pro", NewLimit(200, time.Hour), Limit{})
+	require.NoError(t, err)
+
+	catalog, err := NewCatalog(free, pro)
+	require.NoError(t, err)
+
+	require.Equal(t, pro, catalog.Resolve("pro"))
+	require.True(t, catalog.Resolve("pro").Tokens().Unlimited())
+	// removed or never assigned plans fall back to default one.
+	require.Equal(t, free, catalog.Resolve("legacy"))
+	require.Equal(t, free, catalog.Resolve(""))
+	require.Equal(t, []string{"free", "pro"}, catalog.Names())
+
+	_, err = NewCatalog(free, free)
+	require.ErrorIs(t, err, ErrDuplicatePlan)
+
+	_, err = NewPlan("Pro Plan", Limit{}, Limit{})
+	require.ErrorIs(t, err, ErrInvalidName)
+}
@@ -0,0 +1,3 @@
+// Package prompts defines MCP prompts: parameterized message templates, which
+// servers expose to be invoked by users.
+package prompts
@@ -0,0 +1,50 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRole is returned, when message role is not known.
+var ErrInvalidRole = errors.New("invalid prompt message role")
+
+// Role is an author of the prompt message.
+type Role uint8
+
+const (
+	_ Role = iota
+	RoleUser
+	RoleAssistant
+)
+
+func (r Role) Valid() bool { return r == RoleUser || r == RoleAssistant }
+
+func (r Role) String() string {
+	switch r {
+	case RoleUser:
+		return "user"
+	case RoleAssistant:
+		return "assistant"
+	default:
+		return fmt.Sprintf("Role(%d)", uint8(r))
+	}
+}
+
+// Message is a single message of rendered prompt. Only textual content is
+// kept: agent receives prompt as regular conversation text.
+type Message struct {
+	role Role
+	text string
+}
+
+// NewMessage creates rendered prompt message.
+func NewMessage(role Role, text string) (Message, error) {
+	if !role.Valid() {
+		return Message{}, fmt.Errorf("%w: %v", ErrInvalidRole, role)
+	}
+
+	return Message{role: role, text: text}, nil
+}
+
+func (m Message) Role() Role   { return m.role }
+func (m Message) Text() string { return m.text }
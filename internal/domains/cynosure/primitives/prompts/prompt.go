@@ -0,0 +1,158 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+var (
+	// ErrInvalidName is returned, when prompt or its argument has no name.
+	ErrInvalidName = errors.New("prompt name is required")
+
+	// ErrInvalidAccount is returned, when prompt is not bound to account.
+	ErrInvalidAccount = errors.New("prompt account is invalid")
+
+	// ErrDuplicateArgument is returned, when prompt declares same argument
+	// twice.
+	ErrDuplicateArgument = errors.New("duplicate prompt argument")
+
+	// ErrMissingArgument is returned, when required argument is not set.
+	ErrMissingArgument = errors.New("required prompt argument is missing")
+
+	// ErrUnknownArgument is returned, when argument is not declared by the
+	// prompt.
+	ErrUnknownArgument = errors.New("unknown prompt argument")
+)
+
+// Argument describes a single parameter of the prompt template.
+type Argument struct {
+	name        string
+	description string
+	required    bool
+}
+
+// NewArgument creates prompt argument.
+func NewArgument(name, description string, required bool) (Argument, error) {
+	if name == "" {
+		return Argument{}, ErrInvalidName
+	}
+
+	return Argument{
+		name:        name,
+		description: description,
+		required:    required,
+	}, nil
+}
+
+func (a Argument) Name() string        { return a.name }
+func (a Argument) Description() string { return a.description }
+func (a Argument) Required() bool      { return a.required }
+
+// Prompt describes metadata of prompt, exposed by server of the account.
+// Name is unique only within the account: different servers may use same
+// names for different templates.
+type Prompt struct {
+	account     ids.AccountID
+	name        string
+	title       string
+	description string
+	arguments   []Argument
+}
+
+type Option func(*Prompt)
+
+func WithTitle(title string) Option {
+	return func(p *Prompt) { p.title = title }
+}
+
+func WithDescription(description string) Option {
+	return func(p *Prompt) { p.description = description }
+}
+
+// WithArguments sets arguments of the template in order, declared by server.
+func WithArguments(args ...Argument) Option {
+	return func(p *Prompt) { p.arguments = slices.Clone(args) }
+}
+
+// New creates prompt metadata.
+func New(account ids.AccountID, name string, opts ...Option) (Prompt, error) {
+	prompt := Prompt{
+		account:     account,
+		name:        name,
+		title:       "",
+		description: "",
+		arguments:   nil,
+	}
+
+	for _, opt := range opts {
+		opt(&prompt)
+	}
+
+	if err := prompt.validate(); err != nil {
+		return Prompt{}, err
+	}
+
+	return prompt, nil
+}
+
+func (p Prompt) validate() error {
+	switch {
+	case !p.account.Valid():
+		return ErrInvalidAccount
+	case p.name == "":
+		return ErrInvalidName
+	}
+
+	seen := make(map[string]struct{}, len(p.arguments))
+
+	for _, arg := range p.arguments {
+		if arg.name == "" {
+			return fmt.Errorf("argument of %q: %w", p.name, ErrInvalidName)
+		}
+
+		if _, ok := seen[arg.name]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateArgument, arg.name)
+		}
+
+		seen[arg.name] = struct{}{}
+	}
+
+	return nil
+}
+
+func (p Prompt) Account() ids.AccountID { return p.account }
+func (p Prompt) Name() string           { return p.name }
+func (p Prompt) Title() string          { return p.title }
+func (p Prompt) Description() string    { return p.description }
+func (p Prompt) Arguments() []Argument  { return slices.Clone(p.arguments) }
+
+// CheckArguments verifies, that all required arguments are set, and there are
+// no undeclared ones. Empty values of required arguments are treated as
+// missing.
+func (p Prompt) CheckArguments(args map[string]string) error {
+	for name := range args {
+		if !slices.ContainsFunc(p.arguments, func(arg Argument) bool { return arg.name == name }) {
+			return fmt.Errorf("%w: %q", ErrUnknownArgument, name)
+		}
+	}
+
+	for _, arg := range p.arguments {
+		if arg.required && args[arg.name] == "" {
+			return fmt.Errorf("%w: %q", ErrMissingArgument, arg.name)
+		}
+	}
+
+	return nil
+}
+
+// Equal reports whether both prompts have the same metadata.
+func (p Prompt) Equal(other Prompt) bool {
+	return p.account == other.account &&
+		p.name == other.name &&
+		p.title == other.title &&
+		p.description == other.description &&
+		slices.Equal(p.arguments, other.arguments)
+}
@@ -0,0 +1,56 @@
+package prompts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+func TestNew(t *testing.T) {
+	account, err := ids.RandomAccountID(ids.RandomUserID(), ids.RandomServerID())
+	require.NoError(t, err)
+
+	lang, err := prompts.NewArgument("language", "Language of the code", false)
+	require.NoError(t, err)
+
+	code, err := prompts.NewArgument("code", "Code to review", true)
+	require.NoError(t, err)
+
+	prompt, err := prompts.New(account, "code_review",
+		prompts.WithTitle("Code review"),
+		prompts.WithArguments(code, lang),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []prompts.Argument{code, lang}, prompt.Arguments())
+
+	_, err = prompts.New(account, "")
+	require.ErrorIs(t, err, prompts.ErrInvalidName)
+
+	_, err = prompts.New(ids.AccountID{}, "code_review")
+	require.ErrorIs(t, err, prompts.ErrInvalidAccount)
+
+	_, err = prompts.New(account, "code_review", prompts.WithArguments(code, code))
+	require.ErrorIs(t, err, prompts.ErrDuplicateArgument)
+}
+
+func TestCheckArguments(t *testing.T) {
+	account, err := ids.RandomAccountID(ids.RandomUserID(), ids.RandomServerID())
+	require.NoError(t, err)
+
+	code, err := prompts.NewArgument("code", "", true)
+	require.NoError(t, err)
+
+	prompt, err := prompts.New(account, "code_review", prompts.WithArguments(code))
+	require.NoError(t, err)
+
+	require.NoError(t, prompt.CheckArguments(map[string]string{"code": "x := 1"}))
+	require.ErrorIs(t, prompt.CheckArguments(map[string]string{"code": ""}), prompts.ErrMissingArgument)
+	require.ErrorIs(t, prompt.CheckArguments(nil), prompts.ErrMissingArgument)
+	require.ErrorIs(t,
+		prompt.CheckArguments(map[string]string{"code": "x", "style": "google"}),
+		prompts.ErrUnknownArgument,
+	)
+}
@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"slices"
+)
+
+// Content is a single part of resource content. Server may return several
+// parts for one read, e.g. files of the directory. Content is either text or
+// binary blob.
+type Content struct {
+	uri      string
+	mimeType string
+	text     string
+	blob     []byte
+	isBlob   bool
+}
+
+// NewTextContent creates textual content part.
+func NewTextContent(uri, mimeType, text string) (Content, error) {
+	if uri == "" {
+		return Content{}, ErrInvalidURI
+	}
+
+	return Content{
+		uri:      uri,
+		mimeType: mimeType,
+		text:     text,
+		blob:     nil,
+		isBlob:   false,
+	}, nil
+}
+
+// NewBlobContent creates binary content part.
+func NewBlobContent(uri, mimeType string, blob []byte) (Content, error) {
+	if uri == "" {
+		return Content{}, ErrInvalidURI
+	}
+
+	return Content{
+		uri:      uri,
+		mimeType: mimeType,
+		text:     "",
+		blob:     slices.Clone(blob),
+		isBlob:   true,
+	}, nil
+}
+
+func (c Content) URI() string      { return c.uri }
+func (c Content) MIMEType() string { return c.mimeType }
+func (c Content) Text() string     { return c.text }
+func (c Content) Blob() []byte     { return slices.Clone(c.blob) }
+
+// IsBlob reports whether content is binary.
+func (c Content) IsBlob() bool { return c.isBlob }
@@ -0,0 +1,3 @@
+// Package resources defines MCP resources: documents, files and records,
+// which servers expose to be read by agents.
+package resources
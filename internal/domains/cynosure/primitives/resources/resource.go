@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+var (
+	// ErrInvalidURI is returned, when resource has no URI.
+	ErrInvalidURI = errors.New("resource URI is required")
+
+	// ErrInvalidAccount is returned, when resource is not bound to account.
+	ErrInvalidAccount = errors.New("resource account is invalid")
+
+	// ErrInvalidSize is returned, when resource size is negative.
+	ErrInvalidSize = errors.New("resource size must not be negative")
+)
+
+// Resource describes metadata of resource, exposed by server of the account.
+// URI is unique only within the account: different servers may use same
+// URIs for different data.
+type Resource struct {
+	account     ids.AccountID
+	uri         string
+	name        string
+	title       string
+	description string
+	mimeType    string
+	// zero means, that server didn't report the size.
+	size int64
+}
+
+type Option func(*Resource)
+
+func WithTitle(title string) Option {
+	return func(r *Resource) { r.title = title }
+}
+
+func WithDescription(description string) Option {
+	return func(r *Resource) { r.description = description }
+}
+
+func WithMIMEType(mimeType string) Option {
+	return func(r *Resource) { r.mimeType = mimeType }
+}
+
+// WithSize sets size of raw content in bytes, if server knows it.
+func WithSize(size int64) Option {
+	return func(r *Resource) { r.size = size }
+}
+
+// New creates resource metadata. Name is optional, URI is used instead, if
+// it's empty.
+func New(account ids.AccountID, uri, name string, opts ...Option) (Resource, error) {
+	res := Resource{
+		account:     account,
+		uri:         uri,
+		name:        name,
+		title:       "",
+		description: "",
+		mimeType:    "",
+		size:        0,
+	}
+
+	for _, opt := range opts {
+		opt(&res)
+	}
+
+	if err := res.validate(); err != nil {
+		return Resource{}, err
+	}
+
+	return res, nil
+}
+
+func (r Resource) validate() error {
+	switch {
+	case !r.account.Valid():
+		return ErrInvalidAccount
+	case r.uri == "":
+		return ErrInvalidURI
+	case r.size < 0:
+		return fmt.Errorf("%w: %v", ErrInvalidSize, r.size)
+	default:
+		return nil
+	}
+}
+
+func (r Resource) Account() ids.AccountID { return r.account }
+func (r Resource) URI() string            { return r.uri }
+func (r Resource) Title() string          { return r.title }
+func (r Resource) Description() string    { return r.description }
+func (r Resource) MIMEType() string       { return r.mimeType }
+func (r Resource) Size() int64            { return r.size }
+
+// Name returns programmatic name of the resource, or its URI, if server didn't
+// provide the name.
+func (r Resource) Name() string {
+	if r.name == "" {
+		return r.uri
+	}
+
+	return r.name
+}
+
+// Equal reports whether both resources have the same metadata.
+func (r Resource) Equal(other Resource) bool {
+	return r == other
+}
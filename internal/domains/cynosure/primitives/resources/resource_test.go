@@ -0,0 +1,44 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+)
+
+func TestNew(t *testing.T) {
+	account, err := ids.RandomAccountID(ids.RandomUserID(), ids.RandomServerID())
+	require.NoError(t, err)
+
+	res, err := resources.New(account, "file:///notes.md", "",
+		resources.WithMIMEType("text/markdown"),
+		resources.WithSize(42),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "file:///notes.md", res.Name(), "uri must be used as name")
+	require.Equal(t, "text/markdown", res.MIMEType())
+	require.Equal(t, int64(42), res.Size())
+
+	_, err = resources.New(account, "", "notes")
+	require.ErrorIs(t, err, resources.ErrInvalidURI)
+
+	_, err = resources.New(ids.AccountID{}, "file:///notes.md", "notes")
+	require.ErrorIs(t, err, resources.ErrInvalidAccount)
+
+	_, err = resources.New(account, "file:///notes.md", "notes", resources.WithSize(-1))
+	require.ErrorIs(t, err, resources.ErrInvalidSize)
+}
+
+func TestContent(t *testing.T) {
+	text, err := resources.NewTextContent("file:///notes.md", "text/markdown", "# Notes")
+	require.NoError(t, err)
+	require.False(t, text.IsBlob())
+	require.Equal(t, "# Notes", text.Text())
+
+	blob, err := resources.NewBlobContent("file:///empty.bin", "", nil)
+	require.NoError(t, err)
+	require.True(t, blob.IsBlob(), "empty blob is still a blob")
+}
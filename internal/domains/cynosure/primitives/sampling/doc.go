@@ -0,0 +1,3 @@
+// Package sampling defines MCP sampling: completions, which connected servers
+// request from the client, and policy, which decides whether they are allowed.
+package sampling
@@ -0,0 +1,69 @@
+package sampling
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPolicy is returned, when policy name is not known.
+var ErrInvalidPolicy = errors.New("invalid sampling policy")
+
+// Policy decides, which servers are approved to request completions on
+// behalf of the user: every approved request spends user tokens.
+type Policy uint8
+
+const (
+	// PolicyDeny rejects every request. It's the default: servers can't
+	// spend user tokens, unless operator allowed it.
+	PolicyDeny Policy = iota
+	// PolicyInternal approves only servers, deployed together with cynosure.
+	// They are trusted by operator, so user approval is not required.
+	PolicyInternal
+	// PolicyAllow approves internal servers and servers, which user allowed
+	// explicitly for the account. Connecting an account is not an approval:
+	// sampling must be enabled for each account separately.
+	PolicyAllow
+)
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyDeny:
+		return "deny"
+	case PolicyInternal:
+		return "internal"
+	case PolicyAllow:
+		return "allow"
+	default:
+		return fmt.Sprintf("Policy(%d)", uint8(p))
+	}
+}
+
+// ParsePolicy parses policy from its [Policy.String] representation. Empty
+// string is parsed as [PolicyDeny].
+func ParsePolicy(s string) (Policy, error) {
+	if s == "" {
+		return PolicyDeny, nil
+	}
+
+	for policy := PolicyDeny; policy <= PolicyAllow; policy++ {
+		if policy.String() == s {
+			return policy, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidPolicy, s)
+}
+
+// Approves reports, whether request of the server is allowed. Internal
+// servers are deployed with cynosure, other servers are connected by users,
+// and allowed reports, whether user enabled sampling for the account.
+func (p Policy) Approves(internal, allowed bool) bool {
+	switch p {
+	case PolicyAllow:
+		return internal || allowed
+	case PolicyInternal:
+		return internal
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package sampling
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+var (
+	// ErrEmptyRequest is returned, when request has no messages.
+	ErrEmptyRequest = errors.New("sampling request has no messages")
+
+	// ErrUnsupportedMessage is returned, when request contains message,
+	// which is neither user, nor assistant one.
+	ErrUnsupportedMessage = errors.New("unsupported sampling message")
+
+	// ErrInvalidMaxTokens is returned, when token limit of the request is not
+	// positive.
+	ErrInvalidMaxTokens = errors.New("sampling max tokens must be positive")
+)
+
+// Request is a completion, requested by server. Server decides conversation
+// and limits, client decides which model answers it.
+type Request struct {
+	systemPrompt string
+	messages     []messages.Message
+	maxTokens    int
+}
+
+type RequestOption func(*Request)
+
+// WithSystemPrompt sets instructions, which server wants to use. Client may
+// modify or omit them.
+func WithSystemPrompt(prompt string) RequestOption {
+	return func(r *Request) { r.systemPrompt = prompt }
+}
+
+// NewRequest creates sampling request. Messages must be user or assistant
+// ones: servers can't pass tool calls.
+func NewRequest(msgs []messages.Message, maxTokens int, opts ...RequestOption) (Request, error) {
+	req := Request{
+		systemPrompt: "",
+		messages:     msgs,
+		maxTokens:    maxTokens,
+	}
+	for _, opt := range opts {
+		opt(&req)
+	}
+
+	if err := req.validate(); err != nil {
+		return Request{}, err
+	}
+
+	return req, nil
+}
+
+func (r Request) validate() error {
+	if len(r.messages) == 0 {
+		return ErrEmptyRequest
+	}
+
+	if r.maxTokens <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidMaxTokens, r.maxTokens)
+	}
+
+	for i, msg := range r.messages {
+		switch msg.(type) {
+		case messages.MessageUser, messages.MessageAssistant:
+		default:
+			return fmt.Errorf("%w: message %d is %T", ErrUnsupportedMessage, i, msg)
+		}
+	}
+
+	return nil
+}
+
+func (r Request) SystemPrompt() string { return r.systemPrompt }
+
+// MaxTokens is the upper bound of generated tokens, requested by server.
+func (r Request) MaxTokens() int { return r.maxTokens }
+
+func (r Request) Messages() []messages.Message { return r.messages }
+
+// Result is an answer of the agent for sampling request.
+type Result struct {
+	text  string
+	model string
+}
+
+// NewResult creates sampling result. Model is a name of the model, which
+// generated the text.
+func NewResult(text, model string) Result {
+	return Result{text: text, model: model}
+}
+
+func (r Result) Text() string  { return r.text }
+func (r Result) Model() string { return r.model }
@@ -0,0 +1,60 @@
+package sampling_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
+)
+
+func TestNewRequest(t *testing.T) {
+	question, err := messages.NewMessageUser("What is the capital of France?")
+	require.NoError(t, err)
+
+	req, err := sampling.NewRequest([]messages.Message{question}, 100,
+		sampling.WithSystemPrompt("Answer briefly."),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Answer briefly.", req.SystemPrompt())
+	require.Equal(t, 100, req.MaxTokens())
+
+	_, err = sampling.NewRequest(nil, 100)
+	require.ErrorIs(t, err, sampling.ErrEmptyRequest)
+
+	_, err = sampling.NewRequest([]messages.Message{question}, 0)
+	require.ErrorIs(t, err, sampling.ErrInvalidMaxTokens)
+
+	progress, err := messages.NewMessageToolProgress(1, "tool", "call-1")
+	require.NoError(t, err)
+
+	_, err = sampling.NewRequest([]messages.Message{question, progress}, 100)
+	require.ErrorIs(t, err, sampling.ErrUnsupportedMessage)
+}
+
+func TestPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		internal bool
+		external bool
+		allowed  bool
+	}{
+		{name: "", internal: false, external: false, allowed: false},
+		{name: "deny", internal: false, external: false, allowed: false},
+		{name: "internal", internal: true, external: false, allowed: false},
+		{name: "allow", internal: true, external: false, allowed: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := sampling.ParsePolicy(tt.name)
+			require.NoError(t, err)
+			require.Equal(t, tt.internal, policy.Approves(true, false))
+			require.Equal(t, tt.external, policy.Approves(false, false))
+			require.Equal(t, tt.allowed, policy.Approves(false, true),
+				"external server is approved only, if user allowed it")
+		})
+	}
+
+	_, err := sampling.ParsePolicy("ask")
+	require.ErrorIs(t, err, sampling.ErrInvalidPolicy)
+}
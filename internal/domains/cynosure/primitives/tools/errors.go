@@ -15,6 +15,7 @@ var (
 	ErrInvalidToolID             = errors.New("invalid tool id")
 	ErrAccountSlugEmpty          = errors.New("account slug cannot be empty")
 	ErrInvalidInputSchema        = errors.New("invalid input schema")
+	ErrInvalidOutputSchema       = errors.New("invalid output schema")
 	ErrReservedPropertyUsed      = errors.New("reserved property used")
 	ErrDuplicateToolID           = errors.New("duplicate tool id")
 	ErrAccountNotFound           = errors.New("account not found")
@@ -30,6 +31,8 @@ var (
 	ErrNoToolsToMerge            = errors.New("no tools to merge")
 	ErrSchemaParse               = errors.New("cannot parse schema")
 	ErrSchemaInvalid             = errors.New("invalid schema")
+	ErrSchemaUnsupported         = errors.New("schema is not supported")
 	ErrUnreachable               = errors.New("unreachable code reached")
 	ErrSchemaCollistion          = errors.New("schema collision")
+	ErrToolBuiltin               = errors.New("tool is built-in")
 )
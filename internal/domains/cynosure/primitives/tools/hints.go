@@ -0,0 +1,29 @@
+package tools
+
+// Hints describe behavior of the tool, as it's declared by its server. Hints
+// are not verified, so they must be used only for optimizations, which are
+// safe to be wrong about.
+type Hints struct {
+	readOnly   bool
+	idempotent bool
+}
+
+func NewHints(readOnly, idempotent bool) Hints {
+	return Hints{
+		readOnly:   readOnly,
+		idempotent: idempotent,
+	}
+}
+
+// ReadOnly reports whether tool doesn't modify its environment.
+func (h Hints) ReadOnly() bool { return h.readOnly }
+
+// Idempotent reports whether repeated calls with the same arguments have no
+// additional effect.
+func (h Hints) Idempotent() bool { return h.idempotent }
+
+// Cacheable reports whether results of the tool could be reused for calls
+// with the same arguments. Idempotent tools are not cacheable: repeated call
+// has no additional effect, but it still must reach the server, e.g. to
+// re-apply the change after it was reverted.
+func (h Hints) Cacheable() bool { return h.readOnly }
@@ -2,6 +2,8 @@
 package tools
 
 import (
+	"net/url"
+
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives"
 )
 
@@ -15,6 +17,10 @@ const (
 	ProtocolUnknown Protocol = iota // unknown
 	ProtocolHTTP                    // http
 	ProtocolSSE                     // sse
+	// local server, launched as a subprocess. Links of such servers look
+	// like "stdio://<name>", where name refers to the command, configured by
+	// administrator.
+	ProtocolStdio // stdio
 )
 
 // ParseProtocol parses a string into a Protocol.
@@ -43,3 +49,9 @@ func (s *Protocol) UnmarshalText(buf []byte) error {
 func (s Protocol) Valid() bool {
 	return s <= Protocol(len(_Protocol_index)-1)
 }
+
+// IsStdioLink reports whether the link points to the local subprocess
+// server.
+func IsStdioLink(link *url.URL) bool {
+	return link != nil && link.Scheme == ProtocolStdio.String()
+}
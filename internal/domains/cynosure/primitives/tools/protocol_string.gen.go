@@ -11,11 +11,12 @@ func _() {
 	_ = x[ProtocolUnknown-0]
 	_ = x[ProtocolHTTP-1]
 	_ = x[ProtocolSSE-2]
+	_ = x[ProtocolStdio-3]
 }
 
-const _Protocol_name = "unknownhttpsse"
+const _Protocol_name = "unknownhttpssestdio"
 
-var _Protocol_index = [...]uint8{0, 7, 11, 14}
+var _Protocol_index = [...]uint8{0, 7, 11, 14, 19}
 
 func (i Protocol) String() string {
 	idx := int(i) - 0
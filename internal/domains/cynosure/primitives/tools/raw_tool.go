@@ -2,7 +2,6 @@
 package tools
 
 import (
-	"bytes"
 	"cmp"
 	"encoding/json"
 	"fmt"
@@ -54,20 +53,61 @@ type RawTool struct {
 	// (description of tool located at [RawTool.desc])
 	encodedTools toolAccounts
 
-	params   json.RawMessage
-	response json.RawMessage
+	params   Schema
+	response Schema
+
+	// builtin tools are executed by cynosure itself, so they don't belong to
+	// any account.
+	builtin bool
+
+	hints Hints
 
 	_valid bool
 }
 
+type RawToolOption func(*RawTool)
+
+// WithRawToolHints sets behavior hints, declared by tool server.
+func WithRawToolHints(hints Hints) RawToolOption {
+	return func(r *RawTool) { r.hints = hints }
+}
+
 // NewRawTool constructs and validates a tool definition.
 func NewRawTool(
 	name, desc string,
-	params, response json.RawMessage,
+	params, response Schema,
 	accountID ids.ToolID,
 	accountName, accountDec string,
+	opts ...RawToolOption,
 ) (RawTool, error) {
 	tool := unsafeRawTool(name, desc, params, response, accountID, accountName, accountDec)
+	for _, opt := range opts {
+		opt(&tool)
+	}
+
+	if err := tool.Validate(); err != nil {
+		return RawTool{}, err
+	}
+
+	tool._valid = true
+
+	return tool, nil
+}
+
+// NewBuiltinTool constructs a tool, which is executed by cynosure itself,
+// instead of MCP server. Built-in tools are not bound to any account, and they
+// shadow MCP tools with the same name in [Toolbox].
+func NewBuiltinTool(name, desc string, params, response Schema) (RawTool, error) {
+	tool := RawTool{
+		name:         name,
+		desc:         desc,
+		encodedTools: toolAccounts{},
+		params:       params,
+		response:     response,
+		builtin:      true,
+		hints:        Hints{},
+		_valid:       false,
+	}
 
 	if err := tool.Validate(); err != nil {
 		return RawTool{}, err
@@ -102,6 +142,8 @@ func MergeTools(items ...RawTool) (RawTool, error) {
 		encodedTools: tools,
 		params:       items[0].params,
 		response:     items[0].response,
+		builtin:      false,
+		hints:        mergeHints(items...),
 		_valid:       true,
 	}, nil
 }
@@ -109,8 +151,8 @@ func MergeTools(items ...RawTool) (RawTool, error) {
 func unsafeRawTool(
 	name string,
 	desc string,
-	params json.RawMessage,
-	response json.RawMessage,
+	params Schema,
+	response Schema,
 	accountID ids.ToolID,
 	accountName, accountDec string,
 ) RawTool {
@@ -125,6 +167,8 @@ func unsafeRawTool(
 		},
 		params:   params,
 		response: response,
+		builtin:  false,
+		hints:    Hints{},
 		_valid:   false,
 	}
 
@@ -141,6 +185,10 @@ func (r RawTool) Validate() error {
 		return err
 	}
 
+	if err := r.validateSchemas(); err != nil {
+		return err
+	}
+
 	if err := r.validateAccounts(); err != nil {
 		return err
 	}
@@ -160,7 +208,31 @@ func (r RawTool) validateFields() error {
 	return nil
 }
 
+func (r RawTool) validateSchemas() error {
+	if err := r.params.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInputSchema, err)
+	}
+
+	if !r.params.object {
+		return fmt.Errorf("%w: %w", ErrInvalidInputSchema, ErrSchemaMustBeObject)
+	}
+
+	if err := r.response.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidOutputSchema, err)
+	}
+
+	return nil
+}
+
 func (r RawTool) validateAccounts() error {
+	if r.builtin {
+		if len(r.encodedTools) > 0 {
+			return fmt.Errorf("%w: built-in tool %q has accounts", ErrToolInvalid, r.name)
+		}
+
+		return nil
+	}
+
 	if len(r.encodedTools) < 1 {
 		return ErrToolNoAccounts
 	}
@@ -183,12 +255,7 @@ func (r RawTool) validateMultiAccountSchema() error {
 		return nil
 	}
 
-	var schema openapi3.Schema
-	if err := json.Unmarshal(r.params, &schema); err != nil {
-		return fmt.Errorf("%w: %w", ErrInvalidInputSchema, err)
-	}
-
-	if _, ok := schema.Properties[RawAccountInjectKey]; ok {
+	if r.params.HasProperty(RawAccountInjectKey) {
 		return fmt.Errorf("%w: %s", ErrReservedPropertyUsed, RawAccountInjectKey)
 	}
 
@@ -198,14 +265,18 @@ func (r RawTool) validateMultiAccountSchema() error {
 func (r RawTool) Name() string { return r.name }
 func (r RawTool) Desc() string { return r.desc }
 
+// Builtin reports whether tool is executed by cynosure itself.
+func (r RawTool) Builtin() bool { return r.builtin }
+
 // EncodedTools returns all tool-account associations.
 // The map key is the ToolID, value is the account description.
 func (r RawTool) EncodedTools() map[ids.ToolID]accountDesc {
 	return maps.Clone(r.encodedTools)
 }
 
-func (r RawTool) Params() json.RawMessage   { return slices.Clone(r.params) }
-func (r RawTool) Response() json.RawMessage { return slices.Clone(r.response) }
+func (r RawTool) Params() Schema   { return r.params }
+func (r RawTool) Response() Schema { return r.response }
+func (r RawTool) Hints() Hints     { return r.hints }
 
 func (r RawTool) mergeToolMap(others ...RawTool) (toolAccounts, error) {
 	tools := maps.Clone(r.encodedTools)
@@ -223,6 +294,17 @@ func (r RawTool) mergeToolMap(others ...RawTool) (toolAccounts, error) {
 	return tools, nil
 }
 
+// mergeHints keeps only hints, which are declared by all merged tools.
+func mergeHints(items ...RawTool) Hints {
+	hints := items[0].hints
+	for _, item := range items[1:] {
+		hints.readOnly = hints.readOnly && item.hints.readOnly
+		hints.idempotent = hints.idempotent && item.hints.idempotent
+	}
+
+	return hints
+}
+
 func checkMergeCompatibility(items ...RawTool) error {
 	switch len(items) {
 	case 0:
@@ -250,6 +332,10 @@ func checkMergeAccountsCompatibilityBetween(first, other RawTool) error {
 		return other.Validate()
 	}
 
+	if first.builtin || other.builtin {
+		return fmt.Errorf("%w: %q", ErrToolBuiltin, first.name)
+	}
+
 	if first.name != other.name {
 		return ErrMergeDifferentNames
 	}
@@ -258,11 +344,11 @@ func checkMergeAccountsCompatibilityBetween(first, other RawTool) error {
 		return ErrMergeDifferentDescs
 	}
 
-	if !bytes.Equal(first.params, other.params) {
+	if !first.params.Equal(other.params) {
 		return ErrMergeDifferentParams
 	}
 
-	if !bytes.Equal(first.response, other.response) {
+	if !first.response.Equal(other.response) {
 		return ErrMergeDifferentResps
 	}
 
@@ -271,38 +357,14 @@ func checkMergeAccountsCompatibilityBetween(first, other RawTool) error {
 
 // ConvertedSchema injects into original schema list of accounts, to provide
 // aviability for model to choose between accounts.
-func (r RawTool) ConvertedSchema() json.RawMessage {
-	if !r.Valid() {
-		return []byte("{}")
-	}
-
-	if len(r.encodedTools) == 1 {
-		return slices.Clone(r.params)
-	}
-
-	return buildMultiAccountSchema([]byte(r.params), r.encodedTools)
-}
-
-func buildMultiAccountSchema(params []byte, accounts toolAccounts) json.RawMessage {
-	var schema openapi3.Schema
-
-	if err := json.Unmarshal(params, &schema); err != nil {
-		// panic by intention: invariants must be detected on primitive
-		// creation, not here.
-		//
-		//nolint:forbidigo // see above.
-		panic(fmt.Errorf("unreachable: %w", err))
-	}
-
-	if err := injectMultiAccountProperty(&schema, accounts); err != nil {
-		// panic by intention: invariants must be detected on primitive
-		// creation, not here.
-		//
-		//nolint:forbidigo // see above.
-		panic(fmt.Errorf("unreachable: %w", err))
+func (r RawTool) ConvertedSchema() Schema {
+	if !r.Valid() || len(r.encodedTools) <= 1 {
+		return r.params
 	}
 
-	res, err := json.Marshal(schema)
+	schema, err := r.params.withRequiredProperty(
+		RawAccountInjectKey, accountNamesAsSchema(r.encodedTools),
+	)
 	if err != nil {
 		// panic by intention: invariants must be detected on primitive
 		// creation, not here.
@@ -311,29 +373,19 @@ func buildMultiAccountSchema(params []byte, accounts toolAccounts) json.RawMessa
 		panic(fmt.Errorf("unreachable: %w", err))
 	}
 
-	return res
-}
-
-func injectMultiAccountProperty(schema *openapi3.Schema, accounts toolAccounts) error {
-	if schema.Properties == nil {
-		schema.Properties = make(openapi3.Schemas)
-	}
-
-	if _, ok := schema.Properties[RawAccountInjectKey]; ok {
-		return fmt.Errorf("%w: %q", ErrReservedPropertyCollision, RawAccountInjectKey)
-	}
-
-	schRef := accountNamesAsSchema(accounts)
-
-	schema.Properties[RawAccountInjectKey] = schRef
-	schema.Required = append(schema.Required, RawAccountInjectKey)
-
-	return nil
+	return schema
 }
 
+// ConvertRequest finds, which account tool should be called, and cleans up
+// arguments from injected fields. Built-in tools have no accounts, so they
+// can't be converted.
 func (r RawTool) ConvertRequest(
 	req map[string]json.RawMessage,
 ) (ids.ToolID, map[string]json.RawMessage, error) {
+	if r.builtin {
+		return ids.ToolID{}, nil, fmt.Errorf("%w: %q", ErrToolBuiltin, r.name)
+	}
+
 	if len(r.encodedTools) == 1 {
 		toolID := r.getSingleAccountID()
 
@@ -373,17 +425,17 @@ func (r RawTool) finishRequestConversion(
 	return ids.ToolID{}, nil, fmt.Errorf("%w: %q", ErrAccountNotFound, name)
 }
 
-func accountNamesAsSchema(accounts map[ids.ToolID]accountDesc) *openapi3.SchemaRef {
+func accountNamesAsSchema(accounts map[ids.ToolID]accountDesc) *openapi3.Schema {
 	// Multiple accounts case - inject chooser enum
 	sorted := slices.SortedFunc(maps.Values(accounts), func(a, b accountDesc) int {
 		return cmp.Compare(a.Name, b.Name)
 	})
 	desc := getAccountDescription(sorted)
 
-	return buildSchemaRef(sorted, desc)
+	return buildSchema(sorted, desc)
 }
 
-func buildSchemaRef(names []accountDesc, desc string) *openapi3.SchemaRef {
+func buildSchema(names []accountDesc, desc string) *openapi3.Schema {
 	namesRaw := make([]any, len(names))
 	for i, name := range names {
 		namesRaw[i] = name.Name
@@ -394,7 +446,7 @@ func buildSchemaRef(names []accountDesc, desc string) *openapi3.SchemaRef {
 	sch.Enum = namesRaw
 	sch.Description = desc
 
-	return openapi3.NewSchemaRef("", sch)
+	return sch
 }
 
 func getAccountDescription(data []accountDesc) string {
@@ -91,15 +91,39 @@ func (c *mergeTestCase) createTool(
 	accountID := must[ids.AccountID](t)(ids.RandomAccountID(userID, serverID))
 	toolID := must[ids.ToolID](t)(ids.RandomToolID(accountID))
 
-	paramsBytes := must[json.RawMessage](t)(json.Marshal(tool.Params))
-	responseBytes := must[json.RawMessage](t)(json.Marshal(tool.Response))
+	params, response, err := tool.schemas()
+	if err != nil {
+		return RawTool{}, err
+	}
 
 	return NewRawTool(
-		tool.Name, tool.Desc, paramsBytes, responseBytes,
+		tool.Name, tool.Desc, params, response,
 		toolID, tool.AccountName, tool.AccountDesc,
 	)
 }
 
+func (d mergeTestCaseToolDesc) schemas() (params, response Schema, err error) {
+	paramsBytes, err := json.Marshal(d.Params)
+	if err != nil {
+		return Schema{}, Schema{}, err
+	}
+
+	responseBytes, err := json.Marshal(d.Response)
+	if err != nil {
+		return Schema{}, Schema{}, err
+	}
+
+	if params, err = NewInputSchema(paramsBytes); err != nil {
+		return Schema{}, Schema{}, err
+	}
+
+	if response, err = NewOutputSchema(responseBytes); err != nil {
+		return Schema{}, Schema{}, err
+	}
+
+	return params, response, nil
+}
+
 func (c *mergeTestCase) wantErr(testingT require.TestingT, err error, msgAndArgs ...any) {
 	if helper, ok := testingT.(interface{ Helper() }); ok {
 		helper.Helper()
@@ -116,10 +140,10 @@ func (c *mergeTestCase) wantErr(testingT require.TestingT, err error, msgAndArgs
 
 func TestRawTool_ParamsImmutability(t *testing.T) {
 	tool := validRawTool(t)
-	original := slices.Clone(tool.Params())
+	original := slices.Clone(tool.Params().PlainSchema())
 
-	params1 := tool.Params()
-	params2 := tool.Params()
+	params1 := tool.Params().PlainSchema()
+	params2 := tool.Params().PlainSchema()
 
 	if len(params1) > 0 {
 		params1[0] = 0xFF
@@ -131,10 +155,10 @@ func TestRawTool_ParamsImmutability(t *testing.T) {
 
 func TestRawTool_ResponseImmutability(t *testing.T) {
 	tool := validRawTool(t)
-	original := slices.Clone(tool.Response())
+	original := slices.Clone(tool.Response().PlainSchema())
 
-	resp1 := tool.Response()
-	resp2 := tool.Response()
+	resp1 := tool.Response().PlainSchema()
+	resp2 := tool.Response().PlainSchema()
 
 	if len(resp1) > 0 {
 		resp1[0] = 0xFF
@@ -150,8 +174,8 @@ func validRawTool(t *testing.T) RawTool {
 	return must[RawTool](t)(NewRawTool(
 		"send_message",
 		"Desc",
-		json.RawMessage(`{"type": "object"}`),
-		json.RawMessage(`{"type": "object"}`),
+		must[Schema](t)(NewInputSchema(json.RawMessage(`{"type": "object"}`))),
+		must[Schema](t)(NewOutputSchema(json.RawMessage(`{"type": "object"}`))),
 		RandomToolID(t),
 		"main_tool",
 		"Main Description",
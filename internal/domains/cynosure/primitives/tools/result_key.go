@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// ResultKey identifies result of the tool call. Calls of the same tool of the
+// same account with equal arguments have equal keys, even if arguments were
+// formatted differently.
+type ResultKey struct {
+	tool ids.ToolID
+	hash [sha256.Size]byte
+
+	_valid bool
+}
+
+func NewResultKey(tool ids.ToolID, args map[string]json.RawMessage) (ResultKey, error) {
+	if !tool.Valid() {
+		return ResultKey{}, fmt.Errorf("%w: %v", ErrInvalidToolID, tool.ID())
+	}
+
+	canonical, err := canonicalArguments(args)
+	if err != nil {
+		return ResultKey{}, err
+	}
+
+	account, toolID := tool.Account().ID(), tool.ID()
+
+	hash := sha256.New()
+	hash.Write(account[:])
+	hash.Write(toolID[:])
+	hash.Write(canonical)
+
+	key := ResultKey{
+		tool:   tool,
+		hash:   [sha256.Size]byte{},
+		_valid: true,
+	}
+	copy(key.hash[:], hash.Sum(nil))
+
+	return key, nil
+}
+
+func (k ResultKey) Valid() bool      { return k._valid }
+func (k ResultKey) Tool() ids.ToolID { return k.tool }
+func (k ResultKey) String() string   { return hex.EncodeToString(k.hash[:]) }
+
+// canonicalArguments encodes arguments in the stable form: object keys are
+// sorted, and insignificant whitespace is removed.
+func canonicalArguments(args map[string]json.RawMessage) ([]byte, error) {
+	values := make(map[string]any, len(args))
+
+	for name, raw := range args {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("decoding argument %q: %w", name, err)
+		}
+
+		values[name] = value
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("encoding arguments: %w", err)
+	}
+
+	return encoded, nil
+}
@@ -0,0 +1,52 @@
+package tools_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+func TestResultKey(t *testing.T) {
+	t.Parallel()
+
+	account := must[ids.AccountID](t)(ids.RandomAccountID(ids.RandomUserID(), ids.RandomServerID()))
+	tool := must[ids.ToolID](t)(ids.RandomToolID(account))
+	other := must[ids.ToolID](t)(ids.RandomToolID(account))
+
+	key := func(tool ids.ToolID, args map[string]json.RawMessage) ResultKey {
+		t.Helper()
+
+		return must[ResultKey](t)(NewResultKey(tool, args))
+	}
+
+	base := key(tool, map[string]json.RawMessage{
+		"filter": json.RawMessage(`{"owner": "me", "state": "open"}`),
+		"limit":  json.RawMessage(`10`),
+	})
+
+	reformatted := key(tool, map[string]json.RawMessage{
+		"limit":  json.RawMessage(` 10 `),
+		"filter": json.RawMessage(`{"state":"open","owner":"me"}`),
+	})
+	require.Equal(t, base, reformatted, "formatting must not affect key")
+
+	changed := key(tool, map[string]json.RawMessage{
+		"filter": json.RawMessage(`{"owner": "me", "state": "closed"}`),
+		"limit":  json.RawMessage(`10`),
+	})
+	require.NotEqual(t, base.String(), changed.String())
+
+	otherTool := key(other, map[string]json.RawMessage{
+		"filter": json.RawMessage(`{"owner": "me", "state": "open"}`),
+		"limit":  json.RawMessage(`10`),
+	})
+	require.NotEqual(t, base.String(), otherTool.String())
+
+	_, err := NewResultKey(tool, map[string]json.RawMessage{"broken": json.RawMessage(`{`)})
+	require.Error(t, err)
+}
@@ -1,38 +1,154 @@
 package tools
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/jsonschema-go/jsonschema"
 )
 
+// validatedDrafts lists values of "$schema", which validator of original
+// schemas understands. Empty value means the latest draft.
+//
+//nolint:gochecknoglobals // constant set
+var validatedDrafts = []string{
+	"",
+	"http://json-schema.org/draft-07/schema#",
+	"https://json-schema.org/draft-07/schema#",
+	"https://json-schema.org/draft/2020-12/schema",
+}
+
 // Schema defines json schema for input, or output data for different tools.
 //
-// Core purpose of this component is to inject multi-accounting to model's
+// Schema is parsed only once, when tool is discovered, and normalized to the
+// subset of JSON Schema, which is understood by model providers: local
+// references are inlined, "allOf" compositions are merged, type unions with
+// "null" become nullable types, and unsupported keywords are dropped. So if
+// tool schema is not compatible with models, it fails on discovery, instead of
+// failing on each generation.
+//
+// Normalization is lossy, so normalized schema is only what model sees. Values
+// are also validated against original schema, as tool declared it.
+//
+// Another purpose of this component is to inject multi-accounting to model's
 // requests. This works through patching of original MCP tool schema.
 type Schema struct {
 	// TODO: kin-openapi далеко не лучший вариант. Выглядит так, что лучше
 	// самостоятельно написать jsonschema тулу
 	schema *openapi3.Schema
 
+	// normalized encoding of the schema. Stable for the same schemas, so
+	// schemas could be compared byte by byte.
+	raw json.RawMessage
+
+	// original schema, as tool declared it, re-encoded with sorted keys.
+	original json.RawMessage
+	// validator of the original schema. Nil, if original schema can't be
+	// compiled (e.g. it uses unknown draft): then only normalized schema is
+	// checked.
+	validator *jsonschema.Resolved
+
+	// object reports that root of the schema must be an object, like for tool
+	// input.
+	object bool
+
 	// caching validation result for simpler verification on different domain
 	// layers
 	valid bool
 }
 
-// NewSchema creates a new Schema from json raw message.
-func NewSchema(raw json.RawMessage) (Schema, error) {
+// NewInputSchema creates a schema of tool arguments. Root of input schema is
+// always an object.
+func NewInputSchema(raw json.RawMessage, opts ...SchemaOption) (Schema, error) {
+	return newSchema(raw, true, buildSchemaParams(opts...))
+}
+
+// NewOutputSchema creates a schema of tool response. Unlike input schema, it
+// may describe any json value.
+func NewOutputSchema(raw json.RawMessage, opts ...SchemaOption) (Schema, error) {
+	return newSchema(raw, false, buildSchemaParams(opts...))
+}
+
+func newSchema(raw json.RawMessage, object bool, params schemaParams) (Schema, error) {
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return Schema{}, fmt.Errorf("%w: %w", ErrSchemaParse, err)
+	}
+
+	original, err := json.Marshal(decoded)
+	if err != nil {
+		return Schema{}, fmt.Errorf("%w: %w", ErrSchemaParse, err)
+	}
+
+	normalized, err := normalizeSchema(decoded, params)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if _, ok := normalized["type"]; !ok && object {
+		// many servers skip type of arguments, implying an object.
+		normalized["type"] = openapi3.TypeObject
+	}
+
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return Schema{}, fmt.Errorf("%w: %w", ErrSchemaParse, err)
+	}
+
 	var parsed openapi3.Schema
+	if err := json.Unmarshal(encoded, &parsed); err != nil {
+		return Schema{}, fmt.Errorf("%w: %w", ErrSchemaParse, err)
+	}
+
+	schema, err := newSchemaFromParsed(&parsed, object)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	schema.original = original
+	schema.validator = compileOriginal(original)
 
-	if err := json.Unmarshal(raw, &parsed); err != nil {
+	return schema, nil
+}
+
+// compileOriginal prepares validator of the original schema. Schemas, which
+// can't be compiled, are not rejected: normalized schema is already valid,
+// so they are just checked less strictly.
+func compileOriginal(raw json.RawMessage) *jsonschema.Resolved {
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil
+	}
+
+	if !slices.Contains(validatedDrafts, schema.Schema) {
+		return nil
+	}
+
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil
+	}
+
+	return resolved
+}
+
+func newSchemaFromParsed(parsed *openapi3.Schema, object bool) (Schema, error) {
+	raw, err := json.Marshal(parsed)
+	if err != nil {
 		return Schema{}, fmt.Errorf("%w: %w", ErrSchemaParse, err)
 	}
 
 	schema := Schema{
-		schema: &parsed,
-		valid:  false,
+		schema:    parsed,
+		raw:       raw,
+		original:  raw,
+		validator: nil,
+		object:    object,
+		valid:     false,
 	}
 
 	if err := schema.Validate(); err != nil {
@@ -57,20 +173,70 @@ func (s Schema) Validate() error {
 		return fmt.Errorf("%w: %w", ErrSchemaInvalid, err)
 	}
 
-	if !s.schema.Type.Is(openapi3.TypeObject) {
+	if s.object && !s.schema.Type.Is(openapi3.TypeObject) {
 		return fmt.Errorf("%w: found %v", ErrSchemaMustBeObject, s.schema.Type)
 	}
 
 	return nil
 }
 
-// PlainSchema returns the original json schema.
-func (s Schema) PlainSchema() json.RawMessage {
-	res, err := json.Marshal(s.schema)
-	if err != nil {
-		//nolint:forbidigo // unreachable, if we checked invariants
-		panic(fmt.Sprintf("unreachable: %v", err))
+// PlainSchema returns normalized json schema, which is sent to the model.
+func (s Schema) PlainSchema() json.RawMessage { return slices.Clone(s.raw) }
+
+// Original returns schema, as tool declared it. Storages keep it instead of
+// normalized one, so it could be normalized again, when normalization rules
+// change.
+func (s Schema) Original() json.RawMessage { return slices.Clone(s.original) }
+
+// Equal reports whether both schemas describe the same values.
+func (s Schema) Equal(other Schema) bool {
+	return s.object == other.object &&
+		bytes.Equal(s.raw, other.raw) &&
+		bytes.Equal(s.original, other.original)
+}
+
+// IsObject reports whether root of the schema describes json object.
+func (s Schema) IsObject() bool {
+	return s.schema != nil && s.schema.Type.Is(openapi3.TypeObject)
+}
+
+// HasProperty reports whether object schema declares property with given
+// name.
+func (s Schema) HasProperty(name string) bool {
+	if s.schema == nil {
+		return false
+	}
+
+	_, ok := s.schema.Properties[name]
+
+	return ok
+}
+
+// withRequiredProperty returns copy of the schema with additional required
+// property.
+func (s Schema) withRequiredProperty(name string, property *openapi3.Schema) (Schema, error) {
+	if s.HasProperty(name) {
+		return Schema{}, fmt.Errorf("%w: %q", ErrReservedPropertyCollision, name)
+	}
+
+	patched := *s.schema
+	patched.Properties = make(openapi3.Schemas, len(s.schema.Properties)+1)
+
+	for key, value := range s.schema.Properties {
+		patched.Properties[key] = value
+	}
+
+	patched.Properties[name] = openapi3.NewSchemaRef("", property)
+	patched.Required = append(slices.Clone(s.schema.Required), name)
+
+	return newSchemaFromParsed(&patched, s.object)
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.schema == nil {
+		return nil, ErrSchemaNil
 	}
 
-	return res
+	return s.PlainSchema(), nil
 }
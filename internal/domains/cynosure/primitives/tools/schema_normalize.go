@@ -0,0 +1,526 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// defaultKeywords is a subset of JSON Schema keywords, which model providers
+// accept in function declarations. Everything else (like "$schema",
+// "examples", "exclusiveMinimum" or "uniqueItems") is dropped during
+// normalization, because providers either reject such schemas, or
+// interpret them differently. Adapters of specific providers may replace it
+// with [WithKeywords].
+//
+//nolint:gochecknoglobals // constant set
+var defaultKeywords = []string{
+	"type",
+	"format",
+	"title",
+	"description",
+	"nullable",
+	"enum",
+	"default",
+	"example",
+	"items",
+	"properties",
+	"additionalProperties",
+	"required",
+	"minItems",
+	"maxItems",
+	"minProperties",
+	"maxProperties",
+	"minLength",
+	"maxLength",
+	"pattern",
+	"minimum",
+	"maximum",
+	"anyOf",
+}
+
+const (
+	typeNull = "null"
+
+	// maxUnionBranches limits size of "anyOf", which is built from several
+	// unions of the same schema: each combination of branches becomes a
+	// separate branch.
+	maxUnionBranches = 32
+)
+
+// DefaultKeywords returns keywords, which are kept in normalized schemas by
+// default.
+func DefaultKeywords() []string { return slices.Clone(defaultKeywords) }
+
+// SchemaOption configures normalization of the schema.
+type SchemaOption func(*schemaParams)
+
+type schemaParams struct {
+	keywords map[string]struct{}
+}
+
+// WithKeywords sets keywords, which model provider accepts. Everything else
+// is dropped from normalized schema. "type" is kept anyway, as it defines
+// root of the input schema.
+func WithKeywords(keywords ...string) SchemaOption {
+	return func(p *schemaParams) {
+		p.keywords = make(map[string]struct{}, len(keywords)+1)
+		for _, keyword := range keywords {
+			p.keywords[keyword] = struct{}{}
+		}
+
+		p.keywords["type"] = struct{}{}
+	}
+}
+
+func buildSchemaParams(opts ...SchemaOption) schemaParams {
+	var params schemaParams
+
+	WithKeywords(defaultKeywords...)(&params)
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return params
+}
+
+// normalizeSchema converts decoded JSON Schema into self-contained schema,
+// which contains only keywords from params.
+func normalizeSchema(schema any, params schemaParams) (map[string]any, error) {
+	root, ok := schema.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: schema must be an object, got %T", ErrSchemaParse, schema)
+	}
+
+	n := schemaNormalizer{
+		root:      root,
+		keywords:  params.keywords,
+		resolving: make(map[string]struct{}),
+	}
+
+	return n.node(root, "#")
+}
+
+// schemaNormalizer walks keywords in sorted order, and keywords, which
+// produce the same normalized one (like "const" and "enum", or "oneOf" and
+// type union), are intersected instead of overwriting each other. So the same
+// schema is always normalized to the same result.
+type schemaNormalizer struct {
+	root     map[string]any
+	keywords map[string]struct{}
+	// resolving contains references, which are inlined right now. Finding
+	// the same reference again means that schema is recursive.
+	resolving map[string]struct{}
+}
+
+func (n *schemaNormalizer) node(value any, path string) (map[string]any, error) {
+	switch schema := value.(type) {
+	case bool:
+		if !schema {
+			return nil, fmt.Errorf("%w: %v: false schema", ErrSchemaUnsupported, path)
+		}
+
+		return map[string]any{}, nil
+	case map[string]any:
+		return n.object(schema, path)
+	default:
+		return nil, fmt.Errorf("%w: %v: schema must be an object, got %T", ErrSchemaParse, path, value)
+	}
+}
+
+func (n *schemaNormalizer) object(schema map[string]any, path string) (map[string]any, error) {
+	res := make(map[string]any, len(schema))
+
+	if ref, ok := schema["$ref"]; ok {
+		resolved, err := n.ref(ref, path)
+		if err != nil {
+			return nil, err
+		}
+
+		maps.Copy(res, resolved)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(schema)) {
+		if err := n.keyword(res, key, schema[key], path); err != nil {
+			return nil, err
+		}
+	}
+
+	if allOf, ok := schema["allOf"]; ok {
+		if err := n.mergeAllOf(res, allOf, path+"/allOf"); err != nil {
+			return nil, err
+		}
+	}
+
+	return n.filter(res, path)
+}
+
+//nolint:cyclop // flat switch over keywords
+func (n *schemaNormalizer) keyword(res map[string]any, key string, value any, path string) error {
+	path += "/" + key
+
+	switch key {
+	case "type":
+		return normalizeType(res, value, path)
+	case "const":
+		return intersectEnum(res, []any{value}, path)
+	case "enum":
+		values, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%w: %v: must be an array", ErrSchemaParse, path)
+		}
+
+		return intersectEnum(res, values, path)
+	case "oneOf", "anyOf":
+		branches, err := n.list(value, path)
+		if err != nil {
+			return err
+		}
+
+		return intersectAnyOf(res, branches, path)
+	case "items":
+		items, err := n.node(value, path)
+		res[key] = items
+
+		return err
+	case "properties":
+		props, err := n.properties(value, path)
+		res[key] = props
+
+		return err
+	case "additionalProperties":
+		if allowed, ok := value.(bool); ok {
+			res[key] = allowed
+			return nil
+		}
+
+		schema, err := n.node(value, path)
+		res[key] = schema
+
+		return err
+	default:
+		if _, ok := n.keywords[key]; ok {
+			res[key] = value
+		}
+
+		return nil
+	}
+}
+
+// filter drops keywords, which are not supported by provider. Unions can't
+// be dropped: without them schema describes completely different values.
+func (n *schemaNormalizer) filter(res map[string]any, path string) (map[string]any, error) {
+	for key := range res {
+		if _, ok := n.keywords[key]; ok {
+			continue
+		}
+
+		if key == "anyOf" {
+			return nil, fmt.Errorf("%w: %v: unions are not supported", ErrSchemaUnsupported, path)
+		}
+
+		delete(res, key)
+	}
+
+	return res, nil
+}
+
+func (n *schemaNormalizer) ref(value any, path string) (map[string]any, error) {
+	ref, ok := value.(string)
+	if !ok || !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("%w: %v: only local references are supported, got %v",
+			ErrSchemaUnsupported, path, value)
+	}
+
+	if _, ok := n.resolving[ref]; ok {
+		return nil, fmt.Errorf("%w: %v: recursive reference %q", ErrSchemaUnsupported, path, ref)
+	}
+
+	target, err := lookupPointer(n.root, ref)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v: %w", ErrSchemaParse, path, err)
+	}
+
+	n.resolving[ref] = struct{}{}
+	defer delete(n.resolving, ref)
+
+	return n.node(target, ref)
+}
+
+func (n *schemaNormalizer) list(value any, path string) ([]any, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v: must be an array", ErrSchemaParse, path)
+	}
+
+	res := make([]any, len(items))
+
+	for i, item := range items {
+		normalized, err := n.node(item, fmt.Sprintf("%v/%v", path, i))
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = normalized
+	}
+
+	return res, nil
+}
+
+func (n *schemaNormalizer) properties(value any, path string) (map[string]any, error) {
+	props, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v: must be an object", ErrSchemaParse, path)
+	}
+
+	res := make(map[string]any, len(props))
+
+	for _, name := range slices.Sorted(maps.Keys(props)) {
+		normalized, err := n.node(props[name], path+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+
+		res[name] = normalized
+	}
+
+	return res, nil
+}
+
+// mergeAllOf inlines every subschema into the parent one. Keywords of parent
+// schema have priority, except properties and required fields, which are
+// combined, and enums with unions, which are intersected.
+func (n *schemaNormalizer) mergeAllOf(res map[string]any, value any, path string) error {
+	items, err := n.list(value, path)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		schema := item.(map[string]any) //nolint:forcetypeassert // see n.list
+		if err := mergeSchema(res, schema, fmt.Sprintf("%v/%v", path, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeSchema adds constraints of normalized schema to res.
+func mergeSchema(res, schema map[string]any, path string) error {
+	for _, key := range slices.Sorted(maps.Keys(schema)) {
+		value := schema[key]
+
+		var err error
+
+		switch key {
+		case "properties":
+			props, _ := res[key].(map[string]any)
+			res[key] = mergeProperties(props, value.(map[string]any)) //nolint:forcetypeassert // normalized
+		case "required":
+			res[key] = mergeRequired(res[key], value)
+		case "enum":
+			err = intersectEnum(res, value.([]any), path) //nolint:forcetypeassert // normalized
+		case "anyOf":
+			err = intersectAnyOf(res, value.([]any), path) //nolint:forcetypeassert // normalized
+		default:
+			if _, ok := res[key]; !ok {
+				res[key] = value
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// intersectEnum keeps only values, which are allowed by both existing enum
+// and the new one.
+func intersectEnum(res map[string]any, values []any, path string) error {
+	existing, ok := res["enum"].([]any)
+	if !ok {
+		res["enum"] = slices.Clone(values)
+		return nil
+	}
+
+	common := slices.DeleteFunc(slices.Clone(existing), func(value any) bool {
+		return !slices.ContainsFunc(values, func(other any) bool {
+			return reflect.DeepEqual(value, other)
+		})
+	})
+	if len(common) == 0 {
+		return fmt.Errorf("%w: %v: no value satisfies all enums", ErrSchemaUnsupported, path)
+	}
+
+	res["enum"] = common
+
+	return nil
+}
+
+// intersectAnyOf combines new union with existing one: value must match a
+// branch of each union, so every pair of branches becomes a single branch.
+// Pairs of different types are impossible, and they are skipped.
+func intersectAnyOf(res map[string]any, branches []any, path string) error {
+	existing, ok := res["anyOf"].([]any)
+	if !ok {
+		res["anyOf"] = branches
+		return nil
+	}
+
+	combined := make([]any, 0, len(existing)*len(branches))
+
+	for _, left := range existing {
+		for _, right := range branches {
+			//nolint:forcetypeassert // normalized
+			branch, ok, err := intersectBranches(left.(map[string]any), right.(map[string]any), path)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				combined = append(combined, branch)
+			}
+		}
+	}
+
+	switch {
+	case len(combined) == 0:
+		return fmt.Errorf("%w: %v: no value satisfies all unions", ErrSchemaUnsupported, path)
+	case len(combined) > maxUnionBranches:
+		return fmt.Errorf("%w: %v: unions produce %v branches, at most %v are supported",
+			ErrSchemaUnsupported, path, len(combined), maxUnionBranches)
+	}
+
+	res["anyOf"] = combined
+
+	return nil
+}
+
+func intersectBranches(left, right map[string]any, path string) (map[string]any, bool, error) {
+	leftType, leftOK := left["type"]
+	rightType, rightOK := right["type"]
+
+	if leftOK && rightOK && leftType != rightType {
+		// integers are numbers too.
+		switch {
+		case leftType == "integer" && rightType == "number":
+		case leftType == "number" && rightType == "integer":
+			leftType = "integer"
+		default:
+			return nil, false, nil
+		}
+	}
+
+	res := maps.Clone(left)
+	if err := mergeSchema(res, right, path); err != nil {
+		if errors.Is(err, ErrSchemaUnsupported) {
+			// nested enums or unions can't be satisfied: the whole branch
+			// is impossible.
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	if leftOK {
+		res["type"] = leftType
+	}
+
+	return res, true, nil
+}
+
+func normalizeType(res map[string]any, value any, path string) error {
+	switch typ := value.(type) {
+	case string:
+		if typ == typeNull {
+			return fmt.Errorf("%w: %v: null type", ErrSchemaUnsupported, path)
+		}
+
+		res["type"] = typ
+
+		return nil
+	case []any:
+		types := make([]any, 0, len(typ))
+
+		for _, item := range typ {
+			if item == typeNull {
+				res["nullable"] = true
+			} else {
+				types = append(types, item)
+			}
+		}
+
+		switch len(types) {
+		case 0:
+			return fmt.Errorf("%w: %v: null type", ErrSchemaUnsupported, path)
+		case 1:
+			res["type"] = types[0]
+
+			return nil
+		default:
+			anyOf := make([]any, len(types))
+			for i, item := range types {
+				anyOf[i] = map[string]any{"type": item}
+			}
+
+			return intersectAnyOf(res, anyOf, path)
+		}
+	default:
+		return fmt.Errorf("%w: %v: must be a string or an array", ErrSchemaParse, path)
+	}
+}
+
+func mergeProperties(existing, other map[string]any) map[string]any {
+	res := make(map[string]any, len(existing)+len(other))
+	maps.Copy(res, other)
+	maps.Copy(res, existing)
+
+	return res
+}
+
+func mergeRequired(existing, other any) []any {
+	res, _ := existing.([]any)
+	res = slices.Clone(res)
+
+	items, _ := other.([]any)
+	for _, item := range items {
+		if !slices.Contains(res, item) {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+// lookupPointer finds value in the document by local JSON pointer reference,
+// like "#/$defs/User".
+func lookupPointer(root map[string]any, ref string) (any, error) {
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return root, nil
+	}
+
+	var current any = root
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("reference %q not found", ref)
+		}
+
+		if current, ok = object[token]; !ok {
+			return nil, fmt.Errorf("reference %q not found", ref)
+		}
+	}
+
+	return current, nil
+}
@@ -0,0 +1,187 @@
+package tools_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+func TestNewInputSchema(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		raw     string
+		opts    []SchemaOption
+		want    string
+		wantErr error
+	}{{
+		name: "local references are inlined",
+		raw: `{
+			"type": "object",
+			"properties": {"user": {"$ref": "#/$defs/User", "description": "Owner"}},
+			"$defs": {"User": {"type": "object", "properties": {"id": {"type": "string"}}}}
+		}`,
+		want: `{
+			"type": "object",
+			"properties": {"user": {
+				"type": "object",
+				"description": "Owner",
+				"properties": {"id": {"type": "string"}}
+			}}
+		}`,
+	}, {
+		name: "nullable types and constants",
+		raw: `{
+			"properties": {
+				"name": {"type": ["string", "null"]},
+				"kind": {"const": "issue"}
+			}
+		}`,
+		want: `{
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "nullable": true},
+				"kind": {"enum": ["issue"]}
+			}
+		}`,
+	}, {
+		name: "unsupported keywords are dropped",
+		raw: `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"limit": {"type": "integer", "exclusiveMinimum": 0, "examples": [10]},
+				"tags": {"type": "array", "uniqueItems": true, "items": {"type": "string"}}
+			}
+		}`,
+		want: `{
+			"type": "object",
+			"properties": {
+				"limit": {"type": "integer"},
+				"tags": {"type": "array", "items": {"type": "string"}}
+			}
+		}`,
+	}, {
+		name: "allOf is merged",
+		raw: `{
+			"type": "object",
+			"allOf": [
+				{"properties": {"a": {"type": "string"}}, "required": ["a"]},
+				{"properties": {"b": {"type": "number"}}, "required": ["b"]}
+			]
+		}`,
+		want: `{
+			"type": "object",
+			"properties": {"a": {"type": "string"}, "b": {"type": "number"}},
+			"required": ["a", "b"]
+		}`,
+	}, {
+		name: "colliding keywords are intersected",
+		raw: `{
+			"properties": {
+				"kind": {"const": "issue", "enum": ["issue", "pull"]},
+				"value": {
+					"type": ["string", "integer"],
+					"oneOf": [{"type": "integer", "minimum": 1}, {"type": "boolean"}]
+				}
+			}
+		}`,
+		want: `{
+			"type": "object",
+			"properties": {
+				"kind": {"enum": ["issue"]},
+				"value": {"anyOf": [{"type": "integer", "minimum": 1}]}
+			}
+		}`,
+	}, {
+		name:    "conflicting constants",
+		raw:     `{"type": "object", "properties": {"kind": {"const": "issue", "enum": ["pull"]}}}`,
+		wantErr: ErrSchemaUnsupported,
+	}, {
+		name: "provider keywords",
+		raw: `{
+			"type": "object",
+			"description": "Search",
+			"properties": {"query": {"type": "string", "minLength": 1}}
+		}`,
+		opts: []SchemaOption{WithKeywords("properties", "minLength")},
+		want: `{"type": "object", "properties": {"query": {"type": "string", "minLength": 1}}}`,
+	}, {
+		name:    "unions are not dropped",
+		raw:     `{"type": "object", "properties": {"id": {"type": ["string", "integer"]}}}`,
+		opts:    []SchemaOption{WithKeywords("properties")},
+		wantErr: ErrSchemaUnsupported,
+	}, {
+		name: "recursive schema",
+		raw: `{
+			"type": "object",
+			"properties": {"node": {"$ref": "#/$defs/Node"}},
+			"$defs": {"Node": {"type": "object", "properties": {"child": {"$ref": "#/$defs/Node"}}}}
+		}`,
+		wantErr: ErrSchemaUnsupported,
+	}, {
+		name:    "remote reference",
+		raw:     `{"type": "object", "properties": {"a": {"$ref": "https://example.com/a.json"}}}`,
+		wantErr: ErrSchemaUnsupported,
+	}, {
+		name:    "not an object",
+		raw:     `{"type": "string"}`,
+		wantErr: ErrSchemaMustBeObject,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schema, err := NewInputSchema(json.RawMessage(tt.raw), tt.opts...)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(schema.PlainSchema()))
+		})
+	}
+}
+
+func TestNewOutputSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := NewOutputSchema(json.RawMessage(`{"type": "string"}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type": "string"}`, string(schema.PlainSchema()))
+
+	input, err := NewInputSchema(json.RawMessage(`{"type": "object"}`))
+	require.NoError(t, err)
+
+	output, err := NewOutputSchema(json.RawMessage(`{"type": "object"}`))
+	require.NoError(t, err)
+	require.False(t, input.Equal(output))
+}
+
+func TestNewInputSchema_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	raw := json.RawMessage(`{
+		"type": "object",
+		"properties": {"value": {
+			"type": ["string", "number"],
+			"anyOf": [{"type": "string", "minLength": 1}, {"type": "integer"}],
+			"oneOf": [{"type": "string", "maxLength": 8}, {"type": "number", "maximum": 100}],
+			"const": 5,
+			"enum": [5, "five"]
+		}}
+	}`)
+
+	want, err := NewInputSchema(raw)
+	require.NoError(t, err)
+
+	for range 50 {
+		schema, err := NewInputSchema(raw)
+		require.NoError(t, err)
+		require.Equal(t, string(want.PlainSchema()), string(schema.PlainSchema()))
+	}
+}
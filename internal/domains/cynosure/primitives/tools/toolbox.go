@@ -56,7 +56,9 @@ func (t Toolbox) List() []RawTool {
 }
 
 // Merge combines this toolbox with a set of tools. If a tool already exists
-// with the same name, they are merged. Returns a copy of the toolbox.
+// with the same name, they are merged. Built-in tools are never merged: they
+// replace MCP tools with the same name, so servers can't intercept calls of
+// built-in tools. Returns a copy of the toolbox.
 func (t Toolbox) Merge(tools ...RawTool) (Toolbox, error) {
 	cloned := maps.Clone(t.tools)
 
@@ -66,12 +68,16 @@ func (t Toolbox) Merge(tools ...RawTool) (Toolbox, error) {
 		}
 
 		existing, exists := cloned[tool.Name()]
-		if !exists {
+		if !exists || tool.Builtin() {
 			cloned[tool.Name()] = tool
 
 			continue
 		}
 
+		if existing.Builtin() {
+			continue
+		}
+
 		merged, err := MergeTools(existing, tool)
 		if err != nil {
 			return Toolbox{}, fmt.Errorf("cannot merge tool %q: %w", tool.Name(), err)
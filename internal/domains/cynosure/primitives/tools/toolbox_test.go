@@ -109,8 +109,10 @@ func createToolFromDesc(
 ) (RawTool, error) {
 	t.Helper()
 
-	params := must[json.RawMessage](t)(json.Marshal(desc.Params))
-	resp := must[json.RawMessage](t)(json.Marshal(desc.Response))
+	params, resp, err := desc.schemas()
+	if err != nil {
+		return RawTool{}, err
+	}
 
 	accID := must[ids.AccountID](t)(ids.RandomAccountID(userID, serverID))
 	toolID := must[ids.ToolID](t)(ids.RandomToolID(accID))
@@ -233,8 +235,8 @@ func TestToolbox_Immutability(t *testing.T) {
 	require.NoError(t, err)
 
 	tool, err := NewRawTool("send_message", "Desc",
-		json.RawMessage(`{"type": "object"}`),
-		json.RawMessage(`{"type": "object"}`),
+		must[Schema](t)(NewInputSchema(json.RawMessage(`{"type": "object"}`))),
+		must[Schema](t)(NewOutputSchema(json.RawMessage(`{"type": "object"}`))),
 		tID, "bot", "Bot")
 	require.NoError(t, err)
 
@@ -253,3 +255,31 @@ func TestToolbox_Immutability(t *testing.T) {
 		require.True(t, exists)
 	})
 }
+
+func TestToolbox_Builtin(t *testing.T) {
+	t.Parallel()
+
+	builtin := must[RawTool](t)(NewBuiltinTool("send_message", "Built-in",
+		must[Schema](t)(NewInputSchema(json.RawMessage(`{"type": "object"}`))),
+		must[Schema](t)(NewOutputSchema(json.RawMessage(`{"type": "object"}`))),
+	))
+
+	t.Run("built-in tool shadows mcp tool", func(t *testing.T) {
+		t.Parallel()
+
+		toolbox := must[Toolbox](t)(NewToolbox().Merge(validRawTool(t), builtin, validRawTool(t)))
+
+		tool := toolbox.Tools()["send_message"]
+		require.True(t, tool.Builtin())
+		require.Equal(t, "Built-in", tool.Desc())
+	})
+
+	t.Run("built-in tool is not converted", func(t *testing.T) {
+		t.Parallel()
+
+		toolbox := must[Toolbox](t)(NewToolbox().Merge(builtin))
+
+		_, _, err := toolbox.ConvertRequest("send_message", map[string]json.RawMessage{})
+		require.ErrorIs(t, err, ErrToolBuiltin)
+	})
+}
@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// rootField is a field name of violation, related to the whole arguments
+// object, and not to the specific property.
+const rootField = "$"
+
+// Violation describes single mismatch between json value and tool schema:
+// either tool arguments and input schema, or tool result and output schema.
+type Violation struct {
+	// Field is a dot-separated path to the invalid field, e.g.
+	// "filter.labels.0". Violations of the object itself (like missing
+	// required property) are reported with path of the object.
+	Field string `json:"field"`
+	// Reason is a human-readable explanation of the issue. It never contains
+	// original value to prevent leaking sensitive data.
+	Reason string `json:"reason"`
+}
+
+// ArgumentsError is returned when tool arguments do not match input schema.
+// It lists all found violations, so model is able to fix all of them in a
+// single attempt.
+type ArgumentsError struct {
+	violations []Violation
+}
+
+//nolint:errcheck // interface check
+var _ error = (*ArgumentsError)(nil)
+
+func (e *ArgumentsError) Error() string {
+	return "invalid tool arguments: " + joinViolations(e.violations)
+}
+
+func (e *ArgumentsError) Violations() []Violation {
+	return append([]Violation(nil), e.violations...)
+}
+
+// ResultError is returned when tool result does not match output schema.
+type ResultError struct {
+	violations []Violation
+}
+
+//nolint:errcheck // interface check
+var _ error = (*ResultError)(nil)
+
+func (e *ResultError) Error() string {
+	return "invalid tool result: " + joinViolations(e.violations)
+}
+
+func (e *ResultError) Violations() []Violation {
+	return append([]Violation(nil), e.violations...)
+}
+
+func joinViolations(violations []Violation) string {
+	parts := make([]string, len(violations))
+	for i, v := range violations {
+		parts[i] = v.Field + ": " + v.Reason
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// ValidateArguments checks tool call arguments against input schema.
+//
+// Throws:
+//
+//   - [ArgumentsError] if arguments do not satisfy schema.
+func (s Schema) ValidateArguments(args map[string]json.RawMessage) error {
+	if !s.Valid() {
+		return ErrSchemaInvalid
+	}
+
+	value := make(map[string]any, len(args))
+
+	for key, raw := range args {
+		var decoded any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return &ArgumentsError{violations: []Violation{{
+				Field:  key,
+				Reason: "value is not a valid JSON",
+			}}}
+		}
+
+		value[key] = decoded
+	}
+
+	violations := s.violations(value, openapi3.VisitAsRequest())
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ArgumentsError{violations: violations}
+}
+
+// ValidateResult checks structured tool result against output schema.
+//
+// Throws:
+//
+//   - [ResultError] if result does not satisfy schema.
+func (s Schema) ValidateResult(result json.RawMessage) error {
+	if !s.Valid() {
+		return ErrSchemaInvalid
+	}
+
+	var value any
+	if err := json.Unmarshal(result, &value); err != nil {
+		return &ResultError{violations: []Violation{{
+			Field:  rootField,
+			Reason: "value is not a valid JSON",
+		}}}
+	}
+
+	violations := s.violations(value, openapi3.VisitAsResponse())
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ResultError{violations: violations}
+}
+
+// violations checks value against normalized schema, which lists every
+// violation, and then against original one, which catches constraints, lost
+// during normalization.
+func (s Schema) violations(value any, opts ...openapi3.SchemaValidationOption) []Violation {
+	opts = append(opts, openapi3.MultiErrors())
+	if err := s.schema.VisitJSON(value, opts...); err != nil {
+		return collectViolations(err)
+	}
+
+	if s.validator == nil {
+		return nil
+	}
+
+	if err := s.validator.Validate(value); err != nil {
+		return []Violation{originalViolation(err)}
+	}
+
+	return nil
+}
+
+// originalViolation converts error of original schema validator. It reports
+// only the first violation in form "validating {schema path}: {keyword}:
+// {details}", where details contain the value itself, so they are skipped.
+func originalViolation(err error) Violation {
+	msg, location := err.Error(), ""
+
+	for {
+		rest, ok := strings.CutPrefix(msg, "validating ")
+		if !ok {
+			break
+		}
+
+		if location, msg, ok = strings.Cut(rest, ": "); !ok {
+			break
+		}
+	}
+
+	reason, _, _ := strings.Cut(msg, ":")
+	if !strings.Contains(reason, " ") {
+		reason = fmt.Sprintf("doesn't match %q constraint", reason)
+	}
+
+	return Violation{Field: schemaLocationField(location), Reason: reason}
+}
+
+// schemaLocationField guesses field of the value from location of the
+// subschema, e.g. "/properties/filter/properties/labels/items" becomes
+// "filter.labels". Array indexes are unknown, so they are omitted.
+func schemaLocationField(location string) string {
+	var fields []string
+
+	tokens := strings.Split(location, "/")
+	for i := 0; i < len(tokens)-1; i++ {
+		if tokens[i] == "properties" {
+			i++
+			fields = append(fields, strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[i]))
+		}
+	}
+
+	if len(fields) == 0 {
+		return rootField
+	}
+
+	return strings.Join(fields, ".")
+}
+
+func collectViolations(err error) []Violation {
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		multi = openapi3.MultiError{err}
+	}
+
+	res := make([]Violation, 0, len(multi))
+
+	for _, item := range multi {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(item, &schemaErr) {
+			res = append(res, Violation{Field: rootField, Reason: item.Error()})
+			continue
+		}
+
+		field := rootField
+		if path := schemaErr.JSONPointer(); len(path) > 0 {
+			field = strings.Join(path, ".")
+		}
+
+		reason := schemaErr.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("doesn't match %q constraint", schemaErr.SchemaField)
+		}
+
+		res = append(res, Violation{Field: field, Reason: reason})
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package tools_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+func TestSchema_ValidateArguments(t *testing.T) {
+	t.Parallel()
+
+	schema, err := NewInputSchema(json.RawMessage(`{
+		"type": "object",
+		"required": ["query"],
+		"properties": {
+			"query": {"type": "string"},
+			"limit": {"type": "integer", "minimum": 1},
+			"labels": {"type": "array", "items": {"type": "string"}}
+		}
+	}`))
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		args   map[string]json.RawMessage
+		fields []string
+	}{{
+		name: "valid",
+		args: map[string]json.RawMessage{
+			"query": json.RawMessage(`"hello"`),
+			"limit": json.RawMessage(`10`),
+		},
+	}, {
+		name:   "missing required",
+		args:   map[string]json.RawMessage{"limit": json.RawMessage(`10`)},
+		fields: []string{"query"},
+	}, {
+		name: "multiple violations",
+		args: map[string]json.RawMessage{
+			"query":  json.RawMessage(`42`),
+			"limit":  json.RawMessage(`0`),
+			"labels": json.RawMessage(`["ok", 1]`),
+		},
+		fields: []string{"query", "limit", "labels.1"},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := schema.ValidateArguments(tt.args)
+			if len(tt.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			argsErr := new(ArgumentsError)
+			require.ErrorAs(t, err, &argsErr)
+
+			fields := make([]string, 0, len(argsErr.Violations()))
+			for _, v := range argsErr.Violations() {
+				require.NotEmpty(t, v.Reason)
+
+				fields = append(fields, v.Field)
+			}
+
+			require.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestSchema_ValidateArguments_Original(t *testing.T) {
+	t.Parallel()
+
+	// exclusiveMinimum is dropped from normalized schema, but still checked.
+	schema, err := NewInputSchema(json.RawMessage(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {"filter": {"type": "object", "properties": {
+			"limit": {"type": "integer", "exclusiveMinimum": 0}
+		}}}
+	}`))
+	require.NoError(t, err)
+	require.NotContains(t, string(schema.PlainSchema()), "exclusiveMinimum")
+
+	require.NoError(t, schema.ValidateArguments(map[string]json.RawMessage{
+		"filter": json.RawMessage(`{"limit": 1}`),
+	}))
+
+	err = schema.ValidateArguments(map[string]json.RawMessage{
+		"filter": json.RawMessage(`{"limit": 0}`),
+	})
+
+	argsErr := new(ArgumentsError)
+	require.ErrorAs(t, err, &argsErr)
+	require.Equal(t, []Violation{{
+		Field:  "filter.limit",
+		Reason: `doesn't match "exclusiveMinimum" constraint`,
+	}}, argsErr.Violations())
+}
+
+func TestSchema_ValidateResult(t *testing.T) {
+	t.Parallel()
+
+	schema, err := NewOutputSchema(json.RawMessage(`{
+		"type": "object",
+		"required": ["temperature"],
+		"properties": {
+			"temperature": {"type": "number"},
+			"unit": {"type": "string", "enum": ["celsius", "fahrenheit"]}
+		}
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, schema.ValidateResult(json.RawMessage(`{"temperature": 21.5, "unit": "celsius"}`)))
+
+	err = schema.ValidateResult(json.RawMessage(`{"unit": "kelvin"}`))
+
+	resultErr := new(ResultError)
+	require.ErrorAs(t, err, &resultErr)
+
+	fields := make([]string, 0, len(resultErr.Violations()))
+	for _, v := range resultErr.Violations() {
+		fields = append(fields, v.Field)
+	}
+
+	require.ElementsMatch(t, []string{"temperature", "unit"}, fields)
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
@@ -172,6 +173,12 @@ func (s *Usecase) addAnonymousAccount(
 		return nil, fmt.Errorf("saving account and tools: %w", err)
 	}
 
+	// account is already usable, resources and prompts will be synchronized
+	// on next re-discovery.
+	if err := s.syncMetadata(ctx, account.ID()); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+
 	return AddAccountResponseOK{account: account.ID(), authAvailable: false}, nil
 }
 
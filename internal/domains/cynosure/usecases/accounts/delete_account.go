@@ -0,0 +1,76 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/oauthhandler"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// DeleteAccount removes account with its tools. If server supports token
+// revocation, stored token is revoked upstream first. Failed revocation does
+// not prevent deletion: token is dropped locally anyway. Connection state of
+// the account is dropped too.
+//
+// Throws:
+//
+//   - [ErrAccountNotFound] if user has no such account.
+func (s *Usecase) DeleteAccount(ctx context.Context, user ids.UserID, account uuid.UUID) error {
+	ctx, span := s.trace.Start(ctx, "Usecase.DeleteAccount")
+	defer span.End()
+
+	accountID, err := s.findUserAccount(ctx, user, account)
+	if err != nil {
+		return err
+	}
+
+	acc, err := s.accounts.GetAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if err := s.revokeToken(ctx, acc); err != nil {
+		span.RecordError(err)
+	}
+
+	if err := s.accounts.DeleteAccount(ctx, accountID); err != nil {
+		return fmt.Errorf("deleting account: %w", err)
+	}
+
+	// session and limits of the account won't be used anymore.
+	s.toolClient.ForgetAccount(accountID)
+
+	return nil
+}
+
+func (s *Usecase) revokeToken(ctx context.Context, acc entities.AccountReadOnly) error {
+	if acc.Token() == nil {
+		return nil
+	}
+
+	server, err := s.servers.GetServerInfo(ctx, acc.ID().Server())
+	if err != nil {
+		return fmt.Errorf("getting server info: %w", err)
+	}
+
+	if server.AuthConfig() == nil {
+		return nil
+	}
+
+	var opts []oauthhandler.RevokeTokenOption
+	if server.Internal() {
+		opts = append(opts, oauthhandler.WithInternalConnection())
+	}
+
+	err = s.oauth.RevokeToken(ctx, server.AuthConfig(), acc.Token(), opts...)
+	if err != nil && !errors.Is(err, oauthhandler.ErrRevocationNotSupported) {
+		return fmt.Errorf("revoking token: %w", err)
+	}
+
+	return nil
+}
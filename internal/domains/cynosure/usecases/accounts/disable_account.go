@@ -0,0 +1,43 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// DisableAccount hides tools of the account from agents. Token and discovered
+// tools are kept, so account could be reactivated later.
+//
+// Throws:
+//
+//   - [ErrAccountNotFound] if user has no such account.
+func (s *Usecase) DisableAccount(ctx context.Context, user ids.UserID, account uuid.UUID) error {
+	ctx, span := s.trace.Start(ctx, "Usecase.DisableAccount")
+	defer span.End()
+
+	accountID, err := s.findUserAccount(ctx, user, account)
+	if err != nil {
+		return err
+	}
+
+	acc, err := s.accounts.GetAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if err := acc.Disable(); err != nil {
+		return fmt.Errorf("disabling account: %w", err)
+	}
+
+	if err := s.accounts.SaveAccount(ctx, acc); err != nil {
+		return fmt.Errorf("saving account: %w", err)
+	}
+
+	acc.ClearEvents()
+
+	return nil
+}
@@ -10,6 +10,10 @@ var (
 	ErrAccountIDAlreadySet   = errors.New("account ID is already set")
 	ErrStateRequired         = errors.New("state parameter is required")
 	ErrExchangeTokenRequired = errors.New("exchange token is required")
+	ErrAccountNotFound       = errors.New("account not found")
+	ErrToolNotFound          = errors.New("tool not found")
+	ErrReauthUnsupported     = errors.New("account credentials are rejected, but server has no authorization")
+	ErrQueryRequired         = errors.New("search query is required")
 )
 
 type InternalValidationError string
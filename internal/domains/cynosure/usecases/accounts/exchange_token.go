@@ -90,36 +90,157 @@ func (s *Usecase) runDiscoveryTask(ctx context.Context, task discoveryTask) {
 
 	if err := s.saveAccountAndTools(ctx, task.server, task.account, task.token); err != nil {
 		span.RecordError(err)
+
+		return
+	}
+
+	if err := s.syncMetadata(ctx, task.account.ID()); err != nil {
+		span.RecordError(err)
 	}
 }
 
+// saveAccountAndTools discovers tools of the account and synchronizes them
+// with storage: already known tools keep their IDs and cache settings, tools
+// which are no longer exposed by server are deleted.
 func (s *Usecase) saveAccountAndTools(
 	ctx context.Context,
 	server entities.ServerConfigReadOnly,
 	account entities.AccountReadOnly,
 	token *oauth2.Token,
+	opts ...toolclient.DiscoverToolsOption,
 ) error {
-	rawTools, err := s.discoverTools(ctx, server, account, token)
+	existing, err := s.tools.ListTools(ctx, account.ID())
+	if err != nil {
+		return fmt.Errorf("listing tools: %w", err)
+	}
+
+	opts = append(opts, toolclient.WithToolIDBuilder(reuseToolIDs(existing)))
+
+	rawTools, err := s.discoverTools(ctx, server, account, token, opts...)
 	if err != nil {
 		return err
 	}
 
+	return s.syncTools(ctx, account, existing, rawTools)
+}
+
+// syncTools reconciles discovered tools with stored ones: new tools are
+// added, removed ones are deleted, and changed ones are indexed again.
+func (s *Usecase) syncTools(
+	ctx context.Context,
+	acc entities.AccountReadOnly,
+	existing []*entities.Tool,
+	rawTools []tools.RawTool,
+) error {
+	known := make(map[string]*entities.Tool, len(existing))
+	for _, tool := range existing {
+		known[tool.Name()] = tool
+	}
+
+	discovered := make(map[string]struct{}, len(rawTools))
+
 	for _, rawTool := range rawTools {
-		if err := s.processAndSaveTool(ctx, account, rawTool); err != nil {
+		discovered[rawTool.Name()] = struct{}{}
+
+		if err := s.syncTool(ctx, acc, known[rawTool.Name()], rawTool); err != nil {
 			return err
 		}
 	}
 
+	for _, tool := range existing {
+		if _, ok := discovered[tool.Name()]; ok {
+			continue
+		}
+
+		if err := s.tools.DeleteTool(ctx, tool.ID()); err != nil {
+			return fmt.Errorf("deleting tool %q: %w", tool.Name(), err)
+		}
+	}
+
 	return nil
 }
 
+// syncTool saves discovered tool. Old tool is nil, if tool is new. Embedding
+// is built only if indexed data of the tool was changed, unchanged tools are
+// not saved at all.
+func (s *Usecase) syncTool(
+	ctx context.Context,
+	acc entities.AccountReadOnly,
+	old *entities.Tool,
+	rawTool tools.RawTool,
+) error {
+	opts := []entities.ToolOption{entities.WithHints(rawTool.Hints())}
+	if old != nil {
+		opts = append(opts,
+			entities.WithCacheTTL(old.CacheTTL()),
+			entities.WithEmbedding(old.Embedding()),
+		)
+	}
+
+	tool, err := entities.NewTool(
+		s.extractToolID(rawTool),
+		acc.Name(),
+		rawTool.Name(),
+		rawTool.Desc(),
+		rawTool.Params(),
+		rawTool.Response(),
+		opts...,
+	)
+	if err != nil {
+		return fmt.Errorf("creating tool entity for %q: %w", rawTool.Name(), err)
+	}
+
+	switch {
+	case old == nil || indexChanged(old, tool):
+		return s.indexAndSaveTool(ctx, tool)
+	case old.Hints() != tool.Hints():
+		if err := s.tools.SaveTool(ctx, tool); err != nil {
+			return fmt.Errorf("saving tool %q: %w", tool.Name(), err)
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// indexChanged reports, whether tool must be indexed again.
+func indexChanged(old, tool entities.ToolReadOnly) bool {
+	return old.AccountName() != tool.AccountName() ||
+		old.Description() != tool.Description() ||
+		!old.InputSchema().Equal(tool.InputSchema()) ||
+		!old.OutputSchema().Equal(tool.OutputSchema())
+}
+
+// reuseToolIDs keeps IDs of already known tools, so references from threads
+// stay valid after discovery.
+func reuseToolIDs(existing []*entities.Tool) toolclient.ToolIDBuilder {
+	known := make(map[string]ids.ToolID, len(existing))
+	for _, tool := range existing {
+		known[tool.Name()] = tool.ID()
+	}
+
+	return func(account ids.AccountID, name string) (ids.ToolID, error) {
+		if id, ok := known[name]; ok {
+			return id, nil
+		}
+
+		id, err := ids.RandomToolID(account)
+		if err != nil {
+			return ids.ToolID{}, fmt.Errorf("random tool ID: %w", err)
+		}
+
+		return id, nil
+	}
+}
+
 func (s *Usecase) discoverTools(
 	ctx context.Context,
 	server entities.ServerConfigReadOnly,
 	account entities.AccountReadOnly,
 	token *oauth2.Token,
+	opts ...toolclient.DiscoverToolsOption,
 ) ([]tools.RawTool, error) {
-	var opts []toolclient.DiscoverToolsOption
 	if token != nil {
 		opts = append(opts, toolclient.WithAuthToken(token))
 	}
@@ -139,28 +260,6 @@ func (s *Usecase) discoverTools(
 	return rawTools, nil
 }
 
-func (s *Usecase) processAndSaveTool(
-	ctx context.Context,
-	account entities.AccountReadOnly,
-	rawTool tools.RawTool,
-) error {
-	toolID := s.extractToolID(rawTool)
-
-	tool, err := entities.NewTool(
-		toolID,
-		account.Name(),
-		rawTool.Name(),
-		rawTool.Desc(),
-		rawTool.Params(),
-		rawTool.Response(),
-	)
-	if err != nil {
-		return fmt.Errorf("creating tool entity for %q: %w", rawTool.Name(), err)
-	}
-
-	return s.indexAndSaveTool(ctx, tool)
-}
-
 func (s *Usecase) indexAndSaveTool(
 	ctx context.Context,
 	tool *entities.Tool,
@@ -22,6 +22,7 @@ func (s *Usecase) ListTools(ctx context.Context, accountID ids.AccountID) ([]too
 			tool.InputSchema(),
 			tool.OutputSchema(),
 			tool.ID(), tool.AccountName(), "",
+			tools.WithRawToolHints(tool.Hints()),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("converting %q: %w", tool.Name(), err)
@@ -0,0 +1,184 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/oauthhandler"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// ReactivateAccountResponse describes result of account reactivation. If
+// AuthLink is set, upstream server rejected stored credentials: account is
+// marked as requiring reauthorization, and user must follow the link to
+// complete reactivation.
+type ReactivateAccountResponse struct {
+	AuthLink   *url.URL
+	ValidUntil time.Time
+}
+
+// ReactivateAccount makes tools of the account available to agents again.
+// Before activation, stored token is refreshed if expired, and tools are
+// discovered again: new tools are added, removed ones are deleted, existing
+// ones keep their IDs and cache settings.
+//
+// Throws:
+//
+//   - [ErrAccountNotFound] if user has no such account.
+//   - [ErrReauthUnsupported] if server rejected anonymous account.
+func (s *Usecase) ReactivateAccount(
+	ctx context.Context,
+	user ids.UserID,
+	account uuid.UUID,
+) (ReactivateAccountResponse, error) {
+	ctx, span := s.trace.Start(ctx, "Usecase.ReactivateAccount")
+	defer span.End()
+
+	accountID, err := s.findUserAccount(ctx, user, account)
+	if err != nil {
+		return ReactivateAccountResponse{}, err
+	}
+
+	acc, err := s.accounts.GetAccount(ctx, accountID)
+	if err != nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("getting account: %w", err)
+	}
+
+	server, err := s.servers.GetServerInfo(ctx, accountID.Server())
+	if err != nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("getting server info: %w", err)
+	}
+
+	err = s.revalidateAccount(ctx, server, acc)
+	if isCredentialsRejected(err) {
+		return s.requireReauth(ctx, server, acc)
+	} else if err != nil {
+		return ReactivateAccountResponse{}, err
+	}
+
+	if err := acc.Activate(); err != nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("activating account: %w", err)
+	}
+
+	if err := s.accounts.SaveAccount(ctx, acc); err != nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("saving account: %w", err)
+	}
+
+	acc.ClearEvents()
+
+	// account is already usable, resources and prompts will be synchronized
+	// on next re-discovery.
+	if err := s.syncMetadata(ctx, accountID); err != nil {
+		span.RecordError(err)
+	}
+
+	return ReactivateAccountResponse{}, nil
+}
+
+func (s *Usecase) revalidateAccount(
+	ctx context.Context,
+	server entities.ServerConfigReadOnly,
+	acc *entities.Account,
+) error {
+	token, err := s.refreshExpiredToken(ctx, server, acc)
+	if err != nil {
+		return err
+	}
+
+	var opts []toolclient.DiscoverToolsOption
+	if server.Internal() {
+		opts = append(opts, toolclient.WithInternalTransport())
+	}
+
+	return s.saveAccountAndTools(ctx, server, acc, token, opts...)
+}
+
+func (s *Usecase) refreshExpiredToken(
+	ctx context.Context,
+	server entities.ServerConfigReadOnly,
+	acc *entities.Account,
+) (*oauth2.Token, error) {
+	token := acc.Token()
+	if token == nil || token.Valid() || server.AuthConfig() == nil {
+		return token, nil
+	}
+
+	var opts []oauthhandler.RefreshTokenOption
+	if server.Internal() {
+		opts = append(opts, oauthhandler.WithInternalConnection())
+	}
+
+	newToken, err := s.oauth.RefreshToken(ctx, server.AuthConfig(), token, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+
+	if err := acc.UpdateToken(newToken); err != nil {
+		return nil, fmt.Errorf("updating token: %w", err)
+	}
+
+	return newToken, nil
+}
+
+func (s *Usecase) requireReauth(
+	ctx context.Context,
+	server entities.ServerConfigReadOnly,
+	acc *entities.Account,
+) (ReactivateAccountResponse, error) {
+	if err := acc.RequireReauth(); err != nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("marking account: %w", err)
+	}
+
+	if err := s.accounts.SaveAccount(ctx, acc); err != nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("saving account: %w", err)
+	}
+
+	acc.ClearEvents()
+
+	if server.AuthConfig() == nil {
+		return ReactivateAccountResponse{}, fmt.Errorf("%w", ErrReauthUnsupported)
+	}
+
+	verifier, verifierStr, err := generateVerifier()
+	if err != nil {
+		return ReactivateAccountResponse{}, err
+	}
+
+	// reusing account ID: after token exchange the same account is saved
+	// as active with fresh token.
+	link, err := s.completeOAuthLink(
+		acc.Name(), acc.Description(), verifier, verifierStr, acc.ID(), server,
+	)
+	if err != nil {
+		return ReactivateAccountResponse{}, err
+	}
+
+	return ReactivateAccountResponse{
+		AuthLink:   link.Link,
+		ValidUntil: link.ValidUntil,
+	}, nil
+}
+
+func isCredentialsRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, oauthhandler.ErrInvalidCredentials) ||
+		errors.Is(err, toolclient.ErrInvalidCredentials) {
+		return true
+	}
+
+	authErr := new(ports.RequiresAuthError)
+
+	return errors.As(err, &authErr)
+}
@@ -0,0 +1,139 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// RediscoverTools discovers tools of the account again and reconciles them
+// with stored ones: new tools are added, removed ones are deleted, and tools
+// with changed description or schemas are indexed again. Resources of the
+// account are synchronized too. Only active accounts are processed, others
+// are skipped silently.
+//
+// If server rejects stored credentials, account is marked as requiring
+// reauthorization, so its tools are hidden until user signs in again. Accounts
+// of internal servers and servers without OAuth are never marked: user can't
+// sign in to them.
+func (s *Usecase) RediscoverTools(ctx context.Context, account ids.AccountID) error {
+	ctx, span := s.trace.Start(ctx, "Usecase.RediscoverTools")
+	defer span.End()
+
+	acc, err := s.accounts.GetAccount(ctx, account)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if acc.Status() != entities.AccountStatusActive {
+		return nil
+	}
+
+	server, err := s.servers.GetServerInfo(ctx, account.Server())
+	if err != nil {
+		return fmt.Errorf("getting server info: %w", err)
+	}
+
+	err = s.revalidateAccount(ctx, server, acc)
+	if isCredentialsRejected(err) && server.AuthConfig() != nil && !server.Internal() {
+		if markErr := acc.RequireReauth(); markErr != nil {
+			return fmt.Errorf("marking account: %w", markErr)
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// token might be refreshed, or account marked as requiring
+	// reauthorization.
+	if !acc.Synchronized() {
+		if err := s.accounts.SaveAccount(ctx, acc); err != nil {
+			return fmt.Errorf("saving account: %w", err)
+		}
+
+		acc.ClearEvents()
+	}
+
+	if acc.Status() != entities.AccountStatusActive {
+		return nil
+	}
+
+	return s.syncMetadata(ctx, account)
+}
+
+// runRediscovery queues re-discovery of accounts, which servers notified about
+// changed tools, resources or prompts, and, if interval is set, of every active account
+// periodically. Blocks until ctx is canceled.
+func (s *Usecase) runRediscovery(ctx context.Context) error {
+	unsubscribeTools := s.toolClient.SubscribeToolsChanged(s.queueRediscovery)
+	defer unsubscribeTools()
+
+	unsubscribeResources := s.toolClient.SubscribeResourcesChanged(s.queueResourcesChanged)
+	defer unsubscribeResources()
+
+	unsubscribePrompts := s.toolClient.SubscribePromptsChanged(s.queueRediscovery)
+	defer unsubscribePrompts()
+
+	if s.rediscoveryInterval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(s.rediscoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			s.queueActiveAccounts(ctx)
+		}
+	}
+}
+
+func (s *Usecase) queueActiveAccounts(ctx context.Context) {
+	ctx, span := s.trace.Start(ctx, "Usecase.RediscoverTools.Queue")
+	defer span.End()
+
+	accounts, err := s.accounts.ListActiveAccounts(ctx)
+	if err != nil {
+		span.RecordError(err)
+
+		return
+	}
+
+	for _, account := range accounts {
+		s.queueRediscovery(ctx, account)
+	}
+}
+
+func (s *Usecase) queueRediscovery(ctx context.Context, account ids.AccountID) {
+	// servers may send notifications in bursts, there is no need to discover
+	// same account concurrently.
+	if _, queued := s.rediscovering.LoadOrStore(account, struct{}{}); queued {
+		return
+	}
+
+	if !s.rediscovery.Submit(ctx, account) {
+		s.rediscovering.Delete(account)
+		s.log.AccountUsecasePoolNotRunning(ctx)
+	}
+}
+
+func (s *Usecase) runRediscoveryTask(ctx context.Context, account ids.AccountID) {
+	ctx, span := s.trace.Start(ctx, "Usecase.RediscoverTools.Discover")
+	defer span.End()
+
+	// removing before discovery: notifications, received during it, must
+	// trigger one more run.
+	s.rediscovering.Delete(account)
+
+	if err := s.RediscoverTools(ctx, account); err != nil {
+		span.RecordError(err)
+	}
+}
@@ -0,0 +1,187 @@
+package accounts_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/identitymanager"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+)
+
+type rediscoverFixture struct {
+	usecase  *Usecase
+	accounts *mocks.MockAccountStorage
+	tools    *mocks.MockToolStorage
+	index    *mocks.MockToolSemanticIndex
+	client   *mocks.ToolClient
+	account  *entities.Account
+}
+
+func TestRediscoverTools(t *testing.T) {
+	f := setupRediscover(t)
+	accountID := f.account.ID()
+
+	kept := mustTool(t, accountID, "kept", "same description")
+	changed := mustTool(t, accountID, "changed", "old description")
+	removed := mustTool(t, accountID, "removed", "not exposed anymore")
+
+	added, err := ids.RandomToolID(accountID)
+	require.NoError(t, err)
+
+	f.tools.EXPECT().ListTools(mock.Anything, accountID).
+		Return([]*entities.Tool{kept, changed, removed}, nil)
+	f.client.EXPECT().DiscoverTools(
+		mock.Anything, mock.Anything, accountID, f.account.Name(), f.account.Description(),
+		mock.Anything,
+	).Return([]tools.RawTool{
+		mustRawTool(t, kept.ID(), "kept", "same description"),
+		mustRawTool(t, changed.ID(), "changed", "new description"),
+		mustRawTool(t, added, "added", "brand new tool"),
+	}, nil)
+
+	var saved []string
+
+	f.index.EXPECT().IndexTool(mock.Anything, mock.Anything).Return([1536]float32{1}, nil).Twice()
+	f.tools.EXPECT().SaveTool(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, tool entities.ToolReadOnly) {
+			saved = append(saved, tool.Name())
+		}).
+		Return(nil).
+		Twice()
+	f.tools.EXPECT().DeleteTool(mock.Anything, removed.ID()).Return(nil).Once()
+
+	require.NoError(t, f.usecase.RediscoverTools(t.Context(), accountID))
+	require.ElementsMatch(t, []string{"changed", "added"}, saved)
+}
+
+func TestRediscoverToolsCredentialsRejected(t *testing.T) {
+	f := setupRediscover(t, entities.WithAuthConfig(&oauth2.Config{
+		ClientID: "cynosure",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://example.com/authorize",
+			TokenURL: "https://example.com/token",
+		},
+	}))
+	accountID := f.account.ID()
+
+	f.tools.EXPECT().ListTools(mock.Anything, accountID).Return(nil, nil)
+	f.client.EXPECT().DiscoverTools(
+		mock.Anything, mock.Anything, accountID, mock.Anything, mock.Anything, mock.Anything,
+	).Return(nil, toolclient.ErrInvalidCredentials)
+	f.accounts.EXPECT().SaveAccount(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, acc entities.AccountReadOnly) {
+			require.Equal(t, entities.AccountStatusNeedsReauth, acc.Status())
+		}).
+		Return(nil).
+		Once()
+
+	require.NoError(t, f.usecase.RediscoverTools(t.Context(), accountID))
+}
+
+func TestRediscoverToolsSkipsDisabled(t *testing.T) {
+	f := setupRediscover(t)
+	require.NoError(t, f.account.Disable())
+	f.account.ClearEvents()
+
+	// no discovery expected: mocks fail on unexpected calls.
+	require.NoError(t, f.usecase.RediscoverTools(t.Context(), f.account.ID()))
+}
+
+func setupRediscover(t *testing.T, opts ...entities.ServerConfigOption) rediscoverFixture {
+	t.Helper()
+
+	accountID, err := ids.RandomAccountID(ids.RandomUserID(), ids.RandomServerID())
+	require.NoError(t, err)
+
+	account, err := entities.NewAccount(accountID, "test", "test account")
+	require.NoError(t, err)
+
+	server, err := entities.NewServerConfig(
+		accountID.Server(), mustURL(t, "https://example.com/mcp"), opts...,
+	)
+	require.NoError(t, err)
+
+	f := rediscoverFixture{
+		usecase:  nil,
+		accounts: mocks.NewMockAccountStorage(t),
+		tools:    mocks.NewMockToolStorage(t),
+		index:    mocks.NewMockToolSemanticIndex(t),
+		client:   mocks.NewToolClient(t),
+		account:  account,
+	}
+
+	servers := mocks.NewMockServerStorage(t)
+	servers.EXPECT().GetServerInfo(mock.Anything, accountID.Server()).Return(server, nil).Maybe()
+	f.accounts.EXPECT().GetAccount(mock.Anything, accountID).Return(account, nil)
+
+	f.usecase, err = New(
+		servers, mocks.NewOAuthHandler(t), f.accounts, f.tools, f.index, f.client, noUsers{},
+		WithOAuthRedirectURL(mustURL(t, "https://cynosure.example.com/oauth/callback")),
+	)
+	require.NoError(t, err)
+
+	return f
+}
+
+// noUsers is never called by re-discovery.
+type noUsers struct{ identitymanager.Port }
+
+func mustTool(t *testing.T, account ids.AccountID, name, desc string) *entities.Tool {
+	t.Helper()
+
+	id, err := ids.RandomToolID(account)
+	require.NoError(t, err)
+
+	tool, err := entities.NewTool(id, "test", name, desc, mustInput(t), mustOutput(t))
+	require.NoError(t, err)
+
+	return tool
+}
+
+func mustRawTool(t *testing.T, id ids.ToolID, name, desc string) tools.RawTool {
+	t.Helper()
+
+	tool, err := tools.NewRawTool(name, desc, mustInput(t), mustOutput(t), id, "test", "test account")
+	require.NoError(t, err)
+
+	return tool
+}
+
+func mustInput(t *testing.T) tools.Schema {
+	t.Helper()
+
+	schema, err := tools.NewInputSchema(json.RawMessage(`{"type":"object"}`))
+	require.NoError(t, err)
+
+	return schema
+}
+
+func mustOutput(t *testing.T) tools.Schema {
+	t.Helper()
+
+	schema, err := tools.NewOutputSchema(json.RawMessage(`{"type":"string"}`))
+	require.NoError(t, err)
+
+	return schema
+}
+
+func mustURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+
+	return u
+}
@@ -0,0 +1,82 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+const (
+	defaultSearchToolsLimit = 10
+	maxSearchToolsLimit     = 50
+)
+
+// ToolSearchResult is a tool found by [Usecase.SearchTools].
+type ToolSearchResult struct {
+	Tool entities.ToolReadOnly
+	// Relevance is cosine similarity between query and tool description, from
+	// -1 to 1. Higher is better.
+	Relevance float64
+}
+
+// SearchTools finds tools of user's active accounts, which are semantically
+// close to the query. Results are ordered by relevance, most relevant first.
+// Non-positive limit means default limit, too large limit is capped.
+//
+// Throws:
+//
+//   - [ErrQueryRequired] if query is empty.
+func (s *Usecase) SearchTools(
+	ctx context.Context,
+	user ids.UserID,
+	query string,
+	limit int,
+) ([]ToolSearchResult, error) {
+	ctx, span := s.trace.Start(ctx, "Usecase.SearchTools")
+	defer span.End()
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w", ErrQueryRequired)
+	}
+
+	msg, err := messages.NewMessageUser(query)
+	if err != nil {
+		return nil, fmt.Errorf("building query message: %w", err)
+	}
+
+	embedding, err := s.index.BuildToolEmbedding(ctx, []messages.Message{msg})
+	if err != nil {
+		return nil, fmt.Errorf("building query embedding: %w", err)
+	}
+
+	found, err := s.tools.MatchTools(ctx, user, embedding, searchToolsLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("looking up tools: %w", err)
+	}
+
+	res := make([]ToolSearchResult, len(found))
+	for i, match := range found {
+		res[i] = ToolSearchResult{
+			Tool:      match.Tool(),
+			Relevance: match.Relevance(),
+		}
+	}
+
+	return res, nil
+}
+
+func searchToolsLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultSearchToolsLimit
+	case limit > maxSearchToolsLimit:
+		return maxSearchToolsLimit
+	default:
+		return limit
+	}
+}
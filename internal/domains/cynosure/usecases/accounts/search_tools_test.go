@@ -0,0 +1,97 @@
+package accounts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+
+	. "github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/accounts"
+)
+
+func TestSearchTools(t *testing.T) {
+	user := ids.RandomUserID()
+
+	accountID, err := ids.RandomAccountID(user, ids.RandomServerID())
+	require.NoError(t, err)
+
+	best := mustTool(t, accountID, "best", "exactly what is needed")
+	worse := mustTool(t, accountID, "worse", "somewhat related")
+
+	u, storage, index := setupSearch(t)
+
+	query := [1536]float32{1}
+	index.EXPECT().BuildToolEmbedding(mock.Anything, mock.Anything).Return(query, nil)
+	storage.EXPECT().MatchTools(mock.Anything, user, query, 3).Return([]entities.ToolMatch{
+		entities.NewToolMatch(best, 0.9),
+		entities.NewToolMatch(worse, 0.4),
+	}, nil).Once()
+
+	found, err := u.SearchTools(t.Context(), user, "  find something  ", 3)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	assert.Equal(t, "best", found[0].Tool.Name(), "order of the storage is kept")
+	assert.InDelta(t, 0.9, found[0].Relevance, 1e-9, "relevance comes from the storage")
+	assert.Equal(t, "worse", found[1].Tool.Name())
+	assert.InDelta(t, 0.4, found[1].Relevance, 1e-9)
+}
+
+func TestSearchToolsLimit(t *testing.T) {
+	user := ids.RandomUserID()
+
+	for _, tt := range []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "zero is default", limit: 0, want: 10},
+		{name: "negative is default", limit: -5, want: 10},
+		{name: "too large is capped", limit: 1000, want: 50},
+		{name: "maximum is kept", limit: 50, want: 50},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			u, storage, index := setupSearch(t)
+
+			index.EXPECT().BuildToolEmbedding(mock.Anything, mock.Anything).Return([1536]float32{}, nil)
+			storage.EXPECT().MatchTools(mock.Anything, user, mock.Anything, tt.want).
+				Return([]entities.ToolMatch{}, nil).Once()
+
+			found, err := u.SearchTools(t.Context(), user, "query", tt.limit)
+			require.NoError(t, err)
+			assert.Empty(t, found)
+		})
+	}
+}
+
+func TestSearchToolsQueryRequired(t *testing.T) {
+	u, _, _ := setupSearch(t)
+
+	for _, query := range []string{"", " \t\n "} {
+		_, err := u.SearchTools(t.Context(), ids.RandomUserID(), query, 0)
+		require.ErrorIs(t, err, ErrQueryRequired)
+	}
+}
+
+// setupSearch returns usecase, which storage and index expect no calls by
+// default.
+func setupSearch(t *testing.T) (*Usecase, *mocks.MockToolStorage, *mocks.MockToolSemanticIndex) {
+	t.Helper()
+
+	storage := mocks.NewMockToolStorage(t)
+	index := mocks.NewMockToolSemanticIndex(t)
+
+	u, err := New(
+		mocks.NewMockServerStorage(t), mocks.NewOAuthHandler(t), mocks.NewMockAccountStorage(t),
+		storage, index, mocks.NewToolClient(t), noUsers{},
+		WithOAuthRedirectURL(mustURL(t, "https://cynosure.example.com/oauth/callback")),
+	)
+	require.NoError(t, err)
+
+	return u, storage, index
+}
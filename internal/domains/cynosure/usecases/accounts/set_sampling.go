@@ -0,0 +1,46 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// SetSampling sets, whether server of the account may request completions on
+// behalf of the user. Completions spend tokens of the user, so sampling is
+// never allowed just by connecting an account.
+//
+// Throws:
+//
+//   - [ErrAccountNotFound] if user has no such account.
+func (s *Usecase) SetSampling(
+	ctx context.Context, user ids.UserID, account uuid.UUID, allowed bool,
+) error {
+	ctx, span := s.trace.Start(ctx, "Usecase.SetSampling")
+	defer span.End()
+
+	accountID, err := s.findUserAccount(ctx, user, account)
+	if err != nil {
+		return err
+	}
+
+	acc, err := s.accounts.GetAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if err := acc.AllowSampling(allowed); err != nil {
+		return fmt.Errorf("setting sampling: %w", err)
+	}
+
+	if err := s.accounts.SaveAccount(ctx, acc); err != nil {
+		return fmt.Errorf("saving account: %w", err)
+	}
+
+	acc.ClearEvents()
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// SetToolCache sets how long results of the tool may be reused. Zero ttl
+// resets tool to the default behavior: only read-only tools are cached.
+//
+// Throws:
+//
+//   - [ErrAccountNotFound] if user has no such account.
+//   - [ErrToolNotFound] if account has no tool with this name.
+//
+//nolint:ireturn // ReadOnly interface is intentional for domain boundary
+func (s *Usecase) SetToolCache(
+	ctx context.Context,
+	user ids.UserID,
+	account uuid.UUID,
+	toolName string,
+	ttl time.Duration,
+) (entities.ToolReadOnly, error) {
+	ctx, span := s.trace.Start(ctx, "SetToolCache")
+	defer span.End()
+
+	accountID, err := s.findUserAccount(ctx, user, account)
+	if err != nil {
+		return nil, err
+	}
+
+	accountTools, err := s.tools.ListTools(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tools: %w", err)
+	}
+
+	for _, tool := range accountTools {
+		if tool.Name() != toolName {
+			continue
+		}
+
+		if err := tool.SetCacheTTL(ttl); err != nil {
+			return nil, fmt.Errorf("setting cache ttl: %w", err)
+		}
+
+		if err := s.tools.SaveTool(ctx, tool); err != nil {
+			return nil, fmt.Errorf("saving tool: %w", err)
+		}
+
+		return tool, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrToolNotFound, toolName)
+}
+
+func (s *Usecase) findUserAccount(
+	ctx context.Context, user ids.UserID, account uuid.UUID,
+) (ids.AccountID, error) {
+	accountIDs, err := s.accounts.ListAccounts(ctx, user)
+	if err != nil {
+		return ids.AccountID{}, fmt.Errorf("listing accounts: %w", err)
+	}
+
+	for _, id := range accountIDs {
+		if id.ID() == account {
+			return id, nil
+		}
+	}
+
+	return ids.AccountID{}, fmt.Errorf("%w: %v", ErrAccountNotFound, account)
+}
@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// syncMetadata synchronizes everything, which server exposes besides tools:
+// resources and prompts. Account must be saved before: metadata is listed
+// through pooled connection, which reads credentials from storage.
+func (s *Usecase) syncMetadata(ctx context.Context, account ids.AccountID) error {
+	return errors.Join(
+		s.syncResources(ctx, account),
+		s.syncPrompts(ctx, account),
+	)
+}
+
+// syncResources replaces stored resources of the account with ones, exposed
+// by server right now. Does nothing, if resource storage is not configured.
+func (s *Usecase) syncResources(ctx context.Context, account ids.AccountID) error {
+	if s.resources == nil {
+		return nil
+	}
+
+	list, err := s.toolClient.ListResources(ctx, account)
+	if err != nil {
+		return fmt.Errorf("listing resources: %w", err)
+	}
+
+	if err := s.resources.SaveResources(ctx, account, list); err != nil {
+		return fmt.Errorf("saving resources: %w", err)
+	}
+
+	return nil
+}
+
+// syncPrompts replaces stored prompts of the account with ones, exposed by
+// server right now. Does nothing, if prompt storage is not configured.
+func (s *Usecase) syncPrompts(ctx context.Context, account ids.AccountID) error {
+	if s.prompts == nil {
+		return nil
+	}
+
+	list, err := s.toolClient.ListPrompts(ctx, account)
+	if err != nil {
+		return fmt.Errorf("listing prompts: %w", err)
+	}
+
+	if err := s.prompts.SavePrompts(ctx, account, list); err != nil {
+		return fmt.Errorf("saving prompts: %w", err)
+	}
+
+	return nil
+}
+
+// queueResourcesChanged re-discovers account, which server reported changed
+// list of resources. Updates of single resources are ignored: only metadata
+// is stored, content is always read from the server.
+func (s *Usecase) queueResourcesChanged(ctx context.Context, account ids.AccountID, uri string) {
+	if uri != "" {
+		return
+	}
+
+	s.queueRediscovery(ctx, account)
+}
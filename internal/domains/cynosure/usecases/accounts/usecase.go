@@ -8,8 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sync"
 	"time"
 
+	"github.com/quenbyako/core"
 	"github.com/quenbyako/cynosure/contrib/taskpool"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -30,21 +32,31 @@ var (
 )
 
 type Usecase struct {
-	oauth            oauthhandler.Port
-	trace            trace.Tracer
-	accounts         ports.AccountStorage
-	tools            ports.ToolStorage
-	index            ports.ToolSemanticIndex
-	toolClient       toolclient.Port
-	servers          ports.ServerStorage
-	users            identitymanager.Port
-	clock            func() time.Time
-	pool             *taskpool.TaskPool[discoveryTask]
+	oauth    oauthhandler.Port
+	trace    trace.Tracer
+	accounts ports.AccountStorage
+	tools    ports.ToolStorage
+	// optional, resources are not stored, if it's nil.
+	resources ports.ResourceStorage
+	// optional, prompts are not stored, if it's nil.
+	prompts     ports.PromptStorage
+	index       ports.ToolSemanticIndex
+	toolClient  toolclient.Port
+	servers     ports.ServerStorage
+	users       identitymanager.Port
+	clock       func() time.Time
+	pool        *taskpool.TaskPool[discoveryTask]
+	rediscovery *taskpool.TaskPool[ids.AccountID]
+	// accounts, queued for re-discovery.
+	rediscovering    sync.Map
 	log              LogCallbacks
 	oauthRedirectURL *url.URL
 	oauthClientName  string
 	stateExpiration  time.Duration
-	key              [16]byte
+	// zero disables periodic re-discovery, tools are still re-discovered on
+	// server notifications.
+	rediscoveryInterval time.Duration
+	key                 [16]byte
 }
 
 type discoveryTask struct {
@@ -58,6 +70,9 @@ type newParams struct {
 	oauthRedirectURL *url.URL
 	clientName       string
 	stateExpiration  time.Duration
+	rediscovery      time.Duration
+	resources        ports.ResourceStorage
+	prompts          ports.PromptStorage
 	fixedKey         [16]byte
 }
 
@@ -79,13 +94,32 @@ func WithOAuthRedirectURL(u *url.URL) NewOption {
 	return func(p *newParams) { p.oauthRedirectURL = u }
 }
 
+// WithRediscoveryInterval sets, how often tools of every active account are
+// discovered again. Zero disables periodic re-discovery.
+func WithRediscoveryInterval(d time.Duration) NewOption {
+	return func(p *newParams) { p.rediscovery = d }
+}
+
+// WithResourceStorage enables storing metadata of resources, exposed by
+// servers of accounts. Resources are synchronized on every discovery.
+func WithResourceStorage(storage ports.ResourceStorage) NewOption {
+	return func(p *newParams) { p.resources = storage }
+}
+
+// WithPromptStorage enables storing metadata of prompts, exposed by servers
+// of accounts. Prompts are synchronized on every discovery.
+func WithPromptStorage(storage ports.PromptStorage) NewOption {
+	return func(p *newParams) { p.prompts = storage }
+}
+
 func WithTracerProvider(tp trace.TracerProvider) NewOption {
 	return func(p *newParams) { p.tracer = tp }
 }
 
 const (
-	stateExpiration      = 5 * time.Minute
-	discoveryPoolWorkers = 10
+	stateExpiration        = 5 * time.Minute
+	discoveryPoolWorkers   = 10
+	rediscoveryPoolWorkers = 4
 )
 
 func New(
@@ -129,31 +163,38 @@ func newUsecase(
 	params *newParams,
 ) *Usecase {
 	usecase := &Usecase{
-		toolClient: toolClient,
-		pool:       nil, // initialized below
-		oauth:      authHandler,
-		servers:    servers,
-		accounts:   accounts,
-		tools:      tools,
-		index:      index,
-		users:      users,
-		clock:      time.Now,
-		log:        NoOpLogCallbacks{},
+		toolClient:    toolClient,
+		pool:          nil, // initialized below
+		rediscovery:   nil, // initialized below
+		rediscovering: sync.Map{},
+		oauth:         authHandler,
+		servers:       servers,
+		accounts:      accounts,
+		tools:         tools,
+		resources:     params.resources,
+		prompts:       params.prompts,
+		index:         index,
+		users:         users,
+		clock:         time.Now,
+		log:           NoOpLogCallbacks{},
 
 		oauthRedirectURL: params.oauthRedirectURL,
 		oauthClientName:  params.clientName,
 		key:              params.fixedKey,
 		stateExpiration:  params.stateExpiration,
 
+		rediscoveryInterval: params.rediscovery,
+
 		trace: params.tracer.Tracer(pkgName),
 	}
 	usecase.pool = taskpool.New(discoveryPoolWorkers, usecase.runDiscoveryTask)
+	usecase.rediscovery = taskpool.New(rediscoveryPoolWorkers, usecase.runRediscoveryTask)
 
 	return usecase
 }
 
 func (s *Usecase) Run(ctx context.Context) error {
-	if err := s.pool.Run(ctx); err != nil {
+	if err := core.RunJobs(ctx, s.pool.Run, s.rediscovery.Run, s.runRediscovery); err != nil {
 		return fmt.Errorf("running usecase task pool: %w", err)
 	}
 
@@ -165,6 +206,9 @@ func buildNewParams(opts ...NewOption) newParams {
 		clientName:       "test-client",
 		fixedKey:         randomAuthKey(),
 		stateExpiration:  stateExpiration,
+		rediscovery:      0,
+		resources:        nil,
+		prompts:          nil,
 		tracer:           noop.NewTracerProvider(),
 		oauthRedirectURL: nil,
 	}
@@ -233,6 +277,10 @@ func (s *Usecase) validateConfig() error {
 		return ErrInternalValidation("state expiration is required")
 	}
 
+	if s.rediscoveryInterval < 0 {
+		return ErrInternalValidation("rediscovery interval must be non-negative")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,101 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// CloneAgent creates new active agent with all parameters of the given one.
+// Empty name makes clone named after the original.
+//
+// Throws:
+//   - [ErrAgentNotFound] if user has no such agent.
+//   - [ErrInvalidName] if name is too long.
+//
+//nolint:ireturn // ReadOnly interface is intentional for domain boundary
+func (u *Usecase) CloneAgent(
+	ctx context.Context,
+	user ids.UserID,
+	agent uuid.UUID,
+	name string,
+) (entities.AgentReadOnly, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.CloneAgent")
+	defer span.End()
+
+	source, err := u.findUserAgent(ctx, user, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = cloneName(source.Name())
+	}
+
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	clone, err := newClone(source, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.storage.SaveAgent(ctx, clone); err != nil {
+		return nil, fmt.Errorf("saving agent: %w", err)
+	}
+
+	return clone, nil
+}
+
+func newClone(source entities.AgentReadOnly, name string) (*entities.Agent, error) {
+	id, err := ids.RandomAgentID(source.ID().UserID())
+	if err != nil {
+		return nil, fmt.Errorf("generating agent id: %w", err)
+	}
+
+	opts := []entities.NewModelSettingsOption{
+		entities.WithName(name),
+		entities.WithSystemMessage(source.SystemMessage()),
+		entities.WithStopWords(source.StopWords()),
+		entities.WithBudget(source.Budget()),
+	}
+	if temperature, ok := source.Temperature(); ok {
+		opts = append(opts, entities.WithTemperature(temperature))
+	}
+
+	if topP, ok := source.TopP(); ok {
+		opts = append(opts, entities.WithTopP(topP))
+	}
+
+	if maxContext, ok := source.MaxContext(); ok {
+		opts = append(opts, entities.WithMaxContext(maxContext))
+	}
+
+	clone, err := entities.NewModelSettings(id, source.Model(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating agent: %w", err)
+	}
+
+	return clone, nil
+}
+
+// cloneName names clone after the original, trimming it to fit the limit.
+func cloneName(name string) string {
+	const suffix = " (copy)"
+
+	if name == "" {
+		return "Copy"
+	}
+
+	runes := []rune(name)
+	if limit := maxNameLength - len([]rune(suffix)); len(runes) > limit {
+		runes = runes[:limit]
+	}
+
+	return string(runes) + suffix
+}
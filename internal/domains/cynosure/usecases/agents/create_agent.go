@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// CreateAgent creates new active agent of the user.
+//
+// Throws:
+//   - [ErrInvalidName] if name is empty or too long.
+//   - [ErrInvalidModel] if model name is empty or malformed.
+//   - [ErrSystemMessageTooLong] if system message exceeds the limit.
+//
+//nolint:ireturn // ReadOnly interface is intentional for domain boundary
+func (u *Usecase) CreateAgent(
+	ctx context.Context,
+	user ids.UserID,
+	name, model, systemMessage string,
+) (entities.AgentReadOnly, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.CreateAgent")
+	defer span.End()
+
+	if !user.Valid() {
+		return nil, errInternalValidation("user id is required")
+	}
+
+	if err := u.validateSettings(name, model, systemMessage); err != nil {
+		return nil, err
+	}
+
+	agentID, err := ids.RandomAgentID(user)
+	if err != nil {
+		return nil, fmt.Errorf("generating agent id: %w", err)
+	}
+
+	agent, err := entities.NewModelSettings(
+		agentID,
+		model,
+		entities.WithName(name),
+		entities.WithSystemMessage(systemMessage),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating agent: %w", err)
+	}
+
+	if err := u.storage.SaveAgent(ctx, agent); err != nil {
+		return nil, fmt.Errorf("saving agent: %w", err)
+	}
+
+	return agent, nil
+}
+
+func (u *Usecase) validateSettings(name, model, systemMessage string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	if err := validateModel(model); err != nil {
+		return err
+	}
+
+	return u.validateSystemMessage(systemMessage)
+}
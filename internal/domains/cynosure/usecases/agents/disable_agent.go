@@ -0,0 +1,60 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// DisableAgent deactivates agent. Disabled agent is kept, so threads still
+// reference it, but it doesn't respond anymore. Disabling already disabled
+// agent is no-op.
+//
+// Throws:
+//   - [ErrAgentNotFound] if user has no such agent.
+//   - [ErrLastActiveAgent] if it's the only active agent of the user.
+func (u *Usecase) DisableAgent(ctx context.Context, user ids.UserID, agent uuid.UUID) error {
+	ctx, span := u.trace.Start(ctx, "Usecase.DisableAgent")
+	defer span.End()
+
+	found, err := u.findUserAgent(ctx, user, agent)
+	if err != nil {
+		return err
+	}
+
+	if found.Disabled() {
+		return nil
+	}
+
+	if err := u.ensureOtherActive(ctx, found.ID()); err != nil {
+		return err
+	}
+
+	found.Disable()
+
+	if err := u.storage.SaveAgent(ctx, found); err != nil {
+		return fmt.Errorf("saving agent: %w", err)
+	}
+
+	found.ClearEvents()
+
+	return nil
+}
+
+func (u *Usecase) ensureOtherActive(ctx context.Context, agent ids.AgentID) error {
+	agents, err := u.storage.ListAgents(ctx, agent.UserID())
+	if err != nil {
+		return fmt.Errorf("listing agents: %w", err)
+	}
+
+	for _, a := range agents {
+		if a.ID().ID() != agent.ID() && !a.Disabled() {
+			return nil
+		}
+	}
+
+	return ErrLastActiveAgent
+}
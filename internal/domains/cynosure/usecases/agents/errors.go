@@ -0,0 +1,46 @@
+package agents
+
+import (
+	"errors"
+)
+
+var (
+	// ErrAgentNotFound is returned when user has no agent with given ID.
+	// Agents of other users are reported the same way, to not reveal their
+	// existence.
+	ErrAgentNotFound = errors.New("agent not found")
+
+	// ErrAgentDisabled is returned when updating disabled agent.
+	ErrAgentDisabled = errors.New("agent is disabled")
+
+	// ErrLastActiveAgent is returned when disabling the only active agent of
+	// the user: without it, user can't chat anymore.
+	ErrLastActiveAgent = errors.New("can't disable the last active agent")
+
+	// ErrInvalidName is returned when agent name is empty or too long.
+	ErrInvalidName = errors.New("invalid agent name")
+
+	// ErrInvalidModel is returned when model name is empty or malformed.
+	ErrInvalidModel = errors.New("invalid model name")
+
+	// ErrSystemMessageTooLong is returned when system message exceeds the
+	// limit.
+	ErrSystemMessageTooLong = errors.New("system message is too long")
+
+	// ErrNothingToUpdate is returned when update has no changes.
+	ErrNothingToUpdate = errors.New("nothing to update")
+)
+
+// InternalValidationError is returned when usecase configuration or parameters are invalid.
+type InternalValidationError struct {
+	Message string
+}
+
+func (e *InternalValidationError) Error() string {
+	return "agents usecase validation error: " + e.Message
+}
+
+// errInternalValidation is a helper to create InternalValidationError.
+func errInternalValidation(msg string) error {
+	return &InternalValidationError{Message: msg}
+}
@@ -0,0 +1,31 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// ListAgents returns all agents of the user, including disabled ones.
+func (u *Usecase) ListAgents(ctx context.Context, user ids.UserID) ([]entities.AgentReadOnly, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.ListAgents")
+	defer span.End()
+
+	if !user.Valid() {
+		return nil, errInternalValidation("user id is required")
+	}
+
+	agents, err := u.storage.ListAgents(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("listing agents: %w", err)
+	}
+
+	res := make([]entities.AgentReadOnly, len(agents))
+	for i, agent := range agents {
+		res[i] = agent
+	}
+
+	return res, nil
+}
@@ -0,0 +1,119 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+type updateParams struct {
+	name          *string
+	model         *string
+	systemMessage *string
+}
+
+// UpdateOption sets single parameter of the agent. Parameters without options
+// are left untouched.
+type UpdateOption func(*updateParams)
+
+func UpdateName(name string) UpdateOption {
+	return func(p *updateParams) { p.name = &name }
+}
+
+func UpdateModel(model string) UpdateOption {
+	return func(p *updateParams) { p.model = &model }
+}
+
+func UpdateSystemMessage(message string) UpdateOption {
+	return func(p *updateParams) { p.systemMessage = &message }
+}
+
+// UpdateAgent changes parameters of the agent. Threads, which use this agent,
+// get new parameters on the next message.
+//
+// Throws:
+//   - [ErrAgentNotFound] if user has no such agent.
+//   - [ErrAgentDisabled] if agent is disabled.
+//   - [ErrNothingToUpdate] if no options are passed.
+//   - [ErrInvalidName] if name is empty or too long.
+//   - [ErrInvalidModel] if model name is empty or malformed.
+//   - [ErrSystemMessageTooLong] if system message exceeds the limit.
+//
+//nolint:ireturn // ReadOnly interface is intentional for domain boundary
+func (u *Usecase) UpdateAgent(
+	ctx context.Context,
+	user ids.UserID,
+	agent uuid.UUID,
+	opts ...UpdateOption,
+) (entities.AgentReadOnly, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.UpdateAgent")
+	defer span.End()
+
+	var params updateParams
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if params == (updateParams{}) {
+		return nil, ErrNothingToUpdate
+	}
+
+	found, err := u.findUserAgent(ctx, user, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	if found.Disabled() {
+		return nil, fmt.Errorf("%w: %v", ErrAgentDisabled, agent)
+	}
+
+	if err := u.applyUpdate(found, &params); err != nil {
+		return nil, err
+	}
+
+	if err := u.storage.SaveAgent(ctx, found); err != nil {
+		return nil, fmt.Errorf("saving agent: %w", err)
+	}
+
+	found.ClearEvents()
+
+	return found, nil
+}
+
+func (u *Usecase) applyUpdate(agent *entities.Agent, params *updateParams) error {
+	if params.name != nil {
+		if err := validateName(*params.name); err != nil {
+			return err
+		}
+
+		if err := agent.SetName(*params.name); err != nil {
+			return fmt.Errorf("setting name: %w", err)
+		}
+	}
+
+	if params.model != nil {
+		if err := validateModel(*params.model); err != nil {
+			return err
+		}
+
+		if err := agent.SetModel(*params.model); err != nil {
+			return fmt.Errorf("setting model: %w", err)
+		}
+	}
+
+	if params.systemMessage != nil {
+		if err := u.validateSystemMessage(*params.systemMessage); err != nil {
+			return err
+		}
+
+		if err := agent.SetSystemMessage(*params.systemMessage); err != nil {
+			return fmt.Errorf("setting system message: %w", err)
+		}
+	}
+
+	return nil
+}
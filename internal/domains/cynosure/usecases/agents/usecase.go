@@ -0,0 +1,84 @@
+// Package agents implements management of user agents: their creation,
+// tuning, cloning and deactivation.
+package agents
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+const pkgName = "github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/agents"
+
+const (
+	// maxNameLength is measured in characters: names are shown in chats and
+	// lists, so they must stay short.
+	maxNameLength = 64
+
+	defaultMaxSystemMessageLength = 32 * 1024
+)
+
+type Usecase struct {
+	storage ports.AgentStorage
+	trace   trace.Tracer
+
+	maxSystemMessageLength int
+}
+
+type newParams struct {
+	tracer                 trace.TracerProvider
+	maxSystemMessageLength int
+}
+
+func buildNewParams(opts ...NewOption) newParams {
+	params := newParams{
+		tracer:                 noop.NewTracerProvider(),
+		maxSystemMessageLength: defaultMaxSystemMessageLength,
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return params
+}
+
+type NewOption func(*newParams)
+
+func WithTracerProvider(tp trace.TracerProvider) NewOption {
+	return func(p *newParams) { p.tracer = tp }
+}
+
+// WithMaxSystemMessageLength limits length of system message in characters.
+func WithMaxSystemMessageLength(limit int) NewOption {
+	return func(p *newParams) { p.maxSystemMessageLength = limit }
+}
+
+func New(storage ports.AgentStorage, opts ...NewOption) (*Usecase, error) {
+	params := buildNewParams(opts...)
+
+	usecase := &Usecase{
+		storage:                storage,
+		trace:                  params.tracer.Tracer(pkgName),
+		maxSystemMessageLength: params.maxSystemMessageLength,
+	}
+
+	if err := usecase.validate(); err != nil {
+		return nil, err
+	}
+
+	return usecase, nil
+}
+
+func (u *Usecase) validate() error {
+	if u.storage == nil {
+		return errInternalValidation("agent storage is required")
+	}
+
+	if u.maxSystemMessageLength <= 0 {
+		return errInternalValidation("max system message length must be positive")
+	}
+
+	return nil
+}
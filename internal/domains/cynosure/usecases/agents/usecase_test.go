@@ -0,0 +1,169 @@
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/agents"
+)
+
+func TestUpdateAgentOwnership(t *testing.T) {
+	owner, stranger := ids.RandomUserID(), ids.RandomUserID()
+	storage := mocks.NewMockAgentStorage(t)
+	agent := newAgent(t, owner, "writer")
+
+	// storage looks agents up by uuid only, so stranger gets owner's agent.
+	storage.EXPECT().GetAgent(mock.Anything, mock.Anything).Return(agent, nil)
+
+	u, err := agents.New(storage)
+	require.NoError(t, err)
+
+	_, err = u.UpdateAgent(t.Context(), stranger, agent.ID().ID(), agents.UpdateName("stolen"))
+	require.ErrorIs(t, err, agents.ErrAgentNotFound)
+	require.Equal(t, "writer", agent.Name())
+}
+
+func TestUpdateAgent(t *testing.T) {
+	user := ids.RandomUserID()
+	storage := mocks.NewMockAgentStorage(t)
+	agent := newAgent(t, user, "writer")
+
+	storage.EXPECT().GetAgent(mock.Anything, agent.ID()).Return(agent, nil)
+	storage.EXPECT().SaveAgent(mock.Anything, agent).Return(nil)
+
+	u, err := agents.New(storage)
+	require.NoError(t, err)
+
+	updated, err := u.UpdateAgent(t.Context(), user, agent.ID().ID(),
+		agents.UpdateModel("gemini-2.5-pro"),
+		agents.UpdateSystemMessage("Write poems."),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "writer", updated.Name())
+	require.Equal(t, "gemini-2.5-pro", updated.Model())
+	require.Equal(t, "Write poems.", updated.SystemMessage())
+
+	_, err = u.UpdateAgent(t.Context(), user, agent.ID().ID(), agents.UpdateModel("gemini 2.5"))
+	require.ErrorIs(t, err, agents.ErrInvalidModel)
+
+	_, err = u.UpdateAgent(t.Context(), user, agent.ID().ID())
+	require.ErrorIs(t, err, agents.ErrNothingToUpdate)
+}
+
+func TestUpdateAgentNotFound(t *testing.T) {
+	storage := mocks.NewMockAgentStorage(t)
+	storage.EXPECT().GetAgent(mock.Anything, mock.Anything).Return(nil, ports.ErrNotFound)
+
+	u, err := agents.New(storage)
+	require.NoError(t, err)
+
+	_, err = u.UpdateAgent(t.Context(), ids.RandomUserID(), uuid.New(), agents.UpdateName("x"))
+	require.ErrorIs(t, err, agents.ErrAgentNotFound)
+}
+
+func TestDisableAgent(t *testing.T) {
+	user := ids.RandomUserID()
+	storage := mocks.NewMockAgentStorage(t)
+	first, second := newAgent(t, user, "first"), newAgent(t, user, "second")
+
+	storage.EXPECT().GetAgent(mock.Anything, first.ID()).Return(first, nil)
+	storage.EXPECT().GetAgent(mock.Anything, second.ID()).Return(second, nil)
+	storage.EXPECT().ListAgents(mock.Anything, user).Return([]*entities.Agent{first, second}, nil)
+	storage.EXPECT().SaveAgent(mock.Anything, first).Return(nil).Once()
+
+	u, err := agents.New(storage)
+	require.NoError(t, err)
+
+	require.NoError(t, u.DisableAgent(t.Context(), user, first.ID().ID()))
+	require.True(t, first.Disabled())
+
+	// repeated call is no-op.
+	require.NoError(t, u.DisableAgent(t.Context(), user, first.ID().ID()))
+
+	err = u.DisableAgent(t.Context(), user, second.ID().ID())
+	require.ErrorIs(t, err, agents.ErrLastActiveAgent)
+	require.False(t, second.Disabled())
+}
+
+func TestCloneAgent(t *testing.T) {
+	user := ids.RandomUserID()
+	storage := mocks.NewMockAgentStorage(t)
+
+	source, err := entities.NewModelSettings(
+		must(ids.RandomAgentID(user)),
+		"gemini-2.5-flash",
+		entities.WithName("writer"),
+		entities.WithSystemMessage("Write stories."),
+		entities.WithTemperature(0.5),
+		entities.WithStopWords([]string{"THE END"}),
+		entities.WithDisabled(true),
+	)
+	require.NoError(t, err)
+
+	storage.EXPECT().GetAgent(mock.Anything, source.ID()).Return(source, nil)
+	storage.EXPECT().SaveAgent(mock.Anything, mock.Anything).Return(nil)
+
+	u, err := agents.New(storage)
+	require.NoError(t, err)
+
+	clone, err := u.CloneAgent(t.Context(), user, source.ID().ID(), "")
+	require.NoError(t, err)
+	require.NotEqual(t, source.ID(), clone.ID())
+	require.Equal(t, user, clone.ID().UserID())
+	require.Equal(t, "writer (copy)", clone.Name())
+	require.Equal(t, source.Model(), clone.Model())
+	require.Equal(t, source.SystemMessage(), clone.SystemMessage())
+	require.Equal(t, source.StopWords(), clone.StopWords())
+	require.False(t, clone.Disabled())
+
+	temperature, ok := clone.Temperature()
+	require.True(t, ok)
+	require.InDelta(t, 0.5, temperature, 1e-6)
+}
+
+func TestCreateAgentValidation(t *testing.T) {
+	u, err := agents.New(mocks.NewMockAgentStorage(t), agents.WithMaxSystemMessageLength(8))
+	require.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		name, model, message string
+		want                 error
+	}{
+		"empty_name":   {name: " ", model: "gemini-2.5-flash", want: agents.ErrInvalidName},
+		"empty_model":  {name: "writer", model: "", want: agents.ErrInvalidModel},
+		"long_message": {name: "writer", model: "m", message: "too long message", want: agents.ErrSystemMessageTooLong},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := u.CreateAgent(t.Context(), ids.RandomUserID(), tt.name, tt.model, tt.message)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func newAgent(t *testing.T, user ids.UserID, name string) *entities.Agent {
+	t.Helper()
+
+	agent, err := entities.NewModelSettings(
+		must(ids.RandomAgentID(user)),
+		"gemini-2.5-flash",
+		entities.WithName(name),
+	)
+	require.NoError(t, err)
+
+	return agent
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return v
+}
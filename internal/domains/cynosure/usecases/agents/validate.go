@@ -0,0 +1,74 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+func validateName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidName)
+	case utf8.RuneCountInString(name) > maxNameLength:
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidName, maxNameLength)
+	default:
+		return nil
+	}
+}
+
+func validateModel(model string) error {
+	switch {
+	case model == "":
+		return fmt.Errorf("%w: model is required", ErrInvalidModel)
+	case strings.ContainsFunc(model, unicode.IsSpace):
+		return fmt.Errorf("%w: %q contains spaces", ErrInvalidModel, model)
+	default:
+		return nil
+	}
+}
+
+func (u *Usecase) validateSystemMessage(message string) error {
+	if utf8.RuneCountInString(message) > u.maxSystemMessageLength {
+		return fmt.Errorf(
+			"%w: limit is %d characters", ErrSystemMessageTooLong, u.maxSystemMessageLength,
+		)
+	}
+
+	return nil
+}
+
+// findUserAgent loads agent and checks, that it belongs to the user.
+func (u *Usecase) findUserAgent(
+	ctx context.Context, user ids.UserID, agent uuid.UUID,
+) (*entities.Agent, error) {
+	if !user.Valid() {
+		return nil, errInternalValidation("user id is required")
+	}
+
+	agentID, err := ids.NewAgentID(user, agent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAgentNotFound, agent)
+	}
+
+	found, err := u.storage.GetAgent(ctx, agentID)
+	switch {
+	case errors.Is(err, ports.ErrNotFound):
+		return nil, fmt.Errorf("%w: %v", ErrAgentNotFound, agent)
+	case err != nil:
+		return nil, fmt.Errorf("getting agent: %w", err)
+	case found.ID().UserID().ID() != user.ID():
+		return nil, fmt.Errorf("%w: %v", ErrAgentNotFound, agent)
+	default:
+		return found, nil
+	}
+}
@@ -0,0 +1,27 @@
+package catalog
+
+import (
+	"errors"
+)
+
+var (
+	// ErrQueryRequired is returned when search query is empty.
+	ErrQueryRequired = errors.New("search query is required")
+
+	// ErrInvalidRegistry is returned when registry file can't be parsed.
+	ErrInvalidRegistry = errors.New("invalid registry file")
+)
+
+// InternalValidationError is returned when usecase configuration or parameters are invalid.
+type InternalValidationError struct {
+	Message string
+}
+
+func (e *InternalValidationError) Error() string {
+	return "catalog usecase validation error: " + e.Message
+}
+
+// errInternalValidation is a helper to create InternalValidationError.
+func errInternalValidation(msg string) error {
+	return &InternalValidationError{Message: msg}
+}
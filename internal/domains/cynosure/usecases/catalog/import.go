@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/quenbyako/cynosure/contrib/mcpregistry"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+// ImportResult describes, how many servers of registry file were imported.
+// Servers are skipped, when they can't be connected remotely (e.g. they are
+// distributed only as packages) or have invalid URL.
+type ImportResult struct {
+	Imported int
+	Skipped  int
+}
+
+// Import reads servers from registry file in JSON or YAML format, embeds
+// their descriptions and saves them to catalog. Servers, which are already in
+// catalog, are updated.
+//
+// Throws:
+//
+//   - [ErrInvalidRegistry] if file can't be parsed.
+func (u *Usecase) Import(ctx context.Context, data []byte) (ImportResult, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.Import")
+	defer span.End()
+
+	servers, err := mcpregistry.Parse(data)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("%w: %w", ErrInvalidRegistry, err)
+	}
+
+	var res ImportResult
+
+	for _, server := range servers {
+		entry, ok := entryFromRegistry(server)
+		if !ok {
+			res.Skipped++
+
+			continue
+		}
+
+		if err := u.saveEntry(ctx, entry); err != nil {
+			return res, err
+		}
+
+		res.Imported++
+	}
+
+	return res, nil
+}
+
+func (u *Usecase) saveEntry(ctx context.Context, entry catalog.Entry) error {
+	msg, err := messages.NewMessageUser(entry.IndexText())
+	if err != nil {
+		return fmt.Errorf("building message for %q: %w", entry.Name(), err)
+	}
+
+	embedding, err := u.index.BuildToolEmbedding(ctx, []messages.Message{msg})
+	if err != nil {
+		return fmt.Errorf("embedding %q: %w", entry.Name(), err)
+	}
+
+	if err := u.storage.SaveCatalogEntry(ctx, entry, embedding); err != nil {
+		return fmt.Errorf("saving %q: %w", entry.Name(), err)
+	}
+
+	return nil
+}
+
+func entryFromRegistry(server mcpregistry.Server) (catalog.Entry, bool) {
+	remote, ok := server.Remote()
+	if !ok {
+		return catalog.Entry{}, false
+	}
+
+	link, err := url.Parse(remote.URL)
+	if err != nil {
+		return catalog.Entry{}, false
+	}
+
+	entry, err := catalog.NewEntry(server.Name, link, server.Description,
+		catalog.WithTitle(server.Title),
+		catalog.WithCategories(server.Meta.Publisher.Categories...),
+		catalog.WithAuthType(authTypeFromRegistry(server.Meta.Publisher.AuthType, remote)),
+	)
+	if err != nil {
+		return catalog.Entry{}, false
+	}
+
+	return entry, true
+}
+
+// authTypeFromRegistry prefers auth type, declared by publisher. Otherwise
+// required secret headers mean API key, and nothing else could be deduced:
+// OAuth is discovered only on connection.
+func authTypeFromRegistry(declared string, remote mcpregistry.Remote) catalog.AuthType {
+	if authType, err := catalog.ParseAuthType(declared); err == nil && authType != catalog.AuthTypeUnknown {
+		return authType
+	}
+
+	if remote.RequiresSecret() {
+		return catalog.AuthTypeAPIKey
+	}
+
+	return catalog.AuthTypeUnknown
+}
@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+// SearchText finds catalog entries, which contain words of the query in name,
+// title, description or categories. Non-positive limit means default limit,
+// too large limit is capped.
+//
+// Throws:
+//
+//   - [ErrQueryRequired] if query is empty.
+func (u *Usecase) SearchText(ctx context.Context, query string, limit int) ([]catalog.Match, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.SearchText")
+	defer span.End()
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w", ErrQueryRequired)
+	}
+
+	matches, err := u.storage.SearchCatalog(ctx, query, searchLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("searching catalog: %w", err)
+	}
+
+	return matches, nil
+}
+
+// SearchSemantic finds catalog entries, which descriptions are semantically
+// close to the query, e.g. "manage my todo list" finds task trackers.
+// Relevance of results is cosine similarity, from -1 to 1.
+//
+// Throws:
+//
+//   - [ErrQueryRequired] if query is empty.
+func (u *Usecase) SearchSemantic(
+	ctx context.Context,
+	query string,
+	limit int,
+) ([]catalog.Match, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.SearchSemantic")
+	defer span.End()
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w", ErrQueryRequired)
+	}
+
+	msg, err := messages.NewMessageUser(query)
+	if err != nil {
+		return nil, fmt.Errorf("building query message: %w", err)
+	}
+
+	embedding, err := u.index.BuildToolEmbedding(ctx, []messages.Message{msg})
+	if err != nil {
+		return nil, fmt.Errorf("building query embedding: %w", err)
+	}
+
+	matches, err := u.storage.LookupCatalog(ctx, embedding, searchLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("looking up catalog: %w", err)
+	}
+
+	return matches, nil
+}
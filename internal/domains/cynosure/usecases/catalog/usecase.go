@@ -0,0 +1,90 @@
+// Package catalog implements public MCP server catalog: importing servers
+// from registry files and searching them.
+package catalog
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+const pkgName = "github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/catalog"
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+type Usecase struct {
+	storage ports.ServerCatalog
+	index   ports.ToolSemanticIndex
+	trace   trace.Tracer
+}
+
+type newParams struct {
+	tracer trace.TracerProvider
+}
+
+func buildNewParams(opts ...NewOption) newParams {
+	params := newParams{
+		tracer: noop.NewTracerProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return params
+}
+
+type NewOption func(*newParams)
+
+func WithTracerProvider(tp trace.TracerProvider) NewOption {
+	return func(p *newParams) { p.tracer = tp }
+}
+
+// New creates catalog usecase. Index is used to embed both entry descriptions
+// and search queries, so they share the same vector space.
+func New(
+	storage ports.ServerCatalog,
+	index ports.ToolSemanticIndex,
+	opts ...NewOption,
+) (*Usecase, error) {
+	params := buildNewParams(opts...)
+
+	usecase := &Usecase{
+		storage: storage,
+		index:   index,
+		trace:   params.tracer.Tracer(pkgName),
+	}
+
+	if err := usecase.validate(); err != nil {
+		return nil, err
+	}
+
+	return usecase, nil
+}
+
+func (u *Usecase) validate() error {
+	if u.storage == nil {
+		return errInternalValidation("server catalog is required")
+	}
+
+	if u.index == nil {
+		return errInternalValidation("semantic index is required")
+	}
+
+	return nil
+}
+
+func searchLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultSearchLimit
+	case limit > maxSearchLimit:
+		return maxSearchLimit
+	default:
+		return limit
+	}
+}
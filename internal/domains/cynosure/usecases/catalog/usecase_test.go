@@ -0,0 +1,87 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	domaincatalog "github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/catalog"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/catalog"
+)
+
+const registry = `
+servers:
+  - server:
+      name: com.todoist/mcp
+      title: Todoist
+      description: Manage tasks and projects.
+      remotes:
+        - type: streamable-http
+          url: https://ai.todoist.net/mcp
+      _meta:
+        io.modelcontextprotocol.registry/publisher-provided:
+          categories: [Productivity]
+          auth_type: oauth
+  - server:
+      name: io.github.example/weather
+      description: Forecasts for any city.
+      remotes:
+        - type: sse
+          url: https://weather.example.com/sse
+          headers:
+            - name: X-API-Key
+              isRequired: true
+              isSecret: true
+  - server:
+      name: io.github.example/local
+      description: Runs only locally.
+      packages:
+        - registryType: npm
+          identifier: "@example/local"
+`
+
+func TestImport(t *testing.T) {
+	storage := mocks.NewMockServerCatalog(t)
+	index := mocks.NewMockToolSemanticIndex(t)
+
+	var saved []domaincatalog.Entry
+
+	index.EXPECT().BuildToolEmbedding(mock.Anything, mock.Anything).Return([1536]float32{1}, nil).Twice()
+	storage.EXPECT().SaveCatalogEntry(mock.Anything, mock.Anything, [1536]float32{1}).
+		Run(func(_ context.Context, entry domaincatalog.Entry, _ [1536]float32) {
+			saved = append(saved, entry)
+		}).
+		Return(nil).
+		Twice()
+
+	u, err := catalog.New(storage, index)
+	require.NoError(t, err)
+
+	res, err := u.Import(t.Context(), []byte(registry))
+	require.NoError(t, err)
+	require.Equal(t, catalog.ImportResult{Imported: 2, Skipped: 1}, res)
+
+	require.Len(t, saved, 2)
+	require.Equal(t, "Todoist", saved[0].Title())
+	require.Equal(t, domaincatalog.AuthTypeOAuth, saved[0].AuthType())
+	require.Equal(t, []string{"productivity"}, saved[0].Categories())
+	require.Equal(t, "https://weather.example.com/sse", saved[1].Link().String())
+	require.Equal(t, domaincatalog.AuthTypeAPIKey, saved[1].AuthType())
+
+	_, err = u.Import(t.Context(), []byte(`"not a registry"`))
+	require.ErrorIs(t, err, catalog.ErrInvalidRegistry)
+}
+
+func TestSearchRequiresQuery(t *testing.T) {
+	u, err := catalog.New(mocks.NewMockServerCatalog(t), mocks.NewMockToolSemanticIndex(t))
+	require.NoError(t, err)
+
+	_, err = u.SearchText(t.Context(), "  ", 0)
+	require.ErrorIs(t, err, catalog.ErrQueryRequired)
+
+	_, err = u.SearchSemantic(t.Context(), "", 0)
+	require.ErrorIs(t, err, catalog.ErrQueryRequired)
+}
@@ -0,0 +1,172 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/aggregates/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+// budgetViolation describes exhausted limit.
+type budgetViolation struct {
+	period budget.Period
+	metric budget.Metric
+	// limit of the agent, otherwise user limit is exhausted.
+	agent bool
+}
+
+// message explains user, why response is stopped, and when they may
+// continue.
+func (v budgetViolation) message() string {
+	owner := "Your"
+	if v.agent {
+		owner = "This agent's"
+	}
+
+	switch v.period {
+	case budget.PeriodDay:
+		return fmt.Sprintf("I had to stop here: %s daily %s budget is exhausted. "+
+			"It resets at midnight UTC.", owner, v.metric)
+	case budget.PeriodMonth:
+		return fmt.Sprintf("I had to stop here: %s monthly %s budget is exhausted. "+
+			"It resets on the first day of the next month (UTC).", owner, v.metric)
+	default:
+		return fmt.Sprintf("I had to stop here: %s %s budget for a single response is exhausted. "+
+			"Send a message, if you want me to continue.", owner, v.metric)
+	}
+}
+
+// runBudget tracks usage of single agent loop run against user and agent
+// limits. Nil budget tracks nothing.
+type runBudget struct {
+	obs     *observable
+	storage ports.UsageStorage
+	prices  budget.PriceTable
+	agent   ids.AgentID
+	model   string
+
+	userLimits  budget.Limits
+	agentLimits budget.Limits
+	// usage in calendar periods, including current run.
+	userUsed  map[budget.Period]budget.Usage
+	agentUsed map[budget.Period]budget.Usage
+}
+
+// startBudget loads usage of current day and month. Returns nil, if budgets
+// are not enabled.
+func (u *Usecase) startBudget(
+	ctx context.Context, config entities.AgentReadOnly,
+) (*runBudget, error) {
+	if u.usage == nil {
+		return nil, nil //nolint:nilnil // nil budget is valid, it tracks nothing
+	}
+
+	run := &runBudget{
+		obs:         &u.obs,
+		storage:     u.usage,
+		prices:      u.prices,
+		agent:       config.ID(),
+		model:       config.Model(),
+		userLimits:  u.userBudget,
+		agentLimits: config.Budget(),
+		userUsed:    make(map[budget.Period]budget.Usage),
+		agentUsed:   make(map[budget.Period]budget.Usage),
+	}
+
+	now := u.obs.now()
+
+	for _, period := range []budget.Period{budget.PeriodDay, budget.PeriodMonth} {
+		since := period.Start(now)
+
+		if !run.userLimits.For(period).Empty() {
+			used, err := u.usage.UserUsage(ctx, run.agent.UserID(), since)
+			if err != nil {
+				return nil, fmt.Errorf("getting user usage: %w", err)
+			}
+
+			run.userUsed[period] = used
+		}
+
+		if !run.agentLimits.For(period).Empty() {
+			used, err := u.usage.AgentUsage(ctx, run.agent, since)
+			if err != nil {
+				return nil, fmt.Errorf("getting agent usage: %w", err)
+			}
+
+			run.agentUsed[period] = used
+		}
+	}
+
+	return run, nil
+}
+
+// spend records usage of the turn. Failed record doesn't stop the response:
+// it only makes budgets less strict.
+func (b *runBudget) spend(ctx context.Context, stats chatmodel.UsageStats) {
+	if b == nil || (stats.InputTokens == 0 && stats.OutputTokens == 0) {
+		return
+	}
+
+	usage := b.prices.Usage(b.model, uint64(stats.InputTokens), uint64(stats.OutputTokens))
+
+	if err := b.storage.AddUsage(ctx, b.agent, b.obs.now(), usage); err != nil {
+		b.obs.usageNotSaved(ctx, b.agent.ID().String(), err)
+	}
+
+	for _, period := range budget.Periods() {
+		b.userUsed[period] = b.userUsed[period].Add(usage)
+		b.agentUsed[period] = b.agentUsed[period].Add(usage)
+	}
+}
+
+// exceeded returns first exhausted limit. User limits are checked first, as
+// they are usually the strictest ones.
+func (b *runBudget) exceeded() (budgetViolation, bool) {
+	if b == nil {
+		return budgetViolation{}, false
+	}
+
+	for _, period := range budget.Periods() {
+		if metric, ok := b.userLimits.For(period).Exceeded(b.userUsed[period]); ok {
+			return budgetViolation{period: period, metric: metric, agent: false}, true
+		}
+
+		if metric, ok := b.agentLimits.For(period).Exceeded(b.agentUsed[period]); ok {
+			return budgetViolation{period: period, metric: metric, agent: true}, true
+		}
+	}
+
+	return budgetViolation{}, false
+}
+
+// stopOverBudget ends the response with explanation, which is also saved to
+// the history.
+func (u *Usecase) stopOverBudget(
+	ctx context.Context,
+	thread *chat.Chat,
+	violation budgetViolation,
+	yield func(messages.Message, error) bool,
+) {
+	u.obs.budgetExceeded(ctx, thread.ThreadID().String(),
+		violation.period.String(), violation.metric.String(), violation.agent,
+	)
+
+	msg, err := messages.NewMessageAssistant(violation.message())
+	if err != nil {
+		yield(nil, fmt.Errorf("creating budget message: %w", err))
+		return
+	}
+
+	if err := thread.AcceptAssistantMessage(ctx, msg); err != nil {
+		yield(nil, fmt.Errorf("saving budget message: %w", err))
+		return
+	}
+
+	yield(msg, nil)
+}
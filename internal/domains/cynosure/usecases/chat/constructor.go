@@ -1,32 +1,78 @@
 package chat
 
 import (
+	"net/url"
+	"time"
+
 	"github.com/quenbyako/core"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
 const (
 	defaultAgentLoopTurns = 10
 	defaultChatLimit      = 20
+	// amount of invalid calls of the same tool, which model is allowed to
+	// fix during single response.
+	defaultToolRepairAttempts = 2
+	// amount of progress notifications, buffered while consumer is busy.
+	progressBufferSize = 8
 )
 
 type Usecase struct {
-	obs              observable
-	storage          ports.ThreadStorage
-	model            chatmodel.Port
-	tools            toolclient.Port
-	indexer          ports.ToolSemanticIndex
-	toolStorage      ports.ToolStorage
-	servers          ports.ServerStorage
-	accounts         ports.AccountStorage
-	agents           ports.AgentStorage
-	limiter          ratelimiter.Port
-	agentLoopTurns   uint8
-	defaultChatLimit uint
+	obs         observable
+	storage     ports.ThreadStorage
+	model       chatmodel.Port
+	tools       toolclient.Port
+	indexer     ports.ToolSemanticIndex
+	toolStorage ports.ToolStorage
+	servers     ports.ServerStorage
+	accounts    ports.AccountStorage
+	agents      ports.AgentStorage
+	limiter     ratelimiter.Port
+	// assigned plans of users, optional: everyone uses default plan without
+	// it.
+	planStorage ports.PlanStorage
+	plans       plans.Catalog
+	blobs       ports.BlobStorage
+	links       ports.LinkStorage
+	// links, which were not used for this time, are deleted by
+	// [Usecase.CleanupLinks].
+	linkTTL time.Duration
+	// resources of user accounts, optional: resource tools are disabled
+	// without it.
+	resources ports.ResourceStorage
+	// prompts of user accounts, optional: users can't run prompts without
+	// it.
+	prompts ports.PromptStorage
+	// public address of link redirects, optional.
+	linkRedirect *url.URL
+	toolCache    ports.ToolResultCache
+	// ttl of read-only tool results, if user didn't set it for the tool.
+	defaultToolCacheTTL time.Duration
+	// usage storage enables budgets, optional.
+	usage      ports.UsageStorage
+	prices     budget.PriceTable
+	userBudget budget.Limits
+	// records every turn, optional.
+	ledger ports.LedgerStorage
+	// servers, which may request completions, and quota of each account.
+	samplingPolicy sampling.Policy
+	samplingQuota  plans.Limit
+	// relays input requests of MCP servers to users.
+	elicitation *elicitationRelay
+	// tools, executed by cynosure itself, indexed by name.
+	builtins           map[string]tools.RawTool
+	agentLoopTurns     uint8
+	toolRepairAttempts uint8
+	defaultChatLimit   uint
 }
 
 func defaultNewParams(required newRequiredParams) newParams {
@@ -34,6 +80,23 @@ func defaultNewParams(required newRequiredParams) newParams {
 		newRequiredParams: required,
 		obs:               core.NoopMetrics(),
 		chatLimit:         defaultChatLimit,
+		repairAttempts:    defaultToolRepairAttempts,
+		blobs:             nil,
+		links:             nil,
+		linkTTL:           0,
+		resources:         nil,
+		prompts:           nil,
+		linkRedirect:      nil,
+		toolCache:         nil,
+		toolCacheTTL:      0,
+		usage:             nil,
+		prices:            nil,
+		userBudget:        budget.Limits{},
+		ledger:            nil,
+		planStorage:       nil,
+		plans:             plans.Catalog{},
+		samplingPolicy:    sampling.PolicyDeny,
+		samplingQuota:     plans.Limit{},
 	}
 }
 
@@ -57,6 +120,10 @@ func (s *newParams) validate() error {
 		return errInternalValidation("model settings storage is required")
 	case s.limiter == nil:
 		return errInternalValidation("rate limiter is required")
+	case s.toolCacheTTL < 0:
+		return errInternalValidation("tool cache ttl must not be negative")
+	case s.linkTTL < 0:
+		return errInternalValidation("link ttl must not be negative")
 	default:
 		return nil
 	}
@@ -91,18 +158,70 @@ func New(
 		return nil, err
 	}
 
+	builtins, err := newBuiltinTools(params)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Usecase{
-		storage:          storage,
-		model:            model,
-		tools:            tool,
-		indexer:          indexer,
-		toolStorage:      toolStorage,
-		servers:          server,
-		accounts:         account,
-		agents:           agents,
-		limiter:          limiter,
-		agentLoopTurns:   defaultAgentLoopTurns,
-		defaultChatLimit: params.chatLimit,
-		obs:              obs,
+		storage:             storage,
+		model:               model,
+		tools:               tool,
+		indexer:             indexer,
+		toolStorage:         toolStorage,
+		servers:             server,
+		accounts:            account,
+		agents:              agents,
+		limiter:             limiter,
+		blobs:               params.blobs,
+		links:               params.links,
+		linkTTL:             params.linkTTL,
+		resources:           params.resources,
+		prompts:             params.prompts,
+		linkRedirect:        params.linkRedirect,
+		toolCache:           params.toolCache,
+		defaultToolCacheTTL: params.toolCacheTTL,
+		usage:               params.usage,
+		prices:              params.prices,
+		userBudget:          params.userBudget,
+		ledger:              params.ledger,
+		planStorage:         params.planStorage,
+		plans:               params.plans,
+		samplingPolicy:      params.samplingPolicy,
+		samplingQuota:       params.samplingQuota,
+		elicitation:         newElicitationRelay(),
+		builtins:            builtins,
+		agentLoopTurns:      defaultAgentLoopTurns,
+		toolRepairAttempts:  params.repairAttempts,
+		defaultChatLimit:    params.chatLimit,
+		obs:                 obs,
 	}, nil
 }
+
+// newBuiltinTools prepares tools, which are available with current
+// configuration.
+func newBuiltinTools(params newParams) (map[string]tools.RawTool, error) {
+	builtins := make(map[string]tools.RawTool)
+
+	if params.blobs != nil {
+		tool, err := newReadToolOutputTool()
+		if err != nil {
+			return nil, err
+		}
+
+		builtins[tool.Name()] = tool
+	}
+
+	if params.resources != nil {
+		resourceTools, err := newResourceTools()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tool := range resourceTools {
+			builtins[tool.Name()] = tool
+		}
+	}
+
+	return builtins, nil
+}
@@ -0,0 +1,158 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/aggregates/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+const (
+	// progressSummaryPrompt is never saved to the history: model receives it
+	// only once, when agent loop is paused.
+	progressSummaryPrompt = "You have reached the limit of steps for this response, " +
+		"and tools are not available anymore. Briefly summarize what you have done " +
+		"so far, what is left to do, and tell the user, that they may continue."
+
+	// continueMessage is saved to the history, when user continues paused
+	// thread, so model knows, why the loop is resumed.
+	continueMessage = "Continue"
+)
+
+// ContinueResponse resumes agent loop of the thread, which was paused at turn
+// limit. Loop starts with a fresh turn budget.
+//
+// Throws:
+//   - [ErrThreadNotPaused] if thread is not paused, or was continued
+//     concurrently.
+//   - [RateLimitError] if message quota of user plan is exhausted.
+//   - [ErrAgentDisabled] if agent of the thread is disabled.
+//   - [ports.ErrNotFound] if thread doesn't exist.
+func (u *Usecase) ContinueResponse(
+	ctx context.Context, threadID ids.ThreadID,
+) (iter.Seq2[messages.Message, error], error) {
+	ctx, span := u.obs.continueResponse(ctx)
+	defer span.end()
+
+	if !threadID.Valid() {
+		return nil, errInternalValidation("thread id is required")
+	}
+
+	if err := u.allowLimits(ctx, threadID.User()); err != nil {
+		return nil, err
+	}
+
+	chatAgg, err := chat.New(ctx,
+		u.storage, u.indexer, u.toolStorage, u.accounts,
+		threadID, u.defaultChatLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("loading chat: %w", err)
+	}
+
+	if !chatAgg.Paused() {
+		return nil, ErrThreadNotPaused
+	}
+
+	msg, err := messages.NewMessageUser(continueMessage)
+	if err != nil {
+		return nil, fmt.Errorf("creating continue message: %w", err)
+	}
+
+	// thread is resumed conditionally: if another request continued it, or
+	// user sent a message in the meantime, storage rejects the update.
+	err = chatAgg.AcceptUserMessage(ctx, msg)
+	if errors.Is(err, ports.ErrConcurrentModification) {
+		return nil, ErrThreadNotPaused
+	} else if err != nil {
+		return nil, fmt.Errorf("adding continue message: %w", err)
+	}
+
+	agentID, err := u.resolveAgentID(ctx, threadID, chatAgg, ids.AgentID{})
+	if err != nil {
+		return nil, err
+	}
+
+	modelConfig, err := u.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.agentLoop(ctx, chatAgg, modelConfig, tools.ToolChoiceAllowed), nil
+}
+
+// pauseAtTurnLimit asks model to summarize its progress, and marks thread as
+// paused. If everything went well, [ErrTurnLimitReached] is yielded as the
+// last element, so caller may offer user to continue.
+func (u *Usecase) pauseAtTurnLimit(
+	ctx context.Context,
+	thread *chat.Chat,
+	config entities.AgentReadOnly,
+	yield func(messages.Message, error) bool,
+) chatmodel.UsageStats {
+	usage, ok := u.summarizeProgress(ctx, thread, config, yield)
+	if !ok {
+		return usage
+	}
+
+	if err := thread.Pause(ctx); err != nil {
+		yield(nil, fmt.Errorf("pausing thread: %w", err))
+		return usage
+	}
+
+	yield(nil, ErrTurnLimitReached)
+
+	return usage
+}
+
+// summarizeProgress requests response without tools, so loop ends with a
+// message for the user instead of dangling tool results.
+func (u *Usecase) summarizeProgress(
+	ctx context.Context,
+	thread *chat.Chat,
+	config entities.AgentReadOnly,
+	yield func(messages.Message, error) bool,
+) (chatmodel.UsageStats, bool) {
+	var failures uint64
+
+	yield = countFailures(&failures, yield)
+
+	prompt, err := messages.NewMessageUser(progressSummaryPrompt)
+	if err != nil {
+		yield(nil, fmt.Errorf("creating summary prompt: %w", err))
+		return chatmodel.UsageStats{}, false
+	}
+
+	history := append(thread.Messages(u.maxContext(config)), prompt)
+
+	stream, err := u.model.StreamWithStats(ctx, history, config)
+	if err != nil {
+		u.handleModelError(ctx, thread, fmt.Errorf("calling model stream: %w", err), yield)
+		return chatmodel.UsageStats{}, false
+	}
+
+	_, usage, ok := u.streamModelMessages(ctx, thread, stream, yield)
+
+	u.obs.recordUsage(ctx, config.Model(),
+		usage.InputTokens, usage.OutputTokens, usage.Duration,
+	)
+	u.recordTurn(ctx, thread.ThreadID(), config, usage, 0, failures)
+
+	return usage, ok
+}
+
+func addUsage(total, usage chatmodel.UsageStats) chatmodel.UsageStats {
+	return chatmodel.UsageStats{
+		InputTokens:  usage.InputTokens + total.InputTokens,
+		OutputTokens: usage.OutputTokens + total.OutputTokens,
+		Duration:     usage.Duration + total.Duration,
+	}
+}
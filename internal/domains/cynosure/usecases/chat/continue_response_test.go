@@ -0,0 +1,128 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+// loopTurns is a default amount of agent loop turns in single response.
+const loopTurns = 10
+
+func TestPauseAndContinue(t *testing.T) {
+	f := newPausedFixture(t)
+	u := f.usecase()
+
+	f.pause(u)
+
+	f.model.script(answer("Done."))
+
+	seq, err := u.ContinueResponse(t.Context(), f.threadID)
+	require.NoError(t, err)
+
+	msgs, err := collect(seq)
+	require.NoError(t, err)
+
+	last, ok := msgs[len(msgs)-1].(messages.MessageAssistant)
+	require.True(t, ok)
+	assert.Equal(t, "Done.", last.Content())
+	assert.False(t, f.thread.Paused())
+
+	_, err = u.ContinueResponse(t.Context(), f.threadID)
+	require.ErrorIs(t, err, chat.ErrThreadNotPaused, "thread is continued only once")
+}
+
+func TestContinueResponseConflict(t *testing.T) {
+	f := newPausedFixture(t)
+	u := f.usecase()
+
+	f.pause(u)
+
+	// another request resumed the thread after it was read.
+	f.mu.Lock()
+	f.updateErr = ports.ErrConcurrentModification
+	f.mu.Unlock()
+
+	_, err := u.ContinueResponse(t.Context(), f.threadID)
+	require.ErrorIs(t, err, chat.ErrThreadNotPaused)
+	assert.Empty(t, f.model.turns, "model must not be called")
+}
+
+func TestMessageResumesPausedThread(t *testing.T) {
+	f := newPausedFixture(t)
+	u := f.usecase()
+
+	f.pause(u)
+
+	f.model.script(answer("Sure."))
+	f.respond(u, "let's talk about something else")
+
+	assert.False(t, f.thread.Paused())
+
+	_, err := u.ContinueResponse(t.Context(), f.threadID)
+	require.ErrorIs(t, err, chat.ErrThreadNotPaused)
+}
+
+func newPausedFixture(t *testing.T) *chatFixture {
+	t.Helper()
+
+	f := newChatFixture(t)
+	f.tool("step", `{"type":"object"}`)
+
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, tool entities.ToolReadOnly, _ map[string]json.RawMessage,
+			callID string, _ ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			return messages.NewMessageToolResponse(json.RawMessage(`{}`), tool.Name(), callID)
+		}).Maybe()
+
+	return f
+}
+
+// pause runs response, which uses every turn of agent loop, so thread is
+// paused at turn limit.
+func (f *chatFixture) pause(u *chat.Usecase) {
+	f.t.Helper()
+
+	for turn := range loopTurns {
+		f.model.script(callTool("step", fmt.Sprintf("call-%d", turn), `{}`))
+	}
+
+	f.model.script(answer("Halfway there."))
+
+	seq, err := u.GenerateResponse(f.t.Context(), f.threadID, must(messages.NewMessageUser("go")))
+	require.NoError(f.t, err)
+
+	_, err = collect(seq)
+	require.ErrorIs(f.t, err, chat.ErrTurnLimitReached)
+	require.True(f.t, f.thread.Paused())
+}
+
+// collect reads the whole response, and returns messages with the first
+// error.
+func collect(seq iter.Seq2[messages.Message, error]) ([]messages.Message, error) {
+	var res []messages.Message
+
+	for msg, err := range seq {
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, msg)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,128 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/elicitation"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+)
+
+// ElicitationHandler asks user of the thread to fill the form, which MCP
+// server requested during the tool call. It's called from the goroutine,
+// which iterates the response, so handler never races with stream consumer.
+type ElicitationHandler = func(
+	ctx context.Context, thread ids.ThreadID, req elicitation.Request,
+) (elicitation.Result, error)
+
+// elicitationRelay keeps handler, registered by the controller, which talks
+// to users.
+type elicitationRelay struct {
+	handler ElicitationHandler
+	// registration id of the handler, so replaced handler can't be
+	// unregistered by its previous owner.
+	id     uint64
+	lastID uint64
+	mu     sync.RWMutex
+}
+
+func newElicitationRelay() *elicitationRelay {
+	return &elicitationRelay{
+		handler: nil,
+		id:      0,
+		lastID:  0,
+		mu:      sync.RWMutex{},
+	}
+}
+
+func (r *elicitationRelay) get() ElicitationHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.handler
+}
+
+// HandleElicitation registers handler, which relays input requests of MCP
+// servers to users. Only one handler is active: new one replaces previous.
+// Without handler, servers are told that client doesn't support elicitation.
+func (u *Usecase) HandleElicitation(handler ElicitationHandler) (unregister func()) {
+	u.elicitation.mu.Lock()
+	u.elicitation.lastID++
+	id := u.elicitation.lastID
+	u.elicitation.handler, u.elicitation.id = handler, id
+	u.elicitation.mu.Unlock()
+
+	return func() {
+		u.elicitation.mu.Lock()
+		if u.elicitation.id == id {
+			u.elicitation.handler, u.elicitation.id = nil, 0
+		}
+		u.elicitation.mu.Unlock()
+	}
+}
+
+type elicitCall struct {
+	req   elicitation.Request
+	reply chan elicitAnswer
+}
+
+type elicitAnswer struct {
+	res elicitation.Result
+	err error
+}
+
+// elicitationOption passes requests of the server to the iterator goroutine
+// through calls channel. If tool call is finished before user answered,
+// request is cancelled.
+func elicitationOption(
+	callCtx context.Context, calls chan<- elicitCall,
+) toolclient.ExecuteToolOption {
+	return toolclient.WithElicitationHandler(func(
+		ctx context.Context, req elicitation.Request,
+	) (elicitation.Result, error) {
+		call := elicitCall{req: req, reply: make(chan elicitAnswer, 1)}
+
+		select {
+		case calls <- call:
+		case <-callCtx.Done():
+			return elicitation.Cancel(), nil
+		case <-ctx.Done():
+			return elicitation.Result{}, fmt.Errorf("relaying elicitation: %w", ctx.Err())
+		}
+
+		select {
+		case answer := <-call.reply:
+			return answer.res, answer.err
+		case <-callCtx.Done():
+			return elicitation.Cancel(), nil
+		case <-ctx.Done():
+			return elicitation.Result{}, fmt.Errorf("waiting for elicitation: %w", ctx.Err())
+		}
+	})
+}
+
+// answerElicitation asks user and passes the answer back to the tool call.
+func (u *Usecase) answerElicitation(
+	ctx context.Context,
+	thread ids.ThreadID,
+	tool string,
+	handler ElicitationHandler,
+	call elicitCall,
+) {
+	ctx, span := u.obs.elicit(ctx, tool)
+	defer span.end()
+
+	res, err := handler(ctx, thread, call.req)
+	if err != nil {
+		span.recordError(err)
+		call.reply <- elicitAnswer{res: elicitation.Result{}, err: err}
+
+		return
+	}
+
+	u.obs.elicitationAnswered(ctx, thread.String(), tool, res.Action().String())
+
+	call.reply <- elicitAnswer{res: res, err: nil}
+}
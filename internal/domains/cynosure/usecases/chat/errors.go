@@ -3,16 +3,87 @@ package chat
 import (
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 )
 
 var (
 	// ErrNoAgentsFound is returned when no agents are available for a user.
 	ErrNoAgentsFound = errors.New("no agents found")
 
+	// ErrAgentDisabled is returned when agent of the thread is disabled.
+	ErrAgentDisabled = errors.New("agent is disabled")
+
 	// ErrUnexpectedMessageType is returned when an unknown message type is encountered.
 	ErrUnexpectedMessageType = errors.New("unexpected message type")
+
+	// ErrLinkNotFound is returned when link reference is unknown.
+	ErrLinkNotFound = errors.New("link not found")
+
+	// ErrTurnLimitReached is yielded as the last element of the response,
+	// when agent loop stopped at turn limit. Thread is paused, and user may
+	// continue it with [Usecase.ContinueResponse].
+	ErrTurnLimitReached = errors.New("agent loop reached turn limit")
+
+	// ErrThreadNotPaused is returned when continuing thread, which is not
+	// paused.
+	ErrThreadNotPaused = errors.New("thread is not paused")
+
+	// ErrPromptNotFound is returned when prompt is not exposed by active
+	// accounts of the user.
+	ErrPromptNotFound = errors.New("prompt not found")
+
+	// ErrEmptyPrompt is returned when rendered prompt has no text to send.
+	ErrEmptyPrompt = errors.New("prompt has no text messages")
+
+	// ErrEmptyCompletion is returned when model answered sampling request
+	// without any text.
+	ErrEmptyCompletion = errors.New("model returned empty completion")
+)
+
+// LimitKind tells, which quota of the plan is exhausted.
+type LimitKind uint8
+
+const (
+	// LimitMessages is a quota of user messages.
+	LimitMessages LimitKind = iota
+	// LimitTokens is a quota of model tokens.
+	LimitTokens
 )
 
+func (k LimitKind) String() string {
+	if k == LimitTokens {
+		return "tokens"
+	}
+
+	return "messages"
+}
+
+// RateLimitError is returned, when user exhausted quota of their plan, or
+// yielded as the last element of the response, when it happened in the
+// middle of the agent loop. It matches [ratelimiter.ErrRateLimitExceeded].
+type RateLimitError struct {
+	// Plan is a name of user plan.
+	Plan string
+	Kind LimitKind
+	// Remaining amount of messages or tokens, which is less than requested.
+	Remaining int
+	// RetryAfter is a time to wait until user may continue. Zero, if request
+	// exceeds the whole limit of the plan.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter <= 0 {
+		return fmt.Sprintf("%s limit of plan %q is too small for the request", e.Kind, e.Plan)
+	}
+
+	return fmt.Sprintf("%s limit of plan %q exceeded, retry after %v", e.Kind, e.Plan, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error { return ratelimiter.ErrRateLimitExceeded }
+
 // InternalValidationError is returned when usecase configuration or parameters are invalid.
 type InternalValidationError struct {
 	Message string
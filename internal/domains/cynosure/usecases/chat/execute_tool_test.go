@@ -0,0 +1,53 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+func TestInactiveAccountTools(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("search", `{"type":"object"}`)
+
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, "call-1", mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, tool entities.ToolReadOnly, _ map[string]json.RawMessage,
+			callID string, _ ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			return messages.NewMessageToolResponse(json.RawMessage(`{"found":1}`), tool.Name(), callID)
+		}).Once()
+
+	u := f.usecase()
+
+	f.model.script(callTool("search", "call-1", `{}`), answer("Found."))
+
+	results := toolResults(f.respond(u, "find cats"))
+	require.Len(t, results, 1)
+	assert.IsType(t, messages.MessageToolResponse{}, results[0])
+
+	// tool is still relevant for the thread, but it must not be called.
+	for _, status := range []entities.AccountStatus{
+		entities.AccountStatusDisabled,
+		entities.AccountStatusNeedsReauth,
+	} {
+		f.mu.Lock()
+		f.accountOpts = []entities.NewAccountOption{entities.WithAccountStatus(status)}
+		f.mu.Unlock()
+
+		f.model.script(callTool("search", "call-2", `{}`), answer("Search is unavailable."))
+
+		results = toolResults(f.respond(u, "find dogs"))
+		require.Len(t, results, 1)
+		require.IsType(t, messages.MessageToolError{}, results[0])
+		assert.Contains(t, string(results[0].Content()), "account is "+status.String())
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"maps"
+	"slices"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -14,7 +16,7 @@ import (
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
-	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
@@ -39,6 +41,12 @@ func (p generateResponseParams) validate() error {
 	}
 }
 
+// GenerateResponse saves user message to the thread and runs agent loop,
+// yielding messages of the response.
+//
+// Throws:
+//   - [RateLimitError] if message quota of user plan is exhausted.
+//   - [ErrAgentDisabled] if agent of the thread is disabled.
 func (u *Usecase) GenerateResponse(
 	ctx context.Context,
 	threadID ids.ThreadID,
@@ -53,11 +61,8 @@ func (u *Usecase) GenerateResponse(
 		return nil, err
 	}
 
-	allow, err := u.allowLimits(ctx, threadID.User())
-	if err != nil {
+	if err := u.allowLimits(ctx, threadID.User()); err != nil {
 		return nil, err
-	} else if !allow {
-		return u.yieldRateLimitError(err)
 	}
 
 	chatAgg, modelConfig, err := u.getAgentWithChat(ctx, threadID, params.model, msg)
@@ -68,19 +73,6 @@ func (u *Usecase) GenerateResponse(
 	return u.agentLoop(ctx, chatAgg, modelConfig, params.toolChoice), nil
 }
 
-func (u *Usecase) allowLimits(ctx context.Context, id ids.UserID) (bool, error) {
-	err := u.limiter.Consume(ctx, id, 1)
-	if err == nil {
-		return true, nil
-	}
-
-	if errors.Is(err, ratelimiter.ErrRateLimitExceeded) {
-		return false, nil
-	}
-
-	return false, fmt.Errorf("checking rate limit: %w", err)
-}
-
 func (u *Usecase) getAgentWithChat(
 	ctx context.Context, thread ids.ThreadID, agent ids.AgentID, msg messages.MessageUser,
 ) (*chat.Chat, entities.AgentReadOnly, error) {
@@ -94,14 +86,28 @@ func (u *Usecase) getAgentWithChat(
 		return nil, nil, err
 	}
 
-	modelConfig, err := u.agents.GetAgent(ctx, agentID)
+	modelConfig, err := u.getAgent(ctx, agentID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("getting model: %w", err)
+		return nil, nil, err
 	}
 
 	return chatAgg, modelConfig, nil
 }
 
+// getAgent loads agent, which is allowed to respond.
+func (u *Usecase) getAgent(ctx context.Context, id ids.AgentID) (entities.AgentReadOnly, error) {
+	agent, err := u.agents.GetAgent(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting model: %w", err)
+	}
+
+	if agent.Disabled() {
+		return nil, fmt.Errorf("%w: %v", ErrAgentDisabled, id.ID())
+	}
+
+	return agent, nil
+}
+
 func (u *Usecase) resolveAgentID(
 	ctx context.Context,
 	threadID ids.ThreadID,
@@ -125,22 +131,34 @@ func (u *Usecase) deduceAgentID(
 	threadID ids.ThreadID,
 	chatAgg *chat.Chat,
 ) (ids.AgentID, error) {
-	agents, err := u.agents.ListAgents(ctx, threadID.User())
+	agentID, err := u.defaultAgentID(ctx, threadID.User())
+	if err != nil {
+		return ids.AgentID{}, err
+	}
+
+	if err := chatAgg.SetAgent(ctx, agentID); err != nil {
+		return ids.AgentID{}, fmt.Errorf("setting default agent to thread: %w", err)
+	}
+
+	return agentID, nil
+}
+
+// defaultAgentID picks agent of the user, which responds, when nobody else is
+// chosen.
+func (u *Usecase) defaultAgentID(ctx context.Context, user ids.UserID) (ids.AgentID, error) {
+	agents, err := u.agents.ListAgents(ctx, user)
 	if err != nil {
 		return ids.AgentID{}, fmt.Errorf("listing user agents: %w", err)
 	}
 
+	// disabled agents can't respond, so they are never picked by default.
+	agents = slices.DeleteFunc(agents, func(a *entities.Agent) bool { return a.Disabled() })
 	if len(agents) == 0 {
 		return ids.AgentID{}, fmt.Errorf("listing user agents: %w", ErrNoAgentsFound)
 	}
 
 	// TODO: need to select agent. For now, just take the first one.
-	agentID := agents[0].ID()
-	if err := chatAgg.SetAgent(ctx, agentID); err != nil {
-		return ids.AgentID{}, fmt.Errorf("setting default agent to thread: %w", err)
-	}
-
-	return agentID, nil
+	return agents[0].ID(), nil
 }
 
 // loadOrCreateChat retrieves an existing chat session by its thread ID or
@@ -186,24 +204,76 @@ func (u *Usecase) agentLoop(
 		loopCtx, span := u.obs.agentLoop(ctx)
 		defer span.end()
 
-		var totalUsage chatmodel.UsageStats
+		run, err := u.startBudget(loopCtx, config)
+		if err != nil {
+			yield(nil, fmt.Errorf("loading budget: %w", err))
+			return
+		}
 
-		for turn := range u.agentLoopTurns {
-			usage, next := u.agentTurn(loopCtx, thread, config, toolChoice, turn, yield)
+		totalUsage := u.runTurns(loopCtx, thread, config, toolChoice, run, yield)
 
-			totalUsage = chatmodel.UsageStats{
-				InputTokens:  usage.InputTokens + totalUsage.InputTokens,
-				OutputTokens: usage.OutputTokens + totalUsage.OutputTokens,
-				Duration:     usage.Duration + totalUsage.Duration,
-			}
+		span.recordTotalUsage(totalUsage.InputTokens, totalUsage.OutputTokens)
+	}
+}
 
-			if !next {
-				break
-			}
+// runTurns runs agent turns until model answers, budget is exhausted, or turn
+// limit is reached.
+func (u *Usecase) runTurns(
+	ctx context.Context,
+	thread *chat.Chat,
+	config entities.AgentReadOnly,
+	toolChoice tools.ToolChoice,
+	run *runBudget,
+	yield func(messages.Message, error) bool,
+) chatmodel.UsageStats {
+	var totalUsage chatmodel.UsageStats
+
+	repairs := newToolRepairs(u.toolRepairAttempts)
+	tokens, err := u.startTokens(ctx, thread)
+	if err != nil {
+		yield(nil, err)
+		return totalUsage
+	}
+
+	for turn := range u.agentLoopTurns {
+		if violation, ok := run.exceeded(); ok {
+			u.stopOverBudget(ctx, thread, violation, yield)
+			return totalUsage
 		}
 
-		span.recordTotalUsage(totalUsage.InputTokens, totalUsage.OutputTokens)
+		reservation, ok := u.reserveTokens(ctx, thread, tokens, yield)
+		if !ok {
+			return totalUsage
+		}
+
+		usage, next := u.agentTurn(ctx, thread, config, toolChoice, repairs, turn, yield)
+		totalUsage = addUsage(totalUsage, usage)
+		run.spend(ctx, usage)
+		u.settleTokens(ctx, tokens, reservation, usage)
+
+		if !next {
+			return totalUsage
+		}
 	}
+
+	// summary costs tokens too, so it's skipped, when nothing is left.
+	if violation, ok := run.exceeded(); ok {
+		u.stopOverBudget(ctx, thread, violation, yield)
+		return totalUsage
+	}
+
+	reservation, ok := u.reserveTokens(ctx, thread, tokens, yield)
+	if !ok {
+		return totalUsage
+	}
+
+	u.obs.maxTurnsReached(ctx, thread.ThreadID().String())
+
+	usage := u.pauseAtTurnLimit(ctx, thread, config, yield)
+	run.spend(ctx, usage)
+	u.settleTokens(ctx, tokens, reservation, usage)
+
+	return addUsage(totalUsage, usage)
 }
 
 func (u *Usecase) agentTurn(
@@ -211,47 +281,41 @@ func (u *Usecase) agentTurn(
 	thread *chat.Chat,
 	config entities.AgentReadOnly,
 	toolChoice tools.ToolChoice,
+	repairs *toolRepairs,
 	turn uint8,
 	yield func(messages.Message, error) bool,
 ) (chatmodel.UsageStats, bool) {
 	span := trace.SpanFromContext(ctx)
 	span.AddEvent("set.turn", trace.WithAttributes(attribute.Int("turn", int(turn))))
 
+	var failures uint64
+
+	yield = countFailures(&failures, yield)
+
 	toolRequests, usage, shouldContinue := u.askModel(ctx, thread, config, toolChoice, yield)
 
 	u.obs.recordUsage(ctx, config.Model(),
 		usage.InputTokens, usage.OutputTokens, usage.Duration,
 	)
 
-	if !shouldContinue || len(toolRequests) == 0 {
-		return usage, false
-	}
+	next := shouldContinue && len(toolRequests) > 0 &&
+		u.handleToolRequests(ctx, thread, repairs, toolRequests, yield)
 
-	if !u.handleToolRequests(ctx, thread, turn, toolRequests, yield) {
-		return usage, false
-	}
+	u.recordTurn(ctx, thread.ThreadID(), config, usage, uint64(len(toolRequests)), failures)
 
-	return usage, true
+	return usage, next
 }
 
 func (u *Usecase) handleToolRequests(
 	ctx context.Context,
 	thread *chat.Chat,
-	turn uint8,
+	repairs *toolRepairs,
 	toolRequests []messages.MessageToolRequest,
 	yield func(messages.Message, error) bool,
 ) bool {
 	u.obs.toolCalled(ctx, thread.ThreadID().String(), toolRequests)
 
-	if !u.executeTools(ctx, thread, toolRequests, yield) {
-		return false
-	}
-
-	if turn == u.agentLoopTurns-1 {
-		u.obs.maxTurnsReached(ctx, thread.ThreadID().String())
-	}
-
-	return true
+	return u.executeTools(ctx, thread, repairs, toolRequests, yield)
 }
 
 func (u *Usecase) askModel(
@@ -278,15 +342,15 @@ func (u *Usecase) callModel(
 ) (chatmodel.Iter, error) {
 	var opts []chatmodel.StreamOption
 	if toolChoice != tools.ToolChoiceForbidden {
-		opts = append(opts, chatmodel.WithStreamToolbox(thread.RelevantTools()))
-	}
+		toolbox, err := thread.RelevantTools().Merge(slices.Collect(maps.Values(u.builtins))...)
+		if err != nil {
+			return nil, fmt.Errorf("adding built-in tools: %w", err)
+		}
 
-	maxContext, ok := config.MaxContext()
-	if !ok {
-		maxContext = u.defaultChatLimit
+		opts = append(opts, chatmodel.WithStreamToolbox(toolbox))
 	}
 
-	resp, err := u.model.StreamWithStats(ctx, thread.Messages(maxContext), config, opts...)
+	resp, err := u.model.StreamWithStats(ctx, thread.Messages(u.maxContext(config)), config, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("calling model stream: %w", err)
 	}
@@ -294,6 +358,14 @@ func (u *Usecase) callModel(
 	return resp, nil
 }
 
+func (u *Usecase) maxContext(config entities.AgentReadOnly) uint {
+	if maxContext, ok := config.MaxContext(); ok {
+		return maxContext
+	}
+
+	return u.defaultChatLimit
+}
+
 func (u *Usecase) handleModelError(
 	ctx context.Context,
 	thread *chat.Chat,
@@ -373,6 +445,10 @@ func (u *Usecase) saveAndYieldMessage(
 	switch v := msg.(type) {
 	case messages.MessageAssistant:
 		err = thread.AcceptAssistantMessage(ctx, v)
+		if err == nil {
+			// history keeps link references, user receives real addresses.
+			msg, err = u.expandAssistantLinks(ctx, v)
+		}
 	case messages.MessageToolRequest:
 		err = thread.AcceptToolRequest(ctx, v)
 		*toolRequests = append(*toolRequests, v)
@@ -391,11 +467,12 @@ func (u *Usecase) saveAndYieldMessage(
 func (u *Usecase) executeTools(
 	ctx context.Context,
 	c *chat.Chat,
+	repairs *toolRepairs,
 	toolRequests []messages.MessageToolRequest,
 	yield func(messages.Message, error) bool,
 ) bool {
 	for _, req := range toolRequests {
-		if !u.executeTool(ctx, c, req, yield) {
+		if !u.executeTool(ctx, c, repairs, req, yield) {
 			return false
 		}
 	}
@@ -406,9 +483,21 @@ func (u *Usecase) executeTools(
 func (u *Usecase) executeTool(
 	ctx context.Context,
 	thread *chat.Chat,
+	repairs *toolRepairs,
 	req messages.MessageToolRequest,
 	yield func(messages.Message, error) bool,
 ) bool {
+	if repairs.exhausted(req.ToolName()) {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf(
+			"Tool was called with invalid arguments too many times. "+
+				"Do not call %q again in this response.", req.ToolName(),
+		), yield)
+	}
+
+	if builtin, ok := u.builtins[req.ToolName()]; ok {
+		return u.executeBuiltinTool(ctx, thread, repairs, builtin, req, yield)
+	}
+
 	toolID, cleanArgs, err := thread.RelevantTools().ConvertRequest(req.ToolName(), req.Arguments())
 	if err != nil {
 		return yieldToolError(
@@ -416,14 +505,42 @@ func (u *Usecase) executeTool(
 		)
 	}
 
+	if reason, ok := u.toolAccountActive(ctx, toolID.Account()); !ok {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf(
+			"Tool is unavailable: %s. Do not call %q again.", reason, req.ToolName(),
+		), yield)
+	}
+
 	tool, err := u.toolStorage.GetTool(ctx, toolID.Account(), toolID)
 	if err != nil {
 		return yieldToolError(ctx, thread, req, fmt.Sprintf("Tool not found: %v", err), yield)
 	}
 
-	result, err := u.tools.ExecuteTool(ctx, tool, cleanArgs, req.ToolCallID())
+	cleanArgs, err = u.expandArguments(ctx, cleanArgs)
 	if err != nil {
-		return yieldToolError(ctx, thread, req, fmt.Sprintf("Execution failed: %v", err), yield)
+		yield(nil, fmt.Errorf("expanding links in arguments: %w", err))
+		return false
+	}
+
+	if invalid, ok := validateToolArguments(tool.InputSchema(), cleanArgs); !ok {
+		invalid.AttemptsLeft = repairs.fail(req.ToolName())
+
+		return yieldToolErrorPayload(ctx, thread, req, invalid, yield)
+	}
+
+	repairs.succeed(req.ToolName())
+
+	result, ok := u.callToolCached(ctx, thread, tool, cleanArgs, req, yield)
+	if !ok {
+		return false
+	} else if result == nil {
+		return true
+	}
+
+	result, err = u.shortenToolResult(ctx, thread.ThreadID(), result)
+	if err != nil {
+		yield(nil, fmt.Errorf("shortening links in tool result: %w", err))
+		return false
 	}
 
 	if err := thread.AcceptToolResult(ctx, result); err != nil {
@@ -434,6 +551,174 @@ func (u *Usecase) executeTool(
 	return yield(result, nil)
 }
 
+// toolAccountActive checks, that account of the tool is still active: tool
+// stays in relevant tools of the thread after account was disabled or
+// deleted. Returns reason for the model, if tool can't be called.
+func (u *Usecase) toolAccountActive(ctx context.Context, id ids.AccountID) (string, bool) {
+	account, err := u.accounts.GetAccount(ctx, id)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("getting account: %v", err), false
+	case account.Status() != entities.AccountStatusActive:
+		return fmt.Sprintf("account is %v", account.Status()), false
+	default:
+		return "", true
+	}
+}
+
+// callToolCached executes the tool, or reuses its previous result, if tool is
+// cacheable. Returns nil result, if execution error was already passed to the
+// model.
+//
+//nolint:ireturn // tool can return both response and error.
+func (u *Usecase) callToolCached(
+	ctx context.Context,
+	thread *chat.Chat,
+	tool entities.ToolReadOnly,
+	args map[string]json.RawMessage,
+	req messages.MessageToolRequest,
+	yield func(messages.Message, error) bool,
+) (messages.MessageTool, bool) {
+	ttl := u.toolCacheTTL(tool)
+
+	var key tools.ResultKey
+	if ttl > 0 {
+		var err error
+		if key, err = tools.NewResultKey(tool.ID(), args); err != nil {
+			yield(nil, fmt.Errorf("building cache key: %w", err))
+			return nil, false
+		}
+
+		if cached, ok := u.cachedToolResult(ctx, key, req); ok {
+			return cached, true
+		}
+	}
+
+	// offloaded outputs are bound to the thread, so they are not cached.
+	var offloaded bool
+
+	var opts []toolclient.ExecuteToolOption
+	if u.blobs != nil {
+		offload := u.offloadOutput(thread.ThreadID())
+		opts = append(opts, toolclient.WithOversizeHandler(
+			func(ctx context.Context, content json.RawMessage) (json.RawMessage, error) {
+				offloaded = true
+				return offload(ctx, content)
+			},
+		))
+	}
+
+	call, ok := u.callToolWithProgress(
+		ctx, thread.ThreadID(), tool, args, req.ToolCallID(), yield, opts...,
+	)
+	if !ok {
+		return nil, false
+	}
+
+	if call.err != nil {
+		return nil, yieldToolError(
+			ctx, thread, req, fmt.Sprintf("Execution failed: %v", call.err), yield,
+		)
+	}
+
+	if ttl > 0 && !offloaded {
+		u.cacheToolResult(ctx, key, call.result, ttl)
+	}
+
+	return call.result, true
+}
+
+// executeBuiltinTool handles tools, which are executed by cynosure itself.
+func (u *Usecase) executeBuiltinTool(
+	ctx context.Context,
+	thread *chat.Chat,
+	repairs *toolRepairs,
+	tool tools.RawTool,
+	req messages.MessageToolRequest,
+	yield func(messages.Message, error) bool,
+) bool {
+	if invalid, ok := validateToolArguments(tool.Params(), req.Arguments()); !ok {
+		invalid.AttemptsLeft = repairs.fail(req.ToolName())
+
+		return yieldToolErrorPayload(ctx, thread, req, invalid, yield)
+	}
+
+	repairs.succeed(req.ToolName())
+
+	switch tool.Name() {
+	case readToolOutputName:
+		return u.executeReadToolOutput(ctx, thread, req, yield)
+	case listResourcesName:
+		return u.executeListResources(ctx, thread, req, yield)
+	case readResourceName:
+		return u.executeReadResource(ctx, thread, req, yield)
+	default:
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Tool not found: %v", tool.Name()), yield)
+	}
+}
+
+type toolCall struct {
+	result messages.MessageTool
+	err    error
+}
+
+// callToolWithProgress executes tool in background, and relays its progress
+// notifications to the caller. Progress is yielded from the iterator
+// goroutine, so consumer never receives concurrent calls. Input requests of
+// the server are answered from the same goroutine, while tool call is
+// suspended.
+//
+// If consumer stops iteration, tool call is cancelled.
+func (u *Usecase) callToolWithProgress(
+	ctx context.Context,
+	thread ids.ThreadID,
+	tool entities.ToolReadOnly,
+	args map[string]json.RawMessage,
+	toolCallID string,
+	yield func(messages.Message, error) bool,
+	opts ...toolclient.ExecuteToolOption,
+) (toolCall, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progress := make(chan messages.MessageToolProgress, progressBufferSize)
+	elicits := make(chan elicitCall)
+	done := make(chan toolCall, 1)
+
+	elicit := u.elicitation.get()
+	if elicit != nil {
+		opts = append(opts, elicitationOption(ctx, elicits))
+	}
+
+	go func() {
+		result, err := u.tools.ExecuteTool(ctx, tool, args, toolCallID,
+			append(opts, toolclient.WithProgressHandler(func(p messages.MessageToolProgress) {
+				select {
+				case progress <- p:
+				default: // progress is best-effort, slow consumer just misses it.
+				}
+			}))...,
+		)
+		done <- toolCall{result: result, err: err}
+	}()
+
+	for {
+		select {
+		case p := <-progress:
+			if !yield(p, nil) {
+				cancel()
+				<-done
+
+				return toolCall{result: nil, err: nil}, false
+			}
+		case call := <-elicits:
+			u.answerElicitation(ctx, thread, tool.Name(), elicit, call)
+		case call := <-done:
+			return call, true
+		}
+	}
+}
+
 func yieldToolError(
 	ctx context.Context,
 	thread *chat.Chat,
@@ -441,7 +726,17 @@ func yieldToolError(
 	errMsg string,
 	yield func(messages.Message, error) bool,
 ) bool {
-	content, err := json.Marshal(map[string]string{"error": errMsg})
+	return yieldToolErrorPayload(ctx, thread, req, map[string]string{"error": errMsg}, yield)
+}
+
+func yieldToolErrorPayload(
+	ctx context.Context,
+	thread *chat.Chat,
+	req messages.MessageToolRequest,
+	payload any,
+	yield func(messages.Message, error) bool,
+) bool {
+	content, err := json.Marshal(payload)
 	if err != nil {
 		yield(nil, fmt.Errorf("building tool error json: %w", err))
 		return false
@@ -464,18 +759,3 @@ func yieldToolError(
 
 	return yield(toolErr, nil)
 }
-
-func (u *Usecase) yieldRateLimitError(err error) (iter.Seq2[messages.Message, error], error) {
-	return func(yield func(messages.Message, error) bool) {
-		errorMsg, msgErr := messages.NewMessageAssistant(
-			"I apologize, but you have reached your message rate limit. " +
-				"Please wait a moment before sending another message.",
-		)
-		if msgErr != nil {
-			yield(nil, fmt.Errorf("creating rate limit error message: %w", msgErr))
-			return
-		}
-
-		yield(errorMsg, nil)
-	}, nil
-}
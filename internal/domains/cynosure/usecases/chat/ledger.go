@@ -0,0 +1,50 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+// recordTurn appends turn to the usage ledger. Failed record doesn't stop the
+// response, it's only logged.
+func (u *Usecase) recordTurn(
+	ctx context.Context,
+	thread ids.ThreadID,
+	config entities.AgentReadOnly,
+	usage chatmodel.UsageStats,
+	toolCalls, failures uint64,
+) {
+	if u.ledger == nil {
+		return
+	}
+
+	entry := ledger.NewEntry(thread, config.ID(), config.Model(), u.obs.now(), ledger.Stats{
+		InputTokens:  uint64(usage.InputTokens),
+		OutputTokens: uint64(usage.OutputTokens),
+		Duration:     usage.Duration,
+		ToolCalls:    toolCalls,
+		Errors:       failures,
+	})
+
+	if err := u.ledger.AddEntry(ctx, entry); err != nil {
+		u.obs.ledgerNotSaved(ctx, thread.String(), err)
+	}
+}
+
+// countFailures counts errors and failed tool calls, passed to the consumer.
+func countFailures(
+	failures *uint64, yield func(messages.Message, error) bool,
+) func(messages.Message, error) bool {
+	return func(msg messages.Message, err error) bool {
+		if _, ok := msg.(messages.MessageToolError); ok || err != nil {
+			*failures++
+		}
+
+		return yield(msg, err)
+	}
+}
@@ -0,0 +1,300 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+)
+
+const (
+	linkScheme = "link://"
+	// urls shorter than this are left as is: model copies them reliably, and
+	// they often carry useful context, like domain name.
+	minShortenedLength = 40
+	// characters, which are usually punctuation around the url, not its part.
+	urlTrailingPunctuation = ".,;:!?)]}"
+	// stale links are deleted this many times per link ttl.
+	linkCleanupFactor = 2
+)
+
+var (
+	urlPattern  = regexp.MustCompile(`https?://[^\s"'<>` + "`" + `]+`)
+	linkPattern = regexp.MustCompile(
+		regexp.QuoteMeta(linkScheme) + fmt.Sprintf(`[a-z2-7]{%d}`, ids.LinkTokenLength),
+	)
+)
+
+// ResolveLink returns original url of the link reference, which was shown to
+// the user.
+//
+// Throws:
+//
+//   - [ErrLinkNotFound] if token is unknown.
+func (u *Usecase) ResolveLink(ctx context.Context, link ids.LinkID) (*url.URL, error) {
+	if u.links == nil {
+		return nil, ErrLinkNotFound
+	}
+
+	target, err := u.links.GetLink(ctx, link)
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil, ErrLinkNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("getting link: %w", err)
+	}
+
+	return target, nil
+}
+
+// CleanupLinks periodically deletes links, which were not used for link ttl.
+// Blocks until ctx is canceled. Does nothing, if links are disabled or kept
+// forever.
+func (u *Usecase) CleanupLinks(ctx context.Context) error {
+	if u.links == nil || u.linkTTL <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(u.linkTTL / linkCleanupFactor)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// cleanup only frees storage, links are deleted on next start.
+			return nil
+
+		case <-ticker.C:
+			if err := u.links.DeleteLinksBefore(ctx, u.obs.now().Add(-u.linkTTL)); err != nil {
+				u.obs.linksNotDeleted(ctx, err)
+			}
+		}
+	}
+}
+
+// shortenToolResult replaces long urls in tool response with link references,
+// so model doesn't need to copy them character by character.
+//
+//nolint:ireturn // keeps original message type for errors and other results.
+func (u *Usecase) shortenToolResult(
+	ctx context.Context, thread ids.ThreadID, result messages.MessageTool,
+) (messages.MessageTool, error) {
+	resp, ok := result.(messages.MessageToolResponse)
+	if !ok || u.links == nil || !urlPattern.Match(resp.Content()) {
+		return result, nil
+	}
+
+	// storage keeps the same token for url of the thread, this map only
+	// saves round trips for urls, repeated in the response.
+	saved := make(map[string]ids.LinkID)
+
+	content, err := mapJSONStrings(resp.Content(), func(s string) (string, error) {
+		return u.shortenURLs(ctx, thread, s, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shortened, err := resp.WithContent(content)
+	if err != nil {
+		return nil, fmt.Errorf("replacing tool response: %w", err)
+	}
+
+	return shortened, nil
+}
+
+func (u *Usecase) shortenURLs(
+	ctx context.Context, thread ids.ThreadID, text string, saved map[string]ids.LinkID,
+) (string, error) {
+	var errs []error
+
+	text = urlPattern.ReplaceAllStringFunc(text, func(match string) string {
+		raw := strings.TrimRight(match, urlTrailingPunctuation)
+		if len(raw) < minShortenedLength {
+			return match
+		}
+
+		link, ok := saved[raw]
+		if !ok {
+			var err error
+			if link, err = u.saveLink(ctx, thread, raw); err != nil {
+				errs = append(errs, err)
+				return match
+			}
+
+			saved[raw] = link
+		}
+
+		if !link.Valid() {
+			return match
+		}
+
+		return linkScheme + link.String() + match[len(raw):]
+	})
+
+	return text, errors.Join(errs...)
+}
+
+// saveLink returns token of the url in the thread: the one, saved in previous
+// turns, or a new random one. Returns invalid id, if url can't be shortened:
+// it's malformed, or new token is already taken.
+func (u *Usecase) saveLink(ctx context.Context, thread ids.ThreadID, raw string) (ids.LinkID, error) {
+	target, err := url.Parse(raw)
+	if err != nil {
+		return ids.LinkID{}, nil //nolint:nilerr // malformed urls are kept as is.
+	}
+
+	link, err := u.links.SaveLink(ctx, ids.RandomLinkID(), thread, target)
+	if errors.Is(err, ports.ErrAlreadyExists) {
+		return ids.LinkID{}, nil
+	} else if err != nil {
+		return ids.LinkID{}, fmt.Errorf("saving link: %w", err)
+	}
+
+	return link, nil
+}
+
+// expandArguments replaces link references in tool arguments with original
+// urls, so tools receive real addresses.
+func (u *Usecase) expandArguments(
+	ctx context.Context, args map[string]json.RawMessage,
+) (map[string]json.RawMessage, error) {
+	if u.links == nil {
+		return args, nil
+	}
+
+	expanded := make(map[string]json.RawMessage, len(args))
+
+	for name, value := range args {
+		if !linkPattern.Match(value) {
+			expanded[name] = value
+			continue
+		}
+
+		value, err := mapJSONStrings(value, func(s string) (string, error) {
+			return u.expandLinks(ctx, s, nil)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("expanding argument %q: %w", name, err)
+		}
+
+		expanded[name] = value
+	}
+
+	return expanded, nil
+}
+
+// expandAssistantLinks replaces link references in the text, which is shown
+// to the user. If redirect address is configured, references point to it,
+// otherwise original urls are used.
+func (u *Usecase) expandAssistantLinks(
+	ctx context.Context, msg messages.MessageAssistant,
+) (messages.MessageAssistant, error) {
+	if u.links == nil || !linkPattern.MatchString(msg.Content()) {
+		return msg, nil
+	}
+
+	text, err := u.expandLinks(ctx, msg.Content(), u.linkRedirect)
+	if err != nil {
+		return messages.MessageAssistant{}, err
+	}
+
+	expanded, err := msg.WithContent(text)
+	if errors.Is(err, messages.ErrMessageTooLarge) {
+		// expanded urls don't fit into the message, references are still
+		// better, than nothing.
+		return msg, nil
+	} else if err != nil {
+		return messages.MessageAssistant{}, fmt.Errorf("replacing assistant message: %w", err)
+	}
+
+	return expanded, nil
+}
+
+// expandLinks replaces known link references in the text. Unknown references
+// are kept as is: model could make them up.
+func (u *Usecase) expandLinks(ctx context.Context, text string, redirect *url.URL) (string, error) {
+	var errs []error
+
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		link, err := ids.NewLinkID(strings.TrimPrefix(match, linkScheme))
+		if err != nil {
+			return match
+		}
+
+		target, err := u.links.GetLink(ctx, link)
+		if errors.Is(err, ports.ErrNotFound) {
+			return match
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("getting link: %w", err))
+			return match
+		}
+
+		if redirect != nil {
+			return redirect.JoinPath(link.String()).String()
+		}
+
+		return target.String()
+	})
+
+	return text, errors.Join(errs...)
+}
+
+// mapJSONStrings applies f to every string value in the json document. Object
+// keys are not changed.
+func mapJSONStrings(data json.RawMessage, f func(string) (string, error)) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("decoding json: %w", err)
+	}
+
+	value, err := mapStrings(value, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("encoding json: %w", err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func mapStrings(value any, f func(string) (string, error)) (any, error) {
+	var err error
+
+	switch v := value.(type) {
+	case string:
+		return f(v)
+	case []any:
+		for i := range v {
+			if v[i], err = mapStrings(v[i], f); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]any:
+		for key := range v {
+			if v[key], err = mapStrings(v[key], f); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return value, nil
+}
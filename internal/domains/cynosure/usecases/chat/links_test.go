@@ -0,0 +1,215 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+const longURL = "https://example.com/reports/2026/quarterly/summary.pdf?download=true"
+
+var linkRef = regexp.MustCompile(`link://([a-z2-7]+)`)
+
+func TestLinkReferences(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("fetch", `{"type":"object"}`)
+	f.tool("open", `{"type":"object","properties":{"url":{"type":"string"}}}`)
+
+	var opened string
+
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, tool entities.ToolReadOnly, args map[string]json.RawMessage,
+			callID string, _ ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			content := json.RawMessage(`{"url":"` + longURL + `","short":"https://example.com"}`)
+			if tool.Name() == "open" {
+				require.NoError(t, json.Unmarshal(args["url"], &opened))
+				content = json.RawMessage(`{"opened":true}`)
+			}
+
+			return messages.NewMessageToolResponse(content, tool.Name(), callID)
+		})
+
+	links := newMemoryLinks()
+	redirect := must(url.Parse("https://cynosure.example/l"))
+	u := f.usecase(chat.WithLinkStorage(links, 0), chat.WithLinkRedirect(redirect))
+
+	f.model.script(callTool("fetch", "call-1", `{}`), answer("Fetched."))
+
+	results := toolResults(f.respond(u, "fetch the report"))
+	require.Len(t, results, 1)
+
+	content := string(results[0].Content())
+	assert.NotContains(t, content, longURL, "model must receive reference instead of long url")
+	assert.Contains(t, content, `"https://example.com"`, "short urls are kept")
+
+	match := linkRef.FindStringSubmatch(content)
+	require.NotNil(t, match)
+
+	ref, token := match[0], match[1]
+
+	f.model.script(
+		callTool("open", "call-2", `{"url":"`+ref+`"}`),
+		answer("Here is the report: "+ref+"."),
+	)
+
+	msgs := f.respond(u, "open it")
+	assert.Equal(t, longURL, opened, "tool must receive original url")
+
+	last, ok := msgs[len(msgs)-1].(messages.MessageAssistant)
+	require.True(t, ok)
+	assert.Equal(t, "Here is the report: https://cynosure.example/l/"+token+".", last.Content())
+
+	// history keeps references, so model sees the same text, it wrote.
+	history := f.thread.Messages(0)
+	stored, ok := history[len(history)-1].(messages.MessageAssistant)
+	require.True(t, ok)
+	assert.Contains(t, stored.Content(), ref)
+
+	target, err := u.ResolveLink(t.Context(), must(ids.NewLinkID(token)))
+	require.NoError(t, err)
+	assert.Equal(t, longURL, target.String())
+}
+
+func TestLinkTokens(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("fetch", `{"type":"object"}`)
+
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, tool entities.ToolReadOnly, _ map[string]json.RawMessage,
+			callID string, _ ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			content := json.RawMessage(`{"url":"` + longURL + `","mirror":"` + longURL + `"}`)
+
+			return messages.NewMessageToolResponse(content, tool.Name(), callID)
+		})
+
+	links := newMemoryLinks()
+	u := f.usecase(chat.WithLinkStorage(links, 0))
+
+	var tokens []string
+
+	for _, call := range []string{"call-1", "call-2"} {
+		f.model.script(callTool("fetch", call, `{}`), answer("Fetched."))
+
+		results := toolResults(f.respond(u, "fetch the report"))
+		require.NotEmpty(t, results)
+
+		matches := linkRef.FindAllStringSubmatch(string(results[len(results)-1].Content()), -1)
+		require.Len(t, matches, 2)
+		assert.Equal(t, matches[0][1], matches[1][1], "same url in one result reuses token")
+		assert.Len(t, matches[0][1], ids.LinkTokenLength)
+
+		tokens = append(tokens, matches[0][1])
+	}
+
+	assert.Equal(t, tokens[0], tokens[1], "url keeps its token in the thread across turns")
+	assert.Len(t, links.links, 1)
+}
+
+func TestLinkUnknownReferences(t *testing.T) {
+	f := newChatFixture(t)
+
+	redirect := must(url.Parse("https://cynosure.example/l"))
+	u := f.usecase(chat.WithLinkStorage(newMemoryLinks(), 0), chat.WithLinkRedirect(redirect))
+
+	madeUp := "link://" + strings.Repeat("a", ids.LinkTokenLength)
+	f.model.script(answer("Here is the report: " + madeUp + "."))
+
+	msgs := f.respond(u, "where is the report?")
+
+	last, ok := msgs[len(msgs)-1].(messages.MessageAssistant)
+	require.True(t, ok)
+	assert.Equal(t, "Here is the report: "+madeUp+".", last.Content(), "unknown references are kept")
+
+	_, err := u.ResolveLink(t.Context(), ids.RandomLinkID())
+	require.ErrorIs(t, err, chat.ErrLinkNotFound)
+
+	_, err = f.usecase().ResolveLink(t.Context(), ids.RandomLinkID())
+	require.ErrorIs(t, err, chat.ErrLinkNotFound, "links are not resolved without storage")
+}
+
+// memoryLinks is a link storage, which keeps links in memory.
+type memoryLinks struct {
+	links map[ids.LinkID]memoryLink
+	mu    sync.Mutex
+}
+
+type memoryLink struct {
+	thread ids.ThreadID
+	target *url.URL
+	usedAt time.Time
+}
+
+var _ ports.LinkStorage = (*memoryLinks)(nil)
+
+func newMemoryLinks() *memoryLinks {
+	return &memoryLinks{links: make(map[ids.LinkID]memoryLink), mu: sync.Mutex{}}
+}
+
+func (m *memoryLinks) SaveLink(
+	_ context.Context, link ids.LinkID, thread ids.ThreadID, target *url.URL,
+) (ids.LinkID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, saved := range m.links {
+		if saved.thread == thread && saved.target.String() == target.String() {
+			saved.usedAt = time.Now()
+			m.links[id] = saved
+
+			return id, nil
+		}
+	}
+
+	if _, ok := m.links[link]; ok {
+		return ids.LinkID{}, ports.ErrAlreadyExists
+	}
+
+	m.links[link] = memoryLink{thread: thread, target: target, usedAt: time.Now()}
+
+	return link, nil
+}
+
+func (m *memoryLinks) GetLink(_ context.Context, link ids.LinkID) (*url.URL, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	saved, ok := m.links[link]
+	if !ok {
+		return nil, ports.ErrNotFound
+	}
+
+	return saved.target, nil
+}
+
+func (m *memoryLinks) DeleteLinksBefore(_ context.Context, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, saved := range m.links {
+		if saved.usedAt.Before(before) {
+			delete(m.links, id)
+		}
+	}
+
+	return nil
+}
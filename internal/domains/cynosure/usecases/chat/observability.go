@@ -16,8 +16,19 @@ import (
 )
 
 const (
-	eventMaxTurnsReached = "generate.max_turns_reached"
-	eventToolCalled      = "generate.tool_called"
+	eventMaxTurnsReached     = "generate.max_turns_reached"
+	eventToolCalled          = "generate.tool_called"
+	eventToolOutputOffloaded = "generate.tool_output_offloaded"
+	eventToolResultCached    = "generate.tool_result_cached"
+	eventToolCacheFailed     = "generate.tool_cache_failed"
+	eventBudgetExceeded      = "generate.budget_exceeded"
+	eventUsageNotSaved       = "generate.usage_not_saved"
+	eventLedgerNotSaved      = "generate.ledger_not_saved"
+	eventTokenLimitExceeded  = "generate.token_limit_exceeded"
+	eventTokensNotSettled    = "generate.tokens_not_settled"
+	eventSamplingRejected    = "sampling.rejected"
+	eventElicitationAnswered = "elicitation.answered"
+	eventLinksNotDeleted     = "links.not_deleted"
 )
 
 type observable struct {
@@ -63,7 +74,7 @@ func (o *observable) maxTurnsReached(ctx context.Context, threadID string) {
 		Context(
 			attribute.Key("thread_id").String(threadID),
 		).
-		Msg("Model reached max turns with tool calls, consider adjusting settings")
+		Msg("Model reached max turns with tool calls, thread is paused")
 }
 
 func (o *observable) toolCalled(
@@ -82,6 +93,109 @@ func (o *observable) toolCalled(
 		Msg("Tool called during generation")
 }
 
+func (o *observable) toolOutputOffloaded(ctx context.Context, ref string, size int) {
+	o.event(ctx, log.SeverityInfo, eventToolOutputOffloaded).
+		Context(
+			attribute.Key("ref").String(ref),
+			attribute.Key("size").Int(size),
+		).
+		Msg("Tool output is too large, stored in blob storage")
+}
+
+func (o *observable) toolResultCached(ctx context.Context, toolName string, age time.Duration) {
+	o.event(ctx, log.SeverityInfo, eventToolResultCached).
+		Context(
+			attribute.Key("tool_name").String(toolName),
+			attribute.Key("age").String(age.String()),
+		).
+		Msg("Tool result is taken from cache")
+}
+
+func (o *observable) toolCacheFailed(ctx context.Context, toolName string, err error) {
+	o.event(ctx, log.SeverityWarn, eventToolCacheFailed).
+		Context(
+			attribute.Key("tool_name").String(toolName),
+			attribute.Key("error").String(err.Error()),
+		).
+		Msg("Tool result cache is unavailable, calling tool directly")
+}
+
+func (o *observable) budgetExceeded(
+	ctx context.Context, threadID, period, metric string, agent bool,
+) {
+	o.event(ctx, log.SeverityWarn, eventBudgetExceeded).
+		Context(
+			attribute.Key("thread_id").String(threadID),
+			attribute.Key("period").String(period),
+			attribute.Key("metric").String(metric),
+			attribute.Key("agent_budget").Bool(agent),
+		).
+		Msg("Budget is exhausted, response is stopped")
+}
+
+func (o *observable) usageNotSaved(ctx context.Context, agentID string, err error) {
+	o.event(ctx, log.SeverityWarn, eventUsageNotSaved).
+		Context(
+			attribute.Key("agent_id").String(agentID),
+			attribute.Key("error").String(err.Error()),
+		).
+		Msg("Failed to save token usage, budgets may be exceeded")
+}
+
+func (o *observable) ledgerNotSaved(ctx context.Context, threadID string, err error) {
+	o.event(ctx, log.SeverityWarn, eventLedgerNotSaved).
+		Context(
+			attribute.Key("thread_id").String(threadID),
+			attribute.Key("error").String(err.Error()),
+		).
+		Msg("Failed to record turn in usage ledger")
+}
+
+func (o *observable) tokenLimitExceeded(ctx context.Context, threadID string, estimate int) {
+	o.event(ctx, log.SeverityWarn, eventTokenLimitExceeded).
+		Context(
+			attribute.Key("thread_id").String(threadID),
+			attribute.Key("estimate").Int(estimate),
+		).
+		Msg("Token rate limit is exhausted, response is stopped")
+}
+
+func (o *observable) tokensNotSettled(ctx context.Context, userID string, err error) {
+	o.event(ctx, log.SeverityWarn, eventTokensNotSettled).
+		Context(
+			attribute.Key("user_id").String(userID),
+			attribute.Key("error").String(err.Error()),
+		).
+		Msg("Failed to settle reserved tokens, token rate limit may be inaccurate")
+}
+
+func (o *observable) samplingRejected(ctx context.Context, accountID, reason string) {
+	o.event(ctx, log.SeverityInfo, eventSamplingRejected).
+		Context(
+			attribute.Key("account_id").String(accountID),
+			attribute.Key("reason").String(reason),
+		).
+		Msg("Sampling request of MCP server is rejected")
+}
+
+func (o *observable) linksNotDeleted(ctx context.Context, err error) {
+	o.event(ctx, log.SeverityWarn, eventLinksNotDeleted).
+		Context(
+			attribute.Key("error").String(err.Error()),
+		).
+		Msg("Failed to delete stale link references")
+}
+
+func (o *observable) elicitationAnswered(ctx context.Context, threadID, toolName, action string) {
+	o.event(ctx, log.SeverityInfo, eventElicitationAnswered).
+		Context(
+			attribute.Key("thread_id").String(threadID),
+			attribute.Key("tool_name").String(toolName),
+			attribute.Key("action").String(action),
+		).
+		Msg("User answered input request of MCP server")
+}
+
 // metric callbacks
 
 func (o *observable) recordUsage(
@@ -124,6 +238,51 @@ func (o *observable) generateResponse(ctx context.Context) (context.Context, spa
 	return ctx, &spanCallback{span: span}
 }
 
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) continueResponse(ctx context.Context) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.usecases.continue_response",
+		trace.WithSpanKind(trace.SpanKindInternal),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) runPrompt(ctx context.Context, name string) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.usecases.run_prompt",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			semconv.GenAIPromptName(name),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) sample(ctx context.Context, accountID string) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.usecases.sample",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attribute.Key("cynosure.account.id").String(accountID),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
+//nolint:spancheck,ireturn // intentional polymorphism: returns internal span interface
+func (o *observable) elicit(ctx context.Context, toolName string) (context.Context, span) {
+	ctx, span := o.t.Start(ctx, "cynosure.usecases.elicit",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			semconv.GenAIToolName(toolName),
+		),
+	)
+
+	return ctx, &spanCallback{span: span}
+}
+
 type agentLoopCallback interface {
 	span
 
@@ -236,6 +395,10 @@ func (e *eventBuilder) Context(attrs ...attribute.KeyValue) *eventBuilder {
 
 func (e *eventBuilder) Msgf(format string, v ...any) { e.Msg(fmt.Sprintf(format, v...)) }
 func (e *eventBuilder) Msg(msg string) {
+	if e == nil {
+		return
+	}
+
 	e.r.SetBody(log.StringValue(msg))
 	e.h.Emit(e.ctx, e.r)
 }
@@ -1,14 +1,20 @@
 package chat
 
 import (
+	"net/url"
+	"time"
+
 	"github.com/quenbyako/core"
 
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
 	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
 )
 
@@ -20,6 +26,102 @@ func WithChatLimit(limit uint) NewOption {
 	return newFunc(func(p *newParams) { p.chatLimit = limit })
 }
 
+// WithToolRepairAttempts sets how many times model can retry the same tool
+// after passing invalid arguments. When attempts are over, tool is rejected
+// until the end of the response.
+func WithToolRepairAttempts(attempts uint8) NewOption {
+	return newFunc(func(p *newParams) { p.repairAttempts = attempts })
+}
+
+// WithBlobStorage enables offloading of too large tool outputs. Model
+// receives truncated preview of such output, and reads the rest of it through
+// built-in read_tool_output tool.
+func WithBlobStorage(storage ports.BlobStorage) NewOption {
+	return newFunc(func(p *newParams) { p.blobs = storage })
+}
+
+// WithResourceStorage enables built-in list_resources and read_resource
+// tools: model lists resources of user accounts from the storage, and reads
+// their content from MCP servers.
+func WithResourceStorage(storage ports.ResourceStorage) NewOption {
+	return newFunc(func(p *newParams) { p.resources = storage })
+}
+
+// WithPromptStorage enables prompts of user accounts: users list them with
+// [Usecase.ListPrompts] and run them with [Usecase.RunPrompt].
+func WithPromptStorage(storage ports.PromptStorage) NewOption {
+	return newFunc(func(p *newParams) { p.prompts = storage })
+}
+
+// WithLinkStorage enables link references: long urls in tool outputs are
+// replaced with short tokens, and tokens are expanded back in tool arguments
+// and assistant messages. Links, which were not used for ttl, are deleted by
+// [Usecase.CleanupLinks]; zero ttl keeps them forever.
+func WithLinkStorage(storage ports.LinkStorage, ttl time.Duration) NewOption {
+	return newFunc(func(p *newParams) {
+		p.links = storage
+		p.linkTTL = ttl
+	})
+}
+
+// WithLinkRedirect sets public address of link redirect endpoint. If set,
+// link references in assistant messages point to this address instead of
+// original urls.
+func WithLinkRedirect(base *url.URL) NewOption {
+	return newFunc(func(p *newParams) { p.linkRedirect = base })
+}
+
+// WithToolResultCache enables caching of tool results. Results of read-only
+// tools are kept for defaultTTL, unless user set another ttl for the tool.
+// Other tools, including idempotent ones, are cached only if user opted in.
+func WithToolResultCache(cache ports.ToolResultCache, defaultTTL time.Duration) NewOption {
+	return newFunc(func(p *newParams) {
+		p.toolCache = cache
+		p.toolCacheTTL = defaultTTL
+	})
+}
+
+// WithBudgets enables token and cost budgets. Usage of each turn is priced
+// with the given table and saved to the storage. userLimits are applied to
+// every user, agent limits are taken from agent settings. When any budget is
+// exhausted, response is stopped with explanation for the user.
+func WithBudgets(
+	storage ports.UsageStorage, prices budget.PriceTable, userLimits budget.Limits,
+) NewOption {
+	return newFunc(func(p *newParams) {
+		p.usage = storage
+		p.prices = prices
+		p.userBudget = userLimits
+	})
+}
+
+// WithLedger enables usage ledger: every agent turn is recorded with spent
+// tokens, time, tool calls and errors.
+func WithLedger(storage ports.LedgerStorage) NewOption {
+	return newFunc(func(p *newParams) { p.ledger = storage })
+}
+
+// WithPlans enables rate limit plans: user messages and model tokens are
+// limited by the plan, assigned to the user in the storage. Users without
+// assigned plan, or with plan missing in the catalog, use the default one.
+func WithPlans(storage ports.PlanStorage, catalog plans.Catalog) NewOption {
+	return newFunc(func(p *newParams) {
+		p.planStorage = storage
+		p.plans = catalog
+	})
+}
+
+// WithSampling enables completions, requested by MCP servers: see
+// [Usecase.Sample]. Policy decides, which servers are approved, and quota
+// limits requests of every account separately. Sampling is disabled without
+// it.
+func WithSampling(policy sampling.Policy, quota plans.Limit) NewOption {
+	return newFunc(func(p *newParams) {
+		p.samplingPolicy = policy
+		p.samplingQuota = quota
+	})
+}
+
 func WithToolChoice(toolChoice tools.ToolChoice) GenerateResponseOption {
 	return generateResponseFunc(func(params *generateResponseParams) {
 		params.toolChoice = toolChoice
@@ -66,8 +168,25 @@ type newRequiredParams struct {
 
 type newParams struct {
 	newRequiredParams
-	obs       core.Metrics
-	chatLimit uint
+	obs            core.Metrics
+	chatLimit      uint
+	repairAttempts uint8
+	blobs          ports.BlobStorage
+	links          ports.LinkStorage
+	linkTTL        time.Duration
+	resources      ports.ResourceStorage
+	prompts        ports.PromptStorage
+	linkRedirect   *url.URL
+	toolCache      ports.ToolResultCache
+	toolCacheTTL   time.Duration
+	usage          ports.UsageStorage
+	prices         budget.PriceTable
+	userBudget     budget.Limits
+	ledger         ports.LedgerStorage
+	planStorage    ports.PlanStorage
+	plans          plans.Catalog
+	samplingPolicy sampling.Policy
+	samplingQuota  plans.Limit
 }
 
 func buildNewParams(
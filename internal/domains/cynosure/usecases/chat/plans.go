@@ -0,0 +1,63 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+)
+
+// userPlan returns plan, assigned to the user. Users without assigned plan
+// are using the default one.
+func (u *Usecase) userPlan(ctx context.Context, user ids.UserID) (plans.Plan, error) {
+	if u.planStorage == nil {
+		return u.plans.Default(), nil
+	}
+
+	name, err := u.planStorage.UserPlan(ctx, user)
+	switch {
+	case errors.Is(err, ports.ErrNotFound):
+		return u.plans.Default(), nil
+	case err != nil:
+		return plans.Plan{}, fmt.Errorf("getting user plan: %w", err)
+	default:
+		return u.plans.Resolve(name), nil
+	}
+}
+
+// allowLimits consumes single message from user quota.
+//
+// Throws:
+//   - [RateLimitError] if message quota is exhausted.
+func (u *Usecase) allowLimits(ctx context.Context, user ids.UserID) error {
+	plan, err := u.userPlan(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.limiter.Consume(ctx, user, plan.Messages(), 1); err != nil {
+		return rateLimitError(plan, LimitMessages, err)
+	}
+
+	return nil
+}
+
+// rateLimitError converts limiter error to [RateLimitError], keeping other
+// errors as is.
+func rateLimitError(plan plans.Plan, kind LimitKind, err error) error {
+	var exceeded *ratelimiter.LimitExceededError
+	if !errors.As(err, &exceeded) {
+		return fmt.Errorf("checking rate limit: %w", err)
+	}
+
+	return &RateLimitError{
+		Plan:       plan.Name(),
+		Kind:       kind,
+		Remaining:  exceeded.Remaining,
+		RetryAfter: exceeded.RetryAfter,
+	}
+}
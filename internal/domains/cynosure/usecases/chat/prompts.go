@@ -0,0 +1,133 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/prompts"
+)
+
+// ListPrompts returns prompts, which user can run: prompts of all active
+// accounts of the user. Empty list is returned, if prompts are not
+// configured.
+func (u *Usecase) ListPrompts(ctx context.Context, user ids.UserID) ([]prompts.Prompt, error) {
+	if u.prompts == nil {
+		return []prompts.Prompt{}, nil
+	}
+
+	list, err := u.prompts.ListPrompts(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("listing prompts: %w", err)
+	}
+
+	return list, nil
+}
+
+// RunPrompt renders prompt of the user account with given arguments, and
+// answers it as a regular user message: rendered text is saved to the thread
+// and agent loop is started, same as [Usecase.GenerateResponse] does.
+//
+// Throws:
+//   - [ErrPromptNotFound] if prompt is not exposed by active accounts of the
+//     thread owner.
+//   - [prompts.ErrMissingArgument] if required argument is not set.
+//   - [prompts.ErrUnknownArgument] if argument is not declared by prompt.
+//   - [toolclient.ErrPromptRejected] if server rejected the prompt.
+//   - [ErrEmptyPrompt] if rendered prompt has no text.
+//   - [messages.ErrMessageTooLarge] if rendered prompt is too large.
+//   - [RateLimitError] if message quota of user plan is exhausted.
+//   - [ErrAgentDisabled] if agent of the thread is disabled.
+func (u *Usecase) RunPrompt(
+	ctx context.Context,
+	threadID ids.ThreadID,
+	account ids.AccountID,
+	name string,
+	args map[string]string,
+	opts ...GenerateResponseOption,
+) (iter.Seq2[messages.Message, error], error) {
+	ctx, span := u.obs.runPrompt(ctx, name)
+	defer span.end()
+
+	if !threadID.Valid() {
+		return nil, errInternalValidation("thread id is required")
+	}
+
+	prompt, err := u.findPrompt(ctx, threadID.User(), account, name)
+	if err != nil {
+		span.recordError(err)
+		return nil, err
+	}
+
+	if err := prompt.CheckArguments(args); err != nil {
+		return nil, fmt.Errorf("checking arguments of %q: %w", name, err)
+	}
+
+	rendered, err := u.tools.GetPrompt(ctx, account, name, args)
+	if err != nil {
+		span.recordError(err)
+		return nil, fmt.Errorf("rendering prompt %q: %w", name, err)
+	}
+
+	text := renderPrompt(rendered)
+	if text == "" {
+		return nil, ErrEmptyPrompt
+	}
+
+	msg, err := messages.NewMessageUser(text)
+	if err != nil {
+		return nil, fmt.Errorf("making user message: %w", err)
+	}
+
+	return u.GenerateResponse(ctx, threadID, msg, opts...)
+}
+
+// findPrompt looks for the prompt in the storage, not on the server: it
+// guarantees, that account belongs to the user and is active.
+func (u *Usecase) findPrompt(
+	ctx context.Context, user ids.UserID, account ids.AccountID, name string,
+) (prompts.Prompt, error) {
+	list, err := u.ListPrompts(ctx, user)
+	if err != nil {
+		return prompts.Prompt{}, err
+	}
+
+	for _, prompt := range list {
+		if prompt.Account() == account && prompt.Name() == name {
+			return prompt, nil
+		}
+	}
+
+	return prompts.Prompt{}, ErrPromptNotFound
+}
+
+// renderPrompt joins prompt messages into one user message. Thread history
+// can't start with assistant messages, which user didn't see, so if prompt
+// contains them, every message is labeled with its role, and model receives
+// the whole dialogue as an example.
+func renderPrompt(list []prompts.Message) string {
+	labeled := slices.ContainsFunc(list, func(msg prompts.Message) bool {
+		return msg.Role() != prompts.RoleUser
+	})
+
+	parts := make([]string, 0, len(list))
+
+	for _, msg := range list {
+		text := strings.TrimSpace(msg.Text())
+		if text == "" {
+			continue
+		}
+
+		if labeled {
+			text = fmt.Sprintf("[%v]\n%s", msg.Role(), text)
+		}
+
+		parts = append(parts, text)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,314 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/aggregates/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/resources"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+const (
+	listResourcesName = "list_resources"
+	listResourcesDesc = "Lists resources (files, documents, records), exposed by connected MCP " +
+		"servers. Use account and uri of the resource to read it with " + readResourceName + "."
+
+	readResourceName = "read_resource"
+	readResourceDesc = "Reads content of the resource, returned by " + listResourcesName + "."
+
+	// resources list must fit into tool message, long lists are truncated.
+	maxListedResources = 50
+)
+
+const (
+	listResourcesParams = `{
+		"type": "object",
+		"properties": {}
+	}`
+	listResourcesResponse = `{
+		"type": "object",
+		"properties": {
+			"resources": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"account": {"type": "string"},
+						"uri": {"type": "string"},
+						"name": {"type": "string"},
+						"title": {"type": "string"},
+						"description": {"type": "string"},
+						"mime_type": {"type": "string"},
+						"size": {"type": "integer"}
+					}
+				}
+			},
+			"truncated": {"type": "boolean"}
+		}
+	}`
+	readResourceParams = `{
+		"type": "object",
+		"required": ["account", "uri"],
+		"properties": {
+			"account": {"type": "string", "description": "Account of the resource, as returned by list_resources."},
+			"uri": {"type": "string", "description": "URI of the resource."}
+		}
+	}`
+	readResourceResponse = `{
+		"type": "object",
+		"properties": {
+			"contents": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"uri": {"type": "string"},
+						"mime_type": {"type": "string"},
+						"text": {"type": "string"},
+						"blob_size": {"type": "integer"}
+					}
+				}
+			}
+		}
+	}`
+)
+
+// newResourceTools describes built-in tools, which list and read resources
+// of user accounts.
+func newResourceTools() ([]tools.RawTool, error) {
+	res := make([]tools.RawTool, 0, 2)
+
+	for _, tool := range []struct{ name, desc, params, response string }{
+		{listResourcesName, listResourcesDesc, listResourcesParams, listResourcesResponse},
+		{readResourceName, readResourceDesc, readResourceParams, readResourceResponse},
+	} {
+		params, err := tools.NewInputSchema(json.RawMessage(tool.params))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %v params: %w", tool.name, err)
+		}
+
+		response, err := tools.NewOutputSchema(json.RawMessage(tool.response))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %v response: %w", tool.name, err)
+		}
+
+		built, err := tools.NewBuiltinTool(tool.name, tool.desc, params, response)
+		if err != nil {
+			return nil, fmt.Errorf("building %v: %w", tool.name, err)
+		}
+
+		res = append(res, built)
+	}
+
+	return res, nil
+}
+
+type listedResource struct {
+	Account     string `json:"account"`
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mime_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
+
+type resourceList struct {
+	Resources []listedResource `json:"resources"`
+	Truncated bool             `json:"truncated,omitempty"`
+}
+
+// resourceContent is a part of read_resource result. Binary content is not
+// passed to the model, only its size.
+type resourceContent struct {
+	URI      string `json:"uri"`
+	MIMEType string `json:"mime_type,omitempty"`
+	Text     string `json:"text,omitempty"`
+	BlobSize int    `json:"blob_size,omitempty"`
+}
+
+type resourceContents struct {
+	Contents []resourceContent `json:"contents"`
+}
+
+// truncatedResource replaces too large resource content, if it can't be
+// offloaded.
+type truncatedResource struct {
+	Note    string `json:"note"`
+	Preview string `json:"preview"`
+	Size    int    `json:"size"`
+}
+
+type readResourceArgs struct {
+	Account string `json:"account"`
+	URI     string `json:"uri"`
+}
+
+// executeListResources handles built-in list_resources call. Only resources
+// of active accounts of the thread owner are listed.
+func (u *Usecase) executeListResources(
+	ctx context.Context,
+	thread *chat.Chat,
+	req messages.MessageToolRequest,
+	yield func(messages.Message, error) bool,
+) bool {
+	list, err := u.resources.ListResources(ctx, thread.ThreadID().User())
+	if err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Listing resources failed: %v", err), yield)
+	}
+
+	payload := resourceList{
+		Resources: make([]listedResource, 0, min(len(list), maxListedResources)),
+		Truncated: len(list) > maxListedResources,
+	}
+
+	for _, res := range list[:min(len(list), maxListedResources)] {
+		payload.Resources = append(payload.Resources, listedResource{
+			Account:     res.Account().ID().String(),
+			URI:         res.URI(),
+			Name:        res.Name(),
+			Title:       res.Title(),
+			Description: res.Description(),
+			MIMEType:    res.MIMEType(),
+			Size:        res.Size(),
+		})
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		yield(nil, fmt.Errorf("encoding resources list: %w", err))
+		return false
+	}
+
+	return acceptBuiltinResult(ctx, thread, req, content, yield)
+}
+
+// executeReadResource handles built-in read_resource call. Resource must be
+// listed for the thread owner: it guarantees, that account belongs to the
+// user and is active.
+func (u *Usecase) executeReadResource(
+	ctx context.Context,
+	thread *chat.Chat,
+	req messages.MessageToolRequest,
+	yield func(messages.Message, error) bool,
+) bool {
+	var args readResourceArgs
+	if err := decodeArguments(req.Arguments(), &args); err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Invalid arguments: %v", err), yield)
+	}
+
+	account, ok, err := u.findResource(ctx, thread.ThreadID().User(), args)
+	if err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Listing resources failed: %v", err), yield)
+	} else if !ok {
+		return yieldToolError(ctx, thread, req,
+			"Resource not found: call "+listResourcesName+" to see available resources.", yield)
+	}
+
+	contents, err := u.tools.ReadResource(ctx, account, args.URI)
+	if errors.Is(err, toolclient.ErrResourceNotFound) {
+		return yieldToolError(ctx, thread, req, "Resource not found: server doesn't expose it anymore.", yield)
+	} else if err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Reading resource failed: %v", err), yield)
+	}
+
+	content, err := u.encodeResourceContents(ctx, thread.ThreadID(), contents)
+	if err != nil {
+		yield(nil, err)
+		return false
+	}
+
+	return acceptBuiltinResult(ctx, thread, req, content, yield)
+}
+
+func (u *Usecase) findResource(
+	ctx context.Context, user ids.UserID, args readResourceArgs,
+) (ids.AccountID, bool, error) {
+	list, err := u.resources.ListResources(ctx, user)
+	if err != nil {
+		return ids.AccountID{}, false, fmt.Errorf("listing resources: %w", err)
+	}
+
+	for _, res := range list {
+		if res.Account().ID().String() == args.Account && res.URI() == args.URI {
+			return res.Account(), true, nil
+		}
+	}
+
+	return ids.AccountID{}, false, nil
+}
+
+// encodeResourceContents builds read_resource result. Too large content is
+// offloaded, if blob storage is available, otherwise it's truncated.
+func (u *Usecase) encodeResourceContents(
+	ctx context.Context, thread ids.ThreadID, contents []resources.Content,
+) (json.RawMessage, error) {
+	payload := resourceContents{Contents: make([]resourceContent, 0, len(contents))}
+
+	for _, part := range contents {
+		item := resourceContent{
+			URI:      part.URI(),
+			MIMEType: part.MIMEType(),
+			Text:     "",
+			BlobSize: 0,
+		}
+
+		if part.IsBlob() {
+			item.BlobSize = len(part.Blob())
+		} else {
+			item.Text = part.Text()
+		}
+
+		payload.Contents = append(payload.Contents, item)
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding resource contents: %w", err)
+	}
+
+	if len(encoded) <= toolOutputLimit {
+		return encoded, nil
+	}
+
+	if u.blobs != nil {
+		return u.offloadOutput(thread)(ctx, encoded)
+	}
+
+	size := len(encoded)
+
+	return fitOutput(encoded, func(chunk []byte) any {
+		return truncatedResource{
+			Note:    "Resource is too large and was truncated.",
+			Preview: string(chunk),
+			Size:    size,
+		}
+	})
+}
+
+func acceptBuiltinResult(
+	ctx context.Context,
+	thread *chat.Chat,
+	req messages.MessageToolRequest,
+	content json.RawMessage,
+	yield func(messages.Message, error) bool,
+) bool {
+	msg, err := messages.NewMessageToolResponse(content, req.ToolName(), req.ToolCallID())
+	if err != nil {
+		yield(nil, fmt.Errorf("building %v result: %w", req.ToolName(), err))
+		return false
+	}
+
+	if err := thread.AcceptToolResult(ctx, msg); err != nil {
+		yield(nil, fmt.Errorf("saving tool result: %w", err))
+		return false
+	}
+
+	return yield(msg, nil)
+}
@@ -0,0 +1,251 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
+)
+
+const (
+	// sampling doesn't belong to any thread, so ledger entries of every
+	// account are grouped under synthetic thread with this prefix.
+	samplingThreadPrefix = "sampling_"
+	// rough amount of characters in a single token, used to estimate cost
+	// of the request before the model call.
+	charsPerToken = 4
+)
+
+// Sample answers completion, requested by MCP server of the account, with
+// default agent of account owner. Request is allowed only if sampling policy
+// approves the server, and neither quota of the account, nor token quota and
+// budgets of the owner are exhausted. Spent tokens are attributed to the
+// owner: they are taken from owner quota, and recorded in usage and ledger.
+//
+// Throws:
+//   - [toolclient.ErrSamplingRejected] if sampling is disabled, server is not
+//     approved, user didn't allow sampling for the account, account is not
+//     active, or any quota is exhausted.
+//   - [ErrNoAgentsFound] if owner has no enabled agents.
+//   - [ErrEmptyCompletion] if model didn't answer with text.
+func (u *Usecase) Sample(
+	ctx context.Context, account ids.AccountID, req sampling.Request,
+) (sampling.Result, error) {
+	ctx, span := u.obs.sample(ctx, account.ID().String())
+	defer span.end()
+
+	res, err := u.sample(ctx, account, req)
+	if errors.Is(err, toolclient.ErrSamplingRejected) {
+		u.obs.samplingRejected(ctx, account.ID().String(), err.Error())
+	} else if err != nil {
+		span.recordError(err)
+	}
+
+	return res, err
+}
+
+func (u *Usecase) sample(
+	ctx context.Context, account ids.AccountID, req sampling.Request,
+) (sampling.Result, error) {
+	if err := u.approveSampling(ctx, account); err != nil {
+		return sampling.Result{}, err
+	}
+
+	thread, err := ids.NewThreadID(account.User(), samplingThreadPrefix+account.ID().String())
+	if err != nil {
+		return sampling.Result{}, fmt.Errorf("making thread id: %w", err)
+	}
+
+	agentID, err := u.defaultAgentID(ctx, account.User())
+	if err != nil {
+		return sampling.Result{}, err
+	}
+
+	config, err := u.getAgent(ctx, agentID)
+	if err != nil {
+		return sampling.Result{}, err
+	}
+
+	spent, err := u.startBudget(ctx, config)
+	if err != nil {
+		return sampling.Result{}, err
+	}
+
+	if violation, ok := spent.exceeded(); ok {
+		return sampling.Result{}, fmt.Errorf("%w: %s budget of %s is exhausted",
+			toolclient.ErrSamplingRejected, violation.metric, violation.period)
+	}
+
+	tokens, reservation, err := u.reserveSamplingTokens(ctx, account.User(), req)
+	if err != nil {
+		return sampling.Result{}, err
+	}
+
+	text, usage, err := u.complete(ctx, config, req)
+	u.settleTokens(ctx, tokens, reservation, usage)
+	spent.spend(ctx, usage)
+	u.obs.recordUsage(ctx, config.Model(), usage.InputTokens, usage.OutputTokens, usage.Duration)
+
+	if err != nil {
+		u.recordTurn(ctx, thread, config, usage, 0, 1)
+		return sampling.Result{}, err
+	}
+
+	u.recordTurn(ctx, thread, config, usage, 0, 0)
+
+	return sampling.NewResult(text, config.Model()), nil
+}
+
+// approveSampling checks, that sampling policy approves server of the
+// account, or user allowed sampling for the account, and that account didn't
+// exhaust its own quota.
+func (u *Usecase) approveSampling(ctx context.Context, id ids.AccountID) error {
+	if u.samplingPolicy == sampling.PolicyDeny {
+		return fmt.Errorf("%w: sampling is disabled", toolclient.ErrSamplingRejected)
+	}
+
+	account, err := u.accounts.GetAccount(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting account: %w", err)
+	}
+
+	if account.Status() != entities.AccountStatusActive {
+		return fmt.Errorf("%w: account is %v", toolclient.ErrSamplingRejected, account.Status())
+	}
+
+	server, err := u.servers.GetServerInfo(ctx, id.Server())
+	if err != nil {
+		return fmt.Errorf("getting server: %w", err)
+	}
+
+	if !u.samplingPolicy.Approves(server.Internal(), account.SamplingAllowed()) {
+		return fmt.Errorf("%w: server is not approved by %v policy",
+			toolclient.ErrSamplingRejected, u.samplingPolicy)
+	}
+
+	_, err = u.limiter.ConsumeAccount(ctx, id, u.samplingQuota, 1)
+	switch {
+	case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+		return fmt.Errorf("%w: %w", toolclient.ErrSamplingRejected, err)
+	case err != nil:
+		return fmt.Errorf("checking account quota: %w", err)
+	default:
+		return nil
+	}
+}
+
+// reserveSamplingTokens holds the worst cost of the request from owner token
+// quota: requested completion and estimated input.
+func (u *Usecase) reserveSamplingTokens(
+	ctx context.Context, user ids.UserID, req sampling.Request,
+) (*runTokens, ratelimiter.Reservation, error) {
+	plan, err := u.userPlan(ctx, user)
+	if err != nil {
+		return nil, ratelimiter.Reservation{}, err
+	}
+
+	input := len(req.SystemPrompt())
+	for _, msg := range req.Messages() {
+		input += len(messageText(msg))
+	}
+
+	tokens := &runTokens{plan: plan, estimate: req.MaxTokens() + input/charsPerToken}
+
+	reservation, err := u.limiter.Reserve(ctx, user, plan.Tokens(), tokens.estimate)
+	switch {
+	case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+		return nil, ratelimiter.Reservation{}, fmt.Errorf("%w: %w",
+			toolclient.ErrSamplingRejected, rateLimitError(plan, LimitTokens, err))
+	case err != nil:
+		return nil, ratelimiter.Reservation{}, fmt.Errorf("reserving tokens: %w", err)
+	default:
+		return tokens, reservation, nil
+	}
+}
+
+// complete calls the model without tools: server receives only text of the
+// answer. Answer is limited by max tokens of the request, so it never costs
+// more, than was reserved.
+func (u *Usecase) complete(
+	ctx context.Context, config entities.AgentReadOnly, req sampling.Request,
+) (string, chatmodel.UsageStats, error) {
+	history, err := samplingHistory(req)
+	if err != nil {
+		return "", chatmodel.UsageStats{}, err
+	}
+
+	stream, err := u.model.StreamWithStats(ctx, history, config,
+		chatmodel.WithStreamMaxOutputTokens(req.MaxTokens()),
+	)
+	if err != nil {
+		return "", chatmodel.UsageStats{}, fmt.Errorf("calling model stream: %w", err)
+	}
+
+	var text strings.Builder
+
+	for {
+		msg, ok := stream.Next()
+		if !ok {
+			break
+		}
+
+		if chunk, ok := msg.(messages.MessageAssistant); ok {
+			text.WriteString(chunk.Content())
+		}
+	}
+
+	usage, err := stream.Close()
+	if err != nil {
+		return "", usage, fmt.Errorf("closing stream: %w", err)
+	}
+
+	if strings.TrimSpace(text.String()) == "" {
+		return "", usage, ErrEmptyCompletion
+	}
+
+	return text.String(), usage, nil
+}
+
+// samplingHistory passes system prompt of the server as a part of the first
+// user message: agent keeps its own system prompt, and server instructions
+// can't override it.
+func samplingHistory(req sampling.Request) ([]messages.Message, error) {
+	history := req.Messages()
+	if req.SystemPrompt() == "" {
+		return history, nil
+	}
+
+	text := req.SystemPrompt()
+	rest := history
+
+	if first, ok := history[0].(messages.MessageUser); ok {
+		text += "\n\n" + first.Content()
+		rest = history[1:]
+	}
+
+	msg, err := messages.NewMessageUser(text)
+	if err != nil {
+		return nil, fmt.Errorf("making user message: %w", err)
+	}
+
+	return append([]messages.Message{msg}, rest...), nil
+}
+
+func messageText(msg messages.Message) string {
+	switch msg := msg.(type) {
+	case messages.MessageUser:
+		return msg.Content()
+	case messages.MessageAssistant:
+		return msg.Content()
+	default:
+		return ""
+	}
+}
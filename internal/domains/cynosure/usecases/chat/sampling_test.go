@@ -0,0 +1,169 @@
+package chat_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/budget"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/sampling"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+// "What is the capital of France?" costs 7 tokens, so every request reserves
+// 107 tokens.
+const samplingMaxTokens = 100
+
+func TestSample(t *testing.T) {
+	f := newSamplingFixture(t, false, entities.WithSamplingAllowed(true))
+
+	usage := mocks.NewMockUsageStorage(t)
+	usage.EXPECT().UserUsage(mock.Anything, f.user, mock.Anything).
+		Return(budget.Usage{}, nil)
+	usage.EXPECT().AddUsage(mock.Anything, f.agent.ID(), mock.Anything,
+		budget.NewUsage(10, 5, 0)).Return(nil).Times(2)
+
+	// only settled tokens are spent: without settlement, second request
+	// wouldn't fit into the plan.
+	u := f.usecase(
+		chat.WithSampling(sampling.PolicyAllow, plans.NewLimit(10, time.Hour)),
+		chat.WithPlans(nil, samplingPlans(130)),
+		chat.WithBudgets(usage, nil, budget.NewLimits(
+			budget.Limit{}, budget.NewLimit(1000, 0, 0), budget.Limit{},
+		)),
+	)
+
+	for range 2 {
+		f.model.script(answer("Paris."))
+
+		res, err := u.Sample(t.Context(), f.account, samplingRequest())
+		require.NoError(t, err)
+		assert.Equal(t, "Paris.", res.Text())
+	}
+
+	assert.Equal(t, []int{samplingMaxTokens, samplingMaxTokens}, f.model.outputLimits,
+		"answer must be limited by max tokens of the request")
+}
+
+func TestSampleRejected(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		policy   sampling.Policy
+		internal bool
+		account  []entities.NewAccountOption
+		tokens   int
+		used     uint64
+	}{{
+		name:   "disabled",
+		policy: sampling.PolicyDeny,
+		tokens: 1000,
+	}, {
+		name:    "external server under internal policy",
+		policy:  sampling.PolicyInternal,
+		account: []entities.NewAccountOption{entities.WithSamplingAllowed(true)},
+		tokens:  1000,
+	}, {
+		name:   "connected account is not approval",
+		policy: sampling.PolicyAllow,
+		tokens: 1000,
+	}, {
+		name:     "disabled account",
+		policy:   sampling.PolicyAllow,
+		internal: true,
+		account: []entities.NewAccountOption{
+			entities.WithAccountStatus(entities.AccountStatusDisabled),
+		},
+		tokens: 1000,
+	}, {
+		name:     "exhausted budget",
+		policy:   sampling.PolicyAllow,
+		internal: true,
+		tokens:   1000,
+		used:     1000,
+	}, {
+		name:     "exhausted token quota",
+		policy:   sampling.PolicyAllow,
+		internal: true,
+		tokens:   50,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSamplingFixture(t, tt.internal, tt.account...)
+
+			usage := mocks.NewMockUsageStorage(t)
+			usage.EXPECT().UserUsage(mock.Anything, f.user, mock.Anything).
+				Return(budget.NewUsage(tt.used, 0, 0), nil).Maybe()
+
+			u := f.usecase(
+				chat.WithSampling(tt.policy, plans.NewLimit(10, time.Hour)),
+				chat.WithPlans(nil, samplingPlans(tt.tokens)),
+				chat.WithBudgets(usage, nil, budget.NewLimits(
+					budget.Limit{}, budget.NewLimit(1000, 0, 0), budget.Limit{},
+				)),
+			)
+
+			_, err := u.Sample(t.Context(), f.account, samplingRequest())
+			require.ErrorIs(t, err, toolclient.ErrSamplingRejected)
+			assert.Empty(t, f.model.outputLimits, "model must not be called")
+		})
+	}
+}
+
+func TestSampleAccountQuota(t *testing.T) {
+	f := newSamplingFixture(t, true)
+
+	u := f.usecase(
+		chat.WithSampling(sampling.PolicyInternal, plans.NewLimit(1, time.Hour)),
+		chat.WithPlans(nil, samplingPlans(1000)),
+	)
+
+	f.model.script(answer("Paris."))
+
+	_, err := u.Sample(t.Context(), f.account, samplingRequest())
+	require.NoError(t, err)
+
+	_, err = u.Sample(t.Context(), f.account, samplingRequest())
+	require.ErrorIs(t, err, toolclient.ErrSamplingRejected)
+}
+
+// newSamplingFixture prepares account of the chat fixture, which requests
+// completions, and its server.
+func newSamplingFixture(
+	t *testing.T, internal bool, opts ...entities.NewAccountOption,
+) *chatFixture {
+	t.Helper()
+
+	f := newChatFixture(t)
+
+	f.accountOpts = opts
+	server := must(entities.NewServerConfig(f.account.Server(),
+		must(url.Parse("https://mcp.example.com/sse")),
+		entities.WithInternal(internal),
+	))
+
+	f.servers.EXPECT().GetServerInfo(mock.Anything, f.account.Server()).Return(server, nil).Maybe()
+
+	return f
+}
+
+// samplingPlans limits tokens of every user, message quota is not used by
+// sampling.
+func samplingPlans(tokens int) plans.Catalog {
+	plan := must(plans.NewPlan("default", plans.Limit{}, plans.NewLimit(tokens, time.Hour)))
+
+	return must(plans.NewCatalog(plan))
+}
+
+func samplingRequest() sampling.Request {
+	question := must(messages.NewMessageUser("What is the capital of France?"))
+
+	return must(sampling.NewRequest([]messages.Message{question}, samplingMaxTokens))
+}
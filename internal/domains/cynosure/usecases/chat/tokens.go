@@ -0,0 +1,101 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/aggregates/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/ratelimiter"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+)
+
+// defaultTokenEstimate is reserved before the first model call of the run,
+// when nothing is known about the cost of the thread yet.
+const defaultTokenEstimate = 4096
+
+// runTokens tracks token reservations of a single agent loop run.
+type runTokens struct {
+	plan plans.Plan
+	// cost of the next model call. Previous call is the best guess, as
+	// history only grows between turns.
+	estimate int
+}
+
+// startTokens resolves plan of thread owner, which limits tokens of the run.
+func (u *Usecase) startTokens(ctx context.Context, thread *chat.Chat) (*runTokens, error) {
+	plan, err := u.userPlan(ctx, thread.ThreadID().User())
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &runTokens{plan: plan, estimate: 0}
+	tokens.expect(defaultTokenEstimate)
+
+	return tokens, nil
+}
+
+// expect sets cost of the next model call. Estimate never exceeds token
+// burst of the plan: bigger reservation is never allowed, so single expensive
+// call would lock user out of the quota forever.
+func (t *runTokens) expect(estimate int) {
+	if burst := t.plan.Tokens().Burst(); burst > 0 {
+		estimate = min(estimate, burst)
+	}
+
+	t.estimate = estimate
+}
+
+// reserveTokens holds estimated cost of the next model call from user token
+// quota. Returns false, if response must be stopped: exhausted quota is
+// yielded as [RateLimitError].
+func (u *Usecase) reserveTokens(
+	ctx context.Context,
+	thread *chat.Chat,
+	tokens *runTokens,
+	yield func(messages.Message, error) bool,
+) (ratelimiter.Reservation, bool) {
+	reservation, err := u.limiter.Reserve(
+		ctx, thread.ThreadID().User(), tokens.plan.Tokens(), tokens.estimate,
+	)
+	switch {
+	case errors.Is(err, ratelimiter.ErrRateLimitExceeded):
+		u.obs.tokenLimitExceeded(ctx, thread.ThreadID().String(), tokens.estimate)
+		yield(nil, rateLimitError(tokens.plan, LimitTokens, err))
+
+		return ratelimiter.Reservation{}, false
+
+	case err != nil:
+		yield(nil, fmt.Errorf("reserving tokens: %w", err))
+		return ratelimiter.Reservation{}, false
+
+	default:
+		return reservation, true
+	}
+}
+
+// settleTokens commits actually used tokens, or refunds the whole
+// reservation, if model wasn't called at all. Failed settlement doesn't stop
+// the response: it only makes limit less accurate.
+func (u *Usecase) settleTokens(
+	ctx context.Context,
+	tokens *runTokens,
+	reservation ratelimiter.Reservation,
+	usage chatmodel.UsageStats,
+) {
+	used := int(usage.InputTokens) + int(usage.OutputTokens)
+
+	var err error
+	if used == 0 {
+		err = u.limiter.Refund(ctx, reservation)
+	} else {
+		err = u.limiter.Commit(ctx, reservation, used)
+		tokens.expect(used)
+	}
+
+	if err != nil {
+		u.obs.tokensNotSettled(ctx, reservation.User().ID().String(), err)
+	}
+}
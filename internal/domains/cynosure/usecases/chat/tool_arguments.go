@@ -0,0 +1,72 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+// invalidArguments is a tool error, returned to the model when it called tool
+// with arguments, which don't match input schema. It lists every offending
+// field, so model is able to repair call in a single attempt.
+type invalidArguments struct {
+	Error        string            `json:"error"`
+	Violations   []tools.Violation `json:"violations"`
+	AttemptsLeft uint8             `json:"attempts_left"`
+}
+
+// validateToolArguments checks arguments before execution. Tools with broken
+// schemas are not validated at all: server is the last line of defense for
+// them.
+func validateToolArguments(
+	schema tools.Schema, args map[string]json.RawMessage,
+) (invalidArguments, bool) {
+	var argsErr *tools.ArgumentsError
+	if err := schema.ValidateArguments(args); !errors.As(err, &argsErr) {
+		return invalidArguments{}, true
+	}
+
+	return invalidArguments{
+		Error:        "Arguments do not match tool input schema. Fix them and call tool again.",
+		Violations:   argsErr.Violations(),
+		AttemptsLeft: 0,
+	}, false
+}
+
+// toolRepairs counts invalid calls of each tool during single agent loop.
+// Not thread-safe: tools are executed sequentially.
+type toolRepairs struct {
+	failures map[string]uint8
+	attempts uint8
+}
+
+func newToolRepairs(attempts uint8) *toolRepairs {
+	return &toolRepairs{
+		failures: make(map[string]uint8),
+		attempts: attempts,
+	}
+}
+
+// fail registers invalid call of the tool and returns how many repair
+// attempts are left.
+func (r *toolRepairs) fail(toolName string) uint8 {
+	r.failures[toolName]++
+
+	if failures := r.failures[toolName]; failures <= r.attempts {
+		return r.attempts - failures + 1
+	}
+
+	return 0
+}
+
+// succeed registers valid call of the tool: model has learned to call it, so
+// next mistakes are counted from scratch.
+func (r *toolRepairs) succeed(toolName string) {
+	delete(r.failures, toolName)
+}
+
+// exhausted reports that model is not allowed to call this tool anymore.
+func (r *toolRepairs) exhausted(toolName string) bool {
+	return r.failures[toolName] > r.attempts
+}
@@ -0,0 +1,64 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+func TestToolRepairs(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("search", `{"type":"object","required":["query"],"properties":{"query":{"type":"string"}}}`)
+
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, "ok", mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, tool entities.ToolReadOnly, _ map[string]json.RawMessage,
+			callID string, _ ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			return messages.NewMessageToolResponse(json.RawMessage(`{"found":1}`), tool.Name(), callID)
+		}).Once()
+
+	f.model.script(
+		callTool("search", "bad-1", `{"query":1}`),
+		callTool("search", "ok", `{"query":"cats"}`),
+		// successful call resets the counter, so model gets all attempts again.
+		callTool("search", "bad-2", `{}`),
+		callTool("search", "bad-3", `{}`),
+		callTool("search", "rejected", `{"query":"cats"}`),
+		answer("Sorry, search is broken."),
+	)
+
+	results := toolResults(f.respond(f.usecase(chat.WithToolRepairAttempts(1)), "find cats"))
+	require.Len(t, results, 5)
+
+	attemptsLeft := func(result messages.MessageTool) uint8 {
+		t.Helper()
+		require.IsType(t, messages.MessageToolError{}, result)
+
+		var payload struct {
+			AttemptsLeft uint8 `json:"attempts_left"`
+		}
+		require.NoError(t, json.Unmarshal(result.Content(), &payload))
+
+		return payload.AttemptsLeft
+	}
+
+	assert.Equal(t, uint8(1), attemptsLeft(results[0]))
+	assert.IsType(t, messages.MessageToolResponse{}, results[1])
+	assert.Equal(t, uint8(1), attemptsLeft(results[2]))
+	assert.Equal(t, uint8(0), attemptsLeft(results[3]))
+
+	// valid arguments don't help anymore: tool is rejected until the end of
+	// the response.
+	require.IsType(t, messages.MessageToolError{}, results[4])
+	assert.Contains(t, string(results[4].Content()), "too many times")
+}
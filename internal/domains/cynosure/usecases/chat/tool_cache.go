@@ -0,0 +1,124 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+const cachedResultNote = "Result was taken from cache and may be stale. " +
+	"Tell the user if freshness matters."
+
+// cacheMeta is added to cached results under "_meta" key, so model knows,
+// that data could be changed since the call.
+type cacheMeta struct {
+	Cached     bool   `json:"cached"`
+	CachedAt   string `json:"cached_at"`
+	AgeSeconds int64  `json:"age_seconds"`
+	Note       string `json:"note"`
+}
+
+// toolCacheTTL returns how long results of the tool may be reused. Zero means
+// that tool is not cached.
+func (u *Usecase) toolCacheTTL(tool entities.ToolReadOnly) time.Duration {
+	switch {
+	case u.toolCache == nil:
+		return 0
+	case tool.CacheTTL() > 0:
+		return tool.CacheTTL()
+	case tool.Hints().Cacheable():
+		return u.defaultToolCacheTTL
+	default:
+		return 0
+	}
+}
+
+// cachedToolResult returns previous result of the same call, adjusted for the
+// current request. Cache failures are not fatal: tool is just called again.
+func (u *Usecase) cachedToolResult(
+	ctx context.Context, key tools.ResultKey, req messages.MessageToolRequest,
+) (messages.MessageToolResponse, bool) {
+	cached, cachedAt, err := u.toolCache.GetToolResult(ctx, key)
+	if errors.Is(err, ports.ErrNotFound) {
+		return messages.MessageToolResponse{}, false
+	} else if err != nil {
+		u.obs.toolCacheFailed(ctx, req.ToolName(), err)
+		return messages.MessageToolResponse{}, false
+	}
+
+	age := max(u.obs.now().Sub(cachedAt), 0)
+
+	result, err := markCached(cached, req.ToolCallID(), cachedAt, age)
+	if err != nil {
+		u.obs.toolCacheFailed(ctx, req.ToolName(), err)
+		return messages.MessageToolResponse{}, false
+	}
+
+	u.obs.toolResultCached(ctx, req.ToolName(), age)
+
+	return result, true
+}
+
+// cacheToolResult stores successful result of the call.
+func (u *Usecase) cacheToolResult(
+	ctx context.Context, key tools.ResultKey, result messages.MessageTool, ttl time.Duration,
+) {
+	resp, ok := result.(messages.MessageToolResponse)
+	if !ok {
+		return
+	}
+
+	if err := u.toolCache.SetToolResult(ctx, key, resp, ttl); err != nil {
+		u.obs.toolCacheFailed(ctx, resp.ToolName(), err)
+	}
+}
+
+// markCached adds cache metadata to the result. Objects receive "_meta"
+// field, other values are wrapped into object.
+func markCached(
+	result messages.MessageToolResponse, toolCallID string, cachedAt time.Time, age time.Duration,
+) (messages.MessageToolResponse, error) {
+	meta, err := json.Marshal(cacheMeta{
+		Cached:     true,
+		CachedAt:   cachedAt.UTC().Format(time.RFC3339),
+		AgeSeconds: int64(age / time.Second),
+		Note:       cachedResultNote,
+	})
+	if err != nil {
+		return messages.MessageToolResponse{}, fmt.Errorf("encoding cache metadata: %w", err)
+	}
+
+	var content map[string]json.RawMessage
+	if !isObject(result.Content()) || json.Unmarshal(result.Content(), &content) != nil {
+		content = map[string]json.RawMessage{"result": result.Content()}
+	}
+
+	content["_meta"] = meta
+
+	marked, err := json.Marshal(content)
+	if err != nil {
+		return messages.MessageToolResponse{}, fmt.Errorf("encoding cached result: %w", err)
+	}
+
+	if result, err = result.WithContent(marked); err != nil {
+		return messages.MessageToolResponse{}, fmt.Errorf("marking cached result: %w", err)
+	}
+
+	if result, err = result.WithToolCallID(toolCallID); err != nil {
+		return messages.MessageToolResponse{}, fmt.Errorf("replacing tool call id: %w", err)
+	}
+
+	return result, nil
+}
+
+func isObject(data json.RawMessage) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
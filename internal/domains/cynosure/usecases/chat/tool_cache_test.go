@@ -0,0 +1,80 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/inmemory"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+func TestToolResultCache(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("weather", `{"type":"object","properties":{"city":{"type":"string"}}}`,
+		entities.WithHints(tools.NewHints(true, false)),
+	)
+	f.tool("order", `{"type":"object"}`)
+	f.tool("cancel", `{"type":"object"}`,
+		entities.WithHints(tools.NewHints(false, true)),
+	)
+	f.tool("refund", `{"type":"object"}`,
+		entities.WithHints(tools.NewHints(false, true)),
+		entities.WithCacheTTL(time.Minute),
+	)
+
+	var calls []string
+
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			_ context.Context, tool entities.ToolReadOnly, _ map[string]json.RawMessage,
+			callID string, _ ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			calls = append(calls, callID)
+			return messages.NewMessageToolResponse(json.RawMessage(`{"ok":true}`), tool.Name(), callID)
+		})
+
+	u := f.usecase(chat.WithToolResultCache(inmemory.NewToolResultCache(time.Minute, nil), time.Minute))
+
+	f.model.script(
+		callTool("weather", "call-1", `{"city":"Paris"}`),
+		callTool("weather", "call-2", `{"city":"Paris"}`),
+		callTool("weather", "call-3", `{"city":"Rome"}`),
+		callTool("order", "call-4", `{}`),
+		callTool("order", "call-5", `{}`),
+		callTool("cancel", "call-6", `{}`),
+		callTool("cancel", "call-7", `{}`),
+		callTool("refund", "call-8", `{}`),
+		callTool("refund", "call-9", `{}`),
+		answer("Done."),
+	)
+
+	results := toolResults(f.respond(u, "weather and pizza"))
+	require.Len(t, results, 9)
+
+	// read-only tool is reused for the same arguments, other tools, even
+	// idempotent ones, are cached only with explicit ttl.
+	assert.Equal(t, []string{
+		"call-1", "call-3", "call-4", "call-5", "call-6", "call-7", "call-8",
+	}, calls)
+
+	var cached struct {
+		OK   bool `json:"ok"`
+		Meta struct {
+			Cached bool `json:"cached"`
+		} `json:"_meta"`
+	}
+	require.NoError(t, json.Unmarshal(results[1].Content(), &cached))
+	assert.True(t, cached.OK)
+	assert.True(t, cached.Meta.Cached)
+	assert.Equal(t, "call-2", results[1].ToolCallID())
+}
@@ -0,0 +1,255 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/aggregates/chat"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+)
+
+const (
+	readToolOutputName = "read_tool_output"
+	readToolOutputDesc = "Reads part of a tool output, which was too large to be returned at once. " +
+		"Use ref and next_offset from truncated output to continue reading."
+
+	// encoded preview or page of the output must fit into tool message, so
+	// limit is lower than message limit: escaping of json strings may enlarge
+	// content.
+	toolOutputLimit = 6 << 10
+	// default amount of bytes, returned by single read_tool_output call.
+	defaultPageSize = 4 << 10
+)
+
+const (
+	readToolOutputParams = `{
+		"type": "object",
+		"required": ["ref"],
+		"properties": {
+			"ref": {"type": "string", "description": "Reference of the stored output."},
+			"offset": {"type": "integer", "minimum": 0, "description": "Byte offset to start reading from."},
+			"length": {"type": "integer", "minimum": 1, "maximum": 4096, "description": "Amount of bytes to read."}
+		}
+	}`
+	readToolOutputResponse = `{
+		"type": "object",
+		"properties": {
+			"content": {"type": "string"},
+			"offset": {"type": "integer"},
+			"size": {"type": "integer"},
+			"next_offset": {"type": "integer"}
+		}
+	}`
+)
+
+// newReadToolOutputTool describes built-in tool, which pages through
+// offloaded outputs.
+func newReadToolOutputTool() (tools.RawTool, error) {
+	params, err := tools.NewInputSchema(json.RawMessage(readToolOutputParams))
+	if err != nil {
+		return tools.RawTool{}, fmt.Errorf("parsing %v params: %w", readToolOutputName, err)
+	}
+
+	response, err := tools.NewOutputSchema(json.RawMessage(readToolOutputResponse))
+	if err != nil {
+		return tools.RawTool{}, fmt.Errorf("parsing %v response: %w", readToolOutputName, err)
+	}
+
+	tool, err := tools.NewBuiltinTool(readToolOutputName, readToolOutputDesc, params, response)
+	if err != nil {
+		return tools.RawTool{}, fmt.Errorf("building %v: %w", readToolOutputName, err)
+	}
+
+	return tool, nil
+}
+
+// offloadedOutput replaces too large tool output in thread history.
+type offloadedOutput struct {
+	Note       string `json:"note"`
+	Ref        string `json:"ref"`
+	Preview    string `json:"preview"`
+	Size       int64  `json:"size"`
+	NextOffset int64  `json:"next_offset"`
+}
+
+// outputPage is a result of read_tool_output call. NextOffset is omitted, when
+// the end of the output is reached.
+type outputPage struct {
+	Content    string `json:"content"`
+	Offset     int64  `json:"offset"`
+	Size       int64  `json:"size"`
+	NextOffset int64  `json:"next_offset,omitempty"`
+}
+
+type readToolOutputArgs struct {
+	Ref    string `json:"ref"`
+	Offset int64  `json:"offset"`
+	Length int64  `json:"length"`
+}
+
+// offloadOutput stores too large tool output in blob storage, and replaces it
+// with truncated preview and reference to the rest of the output.
+func (u *Usecase) offloadOutput(thread ids.ThreadID) toolclient.OversizeHandler {
+	return func(ctx context.Context, content json.RawMessage) (json.RawMessage, error) {
+		blob, err := ids.RandomBlobID(thread)
+		if err != nil {
+			return nil, fmt.Errorf("creating blob id: %w", err)
+		}
+
+		if err := u.blobs.SaveBlob(ctx, blob, content); err != nil {
+			return nil, fmt.Errorf("saving tool output: %w", err)
+		}
+
+		u.obs.toolOutputOffloaded(ctx, blob.String(), len(content))
+
+		size := int64(len(content))
+
+		return fitOutput(content, func(chunk []byte) any {
+			return offloadedOutput{
+				Note: "Output is too large and was truncated. Call " + readToolOutputName +
+					" with this ref and next_offset to read the rest.",
+				Ref:        blob.String(),
+				Preview:    string(chunk),
+				Size:       size,
+				NextOffset: int64(len(chunk)),
+			}
+		})
+	}
+}
+
+// executeReadToolOutput handles built-in read_tool_output call. Output is
+// looked up only in the current thread.
+func (u *Usecase) executeReadToolOutput(
+	ctx context.Context,
+	thread *chat.Chat,
+	req messages.MessageToolRequest,
+	yield func(messages.Message, error) bool,
+) bool {
+	var args readToolOutputArgs
+	if err := decodeArguments(req.Arguments(), &args); err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Invalid arguments: %v", err), yield)
+	}
+
+	if args.Length <= 0 {
+		args.Length = defaultPageSize
+	}
+
+	blob, err := ids.NewBlobIDFromString(thread.ThreadID(), args.Ref)
+	if err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Invalid ref: %v", err), yield)
+	}
+
+	data, size, err := u.blobs.ReadBlob(ctx, blob, args.Offset, args.Length)
+	if errors.Is(err, ports.ErrNotFound) {
+		return yieldToolError(ctx, thread, req, "Output not found: ref is unknown in this chat.", yield)
+	} else if err != nil {
+		return yieldToolError(ctx, thread, req, fmt.Sprintf("Reading output failed: %v", err), yield)
+	}
+
+	// model may pass any offset, so page starts from the next whole character.
+	skipped := skipPartialRune(data)
+	data, args.Offset = data[skipped:], args.Offset+int64(skipped)
+
+	content, err := fitOutput(data, func(chunk []byte) any {
+		page := outputPage{
+			Content:    string(chunk),
+			Offset:     args.Offset,
+			Size:       size,
+			NextOffset: 0,
+		}
+
+		if next := args.Offset + int64(len(chunk)); next < size {
+			page.NextOffset = next
+		}
+
+		return page
+	})
+	if err != nil {
+		yield(nil, fmt.Errorf("encoding tool output page: %w", err))
+		return false
+	}
+
+	msg, err := messages.NewMessageToolResponse(content, req.ToolName(), req.ToolCallID())
+	if err != nil {
+		yield(nil, fmt.Errorf("building tool output page: %w", err))
+		return false
+	}
+
+	if err := thread.AcceptToolResult(ctx, msg); err != nil {
+		yield(nil, fmt.Errorf("saving tool result: %w", err))
+		return false
+	}
+
+	return yield(msg, nil)
+}
+
+func decodeArguments(args map[string]json.RawMessage, dst any) error {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("encoding arguments: %w", err)
+	}
+
+	if err := json.Unmarshal(encoded, dst); err != nil {
+		return fmt.Errorf("decoding arguments: %w", err)
+	}
+
+	return nil
+}
+
+// fitOutput encodes payload with the longest prefix of data, which fits into
+// [toolOutputLimit]. Prefix never ends in the middle of utf-8 character.
+func fitOutput(data []byte, build func(chunk []byte) any) (json.RawMessage, error) {
+	chunk := data[:min(len(data), toolOutputLimit)]
+
+	for {
+		chunk = trimPartialRune(chunk)
+
+		encoded, err := json.Marshal(build(chunk))
+		if err != nil {
+			return nil, fmt.Errorf("encoding output: %w", err)
+		}
+
+		if len(encoded) <= toolOutputLimit || len(chunk) == 0 {
+			return encoded, nil
+		}
+
+		chunk = chunk[:len(chunk)/2]
+	}
+}
+
+// skipPartialRune counts continuation bytes at the start of the chunk, which
+// belong to utf-8 character, started before the chunk.
+func skipPartialRune(chunk []byte) int {
+	for i := range min(len(chunk), utf8.UTFMax-1) {
+		if utf8.RuneStart(chunk[i]) {
+			return i
+		}
+	}
+
+	return min(len(chunk), utf8.UTFMax-1)
+}
+
+// trimPartialRune cuts incomplete utf-8 character at the end of the chunk.
+func trimPartialRune(chunk []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(chunk); i++ {
+		start := len(chunk) - i
+		if !utf8.RuneStart(chunk[start]) {
+			continue
+		}
+
+		if !utf8.FullRune(chunk[start:]) {
+			return chunk[:start]
+		}
+
+		return chunk
+	}
+
+	return chunk
+}
@@ -0,0 +1,132 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/filesystem"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/toolclient"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+type offloadedOutput struct {
+	Ref        string `json:"ref"`
+	Preview    string `json:"preview"`
+	Size       int64  `json:"size"`
+	NextOffset int64  `json:"next_offset"`
+}
+
+type outputPage struct {
+	Content    string `json:"content"`
+	Offset     int64  `json:"offset"`
+	Size       int64  `json:"size"`
+	NextOffset int64  `json:"next_offset"`
+}
+
+func TestToolOutputOffload(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("dump", `{"type":"object"}`)
+
+	output := strings.Repeat("0123456789", 2000)
+	f.expectOversizedOutput(output)
+
+	blobs, err := filesystem.NewBlobStorage(t.TempDir())
+	require.NoError(t, err)
+
+	u := f.usecase(chat.WithBlobStorage(blobs))
+
+	f.model.script(callTool("dump", "call-1", `{}`), answer("Output is huge."))
+
+	results := toolResults(f.respond(u, "dump everything"))
+	require.Len(t, results, 1)
+
+	var offloaded offloadedOutput
+	require.NoError(t, json.Unmarshal(results[0].Content(), &offloaded))
+	require.NotEmpty(t, offloaded.Ref)
+	assert.Equal(t, int64(len(output)), offloaded.Size)
+	assert.Equal(t, output[:offloaded.NextOffset], offloaded.Preview)
+
+	f.model.script(
+		callTool("read_tool_output", "call-2",
+			`{"ref":"`+offloaded.Ref+`","offset":`+jsonInt(offloaded.NextOffset)+`}`),
+		callTool("read_tool_output", "call-3", `{"ref":"unknown"}`),
+		answer("Read it."),
+	)
+
+	results = toolResults(f.respond(u, "read the rest"))
+	require.Len(t, results, 2)
+
+	var page outputPage
+	require.NoError(t, json.Unmarshal(results[0].Content(), &page))
+	assert.Equal(t, offloaded.NextOffset, page.Offset)
+	assert.Equal(t, output[page.Offset:page.NextOffset], page.Content)
+
+	assert.IsType(t, messages.MessageToolError{}, results[1], "ref must be checked")
+}
+
+func TestToolOutputRuneBoundaries(t *testing.T) {
+	f := newChatFixture(t)
+	f.tool("dump", `{"type":"object"}`)
+
+	output := strings.Repeat("я", 10000)
+	f.expectOversizedOutput(output)
+
+	blobs, err := filesystem.NewBlobStorage(t.TempDir())
+	require.NoError(t, err)
+
+	u := f.usecase(chat.WithBlobStorage(blobs))
+
+	f.model.script(callTool("dump", "call-1", `{}`), answer("Output is huge."))
+
+	results := toolResults(f.respond(u, "dump everything"))
+	require.Len(t, results, 1)
+
+	var offloaded offloadedOutput
+	require.NoError(t, json.Unmarshal(results[0].Content(), &offloaded))
+
+	// offset points to the middle of two-byte character.
+	f.model.script(
+		callTool("read_tool_output", "call-2",
+			`{"ref":"`+offloaded.Ref+`","offset":1,"length":8}`),
+		answer("Read it."),
+	)
+
+	results = toolResults(f.respond(u, "read from the start"))
+	require.Len(t, results, 1)
+
+	var page outputPage
+	require.NoError(t, json.Unmarshal(results[0].Content(), &page))
+	assert.Equal(t, int64(2), page.Offset, "page starts from the next character")
+	assert.Equal(t, "яяя", page.Content, "page ends before incomplete character")
+	assert.Equal(t, int64(8), page.NextOffset)
+}
+
+// expectOversizedOutput makes tool client to return output, which is too
+// large for the model, like MCP adapter does.
+func (f *chatFixture) expectOversizedOutput(output string) {
+	f.tools.EXPECT().ExecuteTool(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			ctx context.Context, tool entities.ToolReadOnly, _ map[string]json.RawMessage,
+			callID string, opts ...toolclient.ExecuteToolOption,
+		) (messages.MessageTool, error) {
+			params := toolclient.ExecuteToolParams(opts...)
+			require.NotNil(f.t, params.OversizeHandler())
+
+			content, err := params.OversizeHandler()(ctx, json.RawMessage(output))
+			if err != nil {
+				return nil, err
+			}
+
+			return messages.NewMessageToolResponse(content, tool.Name(), callID)
+		})
+}
+
+func jsonInt(v int64) string { return string(must(json.Marshal(v))) }
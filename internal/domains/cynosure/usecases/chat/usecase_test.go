@@ -0,0 +1,320 @@
+package chat_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/inmemory"
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/entities"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports/chatmodel"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/messages"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/tools"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/chat"
+)
+
+// --- Fixture ---
+
+// chatFixture runs agent loop with scripted model: every model call returns
+// the next prepared turn. Storages keep state in memory, so the same thread
+// could be used for several responses.
+type chatFixture struct {
+	t        *testing.T
+	user     ids.UserID
+	threadID ids.ThreadID
+	account  ids.AccountID
+	agent    *entities.Agent
+	model    *scriptedModel
+	tools    *mocks.ToolClient
+
+	threads     *mocks.MockThreadStorage
+	toolStorage *mocks.MockToolStorage
+	agents      *mocks.MockAgentStorage
+	accounts    *mocks.MockAccountStorage
+	servers     *mocks.MockServerStorage
+
+	mu         sync.Mutex
+	thread     *entities.Thread
+	registered []*entities.Tool
+	// accountOpts build fixture account, when it's read from the storage.
+	accountOpts []entities.NewAccountOption
+	// updateErr is returned by thread updates, so tests can simulate
+	// concurrent changes of the thread.
+	updateErr error
+}
+
+func newChatFixture(t *testing.T) *chatFixture {
+	t.Helper()
+
+	user := ids.RandomUserID()
+
+	f := &chatFixture{
+		t:           t,
+		user:        user,
+		threadID:    must(ids.NewThreadID(user, "thread")),
+		account:     must(ids.RandomAccountID(user, ids.RandomServerID())),
+		agent:       must(entities.NewModelSettings(must(ids.RandomAgentID(user)), "gemini-2.5-flash")),
+		model:       &scriptedModel{turns: nil, inputs: nil, outputLimits: nil, mu: sync.Mutex{}},
+		tools:       mocks.NewToolClient(t),
+		threads:     mocks.NewMockThreadStorage(t),
+		toolStorage: mocks.NewMockToolStorage(t),
+		agents:      mocks.NewMockAgentStorage(t),
+		accounts:    mocks.NewMockAccountStorage(t),
+		servers:     mocks.NewMockServerStorage(t),
+		mu:          sync.Mutex{},
+		thread:      nil,
+		registered:  nil,
+		accountOpts: nil,
+		updateErr:   nil,
+	}
+
+	f.threads.EXPECT().GetThread(mock.Anything, f.threadID).RunAndReturn(f.getThread).Maybe()
+	f.threads.EXPECT().CreateThread(mock.Anything, mock.Anything).RunAndReturn(f.saveThread).Maybe()
+	f.threads.EXPECT().UpdateThread(mock.Anything, mock.Anything).RunAndReturn(f.updateThread).Maybe()
+	f.toolStorage.EXPECT().LookupTools(mock.Anything, user, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, ids.UserID, [1536]float32, int) ([]*entities.Tool, error) {
+			return f.relevantTools(), nil
+		}).Maybe()
+	f.toolStorage.EXPECT().GetTool(mock.Anything, f.account, mock.Anything).
+		RunAndReturn(f.getTool).Maybe()
+	f.agents.EXPECT().GetAgent(mock.Anything, f.agent.ID()).Return(f.agent, nil).Maybe()
+	f.agents.EXPECT().ListAgents(mock.Anything, user).Return([]*entities.Agent{f.agent}, nil).Maybe()
+	f.accounts.EXPECT().GetAccountsBatch(mock.Anything, mock.Anything).Return([]*entities.Account{
+		must(entities.NewAccount(f.account, "work", "Work account")),
+	}, nil).Maybe()
+	f.accounts.EXPECT().GetAccount(mock.Anything, f.account).RunAndReturn(f.getAccount).Maybe()
+
+	return f
+}
+
+func (f *chatFixture) usecase(opts ...chat.NewOption) *chat.Usecase {
+	f.t.Helper()
+
+	indexer := mocks.NewMockToolSemanticIndex(f.t)
+	indexer.EXPECT().BuildToolEmbedding(mock.Anything, mock.Anything).
+		Return([1536]float32{}, nil).Maybe()
+
+	u, err := chat.New(
+		f.threads, f.model, f.tools, indexer, f.toolStorage,
+		f.servers, f.accounts, f.agents,
+		inmemory.NewRateLimiter(time.Hour, nil, nil),
+		opts...,
+	)
+	require.NoError(f.t, err)
+
+	return u
+}
+
+// tool registers tool of the fixture account, which is always relevant.
+func (f *chatFixture) tool(name, schema string, opts ...entities.ToolOption) *entities.Tool {
+	f.t.Helper()
+
+	tool, err := entities.NewTool(
+		must(ids.NewToolID(f.account, uuid.New())), "work", name, name+" tool",
+		must(tools.NewInputSchema(json.RawMessage(schema))),
+		must(tools.NewOutputSchema(json.RawMessage(`{"type":"object"}`))),
+		opts...,
+	)
+	require.NoError(f.t, err)
+
+	f.mu.Lock()
+	f.registered = append(f.registered, tool)
+	f.mu.Unlock()
+
+	return tool
+}
+
+// respond runs single response and returns every yielded message.
+func (f *chatFixture) respond(u *chat.Usecase, text string) []messages.Message {
+	f.t.Helper()
+
+	seq, err := u.GenerateResponse(f.t.Context(), f.threadID, must(messages.NewMessageUser(text)))
+	require.NoError(f.t, err)
+
+	var res []messages.Message
+
+	for msg, err := range seq {
+		require.NoError(f.t, err)
+
+		res = append(res, msg)
+	}
+
+	return res
+}
+
+func (f *chatFixture) getThread(context.Context, ids.ThreadID) (*entities.Thread, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.thread == nil {
+		return nil, ports.ErrNotFound
+	}
+
+	return f.thread, nil
+}
+
+// saveThread keeps the thread itself: aggregate doesn't change it after
+// saving, so it's the same, as reading it back from the storage.
+func (f *chatFixture) saveThread(_ context.Context, thread entities.ThreadReadOnly) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	saved, ok := thread.(*entities.Thread)
+	require.True(f.t, ok)
+
+	f.thread = saved
+
+	return nil
+}
+
+func (f *chatFixture) updateThread(ctx context.Context, thread entities.ThreadReadOnly) error {
+	f.mu.Lock()
+	err := f.updateErr
+	f.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return f.saveThread(ctx, thread)
+}
+
+func (f *chatFixture) getAccount(context.Context, ids.AccountID) (*entities.Account, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return entities.NewAccount(f.account, "work", "Work account", f.accountOpts...)
+}
+
+func (f *chatFixture) relevantTools() []*entities.Tool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.registered)
+}
+
+func (f *chatFixture) getTool(_ context.Context, _ ids.AccountID, id ids.ToolID) (*entities.Tool, error) {
+	for _, tool := range f.relevantTools() {
+		if tool.ID() == id {
+			return tool, nil
+		}
+	}
+
+	return nil, ports.ErrNotFound
+}
+
+// scriptedModel returns prepared turns one by one, and remembers history and
+// output limits, which it received.
+type scriptedModel struct {
+	turns        [][]messages.Message
+	inputs       [][]messages.Message
+	outputLimits []int
+	mu           sync.Mutex
+}
+
+var _ chatmodel.Port = (*scriptedModel)(nil)
+
+var errNoTurns = errors.New("model has no prepared turns")
+
+func (m *scriptedModel) script(turns ...[]messages.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.turns = append(m.turns, turns...)
+}
+
+func (m *scriptedModel) Stream(
+	context.Context, []messages.Message, entities.AgentReadOnly, ...chatmodel.StreamOption,
+) (chatmodel.StreamIter, error) {
+	return nil, errNoTurns
+}
+
+//nolint:ireturn // implements port.
+func (m *scriptedModel) StreamWithStats(
+	_ context.Context,
+	input []messages.Message,
+	settings entities.AgentReadOnly,
+	opts ...chatmodel.StreamOption,
+) (chatmodel.Iter, error) {
+	params, err := chatmodel.StreamParams(input, settings, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.turns) == 0 {
+		return nil, errNoTurns
+	}
+
+	turn := m.turns[0]
+	m.turns = m.turns[1:]
+	m.inputs = append(m.inputs, input)
+	m.outputLimits = append(m.outputLimits, params.MaxOutputTokens())
+
+	return &scriptedIter{msgs: turn}, nil
+}
+
+type scriptedIter struct{ msgs []messages.Message }
+
+//nolint:ireturn // implements port.
+func (i *scriptedIter) Next() (messages.Message, bool) {
+	if len(i.msgs) == 0 {
+		return nil, false
+	}
+
+	msg := i.msgs[0]
+	i.msgs = i.msgs[1:]
+
+	return msg, true
+}
+
+func (i *scriptedIter) Close() (chatmodel.UsageStats, error) {
+	return chatmodel.UsageStats{InputTokens: 10, OutputTokens: 5, Duration: time.Millisecond}, nil
+}
+
+func callTool(name, callID, args string) []messages.Message {
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(args), &decoded); err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return []messages.Message{must(messages.NewMessageToolRequest(decoded, name, callID))}
+}
+
+func answer(text string) []messages.Message {
+	return []messages.Message{must(messages.NewMessageAssistant(text))}
+}
+
+// toolResults filters results of tool calls from yielded messages.
+func toolResults(msgs []messages.Message) []messages.MessageTool {
+	var res []messages.MessageTool
+
+	for _, msg := range msgs {
+		if result, ok := msg.(messages.MessageTool); ok {
+			res = append(res, result)
+		}
+	}
+
+	return res
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return v
+}
@@ -0,0 +1,22 @@
+package plans
+
+import (
+	"errors"
+)
+
+// ErrUnknownPlan is returned when plan is missing in the catalog.
+var ErrUnknownPlan = errors.New("unknown plan")
+
+// InternalValidationError is returned when usecase configuration or parameters are invalid.
+type InternalValidationError struct {
+	Message string
+}
+
+func (e *InternalValidationError) Error() string {
+	return "plans usecase validation error: " + e.Message
+}
+
+// errInternalValidation is a helper to create InternalValidationError.
+func errInternalValidation(msg string) error {
+	return &InternalValidationError{Message: msg}
+}
@@ -0,0 +1,66 @@
+// Package plans implements assignment of rate limit plans to users.
+package plans
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+)
+
+const pkgName = "github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/plans"
+
+type Usecase struct {
+	storage ports.PlanStorage
+	catalog plans.Catalog
+	trace   trace.Tracer
+}
+
+type newParams struct {
+	tracer trace.TracerProvider
+}
+
+func buildNewParams(opts ...NewOption) newParams {
+	params := newParams{
+		tracer: noop.NewTracerProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return params
+}
+
+type NewOption func(*newParams)
+
+func WithTracerProvider(tp trace.TracerProvider) NewOption {
+	return func(p *newParams) { p.tracer = tp }
+}
+
+// New creates usecase, which assigns plans from the catalog. Catalog must be
+// the same, as used by chat usecase, otherwise assigned plans are ignored.
+func New(storage ports.PlanStorage, catalog plans.Catalog, opts ...NewOption) (*Usecase, error) {
+	params := buildNewParams(opts...)
+
+	usecase := &Usecase{
+		storage: storage,
+		catalog: catalog,
+		trace:   params.tracer.Tracer(pkgName),
+	}
+
+	if err := usecase.validate(); err != nil {
+		return nil, err
+	}
+
+	return usecase, nil
+}
+
+func (u *Usecase) validate() error {
+	if u.storage == nil {
+		return errInternalValidation("plan storage is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/plans"
+)
+
+// AssignPlan sets plan of the user. New limits are applied to the next
+// message of the user.
+//
+// Throws:
+//   - [ErrUnknownPlan] if plan is missing in the catalog.
+func (u *Usecase) AssignPlan(ctx context.Context, user ids.UserID, name string) error {
+	ctx, span := u.trace.Start(ctx, "Usecase.AssignPlan")
+	defer span.End()
+
+	if !user.Valid() {
+		return errInternalValidation("user id is required")
+	}
+
+	if _, ok := u.catalog.Get(name); !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPlan, name)
+	}
+
+	if err := u.storage.SetUserPlan(ctx, user, name); err != nil {
+		return fmt.Errorf("saving user plan: %w", err)
+	}
+
+	return nil
+}
+
+// UserPlan returns plan, which limits the user. Users without assigned plan,
+// or with plan missing in the catalog, get the default one.
+func (u *Usecase) UserPlan(ctx context.Context, user ids.UserID) (plans.Plan, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.UserPlan")
+	defer span.End()
+
+	if !user.Valid() {
+		return plans.Plan{}, errInternalValidation("user id is required")
+	}
+
+	name, err := u.storage.UserPlan(ctx, user)
+	switch {
+	case errors.Is(err, ports.ErrNotFound):
+		return u.catalog.Default(), nil
+	case err != nil:
+		return plans.Plan{}, fmt.Errorf("getting user plan: %w", err)
+	default:
+		return u.catalog.Resolve(name), nil
+	}
+}
+
+// Plans returns names of plans, available for assignment.
+func (u *Usecase) Plans() []string {
+	return u.catalog.Names()
+}
@@ -0,0 +1,22 @@
+package usage
+
+import (
+	"errors"
+)
+
+// ErrInvalidPeriod is returned when report period is empty or too long.
+var ErrInvalidPeriod = errors.New("report period must be from 1 to 366 days")
+
+// InternalValidationError is returned when usecase configuration or parameters are invalid.
+type InternalValidationError struct {
+	Message string
+}
+
+func (e *InternalValidationError) Error() string {
+	return "usage usecase validation error: " + e.Message
+}
+
+// errInternalValidation is a helper to create InternalValidationError.
+func errInternalValidation(msg string) error {
+	return &InternalValidationError{Message: msg}
+}
@@ -0,0 +1,80 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+)
+
+// Report is a usage of user agents in a period, summed by day, agent and
+// model.
+type Report struct {
+	since     time.Time
+	until     time.Time
+	summaries []ledger.Summary
+}
+
+func (r Report) Since() time.Time { return r.since }
+
+// Until returns end of the period (exclusive): start of the next day.
+func (r Report) Until() time.Time { return r.until }
+
+// Summaries are sorted by day, then by agent and model.
+func (r Report) Summaries() []ledger.Summary { return r.summaries }
+
+// Turns returns amount of agent turns in the period.
+func (r Report) Turns() uint64 {
+	var turns uint64
+	for _, summary := range r.summaries {
+		turns += summary.Turns()
+	}
+
+	return turns
+}
+
+// Total returns usage of all agents and models in the period.
+func (r Report) Total() ledger.Stats {
+	var total ledger.Stats
+	for _, summary := range r.summaries {
+		total = total.Add(summary.Stats())
+	}
+
+	return total
+}
+
+// Report returns usage of user agents for the last days, including today.
+// Days are calendar days in UTC.
+//
+// Throws:
+//   - [ErrInvalidPeriod] if days are out of range.
+func (u *Usecase) Report(ctx context.Context, user ids.UserID, days uint) (Report, error) {
+	ctx, span := u.trace.Start(ctx, "Usecase.Report")
+	defer span.End()
+
+	if !user.Valid() {
+		return Report{}, errInternalValidation("user id is required")
+	}
+
+	if days == 0 || days > maxReportDays {
+		return Report{}, ErrInvalidPeriod
+	}
+
+	now := u.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, 1-int(days))
+	until := today.AddDate(0, 0, 1)
+
+	summaries, err := u.ledger.ListSummaries(ctx, user, since, until)
+	if err != nil {
+		return Report{}, fmt.Errorf("listing ledger summaries: %w", err)
+	}
+
+	return Report{
+		since:     since,
+		until:     until,
+		summaries: summaries,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package usage_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/cynosure/internal/adapters/mocks"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ids"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/primitives/ledger"
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
+)
+
+func TestReport(t *testing.T) {
+	user := ids.RandomUserID()
+	agent := must(ids.RandomAgentID(user))
+	now := time.Date(2026, time.March, 10, 23, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	since := time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.March, 11, 0, 0, 0, 0, time.UTC)
+
+	storage := mocks.NewMockLedgerStorage(t)
+	storage.EXPECT().ListSummaries(mock.Anything, user, since, until).Return([]ledger.Summary{
+		ledger.NewSummary(since, agent, "flash", 2, ledger.Stats{
+			InputTokens: 100, OutputTokens: 20, Duration: time.Second, ToolCalls: 1, Errors: 0,
+		}),
+		ledger.NewSummary(until.AddDate(0, 0, -1), agent, "pro", 3, ledger.Stats{
+			InputTokens: 50, OutputTokens: 5, Duration: time.Second, ToolCalls: 2, Errors: 1,
+		}),
+	}, nil).Once()
+
+	u := must(usage.New(storage, usage.WithClock(func() time.Time { return now })))
+
+	report, err := u.Report(t.Context(), user, 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, since, report.Since(), "period is counted in UTC days, including today")
+	assert.Equal(t, until, report.Until())
+	assert.Len(t, report.Summaries(), 2)
+	assert.Equal(t, uint64(5), report.Turns())
+	assert.Equal(t, ledger.Stats{
+		InputTokens: 150, OutputTokens: 25, Duration: 2 * time.Second, ToolCalls: 3, Errors: 1,
+	}, report.Total())
+}
+
+func TestReportErrors(t *testing.T) {
+	user := ids.RandomUserID()
+	errStorage := errors.New("storage is down")
+
+	storage := mocks.NewMockLedgerStorage(t)
+	storage.EXPECT().ListSummaries(mock.Anything, user, mock.Anything, mock.Anything).
+		Return(nil, errStorage).Once()
+
+	u := must(usage.New(storage))
+
+	for _, days := range []uint{0, 367} {
+		_, err := u.Report(t.Context(), user, days)
+		require.ErrorIs(t, err, usage.ErrInvalidPeriod, "days: %d", days)
+	}
+
+	var validationErr *usage.InternalValidationError
+
+	_, err := u.Report(t.Context(), ids.UserID{}, 1)
+	require.ErrorAs(t, err, &validationErr)
+
+	_, err = u.Report(t.Context(), user, 366)
+	require.ErrorIs(t, err, errStorage)
+
+	_, err = usage.New(nil)
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err) //nolint:forbidigo // ok for tests
+	}
+
+	return v
+}
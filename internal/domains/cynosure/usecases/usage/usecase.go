@@ -0,0 +1,83 @@
+// Package usage implements reports of tokens, time and tool calls, consumed
+// by user agents. Reports are built from the usage ledger, while budgets are
+// enforced by sums of [ports.UsageStorage], so report may differ from spent
+// budget, if saving of some turn failed.
+package usage
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/quenbyako/cynosure/internal/domains/cynosure/ports"
+)
+
+const (
+	pkgName = "github.com/quenbyako/cynosure/internal/domains/cynosure/usecases/usage"
+
+	// ledger keeps all entries, but report for longer period is too heavy.
+	maxReportDays = 366
+)
+
+type Usecase struct {
+	ledger ports.LedgerStorage
+	trace  trace.Tracer
+	now    func() time.Time
+}
+
+type newParams struct {
+	tracer trace.TracerProvider
+	now    func() time.Time
+}
+
+func buildNewParams(opts ...NewOption) newParams {
+	params := newParams{
+		tracer: noop.NewTracerProvider(),
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return params
+}
+
+type NewOption func(*newParams)
+
+func WithTracerProvider(tp trace.TracerProvider) NewOption {
+	return func(p *newParams) { p.tracer = tp }
+}
+
+// WithClock overrides current time, used to calculate report period.
+func WithClock(now func() time.Time) NewOption {
+	return func(p *newParams) { p.now = now }
+}
+
+func New(ledger ports.LedgerStorage, opts ...NewOption) (*Usecase, error) {
+	params := buildNewParams(opts...)
+
+	usecase := &Usecase{
+		ledger: ledger,
+		trace:  params.tracer.Tracer(pkgName),
+		now:    params.now,
+	}
+
+	if err := usecase.validate(); err != nil {
+		return nil, err
+	}
+
+	return usecase, nil
+}
+
+func (u *Usecase) validate() error {
+	switch {
+	case u.ledger == nil:
+		return errInternalValidation("ledger storage is required")
+	case u.now == nil:
+		return errInternalValidation("clock is required")
+	default:
+		return nil
+	}
+}
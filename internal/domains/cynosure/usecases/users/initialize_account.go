@@ -59,6 +59,7 @@ func (u *Usecase) ensureDefaultAgent(ctx context.Context, userID ids.UserID) err
 	agent, err := entities.NewModelSettings(
 		agentID,
 		"gemini-2.5-flash", // Default model for now
+		entities.WithName("Cynosure"),
 		entities.WithSystemMessage(
 			"You are Cynosure, a meta-agent assistant. "+
 				"You can manage other MCP servers and help user with various tasks.",
@@ -235,6 +236,7 @@ func (u *Usecase) newToolWithEmbedding(
 		rawTool.Desc(),
 		rawTool.Params(),
 		rawTool.Response(),
+		entities.WithHints(rawTool.Hints()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating tool entity for %q: %w", rawTool.Name(), err)